@@ -0,0 +1,6 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_user_mfa_recovery_codes_user_id;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS user_mfa_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
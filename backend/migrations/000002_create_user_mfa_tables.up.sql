@@ -0,0 +1,24 @@
+-- 二要素認証設定テーブルの作成
+CREATE TABLE user_mfa (
+    user_id UUID PRIMARY KEY,
+    secret VARCHAR(64) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- リカバリーコードテーブルの作成
+CREATE TABLE user_mfa_recovery_codes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- インデックスの作成
+CREATE INDEX idx_user_mfa_recovery_codes_user_id ON user_mfa_recovery_codes(user_id);
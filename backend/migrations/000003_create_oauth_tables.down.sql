@@ -0,0 +1,8 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_oauth_states_expires_at;
+DROP INDEX IF EXISTS idx_user_identities_user_id_provider;
+DROP INDEX IF EXISTS idx_user_identities_provider_subject;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS oauth_states;
+DROP TABLE IF EXISTS user_identities;
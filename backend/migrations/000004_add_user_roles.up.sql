@@ -0,0 +1,7 @@
+-- 旧ロール 'user' を 'fan' に移行
+UPDATE users SET role = 'fan' WHERE role = 'user';
+
+-- ロールとロールバージョンの設定
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'fan';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role_version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('fan', 'creator', 'moderator', 'admin'));
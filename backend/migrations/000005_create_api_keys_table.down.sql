@@ -0,0 +1,6 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_api_keys_user_id;
+DROP INDEX IF EXISTS idx_api_keys_prefix;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS api_keys;
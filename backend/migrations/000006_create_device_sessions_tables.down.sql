@@ -0,0 +1,8 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_security_events_user_id_created_at;
+DROP INDEX IF EXISTS idx_device_sessions_user_id;
+DROP INDEX IF EXISTS idx_device_sessions_refresh_token_hash;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS security_events;
+DROP TABLE IF EXISTS device_sessions;
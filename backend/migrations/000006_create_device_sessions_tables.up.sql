@@ -0,0 +1,33 @@
+-- デバイスセッションテーブルの作成
+CREATE TABLE device_sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    refresh_token_hash VARCHAR(64) NOT NULL,
+    device_id VARCHAR(255) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    app_version VARCHAR(50) NOT NULL DEFAULT '',
+    fcm_token TEXT NOT NULL DEFAULT '',
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- セキュリティイベントテーブルの作成
+CREATE TABLE security_events (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    session_id UUID,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- インデックスの作成
+CREATE UNIQUE INDEX idx_device_sessions_refresh_token_hash ON device_sessions(refresh_token_hash);
+CREATE INDEX idx_device_sessions_user_id ON device_sessions(user_id);
+CREATE INDEX idx_security_events_user_id_created_at ON security_events(user_id, created_at DESC);
@@ -0,0 +1,2 @@
+-- テーブルの削除
+DROP TABLE IF EXISTS user_profiles;
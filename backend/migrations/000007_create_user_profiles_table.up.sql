@@ -0,0 +1,15 @@
+-- ユーザープロフィールテーブルの作成
+CREATE TABLE user_profiles (
+    user_id UUID PRIMARY KEY,
+    display_name VARCHAR(50) NOT NULL,
+    avatar_url TEXT NOT NULL DEFAULT '',
+    bio TEXT NOT NULL DEFAULT '',
+    birthday DATE,
+    favorite_oshi TEXT[] NOT NULL DEFAULT '{}',
+    locale VARCHAR(10) NOT NULL DEFAULT 'ja',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo',
+    privacy JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
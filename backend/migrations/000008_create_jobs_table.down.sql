@@ -0,0 +1,6 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_jobs_running_updated_at;
+DROP INDEX IF EXISTS idx_jobs_pending_run_at;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS jobs;
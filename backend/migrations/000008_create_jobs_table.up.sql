@@ -0,0 +1,20 @@
+-- バックグラウンドジョブテーブルの作成
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- インデックスの作成
+CREATE INDEX idx_jobs_pending_run_at ON jobs(run_at) WHERE status = 'pending';
+CREATE INDEX idx_jobs_running_updated_at ON jobs(updated_at) WHERE status = 'running';
+
+-- 制約の追加
+ALTER TABLE jobs ADD CONSTRAINT check_job_status CHECK (status IN ('pending', 'running', 'done', 'failed'));
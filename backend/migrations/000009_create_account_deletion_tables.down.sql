@@ -0,0 +1,10 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_data_exports_user_id_created_at;
+DROP INDEX IF EXISTS idx_account_deletions_user_id_scheduled;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS data_exports;
+DROP TABLE IF EXISTS account_deletions;
+
+-- ユーザーテーブルから退会日時を削除
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,37 @@
+-- ユーザーテーブルに退会日時を追加
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- 退会申請テーブルの作成
+CREATE TABLE account_deletions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    scheduled_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- データエクスポートテーブルの作成
+CREATE TABLE data_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    file_key VARCHAR(512) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- インデックスの作成
+CREATE UNIQUE INDEX idx_account_deletions_user_id_scheduled ON account_deletions(user_id) WHERE status = 'scheduled';
+CREATE INDEX idx_data_exports_user_id_created_at ON data_exports(user_id, created_at DESC);
+
+-- 制約の追加
+ALTER TABLE account_deletions ADD CONSTRAINT check_account_deletion_status CHECK (status IN ('scheduled', 'cancelled', 'completed'));
+ALTER TABLE data_exports ADD CONSTRAINT check_data_export_status CHECK (status IN ('pending', 'ready', 'failed'));
@@ -0,0 +1,12 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_contents_oshi_id;
+DROP INDEX IF EXISTS idx_oshis_creator_user_id;
+DROP INDEX IF EXISTS idx_oshis_tags;
+DROP INDEX IF EXISTS idx_oshis_group_name;
+DROP INDEX IF EXISTS idx_oshis_name;
+
+-- コンテンツから推しへの紐付けを削除
+ALTER TABLE contents DROP COLUMN IF EXISTS oshi_id;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS oshis;
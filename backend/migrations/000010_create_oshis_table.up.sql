@@ -0,0 +1,27 @@
+-- 推しテーブルの作成
+CREATE TABLE oshis (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    group_name VARCHAR(100) NOT NULL DEFAULT '',
+    agency VARCHAR(100) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    profile_images TEXT[] NOT NULL DEFAULT '{}',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    creator_user_id UUID,
+    official_diagnosis_result VARCHAR(100) NOT NULL DEFAULT '',
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (creator_user_id) REFERENCES users(id),
+    FOREIGN KEY (created_by) REFERENCES users(id)
+);
+
+-- コンテンツに推しへの紐付けを追加
+ALTER TABLE contents ADD COLUMN oshi_id UUID REFERENCES oshis(id) ON DELETE SET NULL;
+
+-- インデックスの作成
+CREATE INDEX idx_oshis_name ON oshis(name);
+CREATE INDEX idx_oshis_group_name ON oshis(group_name);
+CREATE INDEX idx_oshis_tags ON oshis USING GIN (tags);
+CREATE INDEX idx_oshis_creator_user_id ON oshis(creator_user_id);
+CREATE INDEX idx_contents_oshi_id ON contents(oshi_id);
@@ -0,0 +1,8 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_organization_invitations_user_id_status;
+DROP INDEX IF EXISTS idx_organization_members_user_id;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS organization_invitations;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
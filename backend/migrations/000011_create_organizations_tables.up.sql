@@ -0,0 +1,48 @@
+-- 組織テーブルの作成
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (created_by) REFERENCES users(id)
+);
+
+-- 組織メンバーテーブルの作成
+CREATE TABLE organization_members (
+    organization_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (organization_id, user_id),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- 組織への招待テーブルの作成
+CREATE TABLE organization_invitations (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    invited_by UUID NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (organization_id) REFERENCES organizations(id),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (invited_by) REFERENCES users(id)
+);
+
+-- インデックスの作成
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+CREATE INDEX idx_organization_invitations_user_id_status ON organization_invitations(user_id, status);
+
+-- 制約の追加
+ALTER TABLE organizations ADD CONSTRAINT check_organization_type CHECK (type IN ('group', 'agency'));
+ALTER TABLE organization_members ADD CONSTRAINT check_organization_member_role CHECK (role IN ('owner', 'manager', 'member'));
+ALTER TABLE organization_invitations ADD CONSTRAINT check_organization_invitation_role CHECK (role IN ('manager', 'member'));
+ALTER TABLE organization_invitations ADD CONSTRAINT check_organization_invitation_status CHECK (status IN ('pending', 'accepted', 'declined'));
@@ -0,0 +1,11 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_contents_oshi_id_created_at;
+DROP INDEX IF EXISTS idx_oshi_news_oshi_id_created_at;
+DROP INDEX IF EXISTS idx_oshi_follows_oshi_id;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS oshi_news;
+DROP TABLE IF EXISTS oshi_follows;
+
+-- 推しからフォロワー数を削除
+ALTER TABLE oshis DROP COLUMN IF EXISTS follower_count;
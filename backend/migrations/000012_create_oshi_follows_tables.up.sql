@@ -0,0 +1,29 @@
+-- 推しにフォロワー数を追加
+ALTER TABLE oshis ADD COLUMN follower_count INTEGER NOT NULL DEFAULT 0;
+
+-- 推しのフォローテーブルの作成
+CREATE TABLE oshi_follows (
+    user_id UUID NOT NULL,
+    oshi_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, oshi_id),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (oshi_id) REFERENCES oshis(id) ON DELETE CASCADE
+);
+
+-- 推しのお知らせテーブルの作成
+CREATE TABLE oshi_news (
+    id UUID PRIMARY KEY,
+    oshi_id UUID NOT NULL,
+    author_id UUID NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (oshi_id) REFERENCES oshis(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users(id)
+);
+
+-- インデックスの作成（フィードはフォロー中の推しごとに新しい順で読み取る）
+CREATE INDEX idx_oshi_follows_oshi_id ON oshi_follows(oshi_id);
+CREATE INDEX idx_oshi_news_oshi_id_created_at ON oshi_news(oshi_id, created_at DESC, id DESC);
+CREATE INDEX idx_contents_oshi_id_created_at ON contents(oshi_id, created_at DESC, id DESC);
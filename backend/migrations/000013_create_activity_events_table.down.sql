@@ -0,0 +1,6 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_activity_events_user_id_occurred_at;
+DROP INDEX IF EXISTS idx_activity_events_user_id_oshi_id_occurred_at;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS activity_events;
@@ -0,0 +1,17 @@
+-- 推し活の行動の記録テーブルの作成
+CREATE TABLE activity_events (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    oshi_id UUID NOT NULL,
+    activity_type VARCHAR(20) NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (oshi_id) REFERENCES oshis(id) ON DELETE CASCADE
+);
+
+-- インデックスの作成
+CREATE INDEX idx_activity_events_user_id_oshi_id_occurred_at ON activity_events(user_id, oshi_id, occurred_at);
+CREATE INDEX idx_activity_events_user_id_occurred_at ON activity_events(user_id, occurred_at);
+
+-- 制約の追加
+ALTER TABLE activity_events ADD CONSTRAINT check_activity_type CHECK (activity_type IN ('view', 'purchase', 'diagnosis', 'share'));
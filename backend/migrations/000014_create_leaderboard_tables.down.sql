@@ -0,0 +1,7 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_leaderboard_scores_user_id;
+DROP INDEX IF EXISTS idx_leaderboard_scores_ranking;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS account_flags;
+DROP TABLE IF EXISTS leaderboard_scores;
@@ -0,0 +1,30 @@
+-- ファンランキングの集計テーブルの作成
+-- 行動の記録ごとに日間・週間・月間・累計の集計単位へポイントを加算します
+CREATE TABLE leaderboard_scores (
+    oshi_id UUID NOT NULL,
+    period VARCHAR(20) NOT NULL,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    user_id UUID NOT NULL,
+    points DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (oshi_id, period, period_start, user_id),
+    FOREIGN KEY (oshi_id) REFERENCES oshis(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- 不正の疑いで指定されたアカウントテーブルの作成
+CREATE TABLE account_flags (
+    user_id UUID PRIMARY KEY,
+    reason TEXT NOT NULL DEFAULT '',
+    flagged_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (flagged_by) REFERENCES users(id)
+);
+
+-- インデックスの作成
+CREATE INDEX idx_leaderboard_scores_ranking ON leaderboard_scores(oshi_id, period, period_start, points DESC);
+CREATE INDEX idx_leaderboard_scores_user_id ON leaderboard_scores(user_id);
+
+-- 制約の追加
+ALTER TABLE leaderboard_scores ADD CONSTRAINT check_leaderboard_period CHECK (period IN ('daily', 'weekly', 'monthly', 'all_time'));
@@ -0,0 +1,7 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_point_ledger_entries_user_id_created_at;
+DROP INDEX IF EXISTS idx_point_ledger_entries_user_id_reference;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS point_ledger_entries;
+DROP TABLE IF EXISTS user_badges;
@@ -0,0 +1,24 @@
+-- 獲得したバッジテーブルの作成
+-- バッジの定義はアプリケーションの設定（badges.json）で管理します
+CREATE TABLE user_badges (
+    user_id UUID NOT NULL,
+    badge_id VARCHAR(100) NOT NULL,
+    awarded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, badge_id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- 推し活ポイントの台帳テーブルの作成
+CREATE TABLE point_ledger_entries (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    amount BIGINT NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- インデックスの作成
+CREATE UNIQUE INDEX idx_point_ledger_entries_user_id_reference ON point_ledger_entries(user_id, reference);
+CREATE INDEX idx_point_ledger_entries_user_id_created_at ON point_ledger_entries(user_id, created_at DESC);
@@ -0,0 +1,5 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_share_links_user_id;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS share_links;
@@ -0,0 +1,25 @@
+-- 診断結果の共有リンクテーブルの作成
+CREATE TABLE share_links (
+    id UUID PRIMARY KEY,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    user_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    result_id VARCHAR(100) NOT NULL,
+    oshi_id UUID,
+    title VARCHAR(100) NOT NULL,
+    summary TEXT NOT NULL DEFAULT '',
+    score INTEGER,
+    image_key TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (oshi_id) REFERENCES oshis(id) ON DELETE SET NULL
+);
+
+-- インデックスの作成
+CREATE INDEX idx_share_links_user_id ON share_links(user_id, created_at DESC);
+
+-- 制約の追加
+ALTER TABLE share_links ADD CONSTRAINT check_share_kind CHECK (kind IN ('diagnosis', 'compatibility'));
+ALTER TABLE share_links ADD CONSTRAINT check_share_score CHECK (score IS NULL OR (score >= 0 AND score <= 100));
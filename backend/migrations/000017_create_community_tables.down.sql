@@ -0,0 +1,11 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_community_reactions_user_id;
+DROP INDEX IF EXISTS idx_community_comments_user_id;
+DROP INDEX IF EXISTS idx_community_comments_post_id_created_at;
+DROP INDEX IF EXISTS idx_community_posts_user_id;
+DROP INDEX IF EXISTS idx_community_posts_oshi_id_created_at;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS community_reactions;
+DROP TABLE IF EXISTS community_comments;
+DROP TABLE IF EXISTS community_posts;
@@ -0,0 +1,54 @@
+-- コミュニティの投稿テーブルの作成
+CREATE TABLE community_posts (
+    id UUID PRIMARY KEY,
+    oshi_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    image_urls TEXT[] NOT NULL DEFAULT '{}',
+    comment_count INTEGER NOT NULL DEFAULT 0,
+    hidden_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (oshi_id) REFERENCES oshis(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- 投稿へのコメントテーブルの作成
+CREATE TABLE community_comments (
+    id UUID PRIMARY KEY,
+    post_id UUID NOT NULL,
+    parent_id UUID,
+    user_id UUID NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    reply_count INTEGER NOT NULL DEFAULT 0,
+    hidden_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (post_id) REFERENCES community_posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES community_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- 投稿とコメントへのリアクションテーブルの作成
+CREATE TABLE community_reactions (
+    target_type VARCHAR(20) NOT NULL,
+    target_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    reaction VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (target_type, target_id, user_id, reaction),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- インデックスの作成（一覧は推しごと・投稿ごとに作成日時とIDの順で読み取る）
+CREATE INDEX idx_community_posts_oshi_id_created_at ON community_posts(oshi_id, created_at DESC, id DESC);
+CREATE INDEX idx_community_posts_user_id ON community_posts(user_id);
+CREATE INDEX idx_community_comments_post_id_created_at ON community_comments(post_id, parent_id, created_at, id);
+CREATE INDEX idx_community_comments_user_id ON community_comments(user_id);
+CREATE INDEX idx_community_reactions_user_id ON community_reactions(user_id);
+
+-- 制約の追加
+ALTER TABLE community_reactions ADD CONSTRAINT check_reaction_target_type CHECK (target_type IN ('post', 'comment'));
+ALTER TABLE community_reactions ADD CONSTRAINT check_reaction_type CHECK (reaction IN ('like', 'love', 'cheer', 'wow', 'cry'));
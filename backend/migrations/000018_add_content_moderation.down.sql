@@ -0,0 +1,15 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_content_moderation_events_content_id;
+DROP INDEX IF EXISTS idx_content_reports_reporter_id;
+DROP INDEX IF EXISTS idx_contents_report_count;
+DROP INDEX IF EXISTS idx_contents_moderation_status;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS content_moderation_events;
+DROP TABLE IF EXISTS content_reports;
+
+-- コンテンツから審査状態を削除
+ALTER TABLE contents DROP CONSTRAINT IF EXISTS check_moderation_status;
+ALTER TABLE contents DROP COLUMN IF EXISTS report_count;
+ALTER TABLE contents DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE contents DROP COLUMN IF EXISTS moderation_status;
@@ -0,0 +1,47 @@
+-- コンテンツに審査状態を追加（既存のコンテンツは承認済みとし、新しいコンテンツは審査待ちから始める）
+ALTER TABLE contents ADD COLUMN moderation_status VARCHAR(20) NOT NULL DEFAULT 'approved';
+ALTER TABLE contents ALTER COLUMN moderation_status SET DEFAULT 'pending';
+ALTER TABLE contents ADD COLUMN hidden_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE contents ADD COLUMN report_count INTEGER NOT NULL DEFAULT 0;
+
+-- コンテンツの通報テーブルの作成
+CREATE TABLE content_reports (
+    id UUID PRIMARY KEY,
+    content_id UUID NOT NULL,
+    reporter_id UUID NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    resolved_by UUID,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (content_id, reporter_id),
+    FOREIGN KEY (content_id) REFERENCES contents(id) ON DELETE CASCADE,
+    FOREIGN KEY (reporter_id) REFERENCES users(id),
+    FOREIGN KEY (resolved_by) REFERENCES users(id)
+);
+
+-- コンテンツの審査履歴テーブルの作成
+CREATE TABLE content_moderation_events (
+    id UUID PRIMARY KEY,
+    content_id UUID NOT NULL,
+    actor_id UUID,
+    action VARCHAR(20) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (content_id) REFERENCES contents(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id)
+);
+
+-- インデックスの作成
+CREATE INDEX idx_contents_moderation_status ON contents(moderation_status, created_at);
+CREATE INDEX idx_contents_report_count ON contents(report_count DESC) WHERE report_count > 0;
+CREATE INDEX idx_content_reports_reporter_id ON content_reports(reporter_id);
+CREATE INDEX idx_content_moderation_events_content_id ON content_moderation_events(content_id, created_at);
+
+-- 制約の追加
+ALTER TABLE contents ADD CONSTRAINT check_moderation_status CHECK (moderation_status IN ('pending', 'approved', 'rejected', 'taken_down'));
+ALTER TABLE content_reports ADD CONSTRAINT check_report_reason CHECK (reason IN ('copyright', 'inappropriate', 'spam', 'harassment', 'other'));
+ALTER TABLE content_reports ADD CONSTRAINT check_report_status CHECK (status IN ('open', 'resolved', 'dismissed'));
@@ -0,0 +1,6 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_user_relations_user_id_created_at;
+DROP INDEX IF EXISTS idx_user_relations_target_id;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS user_relations;
@@ -0,0 +1,18 @@
+-- ユーザー間のブロック・ミュートテーブルの作成
+CREATE TABLE user_relations (
+    user_id UUID NOT NULL,
+    target_id UUID NOT NULL,
+    relation_type VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, target_id),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (target_id) REFERENCES users(id)
+);
+
+-- インデックスの作成
+CREATE INDEX idx_user_relations_target_id ON user_relations(target_id, user_id) WHERE relation_type = 'block';
+CREATE INDEX idx_user_relations_user_id_created_at ON user_relations(user_id, relation_type, created_at DESC);
+
+-- 制約の追加
+ALTER TABLE user_relations ADD CONSTRAINT check_user_relation_type CHECK (relation_type IN ('block', 'mute'));
+ALTER TABLE user_relations ADD CONSTRAINT check_user_relation_not_self CHECK (user_id <> target_id);
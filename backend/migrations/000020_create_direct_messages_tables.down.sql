@@ -0,0 +1,10 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_direct_messages_sender_id_created_at;
+DROP INDEX IF EXISTS idx_direct_messages_conversation_id_created_at;
+DROP INDEX IF EXISTS idx_conversations_creator_id_updated_at;
+DROP INDEX IF EXISTS idx_conversations_fan_id_updated_at;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS message_tickets;
+DROP TABLE IF EXISTS direct_messages;
+DROP TABLE IF EXISTS conversations;
@@ -0,0 +1,49 @@
+-- ファンとキャストのやり取りテーブルの作成
+CREATE TABLE conversations (
+    id UUID PRIMARY KEY,
+    fan_id UUID NOT NULL,
+    creator_id UUID NOT NULL,
+    fan_plan VARCHAR(20) NOT NULL DEFAULT '',
+    last_message_at TIMESTAMP WITH TIME ZONE,
+    last_sender_id UUID,
+    fan_last_read_at TIMESTAMP WITH TIME ZONE,
+    creator_last_read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (fan_id, creator_id),
+    FOREIGN KEY (fan_id) REFERENCES users(id),
+    FOREIGN KEY (creator_id) REFERENCES users(id)
+);
+
+-- やり取りの中のメッセージテーブルの作成
+CREATE TABLE direct_messages (
+    id UUID PRIMARY KEY,
+    conversation_id UUID NOT NULL,
+    sender_id UUID NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    attachment_urls TEXT[] NOT NULL DEFAULT '{}',
+    charge VARCHAR(10) NOT NULL DEFAULT 'none',
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE,
+    FOREIGN KEY (sender_id) REFERENCES users(id)
+);
+
+-- メッセージチケットの残り枚数テーブルの作成
+CREATE TABLE message_tickets (
+    user_id UUID PRIMARY KEY,
+    balance INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- インデックスの作成
+CREATE INDEX idx_conversations_fan_id_updated_at ON conversations(fan_id, updated_at DESC, id DESC);
+CREATE INDEX idx_conversations_creator_id_updated_at ON conversations(creator_id, updated_at DESC, id DESC);
+CREATE INDEX idx_direct_messages_conversation_id_created_at ON direct_messages(conversation_id, created_at DESC, id DESC);
+CREATE INDEX idx_direct_messages_sender_id_created_at ON direct_messages(sender_id, created_at DESC) WHERE charge = 'plan';
+
+-- 制約の追加
+ALTER TABLE conversations ADD CONSTRAINT check_conversation_not_self CHECK (fan_id <> creator_id);
+ALTER TABLE direct_messages ADD CONSTRAINT check_direct_message_charge CHECK (charge IN ('none', 'plan', 'ticket'));
+ALTER TABLE message_tickets ADD CONSTRAINT check_message_ticket_balance CHECK (balance >= 0);
@@ -0,0 +1,10 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_push_deliveries_user_id_created_at;
+DROP INDEX IF EXISTS idx_push_notifications_user_id_deliver_after;
+DROP INDEX IF EXISTS idx_device_tokens_user_id;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS push_deliveries;
+DROP TABLE IF EXISTS push_notifications;
+DROP TABLE IF EXISTS notification_settings;
+DROP TABLE IF EXISTS device_tokens;
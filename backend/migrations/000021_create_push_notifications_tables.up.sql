@@ -0,0 +1,63 @@
+-- プッシュ通知の送信先の端末テーブルの作成
+CREATE TABLE device_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    platform VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- 通知設定テーブルの作成
+CREATE TABLE notification_settings (
+    user_id UUID PRIMARY KEY,
+    quiet_start VARCHAR(5),
+    quiet_end VARCHAR(5),
+    disabled_categories TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- プッシュ通知テーブルの作成
+CREATE TABLE push_notifications (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    template VARCHAR(100) NOT NULL,
+    collapse_key VARCHAR(100) NOT NULL DEFAULT '',
+    params JSONB NOT NULL DEFAULT '{}',
+    data JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    deliver_after TIMESTAMP WITH TIME ZONE NOT NULL,
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- プッシュ通知の送信記録テーブルの作成
+CREATE TABLE push_deliveries (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    notification_ids UUID[] NOT NULL,
+    device_token_id UUID NOT NULL,
+    platform VARCHAR(10) NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    provider_message_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- インデックスの作成
+CREATE INDEX idx_device_tokens_user_id ON device_tokens(user_id);
+CREATE INDEX idx_push_notifications_user_id_deliver_after ON push_notifications(user_id, deliver_after) WHERE status IN ('pending', 'sending');
+CREATE INDEX idx_push_deliveries_user_id_created_at ON push_deliveries(user_id, created_at DESC);
+
+-- 制約の追加
+ALTER TABLE device_tokens ADD CONSTRAINT check_device_token_platform CHECK (platform IN ('ios', 'android', 'web'));
+ALTER TABLE push_notifications ADD CONSTRAINT check_push_notification_status CHECK (status IN ('pending', 'sending', 'sent', 'failed', 'skipped'));
+ALTER TABLE push_deliveries ADD CONSTRAINT check_push_delivery_status CHECK (status IN ('sent', 'failed', 'invalid_token'));
@@ -0,0 +1,6 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_notifications_user_id_unread;
+DROP INDEX IF EXISTS idx_notifications_user_id_created_at;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,16 @@
+-- 通知の受信箱テーブルの作成
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    template VARCHAR(100) NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}',
+    data JSONB NOT NULL DEFAULT '{}',
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- インデックスの作成
+CREATE INDEX idx_notifications_user_id_created_at ON notifications(user_id, created_at DESC, id DESC);
+CREATE INDEX idx_notifications_user_id_unread ON notifications(user_id) WHERE read_at IS NULL;
@@ -0,0 +1,7 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_emails_provider_message_id;
+DROP INDEX IF EXISTS idx_emails_user_id_created_at;
+
+-- テーブルの削除
+DROP TABLE IF EXISTS email_suppressions;
+DROP TABLE IF EXISTS emails;
@@ -0,0 +1,35 @@
+-- メールの送信記録テーブルの作成
+CREATE TABLE emails (
+    id UUID PRIMARY KEY,
+    user_id UUID,
+    to_address VARCHAR(320) NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    template VARCHAR(100) NOT NULL,
+    locale VARCHAR(5) NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    provider_message_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- メールの送信停止テーブルの作成
+-- category が空の場合は全ての種類のメールを停止する
+CREATE TABLE email_suppressions (
+    email VARCHAR(320) NOT NULL,
+    category VARCHAR(20) NOT NULL DEFAULT '',
+    reason VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (email, category)
+);
+
+-- インデックスの作成
+CREATE INDEX idx_emails_user_id_created_at ON emails(user_id, created_at DESC);
+CREATE INDEX idx_emails_provider_message_id ON emails(provider_message_id) WHERE provider_message_id <> '';
+
+-- 制約の追加
+ALTER TABLE emails ADD CONSTRAINT check_email_status CHECK (status IN ('queued', 'sent', 'failed', 'suppressed', 'bounced'));
+ALTER TABLE email_suppressions ADD CONSTRAINT check_email_suppression_reason CHECK (reason IN ('bounce', 'complaint', 'unsubscribe'));
@@ -0,0 +1,8 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_contents_oshi_id_available_at;
+
+-- コンテンツから公開期間を削除
+ALTER TABLE contents DROP CONSTRAINT IF EXISTS check_availability_window;
+ALTER TABLE contents DROP COLUMN IF EXISTS released_at;
+ALTER TABLE contents DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE contents DROP COLUMN IF EXISTS publish_at;
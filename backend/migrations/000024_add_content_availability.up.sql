@@ -0,0 +1,11 @@
+-- コンテンツに公開期間を追加（既存の公開済みのコンテンツは公開を知らせたものとする）
+ALTER TABLE contents ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE contents ADD COLUMN unpublish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE contents ADD COLUMN released_at TIMESTAMP WITH TIME ZONE;
+UPDATE contents SET released_at = created_at WHERE moderation_status IN ('approved', 'taken_down');
+
+-- インデックスの作成
+CREATE INDEX idx_contents_oshi_id_available_at ON contents(oshi_id, (COALESCE(publish_at, created_at)) DESC, id DESC);
+
+-- 制約の追加
+ALTER TABLE contents ADD CONSTRAINT check_availability_window CHECK (publish_at IS NULL OR unpublish_at IS NULL OR unpublish_at > publish_at);
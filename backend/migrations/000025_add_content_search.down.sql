@@ -0,0 +1,9 @@
+-- インデックスの削除
+DROP INDEX IF EXISTS idx_contents_search_unindexed;
+DROP INDEX IF EXISTS idx_contents_price;
+DROP INDEX IF EXISTS idx_contents_available_at;
+DROP INDEX IF EXISTS idx_contents_search_tokens;
+
+-- コンテンツから検索用の列を削除
+ALTER TABLE contents DROP COLUMN IF EXISTS search_tokens;
+ALTER TABLE contents DROP COLUMN IF EXISTS search_text;
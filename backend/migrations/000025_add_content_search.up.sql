@@ -0,0 +1,9 @@
+-- コンテンツに検索用の列を追加（既存のコンテンツの索引はアプリケーションの起動時に作成する）
+ALTER TABLE contents ADD COLUMN search_text TEXT NOT NULL DEFAULT '';
+ALTER TABLE contents ADD COLUMN search_tokens TEXT[];
+
+-- インデックスの作成
+CREATE INDEX idx_contents_search_tokens ON contents USING GIN (search_tokens);
+CREATE INDEX idx_contents_available_at ON contents((COALESCE(publish_at, created_at)) DESC, id DESC);
+CREATE INDEX idx_contents_price ON contents(price, id);
+CREATE INDEX idx_contents_search_unindexed ON contents(id) WHERE search_tokens IS NULL;
@@ -0,0 +1,3 @@
+-- 二要素認証の失敗回数とロック期限を削除
+ALTER TABLE user_mfa DROP COLUMN IF EXISTS locked_until;
+ALTER TABLE user_mfa DROP COLUMN IF EXISTS failed_attempts;
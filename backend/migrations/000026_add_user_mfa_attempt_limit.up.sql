@@ -0,0 +1,3 @@
+-- 二要素認証の失敗回数とロック期限を追加
+ALTER TABLE user_mfa ADD COLUMN failed_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE user_mfa ADD COLUMN locked_until TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,3 @@
+-- 組織が所有するAPIキーのための組織IDを削除
+DROP INDEX IF EXISTS idx_api_keys_organization_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS organization_id;
@@ -0,0 +1,4 @@
+-- 組織が所有するAPIキーのための組織IDを追加
+ALTER TABLE api_keys ADD COLUMN organization_id UUID REFERENCES organizations(id);
+
+CREATE INDEX idx_api_keys_organization_id ON api_keys(organization_id);
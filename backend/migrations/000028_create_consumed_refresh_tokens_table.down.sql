@@ -0,0 +1,2 @@
+-- 使用済みのリフレッシュトークンのテーブルの削除
+DROP TABLE IF EXISTS consumed_refresh_tokens;
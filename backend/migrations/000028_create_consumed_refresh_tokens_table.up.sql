@@ -0,0 +1,11 @@
+-- ローテーションで使用済みになったリフレッシュトークンのテーブルの作成
+-- 使用済みのトークンが再び使われた場合は、トークンが漏洩したものとしてセッションを失効させる
+CREATE TABLE consumed_refresh_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    session_id UUID NOT NULL,
+    consumed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (session_id) REFERENCES device_sessions(id)
+);
+
+-- インデックスの作成
+CREATE INDEX idx_consumed_refresh_tokens_session_id ON consumed_refresh_tokens(session_id);
@@ -0,0 +1,9 @@
+-- プッシュ通知のテーブルのユーザーへの外部キーを連鎖削除に戻す
+ALTER TABLE device_tokens DROP CONSTRAINT device_tokens_user_id_fkey;
+ALTER TABLE device_tokens ADD CONSTRAINT device_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE notification_settings DROP CONSTRAINT notification_settings_user_id_fkey;
+ALTER TABLE notification_settings ADD CONSTRAINT notification_settings_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE push_notifications DROP CONSTRAINT push_notifications_user_id_fkey;
+ALTER TABLE push_notifications ADD CONSTRAINT push_notifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE push_deliveries DROP CONSTRAINT push_deliveries_user_id_fkey;
+ALTER TABLE push_deliveries ADD CONSTRAINT push_deliveries_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+-- 通知の受信箱のユーザーへの外部キーを連鎖削除に戻す
+ALTER TABLE notifications DROP CONSTRAINT notifications_user_id_fkey;
+ALTER TABLE notifications ADD CONSTRAINT notifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
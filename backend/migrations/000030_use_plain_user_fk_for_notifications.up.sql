@@ -0,0 +1,4 @@
+-- 通知の受信箱のユーザーへの外部キーを、他のテーブルと同じく連鎖削除しない外部キーに変更
+-- 退会時の削除は account_repository の purgeStatements で明示的に行う
+ALTER TABLE notifications DROP CONSTRAINT notifications_user_id_fkey;
+ALTER TABLE notifications ADD CONSTRAINT notifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
@@ -0,0 +1,3 @@
+-- メールの送信記録のユーザーへの外部キーを削除時に NULL にする外部キーに戻す
+ALTER TABLE emails DROP CONSTRAINT emails_user_id_fkey;
+ALTER TABLE emails ADD CONSTRAINT emails_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
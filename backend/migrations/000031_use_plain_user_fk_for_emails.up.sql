@@ -0,0 +1,4 @@
+-- メールの送信記録のユーザーへの外部キーを、他のテーブルと同じく削除時に変更しない外部キーに変更
+-- 退会時の削除は account_repository の purgeStatements で明示的に行う
+ALTER TABLE emails DROP CONSTRAINT emails_user_id_fkey;
+ALTER TABLE emails ADD CONSTRAINT emails_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
@@ -0,0 +1,2 @@
+-- 認可フローを開始したクライアントに状態を結び付けるための値のハッシュを削除
+ALTER TABLE oauth_states DROP COLUMN IF EXISTS binding_hash;
@@ -0,0 +1,2 @@
+-- 認可フローを開始したクライアントに状態を結び付けるための値のハッシュを追加
+ALTER TABLE oauth_states ADD COLUMN binding_hash VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"kimiyomi/backend/src/api/middleware"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AccountHandler は退会とデータエクスポートに関するAPIハンドラーです
+type AccountHandler struct {
+	accountUseCase *usecase.AccountUseCase
+}
+
+// NewAccountHandler は新しいAccountHandlerを作成します
+func NewAccountHandler(accountUseCase *usecase.AccountUseCase) *AccountHandler {
+	return &AccountHandler{
+		accountUseCase: accountUseCase,
+	}
+}
+
+// RequestDeletionRequest は退会申請のリクエストです
+type RequestDeletionRequest struct {
+	Password string `json:"password"`
+	Reason   string `json:"reason"`
+}
+
+// DataExportResponse はデータエクスポートの状態のレスポンスです
+type DataExportResponse struct {
+	*entity.DataExport
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// RequestDeletion は退会を申請します
+func (h *AccountHandler) RequestDeletion(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req RequestDeletionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	deletion, err := h.accountUseCase.RequestDeletion(c.Request.Context(), usecase.RequestDeletionInput{
+		UserID:   userID,
+		Password: req.Password,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, deletion)
+}
+
+// GetDeletion は削除予定の退会申請を返します
+func (h *AccountHandler) GetDeletion(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	deletion, err := h.accountUseCase.GetDeletion(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deletion)
+}
+
+// CancelDeletion は猶予期間中の退会申請を取り消します
+func (h *AccountHandler) CancelDeletion(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	deletion, err := h.accountUseCase.CancelDeletion(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deletion)
+}
+
+// RequestExport はユーザーデータのエクスポートを要求します
+func (h *AccountHandler) RequestExport(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.accountUseCase.RequestExport(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, DataExportResponse{DataExport: export})
+}
+
+// GetExport はデータエクスポートの状態とダウンロードURLを返します
+func (h *AccountHandler) GetExport(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid export id"})
+		return
+	}
+
+	export, url, err := h.accountUseCase.GetExport(c.Request.Context(), userID, exportID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, DataExportResponse{DataExport: export, DownloadURL: url})
+}
+
+// currentUserID はログイン中のユーザーIDを取得します
+// 退会とエクスポートは本人の操作に限定するため、APIキーでの操作は拒否します
+func (h *AccountHandler) currentUserID(c *gin.Context) (string, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return "", false
+	}
+	if middleware.IsAPIKeyRequest(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "account operations are not allowed with an api key"})
+		return "", false
+	}
+	return userID.(string), true
+}
+
+// handleError はエラーをHTTPレスポンスに変換します
+func (h *AccountHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound),
+		errors.Is(err, usecase.ErrDeletionNotFound),
+		errors.Is(err, usecase.ErrExportNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrDeletionAlreadyRequested),
+		errors.Is(err, usecase.ErrExportInProgress):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "account operation failed"})
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *AccountHandler) RegisterRoutes(r *gin.RouterGroup) {
+	me := r.Group("/me")
+	{
+		me.GET("/deletion", h.GetDeletion)
+		me.POST("/deletion", h.RequestDeletion)
+		me.DELETE("/deletion", h.CancelDeletion)
+		me.POST("/export", h.RequestExport)
+		me.GET("/exports/:id", h.GetExport)
+	}
+}
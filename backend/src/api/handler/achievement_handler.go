@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AchievementHandler はバッジと推し活ポイントに関するAPIハンドラーです
+type AchievementHandler struct {
+	achievementUseCase *usecase.AchievementUseCase
+}
+
+// NewAchievementHandler は新しいAchievementHandlerを作成します
+func NewAchievementHandler(achievementUseCase *usecase.AchievementUseCase) *AchievementHandler {
+	return &AchievementHandler{
+		achievementUseCase: achievementUseCase,
+	}
+}
+
+// ListBadges はログイン中のユーザーのバッジの獲得状況を返します
+func (h *AchievementHandler) ListBadges(c *gin.Context) {
+	badges, err := h.achievementUseCase.ListBadges(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list badges"})
+		return
+	}
+
+	c.JSON(http.StatusOK, badges)
+}
+
+// GetPoints はログイン中のユーザーのポイント残高と履歴を返します
+func (h *AchievementHandler) GetPoints(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	points, err := h.achievementUseCase.GetPoints(c.Request.Context(), c.GetString("user_id"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get points"})
+		return
+	}
+
+	c.JSON(http.StatusOK, points)
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *AchievementHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/me/badges", h.ListBadges)
+	r.GET("/me/points", h.GetPoints)
+}
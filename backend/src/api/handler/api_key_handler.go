@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"kimiyomi/backend/src/api/middleware"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// APIKeyHandler はAPIキー関連のAPIハンドラーです
+type APIKeyHandler struct {
+	apiKeyUseCase *usecase.APIKeyUseCase
+}
+
+// NewAPIKeyHandler は新しいAPIKeyHandlerを作成します
+func NewAPIKeyHandler(apiKeyUseCase *usecase.APIKeyUseCase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+	}
+}
+
+// CreateAPIKeyRequest はAPIキー作成のリクエストです
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	// OrganizationID を指定した場合は組織が所有するキーを発行します
+	OrganizationID     *uuid.UUID `json:"organization_id"`
+	Scopes             []string   `json:"scopes" binding:"required,min=1"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute"`
+	ExpiresAt          *time.Time `json:"expires_at"`
+}
+
+// CreateAPIKey はAPIキーを発行します
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at must be in the future"})
+		return
+	}
+
+	userID, ok := h.sessionUserID(c)
+	if !ok {
+		return
+	}
+
+	key, err := h.apiKeyUseCase.Create(c.Request.Context(), usecase.CreateAPIKeyInput{
+		UserID:             userID,
+		OrganizationID:     req.OrganizationID,
+		Name:               req.Name,
+		Scopes:             req.Scopes,
+		RateLimitPerMinute: req.RateLimitPerMinute,
+		ExpiresAt:          req.ExpiresAt,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// ListAPIKeys はAPIキーの一覧を返します
+// organization_id を指定した場合は組織が所有するAPIキーの一覧を返します
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	var orgID *uuid.UUID
+	if v := c.Query("organization_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+			return
+		}
+		orgID = &id
+	}
+
+	userID, ok := h.sessionUserID(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.apiKeyUseCase.List(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"api_keys": keys})
+}
+
+// RevokeAPIKey はAPIキーを失効させます
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid api key id"})
+		return
+	}
+
+	userID, ok := h.sessionUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.apiKeyUseCase.Revoke(c.Request.Context(), userID, id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// sessionUserID はログインセッションのユーザーIDを返します
+// APIキー自身でAPIキーを管理できないよう、APIキーによる認証は拒否します
+func (h *APIKeyHandler) sessionUserID(c *gin.Context) (string, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return "", false
+	}
+	if middleware.IsAPIKeyRequest(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "api keys cannot be managed with an api key"})
+		return "", false
+	}
+	return userID.(string), true
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *APIKeyHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrAPIKeyNotFound), errors.Is(err, usecase.ErrUserNotFound),
+		errors.Is(err, usecase.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidAPIKeyScope), errors.Is(err, usecase.ErrInvalidRateLimit):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrAPIKeyScopeNotAllowed), errors.Is(err, usecase.ErrOrganizationForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrAPIKeyLimitReached):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "api key operation failed"})
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *APIKeyHandler) RegisterRoutes(r *gin.RouterGroup) {
+	keys := r.Group("/api-keys")
+	{
+		keys.GET("", h.ListAPIKeys)
+		keys.POST("", h.CreateAPIKey)
+		keys.DELETE("/:id", h.RevokeAPIKey)
+	}
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
 	"kimiyomi/backend/src/usecase"
 
 	"github.com/gin-gonic/gin"
@@ -11,15 +13,24 @@ import (
 
 // AuthHandler は認証関連のエンドポイントを提供します
 type AuthHandler struct {
-	authUseCase  usecase.AuthUseCase
-	tokenService auth.TokenService
+	authUseCase    usecase.AuthUseCase
+	mfaUseCase     *usecase.MFAUseCase
+	sessionUseCase *usecase.SessionUseCase
+	tokenService   auth.TokenService
 }
 
 // NewAuthHandler は新しいAuthHandlerを作成します
-func NewAuthHandler(authUseCase usecase.AuthUseCase, tokenService auth.TokenService) *AuthHandler {
+func NewAuthHandler(
+	authUseCase usecase.AuthUseCase,
+	mfaUseCase *usecase.MFAUseCase,
+	sessionUseCase *usecase.SessionUseCase,
+	tokenService auth.TokenService,
+) *AuthHandler {
 	return &AuthHandler{
-		authUseCase:  authUseCase,
-		tokenService: tokenService,
+		authUseCase:    authUseCase,
+		mfaUseCase:     mfaUseCase,
+		sessionUseCase: sessionUseCase,
+		tokenService:   tokenService,
 	}
 }
 
@@ -33,10 +44,19 @@ type LoginRequest struct {
 type LoginResponse struct {
 	Token        string `json:"token"`
 	RefreshToken string `json:"refresh_token"`
+	SessionID    string `json:"session_id"`
 	UserID       string `json:"user_id"`
 	Role         string `json:"role"`
 }
 
+// MFAChallengeResponse は二要素認証が必要な場合のログインレスポンスです
+type MFAChallengeResponse struct {
+	MFARequired           bool   `json:"mfa_required"`
+	MFAEnrollmentRequired bool   `json:"mfa_enrollment_required"`
+	MFAToken              string `json:"mfa_token"`
+	ExpiresIn             int    `json:"expires_in"`
+}
+
 // Login はユーザーログインを処理します
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
@@ -51,24 +71,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := h.tokenService.GenerateToken(user.ID, user.Role)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
-		return
-	}
-
-	refreshToken, err := h.tokenService.GenerateToken(user.ID, user.Role)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate refresh token"})
-		return
-	}
-
-	c.JSON(http.StatusOK, LoginResponse{
-		Token:        token,
-		RefreshToken: refreshToken,
-		UserID:       user.ID,
-		Role:         user.Role,
-	})
+	respondLogin(c, h.mfaUseCase, h.sessionUseCase, h.tokenService, user, http.StatusOK)
 }
 
 // RefreshTokenRequest はトークンリフレッシュリクエストの構造を定義します
@@ -77,6 +80,7 @@ type RefreshTokenRequest struct {
 }
 
 // RefreshToken はトークンのリフレッシュを処理します
+// リフレッシュトークンはローテーションされるため、レスポンスの新しい値を保存する必要があります
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -84,14 +88,27 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	newToken, err := h.tokenService.RefreshToken(req.RefreshToken)
+	tokens, user, err := h.sessionUseCase.Refresh(c.Request.Context(), req.RefreshToken, deviceInfoFromRequest(c))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+		if errors.Is(err, usecase.ErrInvalidRefreshToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+			return
+		}
+		// ログインし直すと二要素認証の登録を求められる
+		if errors.Is(err, usecase.ErrMFAEnrollmentRequired) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to refresh token"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token": newToken,
+	c.JSON(http.StatusOK, LoginResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		SessionID:    tokens.SessionID,
+		UserID:       user.ID,
+		Role:         user.Role,
 	})
 }
 
@@ -116,22 +133,78 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	token, err := h.tokenService.GenerateToken(user.ID, user.Role)
+	res, err := newLoginResponse(c, h.sessionUseCase, user.ID, user.Role, user.RoleVersion)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
 		return
 	}
 
-	refreshToken, err := h.tokenService.GenerateToken(user.ID, user.Role)
+	c.JSON(http.StatusCreated, res)
+}
+
+// respondLogin はログイン成功時のレスポンスを返します
+// 二要素認証が有効、またはロールで必須の場合はトークンの代わりにチャレンジトークンを返します
+func respondLogin(
+	c *gin.Context,
+	mfaUseCase *usecase.MFAUseCase,
+	sessionUseCase *usecase.SessionUseCase,
+	tokenService auth.TokenService,
+	user *entity.User,
+	status int,
+) {
+	mfaEnabled, err := mfaUseCase.IsEnabled(c.Request.Context(), user.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate refresh token"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check mfa status"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, LoginResponse{
-		Token:        token,
-		RefreshToken: refreshToken,
-		UserID:       user.ID,
-		Role:         user.Role,
+	if mfaEnabled || user.RequiresMFA() {
+		purpose := auth.PurposeMFAChallenge
+		if !mfaEnabled {
+			purpose = auth.PurposeMFAEnrollment
+		}
+
+		mfaToken, err := tokenService.GenerateMFAToken(user.ID, user.Role, user.RoleVersion, purpose)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate mfa token"})
+			return
+		}
+
+		c.JSON(http.StatusOK, MFAChallengeResponse{
+			MFARequired:           mfaEnabled,
+			MFAEnrollmentRequired: !mfaEnabled,
+			MFAToken:              mfaToken,
+			ExpiresIn:             int(auth.MFATokenDuration.Seconds()),
+		})
+		return
+	}
+
+	res, err := newLoginResponse(c, sessionUseCase, user.ID, user.Role, user.RoleVersion)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
+		return
+	}
+
+	c.JSON(status, res)
+}
+
+// newLoginResponse はリクエスト元の端末のセッションを作成し、アクセストークンとリフレッシュトークンを発行します
+func newLoginResponse(c *gin.Context, sessionUseCase *usecase.SessionUseCase, userID, role string, roleVersion int) (*LoginResponse, error) {
+	tokens, err := sessionUseCase.StartSession(c.Request.Context(), usecase.StartSessionInput{
+		UserID:      userID,
+		Role:        role,
+		RoleVersion: roleVersion,
+		Device:      deviceInfoFromRequest(c),
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		SessionID:    tokens.SessionID,
+		UserID:       userID,
+		Role:         role,
+	}, nil
 }
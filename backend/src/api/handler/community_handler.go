@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"kimiyomi/backend/src/api/middleware"
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CommunityHandler は推しのページのコミュニティに関するAPIハンドラーです
+type CommunityHandler struct {
+	communityUseCase *usecase.CommunityUseCase
+}
+
+// NewCommunityHandler は新しいCommunityHandlerを作成します
+func NewCommunityHandler(communityUseCase *usecase.CommunityUseCase) *CommunityHandler {
+	return &CommunityHandler{
+		communityUseCase: communityUseCase,
+	}
+}
+
+// CreatePost は推しのページに投稿します
+// 本文は body、画像は images（最大4枚）としてマルチパートで送信します
+func (h *CommunityHandler) CreatePost(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	var images []*multipart.FileHeader
+	if form, err := c.MultipartForm(); err == nil {
+		images = form.File["images"]
+	}
+
+	post, err := h.communityUseCase.CreatePost(c.Request.Context(), c.GetString("user_id"), id, c.PostForm("body"), images)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, post)
+}
+
+// ListPosts は推しのページの投稿を新しい順に返します
+// 次のページは前のレスポンスの next_cursor を cursor に指定して取得します
+func (h *CommunityHandler) ListPosts(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.communityUseCase.ListPosts(c.Request.Context(), c.GetString("user_id"), id, c.Query("cursor"), limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// GetPost は投稿を返します
+func (h *CommunityHandler) GetPost(c *gin.Context) {
+	postID, ok := parseUUIDParam(c, "postId", "invalid post id")
+	if !ok {
+		return
+	}
+
+	post, err := h.communityUseCase.GetPost(c.Request.Context(), c.GetString("user_id"), postID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// DeletePost は投稿を削除します
+func (h *CommunityHandler) DeletePost(c *gin.Context) {
+	postID, ok := parseUUIDParam(c, "postId", "invalid post id")
+	if !ok {
+		return
+	}
+
+	if err := h.communityUseCase.DeletePost(c.Request.Context(), communityActor(c), postID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateCommentRequest はコメントのリクエストです
+type CreateCommentRequest struct {
+	Body     string     `json:"body" binding:"required"`
+	ParentID *uuid.UUID `json:"parent_id"` // 返信の場合は返信先のコメントのID
+}
+
+// CreateComment は投稿にコメントします
+func (h *CommunityHandler) CreateComment(c *gin.Context) {
+	postID, ok := parseUUIDParam(c, "postId", "invalid post id")
+	if !ok {
+		return
+	}
+
+	var req CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.communityUseCase.CreateComment(c.Request.Context(), c.GetString("user_id"), postID, req.ParentID, req.Body)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// ListComments は投稿のスレッドの先頭のコメントを古い順に返します
+func (h *CommunityHandler) ListComments(c *gin.Context) {
+	postID, ok := parseUUIDParam(c, "postId", "invalid post id")
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.communityUseCase.ListComments(c.Request.Context(), c.GetString("user_id"), postID, nil, c.Query("cursor"), limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// ListReplies はコメントへの返信を古い順に返します
+func (h *CommunityHandler) ListReplies(c *gin.Context) {
+	commentID, ok := parseUUIDParam(c, "commentId", "invalid comment id")
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.communityUseCase.ListReplies(c.Request.Context(), c.GetString("user_id"), commentID, c.Query("cursor"), limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// DeleteComment はコメントを削除します
+func (h *CommunityHandler) DeleteComment(c *gin.Context) {
+	commentID, ok := parseUUIDParam(c, "commentId", "invalid comment id")
+	if !ok {
+		return
+	}
+
+	if err := h.communityUseCase.DeleteComment(c.Request.Context(), communityActor(c), commentID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddPostReaction は投稿にリアクションします
+func (h *CommunityHandler) AddPostReaction(c *gin.Context) {
+	h.react(c, "postId", "invalid post id", h.communityUseCase.AddPostReaction)
+}
+
+// RemovePostReaction は投稿へのリアクションを取り消します
+func (h *CommunityHandler) RemovePostReaction(c *gin.Context) {
+	h.react(c, "postId", "invalid post id", h.communityUseCase.RemovePostReaction)
+}
+
+// AddCommentReaction はコメントにリアクションします
+func (h *CommunityHandler) AddCommentReaction(c *gin.Context) {
+	h.react(c, "commentId", "invalid comment id", h.communityUseCase.AddCommentReaction)
+}
+
+// RemoveCommentReaction はコメントへのリアクションを取り消します
+func (h *CommunityHandler) RemoveCommentReaction(c *gin.Context) {
+	h.react(c, "commentId", "invalid comment id", h.communityUseCase.RemoveCommentReaction)
+}
+
+// react はリアクションの追加・取り消しを実行し、最新の集計を返します
+func (h *CommunityHandler) react(
+	c *gin.Context,
+	param, invalidMessage string,
+	fn func(ctx context.Context, userID string, targetID uuid.UUID, reaction entity.ReactionType) (*entity.ReactionSummary, error),
+) {
+	targetID, ok := parseUUIDParam(c, param, invalidMessage)
+	if !ok {
+		return
+	}
+
+	summary, err := fn(c.Request.Context(), c.GetString("user_id"), targetID, entity.ReactionType(c.Param("reaction")))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *CommunityHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOshiNotFound),
+		errors.Is(err, usecase.ErrPostNotFound),
+		errors.Is(err, usecase.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrCommunityForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidCursor),
+		errors.Is(err, usecase.ErrInvalidPostImage),
+		errors.Is(err, entity.ErrInvalidPostBody),
+		errors.Is(err, entity.ErrTooManyPostImages),
+		errors.Is(err, entity.ErrInvalidCommentBody),
+		errors.Is(err, entity.ErrInvalidReactionType):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "community operation failed"})
+	}
+}
+
+// communityActor はリクエストのユーザーをコミュニティの操作者に変換します
+func communityActor(c *gin.Context) usecase.CommunityActor {
+	return usecase.CommunityActor{
+		UserID:      c.GetString("user_id"),
+		ModerateAny: middleware.HasPermission(c, auth.PermCommunityModerate),
+	}
+}
+
+// parseUUIDParam はパスパラメータのIDを解析します
+func parseUUIDParam(c *gin.Context, name, invalidMessage string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMessage})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *CommunityHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/oshis/:id/posts", h.CreatePost)
+	r.DELETE("/posts/:postId", h.DeletePost)
+	r.POST("/posts/:postId/comments", h.CreateComment)
+	r.DELETE("/comments/:commentId", h.DeleteComment)
+	r.PUT("/posts/:postId/reactions/:reaction", h.AddPostReaction)
+	r.DELETE("/posts/:postId/reactions/:reaction", h.RemovePostReaction)
+	r.PUT("/comments/:commentId/reactions/:reaction", h.AddCommentReaction)
+	r.DELETE("/comments/:commentId/reactions/:reaction", h.RemoveCommentReaction)
+}
+
+// RegisterPublicRoutes は認証が任意のルートを登録します
+func (h *CommunityHandler) RegisterPublicRoutes(r *gin.RouterGroup) {
+	r.GET("/oshis/:id/posts", h.ListPosts)
+	r.GET("/posts/:postId", h.GetPost)
+	r.GET("/posts/:postId/comments", h.ListComments)
+	r.GET("/comments/:commentId/replies", h.ListReplies)
+}
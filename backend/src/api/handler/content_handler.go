@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
+	"kimiyomi/backend/src/api/middleware"
+	"kimiyomi/backend/src/domain/auth"
 	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
 	"kimiyomi/backend/src/usecase"
 
 	"github.com/gin-gonic/gin"
@@ -24,21 +30,49 @@ func NewContentHandler(contentUseCase *usecase.ContentUseCase) *ContentHandler {
 }
 
 // CreateContentRequest はコンテンツ作成のリクエストです
+// ファイルと同時に送信するため multipart/form-data で受け取ります
 type CreateContentRequest struct {
-	Title       string          `json:"title" binding:"required"`
-	Description string          `json:"description"`
-	ContentType string          `json:"content_type" binding:"required,oneof=image video"`
-	Price       decimal.Decimal `json:"price" binding:"required,min=0"`
+	OnBehalfOf  string `form:"on_behalf_of"`
+	OshiID      string `form:"oshi_id"`
+	Title       string `form:"title" binding:"required"`
+	Description string `form:"description"`
+	ContentType string `form:"content_type" binding:"required,oneof=image video"`
+	Price       string `form:"price" binding:"required"`
+	PublishAt   string `form:"publish_at"`   // 公開日時（RFC3339、任意）
+	UnpublishAt string `form:"unpublish_at"` // 公開終了日時（RFC3339、任意）
+}
+
+// UpdateContentRequest はコンテンツ更新のリクエストです
+// ファイルを差し替える場合は同時に送信するため multipart/form-data で受け取ります
+type UpdateContentRequest struct {
+	OshiID      string `form:"oshi_id"`
+	Title       string `form:"title" binding:"required"`
+	Description string `form:"description"`
+	ContentType string `form:"content_type" binding:"omitempty,oneof=image video"` // 差し替えるファイルの種類（任意）
+	Price       string `form:"price" binding:"required"`
+}
+
+// UpdateAvailabilityRequest はコンテンツの公開期間の変更のリクエストです
+// null を指定した日時は未指定に戻します
+type UpdateAvailabilityRequest struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
 }
 
 // CreateContent はコンテンツを作成します
 func (h *ContentHandler) CreateContent(c *gin.Context) {
 	var req CreateContentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBind(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	price, err := decimal.NewFromString(req.Price)
+	if err != nil || price.IsNegative() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid price"})
+		return
+	}
+
 	// ファイルを取得
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -47,30 +81,122 @@ func (h *ContentHandler) CreateContent(c *gin.Context) {
 	}
 
 	// ユーザーIDを取得（認証ミドルウェアから）
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	userID, ok := contentUserID(c)
+	if !ok {
 		return
 	}
+	orgScope, ok := contentOrgScope(c)
+	if !ok {
+		return
+	}
+
+	var oshiID *uuid.UUID
+	if req.OshiID != "" {
+		id, err := uuid.Parse(req.OshiID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid oshi id"})
+			return
+		}
+		oshiID = &id
+	}
+
+	publishAt, ok := parseOptionalTime(c, req.PublishAt, "invalid publish_at")
+	if !ok {
+		return
+	}
+	unpublishAt, ok := parseOptionalTime(c, req.UnpublishAt, "invalid unpublish_at")
+	if !ok {
+		return
+	}
+
+	var onBehalfOf uuid.UUID
+	if req.OnBehalfOf != "" {
+		onBehalfOf, err = uuid.Parse(req.OnBehalfOf)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid on_behalf_of user id"})
+			return
+		}
+	}
 
 	input := usecase.CreateContentInput{
-		UserID:      userID.(uuid.UUID),
+		UserID:      userID,
+		OnBehalfOf:  onBehalfOf,
+		OshiID:      oshiID,
 		Title:       req.Title,
 		Description: req.Description,
 		ContentType: entity.ContentType(req.ContentType),
 		File:        file,
-		Price:       req.Price,
+		Price:       price,
+		PublishAt:   publishAt,
+		UnpublishAt: unpublishAt,
+		OrgScope:    orgScope,
 	}
 
 	content, err := h.contentUseCase.CreateContent(c.Request.Context(), input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.handleError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, content)
 }
 
+// ListContents はコンテンツの一覧を返します
+// q でタイトルと説明を全文検索し、oshi_id、content_type、min_price、max_price、pricing（free / paid）、owned=true で絞り込めます
+// sort には newest（既定）、oldest、price_asc、price_desc を指定し、続きは前のレスポンスの next_cursor を cursor に指定して取得します
+func (h *ContentHandler) ListContents(c *gin.Context) {
+	userID, ok := contentUserID(c)
+	if !ok {
+		return
+	}
+
+	input := usecase.ListContentsInput{
+		ViewerID:    userID,
+		Owned:       c.Query("owned") == "true",
+		ContentType: entity.ContentType(c.Query("content_type")),
+		Pricing:     c.Query("pricing"),
+		Query:       c.Query("q"),
+		Sort:        repository.ContentSort(c.Query("sort")),
+		Cursor:      c.Query("cursor"),
+	}
+	input.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	if v := c.Query("oshi_id"); v != "" {
+		oshiID, err := uuid.Parse(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid oshi id"})
+			return
+		}
+		input.OshiID = &oshiID
+	}
+	for _, p := range []struct {
+		name string
+		dest **decimal.Decimal
+	}{
+		{"min_price", &input.MinPrice},
+		{"max_price", &input.MaxPrice},
+	} {
+		v := c.Query(p.name)
+		if v == "" {
+			continue
+		}
+		price, err := decimal.NewFromString(v)
+		if err != nil || price.IsNegative() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + p.name})
+			return
+		}
+		*p.dest = &price
+	}
+
+	page, err := h.contentUseCase.ListContents(c.Request.Context(), input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
 // GetContent はコンテンツを取得します
 func (h *ContentHandler) GetContent(c *gin.Context) {
 	contentID, err := uuid.Parse(c.Param("id"))
@@ -79,20 +205,163 @@ func (h *ContentHandler) GetContent(c *gin.Context) {
 		return
 	}
 
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	userID, ok := contentUserID(c)
+	if !ok {
+		return
+	}
+	orgScope, ok := contentOrgScope(c)
+	if !ok {
 		return
 	}
 
 	input := usecase.GetContentInput{
 		ContentID: contentID,
-		UserID:    userID.(uuid.UUID),
+		UserID:    userID,
+		ManageAny: middleware.HasPermission(c, auth.PermContentManageAny),
+		OrgScope:  orgScope,
 	}
 
 	content, err := h.contentUseCase.GetContent(c.Request.Context(), input)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, content)
+}
+
+// UpdateContent はコンテンツを更新します
+// file を送信した場合はファイルを差し替え、審査待ちに戻します
+func (h *ContentHandler) UpdateContent(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content id"})
+		return
+	}
+
+	var req UpdateContentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	price, err := decimal.NewFromString(req.Price)
+	if err != nil || price.IsNegative() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid price"})
+		return
+	}
+
+	// ファイルは差し替える場合のみ送信される
+	file, err := c.FormFile("file")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid file"})
+		return
+	}
+
+	userID, ok := contentUserID(c)
+	if !ok {
+		return
+	}
+	orgScope, ok := contentOrgScope(c)
+	if !ok {
+		return
+	}
+
+	var oshiID *uuid.UUID
+	if req.OshiID != "" {
+		id, err := uuid.Parse(req.OshiID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid oshi id"})
+			return
+		}
+		oshiID = &id
+	}
+
+	content, err := h.contentUseCase.UpdateContent(c.Request.Context(), usecase.UpdateContentInput{
+		ContentID:   contentID,
+		UserID:      userID,
+		OshiID:      oshiID,
+		Title:       req.Title,
+		Description: req.Description,
+		Price:       price,
+		ContentType: entity.ContentType(req.ContentType),
+		File:        file,
+		ManageAny:   middleware.HasPermission(c, auth.PermContentManageAny),
+		OrgScope:    orgScope,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, content)
+}
+
+// DeleteContent はコンテンツを削除します
+func (h *ContentHandler) DeleteContent(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content id"})
+		return
+	}
+
+	userID, ok := contentUserID(c)
+	if !ok {
+		return
+	}
+	orgScope, ok := contentOrgScope(c)
+	if !ok {
+		return
+	}
+
+	input := usecase.DeleteContentInput{
+		ContentID: contentID,
+		UserID:    userID,
+		ManageAny: middleware.HasPermission(c, auth.PermContentManageAny),
+		OrgScope:  orgScope,
+	}
+
+	if err := h.contentUseCase.DeleteContent(c.Request.Context(), input); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateAvailability はコンテンツの公開期間を変更します
+func (h *ContentHandler) UpdateAvailability(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content id"})
+		return
+	}
+
+	var req UpdateAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := contentUserID(c)
+	if !ok {
+		return
+	}
+	orgScope, ok := contentOrgScope(c)
+	if !ok {
+		return
+	}
+
+	content, err := h.contentUseCase.UpdateAvailability(c.Request.Context(), usecase.UpdateAvailabilityInput{
+		ContentID:   contentID,
+		UserID:      userID,
+		PublishAt:   req.PublishAt,
+		UnpublishAt: req.UnpublishAt,
+		ManageAny:   middleware.HasPermission(c, auth.PermContentManageAny),
+		OrgScope:    orgScope,
+	})
+	if err != nil {
+		h.handleError(c, err)
 		return
 	}
 
@@ -124,32 +393,113 @@ func (h *ContentHandler) AttachContentToDiagnosis(c *gin.Context) {
 		return
 	}
 
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	userID, ok := contentUserID(c)
+	if !ok {
+		return
+	}
+	orgScope, ok := contentOrgScope(c)
+	if !ok {
 		return
 	}
 
 	input := usecase.AttachContentToDiagnosisInput{
 		ContentID:   contentID,
 		DiagnosisID: diagnosisID,
-		UserID:      userID.(uuid.UUID),
+		UserID:      userID,
+		ManageAny:   middleware.HasPermission(c, auth.PermContentManageAny),
+		OrgScope:    orgScope,
 	}
 
 	if err := h.contentUseCase.AttachContentToDiagnosis(c.Request.Context(), input); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.handleError(c, err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
-// RegisterRoutes はルートを登録します
+// contentUserID は認証ミドルウェアが設定したユーザーIDを取得します
+// 取得できない場合はエラーレスポンスを書き込んで false を返します
+func contentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// contentOrgScope は組織が所有するAPIキーで認証された場合に、操作を限定する組織を返します
+// 取得できない場合はエラーレスポンスを書き込んで false を返します
+func contentOrgScope(c *gin.Context) (*uuid.UUID, bool) {
+	orgID := middleware.APIKeyOrganizationID(c)
+	if orgID == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(orgID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+	return &id, true
+}
+
+// parseOptionalTime は RFC3339 形式の任意の日時を解析します
+// 解析できない場合はエラーレスポンスを書き込んで false を返します
+func parseOptionalTime(c *gin.Context, value, message string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return nil, false
+	}
+	return &t, true
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *ContentHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrContentNotFound),
+		errors.Is(err, usecase.ErrOshiNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrContentForbidden),
+		errors.Is(err, usecase.ErrContentNoAccess):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrContentTakenDown):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrEmptyTitle),
+		errors.Is(err, entity.ErrInvalidContentType),
+		errors.Is(err, entity.ErrInvalidPrice),
+		errors.Is(err, entity.ErrInvalidAvailabilityWindow),
+		errors.Is(err, usecase.ErrInvalidContentFilter),
+		errors.Is(err, usecase.ErrInvalidSearchQuery),
+		errors.Is(err, usecase.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// RegisterRoutes はコンテンツの閲覧のルートを登録します
 func (h *ContentHandler) RegisterRoutes(r *gin.RouterGroup) {
 	contents := r.Group("/contents")
 	{
-		contents.POST("", h.CreateContent)
+		contents.GET("", h.ListContents)
 		contents.GET("/:id", h.GetContent)
+	}
+}
+
+// RegisterWriteRoutes はコンテンツの投稿・編集のルートを登録します
+// コンテンツを投稿する権限を持つユーザーのグループに登録します
+func (h *ContentHandler) RegisterWriteRoutes(r *gin.RouterGroup) {
+	contents := r.Group("/contents")
+	{
+		contents.POST("", h.CreateContent)
+		contents.PUT("/:id", h.UpdateContent)
+		contents.DELETE("/:id", h.DeleteContent)
+		contents.PUT("/:id/availability", h.UpdateAvailability)
 		contents.POST("/:id/diagnoses", h.AttachContentToDiagnosis)
 	}
 }
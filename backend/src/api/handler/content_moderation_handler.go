@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ContentModerationHandler はコンテンツの通報と審査に関するAPIハンドラーです
+type ContentModerationHandler struct {
+	moderationUseCase *usecase.ContentModerationUseCase
+}
+
+// NewContentModerationHandler は新しいContentModerationHandlerを作成します
+func NewContentModerationHandler(moderationUseCase *usecase.ContentModerationUseCase) *ContentModerationHandler {
+	return &ContentModerationHandler{
+		moderationUseCase: moderationUseCase,
+	}
+}
+
+// ReportContentRequest はコンテンツの通報のリクエストです
+type ReportContentRequest struct {
+	Reason  entity.ReportReason `json:"reason" binding:"required"`
+	Details string              `json:"details"`
+}
+
+// ModerationDecisionRequest は審査の操作のリクエストです
+type ModerationDecisionRequest struct {
+	Action entity.ModerationAction `json:"action" binding:"required"`
+	Reason string                  `json:"reason"`
+}
+
+// ReportContent はコンテンツを通報します
+func (h *ContentModerationHandler) ReportContent(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content id"})
+		return
+	}
+
+	var req ReportContentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.moderationUseCase.ReportContent(c.Request.Context(), usecase.ReportContentInput{
+		ContentID:  contentID,
+		ReporterID: c.GetString("user_id"),
+		Reason:     req.Reason,
+		Details:    req.Details,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// ListQueue は審査キューのコンテンツの一覧を返します
+func (h *ContentModerationHandler) ListQueue(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	contents, err := h.moderationUseCase.ListQueue(c.Request.Context(), repository.ModerationQueue(c.Query("queue")), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"contents": contents})
+}
+
+// GetReview はコンテンツと通報、審査の履歴を返します
+func (h *ContentModerationHandler) GetReview(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content id"})
+		return
+	}
+
+	review, err := h.moderationUseCase.GetReview(c.Request.Context(), contentID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// Decide はコンテンツの審査の操作を行います
+func (h *ContentModerationHandler) Decide(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content id"})
+		return
+	}
+
+	var req ModerationDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	content, err := h.moderationUseCase.Decide(c.Request.Context(), usecase.DecideInput{
+		ModeratorID: c.GetString("user_id"),
+		ContentID:   contentID,
+		Action:      req.Action,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, content)
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *ContentModerationHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrContentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrContentAlreadyReported),
+		errors.Is(err, usecase.ErrNoOpenReports),
+		errors.Is(err, entity.ErrInvalidModerationTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrCannotReportOwnContent),
+		errors.Is(err, usecase.ErrInvalidModerationAction),
+		errors.Is(err, usecase.ErrModerationReasonRequired),
+		errors.Is(err, usecase.ErrInvalidModerationQueue),
+		errors.Is(err, entity.ErrInvalidReportReason),
+		errors.Is(err, entity.ErrReportDetailsTooLong),
+		errors.Is(err, entity.ErrModerationReasonTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "moderation operation failed"})
+	}
+}
+
+// RegisterRoutes は利用者向けの通報のルートを登録します
+func (h *ContentModerationHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/contents/:id/reports", h.ReportContent)
+}
+
+// RegisterModerationRoutes はモデレーター向けの審査のルートを登録します
+func (h *ContentModerationHandler) RegisterModerationRoutes(r *gin.RouterGroup) {
+	r.GET("/contents", h.ListQueue)
+	r.GET("/contents/:id", h.GetReview)
+	r.POST("/contents/:id/decisions", h.Decide)
+}
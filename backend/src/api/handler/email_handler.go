@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"html/template"
+	"net/http"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// emailWebhookSecretHeader は送信サーバーからのバウンスの通知に付ける共有の秘密鍵のヘッダーです
+const emailWebhookSecretHeader = "X-Email-Webhook-Secret"
+
+// unsubscribePageTemplate は配信停止のページのHTMLです
+// メールのリンクを開いただけで停止されないよう、確認のフォームを表示してから停止します
+var unsubscribePageTemplate = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>メールの配信停止 | キミヨミ</title>
+</head>
+<body>
+<main>
+{{if .Done}}
+<h1>配信を停止しました</h1>
+<p>{{.Email}} への「{{.Category}}」のメールの配信を停止しました。マイページの設定からいつでも再開できます。</p>
+{{else}}
+<h1>メールの配信停止</h1>
+<p>{{.Email}} への「{{.Category}}」のメールの配信を停止しますか？</p>
+<form method="post">
+<input type="hidden" name="token" value="{{.Token}}">
+<button type="submit">配信を停止する</button>
+</form>
+{{end}}
+</main>
+</body>
+</html>
+`))
+
+// emailCategoryLabels は配信停止のページに表示するメールの種類の名前です
+var emailCategoryLabels = map[entity.EmailCategory]string{
+	entity.EmailCategoryBilling:  "お支払い",
+	entity.EmailCategoryReminder: "リマインダー",
+}
+
+// EmailHandler はメールの配信停止とバウンスの通知に関するAPIハンドラーです
+type EmailHandler struct {
+	emailUseCase  *usecase.EmailUseCase
+	webhookSecret string
+}
+
+// NewEmailHandler は新しいEmailHandlerを作成します
+// webhookSecret が空の場合はバウンスの通知を受け付けません
+func NewEmailHandler(emailUseCase *usecase.EmailUseCase, webhookSecret string) *EmailHandler {
+	return &EmailHandler{
+		emailUseCase:  emailUseCase,
+		webhookSecret: webhookSecret,
+	}
+}
+
+// UpdateEmailSubscriptionsRequest はメールの配信設定の更新のリクエストです
+// 種類ごとに配信する（true）か停止する（false）かを指定します
+type UpdateEmailSubscriptionsRequest struct {
+	Subscriptions map[entity.EmailCategory]bool `json:"subscriptions" binding:"required"`
+}
+
+// unsubscribePage は配信停止のページに表示する内容です
+type unsubscribePage struct {
+	Email    string
+	Category string
+	Token    string
+	Done     bool
+}
+
+// UnsubscribePage は配信停止の確認のページを返します
+func (h *EmailHandler) UnsubscribePage(c *gin.Context) {
+	token := c.Query("token")
+	address, category, err := h.emailUseCase.ResolveUnsubscribeToken(token)
+	if err == nil && !category.Unsubscribable() {
+		err = usecase.ErrEmailCategoryNotOptional
+	}
+	if err != nil {
+		h.handlePageError(c, err)
+		return
+	}
+
+	h.renderUnsubscribePage(c, unsubscribePage{
+		Email:    address,
+		Category: emailCategoryLabels[category],
+		Token:    token,
+	})
+}
+
+// Unsubscribe はメールの配信を停止します
+// 確認のページのフォームと、メールクライアントのワンクリックの配信停止（RFC 8058）の両方から呼び出されます
+func (h *EmailHandler) Unsubscribe(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		token = c.PostForm("token")
+	}
+
+	category, err := h.emailUseCase.Unsubscribe(c.Request.Context(), token)
+	if err != nil {
+		h.handlePageError(c, err)
+		return
+	}
+	address, _, _ := h.emailUseCase.ResolveUnsubscribeToken(token)
+
+	h.renderUnsubscribePage(c, unsubscribePage{
+		Email:    address,
+		Category: emailCategoryLabels[category],
+		Done:     true,
+	})
+}
+
+// HandleBounce は送信サーバーからのバウンスや迷惑メールの報告の通知を処理します
+func (h *EmailHandler) HandleBounce(c *gin.Context) {
+	secret := c.GetHeader(emailWebhookSecretHeader)
+	if h.webhookSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(h.webhookSecret)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid webhook secret"})
+		return
+	}
+
+	var req usecase.BounceInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.emailUseCase.HandleBounce(c.Request.Context(), req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSubscriptions はメールの種類ごとの配信の状態を返します
+func (h *EmailHandler) GetSubscriptions(c *gin.Context) {
+	subscriptions, err := h.emailUseCase.GetSubscriptions(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscriptions": subscriptions})
+}
+
+// UpdateSubscriptions はメールの種類ごとの配信を変更します
+func (h *EmailHandler) UpdateSubscriptions(c *gin.Context) {
+	var req UpdateEmailSubscriptionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscriptions, err := h.emailUseCase.UpdateSubscriptions(c.Request.Context(), c.GetString("user_id"), req.Subscriptions)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscriptions": subscriptions})
+}
+
+// renderUnsubscribePage は配信停止のページを描画します
+func (h *EmailHandler) renderUnsubscribePage(c *gin.Context, page unsubscribePage) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+	if err := unsubscribePageTemplate.Execute(c.Writer, page); err != nil {
+		c.Error(err)
+	}
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *EmailHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidBounceType),
+		errors.Is(err, usecase.ErrEmailCategoryNotOptional),
+		errors.Is(err, entity.ErrInvalidEmailAddress),
+		errors.Is(err, entity.ErrInvalidEmailCategory):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "email operation failed"})
+	}
+}
+
+// handlePageError は配信停止のページのエラーをテキストのレスポンスに変換します
+func (h *EmailHandler) handlePageError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidUnsubscribeToken),
+		errors.Is(err, entity.ErrInvalidEmailAddress):
+		c.String(http.StatusBadRequest, "配信停止のリンクが正しくありません")
+	case errors.Is(err, usecase.ErrEmailCategoryNotOptional):
+		c.String(http.StatusBadRequest, "このメールは配信を停止できません")
+	default:
+		c.String(http.StatusInternalServerError, "配信を停止できませんでした")
+	}
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *EmailHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/me/email-subscriptions", h.GetSubscriptions)
+	r.PUT("/me/email-subscriptions", h.UpdateSubscriptions)
+}
+
+// RegisterPublicRoutes は認証が不要な配信停止とバウンスの通知のルートを登録します
+func (h *EmailHandler) RegisterPublicRoutes(r *gin.RouterGroup) {
+	r.GET("/email/unsubscribe", h.UnsubscribePage)
+	r.POST("/email/unsubscribe", h.Unsubscribe)
+	r.POST("/webhooks/email/bounces", h.HandleBounce)
+}
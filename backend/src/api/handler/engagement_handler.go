@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EngagementHandler は推し活の行動の記録とエンゲージメントに関するAPIハンドラーです
+type EngagementHandler struct {
+	engagementUseCase *usecase.EngagementUseCase
+}
+
+// NewEngagementHandler は新しいEngagementHandlerを作成します
+func NewEngagementHandler(engagementUseCase *usecase.EngagementUseCase) *EngagementHandler {
+	return &EngagementHandler{
+		engagementUseCase: engagementUseCase,
+	}
+}
+
+// ReportActivityRequest は行動の報告のリクエストです
+type ReportActivityRequest struct {
+	Type entity.ActivityType `json:"type" binding:"required"`
+}
+
+// ReportActivity は推しに対する閲覧・診断・シェアを記録します
+func (h *EngagementHandler) ReportActivity(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	var req ReportActivityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.engagementUseCase.ReportActivity(c.Request.Context(), c.GetString("user_id"), id, req.Type); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListEngagements はログイン中のユーザーの推しごとの推し活レベルを返します
+func (h *EngagementHandler) ListEngagements(c *gin.Context) {
+	scores, err := h.engagementUseCase.ListEngagements(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"engagements": scores})
+}
+
+// GetEngagement はログイン中のユーザーの指定した推しに対する推し活レベルを返します
+func (h *EngagementHandler) GetEngagement(c *gin.Context) {
+	oshiID, err := uuid.Parse(c.Param("oshiId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid oshi id"})
+		return
+	}
+
+	score, err := h.engagementUseCase.GetEngagement(c.Request.Context(), c.GetString("user_id"), oshiID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, score)
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *EngagementHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOshiNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrActivityNotReportable),
+		errors.Is(err, entity.ErrInvalidActivityType):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "engagement operation failed"})
+	}
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *EngagementHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/oshis/:id/activities", h.ReportActivity)
+	r.GET("/me/engagement", h.ListEngagements)
+	r.GET("/me/engagement/:oshiId", h.GetEngagement)
+}
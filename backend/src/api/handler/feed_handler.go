@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedHandler は推しのフォローとホームフィードに関するAPIハンドラーです
+type FeedHandler struct {
+	followUseCase *usecase.FollowUseCase
+	feedUseCase   *usecase.FeedUseCase
+}
+
+// NewFeedHandler は新しいFeedHandlerを作成します
+func NewFeedHandler(followUseCase *usecase.FollowUseCase, feedUseCase *usecase.FeedUseCase) *FeedHandler {
+	return &FeedHandler{
+		followUseCase: followUseCase,
+		feedUseCase:   feedUseCase,
+	}
+}
+
+// Follow は推しをフォローします
+func (h *FeedHandler) Follow(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	oshi, err := h.followUseCase.Follow(c.Request.Context(), c.GetString("user_id"), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"following": true, "follower_count": oshi.FollowerCount})
+}
+
+// Unfollow は推しのフォローを解除します
+func (h *FeedHandler) Unfollow(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.followUseCase.Unfollow(c.Request.Context(), c.GetString("user_id"), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListFollowing はフォロー中の推しの一覧を返します
+func (h *FeedHandler) ListFollowing(c *gin.Context) {
+	following, err := h.followUseCase.ListFollowing(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"following": following})
+}
+
+// GetFeed はフォロー中の推しのホームフィードを返します
+// 次のページは前のレスポンスの next_cursor を cursor に指定して取得します
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.feedUseCase.GetFeed(c.Request.Context(), c.GetString("user_id"), c.Query("cursor"), limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *FeedHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOshiNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "feed operation failed"})
+	}
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *FeedHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/oshis/:id/follow", h.Follow)
+	r.DELETE("/oshis/:id/follow", h.Unfollow)
+	r.GET("/me/following", h.ListFollowing)
+	r.GET("/feed", h.GetFeed)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LeaderboardHandler は推しごとのファンランキングに関するAPIハンドラーです
+type LeaderboardHandler struct {
+	leaderboardUseCase *usecase.LeaderboardUseCase
+}
+
+// NewLeaderboardHandler は新しいLeaderboardHandlerを作成します
+func NewLeaderboardHandler(leaderboardUseCase *usecase.LeaderboardUseCase) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		leaderboardUseCase: leaderboardUseCase,
+	}
+}
+
+// FlagAccountRequest はアカウントを不正の疑いで指定するリクエストです
+type FlagAccountRequest struct {
+	Reason string `json:"reason"`
+}
+
+// GetLeaderboard は推しのランキングを返します
+// period には daily, weekly, monthly, all_time を指定します（既定は weekly）
+// ログイン中の場合は自分の順位も返します
+func (h *LeaderboardHandler) GetLeaderboard(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	period := entity.LeaderboardPeriod(c.Query("period"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	entries, err := h.leaderboardUseCase.GetLeaderboard(c.Request.Context(), c.GetString("user_id"), id, period, limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response := gin.H{"entries": entries}
+	if userID := c.GetString("user_id"); userID != "" {
+		position, err := h.leaderboardUseCase.GetPosition(c.Request.Context(), userID, id, period)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		response["me"] = position
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetMyPosition はログイン中のユーザーの推しのランキングでの順位を返します
+func (h *LeaderboardHandler) GetMyPosition(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	position, err := h.leaderboardUseCase.GetPosition(c.Request.Context(), c.GetString("user_id"), id, entity.LeaderboardPeriod(c.Query("period")))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, position)
+}
+
+// ListFlaggedAccounts は不正の疑いで指定されたアカウントの一覧を返します
+func (h *LeaderboardHandler) ListFlaggedAccounts(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	flags, err := h.leaderboardUseCase.ListFlaggedAccounts(c.Request.Context(), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"flagged_accounts": flags})
+}
+
+// FlagAccount はアカウントを不正の疑いで指定します
+func (h *LeaderboardHandler) FlagAccount(c *gin.Context) {
+	var req FlagAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	flag, err := h.leaderboardUseCase.FlagAccount(c.Request.Context(), c.GetString("user_id"), c.Param("userId"), req.Reason)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
+
+// UnflagAccount はアカウントの指定を解除します
+func (h *LeaderboardHandler) UnflagAccount(c *gin.Context) {
+	if err := h.leaderboardUseCase.UnflagAccount(c.Request.Context(), c.Param("userId")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *LeaderboardHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOshiNotFound),
+		errors.Is(err, usecase.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidLeaderboardPeriod):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "leaderboard operation failed"})
+	}
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *LeaderboardHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/oshis/:id/leaderboard/me", h.GetMyPosition)
+}
+
+// RegisterPublicRoutes は認証が任意のルートを登録します
+func (h *LeaderboardHandler) RegisterPublicRoutes(r *gin.RouterGroup) {
+	r.GET("/oshis/:id/leaderboard", h.GetLeaderboard)
+}
+
+// RegisterModerationRoutes はモデレーター向けのルートを登録します
+func (h *LeaderboardHandler) RegisterModerationRoutes(r *gin.RouterGroup) {
+	flags := r.Group("/flagged-accounts")
+	{
+		flags.GET("", h.ListFlaggedAccounts)
+		flags.PUT("/:userId", h.FlagAccount)
+		flags.DELETE("/:userId", h.UnflagAccount)
+	}
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pollWriteTimeout はロングポーリングの応答の書き込み期限です
+// サーバー全体の WriteTimeout より長く待つため、リクエストごとに延長します
+const pollWriteTimeout = 45 * time.Second
+
+// MessageHandler はファンとキャストのメッセージに関するAPIハンドラーです
+type MessageHandler struct {
+	messageUseCase *usecase.MessageUseCase
+}
+
+// NewMessageHandler は新しいMessageHandlerを作成します
+func NewMessageHandler(messageUseCase *usecase.MessageUseCase) *MessageHandler {
+	return &MessageHandler{
+		messageUseCase: messageUseCase,
+	}
+}
+
+// StartConversationRequest はやり取りの開始のリクエストです
+type StartConversationRequest struct {
+	CreatorID string `json:"creator_id" binding:"required"`
+}
+
+// GrantMessageTicketsRequest はメッセージチケットの付与のリクエストです
+type GrantMessageTicketsRequest struct {
+	Quantity int `json:"quantity" binding:"required"`
+}
+
+// StartConversation はキャストとのやり取りを始めます
+func (h *MessageHandler) StartConversation(c *gin.Context) {
+	var req StartConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conversation, err := h.messageUseCase.StartConversation(c.Request.Context(), c.GetString("user_id"), req.CreatorID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+// ListConversations は受信箱のやり取りを最後のメッセージが新しい順に返します
+// role=creator でキャストとして受けたやり取りを返し、filter（unread / awaiting_reply）と plan で絞り込めます
+func (h *MessageHandler) ListConversations(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.messageUseCase.ListInbox(c.Request.Context(), usecase.InboxQuery{
+		ViewerID: c.GetString("user_id"),
+		Role:     repository.InboxRole(c.Query("role")),
+		Filter:   c.Query("filter"),
+		Plan:     entity.PlanType(c.Query("plan")),
+		Cursor:   c.Query("cursor"),
+		Limit:    limit,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// GetConversation はやり取りを返します
+func (h *MessageHandler) GetConversation(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "invalid conversation id")
+	if !ok {
+		return
+	}
+
+	conversation, err := h.messageUseCase.GetConversation(c.Request.Context(), c.GetString("user_id"), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+// ListMessages はやり取りのメッセージを新しい順に返します
+// 古いメッセージは前のレスポンスの next_cursor を cursor に指定して取得します
+func (h *MessageHandler) ListMessages(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "invalid conversation id")
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.messageUseCase.ListMessages(c.Request.Context(), c.GetString("user_id"), id, c.Query("cursor"), limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// SendMessage はやり取りにメッセージを送信します
+// 本文は body、添付の画像は images（最大4枚）としてマルチパートで送信します
+func (h *MessageHandler) SendMessage(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "invalid conversation id")
+	if !ok {
+		return
+	}
+
+	var attachments []*multipart.FileHeader
+	if form, err := c.MultipartForm(); err == nil {
+		attachments = form.File["images"]
+	}
+
+	message, err := h.messageUseCase.SendMessage(c.Request.Context(), usecase.SendMessageInput{
+		ConversationID: id,
+		SenderID:       c.GetString("user_id"),
+		Body:           c.PostForm("body"),
+		Attachments:    attachments,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// MarkRead はやり取りを既読にします
+func (h *MessageHandler) MarkRead(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "invalid conversation id")
+	if !ok {
+		return
+	}
+
+	if err := h.messageUseCase.MarkRead(c.Request.Context(), c.GetString("user_id"), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Poll は新しいメッセージが届くまで待って返します
+// timeout には待つ秒数（最大30秒）を指定し、次は返された cursor を指定して呼び出します
+func (h *MessageHandler) Poll(c *gin.Context) {
+	seconds, _ := strconv.Atoi(c.Query("timeout"))
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(pollWriteTimeout))
+
+	result, err := h.messageUseCase.Poll(c.Request.Context(), c.GetString("user_id"), c.Query("cursor"), time.Duration(seconds)*time.Second)
+	if err != nil {
+		// クライアントが切断した場合は応答しない
+		if c.Request.Context().Err() != nil {
+			return
+		}
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetQuota は今月のメッセージの送信枠とチケットの残り枚数を返します
+func (h *MessageHandler) GetQuota(c *gin.Context) {
+	quota, err := h.messageUseCase.GetQuota(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quota)
+}
+
+// GrantTickets はユーザーにメッセージチケットを付与します
+func (h *MessageHandler) GrantTickets(c *gin.Context) {
+	var req GrantMessageTicketsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	balance, err := h.messageUseCase.GrantTickets(c.Request.Context(), c.Param("id"), req.Quantity)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tickets": balance})
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *MessageHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrConversationNotFound),
+		errors.Is(err, usecase.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrMessagingBlocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrMessageQuotaExceeded):
+		c.JSON(http.StatusPaymentRequired, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidMessageRecipient),
+		errors.Is(err, usecase.ErrInvalidInboxFilter),
+		errors.Is(err, usecase.ErrInvalidTicketQuantity),
+		errors.Is(err, usecase.ErrInvalidMessageAttachment),
+		errors.Is(err, usecase.ErrInvalidCursor),
+		errors.Is(err, entity.ErrInvalidConversation),
+		errors.Is(err, entity.ErrInvalidMessageBody),
+		errors.Is(err, entity.ErrTooManyMessageAttachments):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "message operation failed"})
+	}
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *MessageHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/conversations", h.StartConversation)
+	r.GET("/conversations", h.ListConversations)
+	r.GET("/conversations/:id", h.GetConversation)
+	r.GET("/conversations/:id/messages", h.ListMessages)
+	r.POST("/conversations/:id/messages", h.SendMessage)
+	r.POST("/conversations/:id/read", h.MarkRead)
+	r.GET("/me/messages/poll", h.Poll)
+	r.GET("/me/message-quota", h.GetQuota)
+}
+
+// RegisterAdminRoutes は管理者向けのチケットの付与のルートを登録します
+func (h *MessageHandler) RegisterAdminRoutes(r *gin.RouterGroup) {
+	r.POST("/users/:id/message-tickets", h.GrantTickets)
+}
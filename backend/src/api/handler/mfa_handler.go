@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MFAHandler は二要素認証関連のエンドポイントを提供します
+type MFAHandler struct {
+	mfaUseCase     *usecase.MFAUseCase
+	sessionUseCase *usecase.SessionUseCase
+	tokenService   auth.TokenService
+}
+
+// NewMFAHandler は新しいMFAHandlerを作成します
+func NewMFAHandler(mfaUseCase *usecase.MFAUseCase, sessionUseCase *usecase.SessionUseCase, tokenService auth.TokenService) *MFAHandler {
+	return &MFAHandler{
+		mfaUseCase:     mfaUseCase,
+		sessionUseCase: sessionUseCase,
+		tokenService:   tokenService,
+	}
+}
+
+// MFAEnrollRequest は二要素認証の登録開始リクエストです
+// ロールで必須のユーザーはログイン時に発行された登録用トークンを指定します
+type MFAEnrollRequest struct {
+	MFAToken string `json:"mfa_token"`
+}
+
+// MFAConfirmRequest は二要素認証の登録確定リクエストです
+type MFAConfirmRequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// MFAConfirmResponse は二要素認証の登録確定レスポンスです
+type MFAConfirmResponse struct {
+	RecoveryCodes []string       `json:"recovery_codes"`
+	Login         *LoginResponse `json:"login,omitempty"`
+}
+
+// MFAVerifyRequest はログイン時の二要素認証リクエストです
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// MFACodeRequest はコードのみを必要とするリクエストです
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// GetStatus は二要素認証の状態を返します
+func (h *MFAHandler) GetStatus(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	status, err := h.mfaUseCase.Status(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get mfa status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// Enroll は二要素認証の登録を開始します
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	var req MFAEnrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	claims, ok := h.resolveEnrollmentUser(c, req.MFAToken)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.mfaUseCase.BeginEnrollment(c.Request.Context(), claims.UserID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrMFAAlreadyEnabled) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to begin mfa enrollment"})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// Confirm は二要素認証の登録を確定し、リカバリーコードを返します
+// 登録用トークンで確定した場合はログイントークンも発行します
+func (h *MFAHandler) Confirm(c *gin.Context) {
+	var req MFAConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	claims, ok := h.resolveEnrollmentUser(c, req.MFAToken)
+	if !ok {
+		return
+	}
+
+	codes, err := h.mfaUseCase.ConfirmEnrollment(c.Request.Context(), claims.UserID, req.Code)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	res := MFAConfirmResponse{RecoveryCodes: codes}
+	if req.MFAToken != "" {
+		login, err := newLoginResponse(c, h.sessionUseCase, claims.UserID, claims.Role, claims.RoleVersion)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
+			return
+		}
+		res.Login = login
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Verify はログイン時の二要素認証を検証し、ログイントークンを発行します
+func (h *MFAHandler) Verify(c *gin.Context) {
+	var req MFAVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	claims, err := h.tokenService.ValidateMFAToken(req.MFAToken, auth.PurposeMFAChallenge)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid mfa token"})
+		return
+	}
+
+	if err := h.mfaUseCase.Verify(c.Request.Context(), claims.UserID, req.Code); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	login, err := newLoginResponse(c, h.sessionUseCase, claims.UserID, claims.Role, claims.RoleVersion)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, login)
+}
+
+// Disable は二要素認証を無効化します
+func (h *MFAHandler) Disable(c *gin.Context) {
+	var req MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.mfaUseCase.Disable(c.Request.Context(), userID.(string), req.Code); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes はリカバリーコードを再発行します
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	codes, err := h.mfaUseCase.RegenerateRecoveryCodes(c.Request.Context(), userID.(string), req.Code)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+// resolveEnrollmentUser は登録用トークンまたはアクセストークンから対象ユーザーを特定します
+func (h *MFAHandler) resolveEnrollmentUser(c *gin.Context, mfaToken string) (*auth.Claims, bool) {
+	if mfaToken != "" {
+		claims, err := h.tokenService.ValidateMFAToken(mfaToken, auth.PurposeMFAEnrollment)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid mfa token"})
+			return nil, false
+		}
+		return claims, true
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+	role, _ := c.Get("role")
+	roleStr, _ := role.(string)
+
+	return &auth.Claims{UserID: userID.(string), Role: roleStr}, true
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *MFAHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidMFACode):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrMFANotEnrolled), errors.Is(err, usecase.ErrMFAEnrollmentNeeded):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrMFAAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrMFARequiredForRole):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrMFALocked):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "mfa operation failed"})
+	}
+}
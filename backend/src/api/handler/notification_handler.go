@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler は通知の受信箱とプッシュ通知に関するAPIハンドラーです
+type NotificationHandler struct {
+	notificationUseCase *usecase.NotificationUseCase
+}
+
+// NewNotificationHandler は新しいNotificationHandlerを作成します
+func NewNotificationHandler(notificationUseCase *usecase.NotificationUseCase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+// RegisterDeviceRequest は端末の登録のリクエストです
+type RegisterDeviceRequest struct {
+	Token    string                `json:"token" binding:"required"`
+	Platform entity.DevicePlatform `json:"platform" binding:"required"`
+}
+
+// UpdateNotificationSettingsRequest は通知設定の更新のリクエストです
+// quiet_hours を null にすると通知を送らない時間帯を解除します
+type UpdateNotificationSettingsRequest struct {
+	QuietHours         *entity.QuietHours            `json:"quiet_hours"`
+	DisabledCategories []entity.NotificationCategory `json:"disabled_categories"`
+}
+
+// ListNotifications は通知の受信箱のお知らせを新しい順に未読の件数と共に返します
+// unread=true で未読のお知らせのみを返し、古いお知らせは前のレスポンスの next_cursor を cursor に指定して取得します
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.notificationUseCase.ListNotifications(c.Request.Context(), c.GetString("user_id"), c.Query("unread") == "true", c.Query("cursor"), limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// MarkNotificationRead はお知らせを既読にします
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "invalid notification id")
+	if !ok {
+		return
+	}
+
+	unread, err := h.notificationUseCase.MarkNotificationRead(c.Request.Context(), c.GetString("user_id"), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unread_count": unread})
+}
+
+// MarkAllNotificationsRead は全てのお知らせを既読にします
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	unread, err := h.notificationUseCase.MarkAllNotificationsRead(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unread_count": unread})
+}
+
+// RegisterDevice はプッシュ通知を受け取る端末を登録します
+// 同じトークンを再度登録した場合は登録済みの端末を返します
+func (h *NotificationHandler) RegisterDevice(c *gin.Context) {
+	var req RegisterDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	device, err := h.notificationUseCase.RegisterDevice(c.Request.Context(), c.GetString("user_id"), req.Token, req.Platform)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, device)
+}
+
+// ListDevices は登録した端末の一覧を返します
+func (h *NotificationHandler) ListDevices(c *gin.Context) {
+	devices, err := h.notificationUseCase.ListDevices(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"devices": devices})
+}
+
+// UnregisterDevice は端末の登録を解除します
+func (h *NotificationHandler) UnregisterDevice(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "invalid device id")
+	if !ok {
+		return
+	}
+
+	if err := h.notificationUseCase.UnregisterDevice(c.Request.Context(), c.GetString("user_id"), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSettings は通知設定を返します
+func (h *NotificationHandler) GetSettings(c *gin.Context) {
+	settings, err := h.notificationUseCase.GetSettings(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings は通知設定を置き換えます
+func (h *NotificationHandler) UpdateSettings(c *gin.Context) {
+	var req UpdateNotificationSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.notificationUseCase.UpdateSettings(c.Request.Context(), c.GetString("user_id"), req.QuietHours, req.DisabledCategories)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// ListDeliveries はプッシュ通知の送信記録を新しい順に返します
+func (h *NotificationHandler) ListDeliveries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	deliveries, err := h.notificationUseCase.ListDeliveries(c.Request.Context(), c.GetString("user_id"), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *NotificationHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrDeviceNotFound),
+		errors.Is(err, usecase.ErrNotificationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidCursor),
+		errors.Is(err, entity.ErrInvalidDeviceToken),
+		errors.Is(err, entity.ErrInvalidDevicePlatform),
+		errors.Is(err, entity.ErrInvalidNotificationSettings):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "notification operation failed"})
+	}
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *NotificationHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/me/notifications", h.ListNotifications)
+	r.POST("/me/notifications/read-all", h.MarkAllNotificationsRead)
+	r.POST("/me/notifications/:id/read", h.MarkNotificationRead)
+	r.POST("/me/devices", h.RegisterDevice)
+	r.GET("/me/devices", h.ListDevices)
+	r.DELETE("/me/devices/:id", h.UnregisterDevice)
+	r.GET("/me/notification-settings", h.GetSettings)
+	r.PUT("/me/notification-settings", h.UpdateSettings)
+	r.GET("/me/push-deliveries", h.ListDeliveries)
+}
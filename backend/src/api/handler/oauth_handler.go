@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// oauthBindingCookie は認可フローを開始したクライアントを識別するクッキー名です
+	oauthBindingCookie = "oauth_binding"
+	// oauthBindingCookiePath はクッキーを送信するパスです
+	oauthBindingCookiePath = "/auth/oauth"
+	// oauthBindingCookieMaxAge はクッキーの有効期間（秒）で、認可フローの状態の有効期限に合わせます
+	oauthBindingCookieMaxAge = 10 * 60
+)
+
+// OAuthHandler は外部IdPによるログイン関連のエンドポイントを提供します
+type OAuthHandler struct {
+	oauthUseCase   *usecase.OAuthUseCase
+	mfaUseCase     *usecase.MFAUseCase
+	sessionUseCase *usecase.SessionUseCase
+	tokenService   auth.TokenService
+}
+
+// NewOAuthHandler は新しいOAuthHandlerを作成します
+func NewOAuthHandler(
+	oauthUseCase *usecase.OAuthUseCase,
+	mfaUseCase *usecase.MFAUseCase,
+	sessionUseCase *usecase.SessionUseCase,
+	tokenService auth.TokenService,
+) *OAuthHandler {
+	return &OAuthHandler{
+		oauthUseCase:   oauthUseCase,
+		mfaUseCase:     mfaUseCase,
+		sessionUseCase: sessionUseCase,
+		tokenService:   tokenService,
+	}
+}
+
+// OAuthCallbackRequest は認可コールバックのリクエストです
+type OAuthCallbackRequest struct {
+	State string `json:"state" binding:"required"`
+	Code  string `json:"code" binding:"required"`
+}
+
+// ListProviders は利用可能なプロバイダーの一覧を返します
+func (h *OAuthHandler) ListProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"providers": h.oauthUseCase.Providers()})
+}
+
+// Authorize は認可フローを開始し、認可エンドポイントのURLを返します
+func (h *OAuthHandler) Authorize(c *gin.Context) {
+	result, err := h.oauthUseCase.Begin(c.Request.Context(), usecase.BeginInput{
+		Provider: c.Param("provider"),
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	setOAuthBinding(c, result.Binding)
+	c.JSON(http.StatusOK, gin.H{"authorization_url": result.AuthorizationURL})
+}
+
+// Link はログイン中のユーザーに外部アカウントを紐付ける認可フローを開始します
+func (h *OAuthHandler) Link(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.oauthUseCase.Begin(c.Request.Context(), usecase.BeginInput{
+		Provider:   c.Param("provider"),
+		LinkUserID: userID.(string),
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	setOAuthBinding(c, result.Binding)
+	c.JSON(http.StatusOK, gin.H{"authorization_url": result.AuthorizationURL})
+}
+
+// Callback は認可コードを検証してログインします
+func (h *OAuthHandler) Callback(c *gin.Context) {
+	var req OAuthCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	user, err := h.oauthUseCase.Complete(c.Request.Context(), usecase.CompleteInput{
+		Provider: c.Param("provider"),
+		State:    req.State,
+		Code:     req.Code,
+		Binding:  takeOAuthBinding(c),
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondLogin(c, h.mfaUseCase, h.sessionUseCase, h.tokenService, user, http.StatusOK)
+}
+
+// LinkCallback は認可コードを検証してログイン中のユーザーに外部アカウントを紐付けます
+// 新しいトークンは発行せず、紐付けた外部アカウントを返します
+func (h *OAuthHandler) LinkCallback(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req OAuthCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	identity, err := h.oauthUseCase.CompleteLink(c.Request.Context(), usecase.CompleteLinkInput{
+		CompleteInput: usecase.CompleteInput{
+			Provider: c.Param("provider"),
+			State:    req.State,
+			Code:     req.Code,
+			Binding:  takeOAuthBinding(c),
+		},
+		UserID: userID.(string),
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, identity)
+}
+
+// ListIdentities は紐付け済みの外部アカウント一覧を返します
+func (h *OAuthHandler) ListIdentities(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	identities, err := h.oauthUseCase.ListIdentities(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list identities"})
+		return
+	}
+
+	c.JSON(http.StatusOK, identities)
+}
+
+// Unlink は外部アカウントの紐付けを解除します
+func (h *OAuthHandler) Unlink(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.oauthUseCase.Unlink(c.Request.Context(), userID.(string), c.Param("provider")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// setOAuthBinding は認可フローを開始したクライアントにだけ値をクッキーで渡します
+func setOAuthBinding(c *gin.Context, binding string) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthBindingCookie, binding, oauthBindingCookieMaxAge, oauthBindingCookiePath, "", true, true)
+}
+
+// takeOAuthBinding はクッキーの値を取り出し、クッキーを削除します
+func takeOAuthBinding(c *gin.Context) string {
+	binding, err := c.Cookie(oauthBindingCookie)
+	if err != nil {
+		return ""
+	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthBindingCookie, "", -1, oauthBindingCookiePath, "", true, true)
+	return binding
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *OAuthHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrUnknownOAuthProvider):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidOAuthState):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrIdentityAlreadyLinked), errors.Is(err, usecase.ErrProviderAlreadyLinked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrLastLoginMethod):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "oauth login failed"})
+	}
+}
@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OrganizationHandler はグループ・事務所に関するAPIハンドラーです
+type OrganizationHandler struct {
+	organizationUseCase *usecase.OrganizationUseCase
+}
+
+// NewOrganizationHandler は新しいOrganizationHandlerを作成します
+func NewOrganizationHandler(organizationUseCase *usecase.OrganizationUseCase) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUseCase: organizationUseCase,
+	}
+}
+
+// CreateOrganizationRequest は組織作成のリクエストです
+type CreateOrganizationRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Type        string `json:"type" binding:"required,oneof=group agency"`
+	Description string `json:"description"`
+}
+
+// UpdateOrganizationRequest は組織更新のリクエストです
+type UpdateOrganizationRequest struct {
+	Name        *string                  `json:"name"`
+	Type        *entity.OrganizationType `json:"type"`
+	Description *string                  `json:"description"`
+}
+
+// InviteMemberRequest はメンバー招待のリクエストです
+type InviteMemberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required"`
+}
+
+// UpdateMemberRoleRequest はメンバーの役割変更のリクエストです
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// CreateOrganization は組織を作成します
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	org, err := h.organizationUseCase.CreateOrganization(c.Request.Context(), usecase.CreateOrganizationInput{
+		ActorID:     c.GetString("user_id"),
+		Name:        req.Name,
+		Type:        entity.OrganizationType(req.Type),
+		Description: req.Description,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, org)
+}
+
+// ListMyOrganizations は所属する組織の一覧を返します
+func (h *OrganizationHandler) ListMyOrganizations(c *gin.Context) {
+	orgs, err := h.organizationUseCase.ListMyOrganizations(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"organizations": orgs})
+}
+
+// GetOrganization は組織とメンバー一覧を返します
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	org, members, err := h.organizationUseCase.GetOrganization(c.Request.Context(), c.GetString("user_id"), orgID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"organization": org, "members": members})
+}
+
+// UpdateOrganization は組織を更新します
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	org, err := h.organizationUseCase.UpdateOrganization(c.Request.Context(), usecase.UpdateOrganizationInput{
+		ActorID:        c.GetString("user_id"),
+		OrganizationID: orgID,
+		Name:           req.Name,
+		Type:           req.Type,
+		Description:    req.Description,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+// DeleteOrganization は組織を削除します
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.organizationUseCase.DeleteOrganization(c.Request.Context(), c.GetString("user_id"), orgID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// InviteMember はユーザーを組織に招待します
+func (h *OrganizationHandler) InviteMember(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invitation, err := h.organizationUseCase.InviteMember(c.Request.Context(), usecase.InviteMemberInput{
+		ActorID:        c.GetString("user_id"),
+		OrganizationID: orgID,
+		UserID:         req.UserID,
+		Role:           entity.OrganizationRole(req.Role),
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// UpdateMemberRole はメンバーの役割を変更します
+func (h *OrganizationHandler) UpdateMemberRole(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.organizationUseCase.UpdateMemberRole(c.Request.Context(), c.GetString("user_id"), orgID, c.Param("userId"), entity.OrganizationRole(req.Role))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveMember はメンバーを組織から外します
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.organizationUseCase.RemoveMember(c.Request.Context(), c.GetString("user_id"), orgID, c.Param("userId")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListMyInvitations は自分宛ての回答待ちの招待一覧を返します
+func (h *OrganizationHandler) ListMyInvitations(c *gin.Context) {
+	invitations, err := h.organizationUseCase.ListMyInvitations(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+// AcceptInvitation は招待を承諾します
+func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
+	h.respondToInvitation(c, true)
+}
+
+// DeclineInvitation は招待を辞退します
+func (h *OrganizationHandler) DeclineInvitation(c *gin.Context) {
+	h.respondToInvitation(c, false)
+}
+
+func (h *OrganizationHandler) respondToInvitation(c *gin.Context, accept bool) {
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation id"})
+		return
+	}
+
+	invitation, err := h.organizationUseCase.RespondToInvitation(c.Request.Context(), c.GetString("user_id"), invitationID, accept)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// parseOrganizationID はパスパラメータの組織IDを解析します
+func parseOrganizationID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *OrganizationHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOrganizationNotFound),
+		errors.Is(err, usecase.ErrInvitationNotFound),
+		errors.Is(err, usecase.ErrMemberNotFound),
+		errors.Is(err, usecase.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrOrganizationForbidden),
+		errors.Is(err, usecase.ErrCannotRemoveOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrAlreadyMember),
+		errors.Is(err, usecase.ErrAlreadyInvited):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidUserInput),
+		errors.Is(err, entity.ErrInvalidOrganizationName),
+		errors.Is(err, entity.ErrInvalidOrganizationType),
+		errors.Is(err, entity.ErrOrganizationDescriptionTooLong),
+		errors.Is(err, entity.ErrInvalidOrganizationRole):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "organization operation failed"})
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *OrganizationHandler) RegisterRoutes(r *gin.RouterGroup) {
+	orgs := r.Group("/organizations")
+	{
+		orgs.GET("", h.ListMyOrganizations)
+		orgs.POST("", h.CreateOrganization)
+		orgs.GET("/:id", h.GetOrganization)
+		orgs.PATCH("/:id", h.UpdateOrganization)
+		orgs.DELETE("/:id", h.DeleteOrganization)
+		orgs.POST("/:id/invitations", h.InviteMember)
+		orgs.PATCH("/:id/members/:userId", h.UpdateMemberRole)
+		orgs.DELETE("/:id/members/:userId", h.RemoveMember)
+	}
+
+	invitations := r.Group("/me/organization-invitations")
+	{
+		invitations.GET("", h.ListMyInvitations)
+		invitations.POST("/:id/accept", h.AcceptInvitation)
+		invitations.POST("/:id/decline", h.DeclineInvitation)
+	}
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"kimiyomi/backend/src/api/middleware"
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OshiHandler は推しカタログに関するAPIハンドラーです
+type OshiHandler struct {
+	oshiUseCase *usecase.OshiUseCase
+}
+
+// NewOshiHandler は新しいOshiHandlerを作成します
+func NewOshiHandler(oshiUseCase *usecase.OshiUseCase) *OshiHandler {
+	return &OshiHandler{
+		oshiUseCase: oshiUseCase,
+	}
+}
+
+// OshiRequest は推しの登録・更新のリクエストです
+// 更新時は指定した項目のみ変更します
+type OshiRequest struct {
+	Name                    *string   `json:"name"`
+	GroupName               *string   `json:"group_name"`
+	Agency                  *string   `json:"agency"`
+	Description             *string   `json:"description"`
+	ProfileImages           *[]string `json:"profile_images"`
+	Tags                    *[]string `json:"tags"`
+	CreatorUserID           *string   `json:"creator_user_id"`
+	OfficialDiagnosisResult *string   `json:"official_diagnosis_result"`
+}
+
+func (r OshiRequest) toInput() usecase.OshiInput {
+	return usecase.OshiInput{
+		Name:                    r.Name,
+		GroupName:               r.GroupName,
+		Agency:                  r.Agency,
+		Description:             r.Description,
+		ProfileImages:           r.ProfileImages,
+		Tags:                    r.Tags,
+		CreatorUserID:           r.CreatorUserID,
+		OfficialDiagnosisResult: r.OfficialDiagnosisResult,
+	}
+}
+
+// ListOshis は推しの一覧を返します
+// q で名前・グループ名・事務所名を検索し、group と tag で絞り込みます
+func (h *OshiHandler) ListOshis(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	oshis, total, err := h.oshiUseCase.ListOshis(c.Request.Context(), usecase.ListOshisInput{
+		Query:     c.Query("q"),
+		GroupName: c.Query("group"),
+		Tag:       c.Query("tag"),
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"oshis": oshis, "total": total})
+}
+
+// GetOshi は推しを返します
+func (h *OshiHandler) GetOshi(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	oshi, err := h.oshiUseCase.GetOshi(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oshi)
+}
+
+// CreateOshi は推しを登録します
+func (h *OshiHandler) CreateOshi(c *gin.Context) {
+	var req OshiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	oshi, err := h.oshiUseCase.CreateOshi(c.Request.Context(), oshiActor(c), req.toInput())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, oshi)
+}
+
+// UpdateOshi は推しを更新します
+func (h *OshiHandler) UpdateOshi(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	var req OshiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	oshi, err := h.oshiUseCase.UpdateOshi(c.Request.Context(), oshiActor(c), id, req.toInput())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oshi)
+}
+
+// DeleteOshi は推しを削除します
+func (h *OshiHandler) DeleteOshi(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.oshiUseCase.DeleteOshi(c.Request.Context(), oshiActor(c), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UploadImage は推しのプロフィール画像をアップロードします
+func (h *OshiHandler) UploadImage(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "image is required"})
+		return
+	}
+
+	oshi, err := h.oshiUseCase.UploadImage(c.Request.Context(), oshiActor(c), id, file)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oshi)
+}
+
+// OshiNewsRequest はお知らせ投稿のリクエストです
+type OshiNewsRequest struct {
+	Title string `json:"title" binding:"required"`
+	Body  string `json:"body"`
+}
+
+// ListNews は推しのお知らせを返します
+func (h *OshiHandler) ListNews(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	newsList, err := h.oshiUseCase.ListNews(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"news": newsList})
+}
+
+// PostNews は推しのお知らせを投稿します
+func (h *OshiHandler) PostNews(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	var req OshiNewsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	news, err := h.oshiUseCase.PostNews(c.Request.Context(), oshiActor(c), id, req.Title, req.Body)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, news)
+}
+
+// DeleteNews は推しのお知らせを削除します
+func (h *OshiHandler) DeleteNews(c *gin.Context) {
+	id, ok := parseOshiID(c)
+	if !ok {
+		return
+	}
+
+	newsID, err := uuid.Parse(c.Param("newsId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid news id"})
+		return
+	}
+
+	if err := h.oshiUseCase.DeleteNews(c.Request.Context(), oshiActor(c), id, newsID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// oshiActor はリクエストしたユーザーを推しの操作者として返します
+func oshiActor(c *gin.Context) usecase.OshiActor {
+	return usecase.OshiActor{
+		UserID:    c.GetString("user_id"),
+		ManageAny: middleware.HasPermission(c, auth.PermOshiManageAny),
+	}
+}
+
+// parseOshiID はパスパラメータの推しIDを解析します
+func parseOshiID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid oshi id"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *OshiHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOshiNotFound),
+		errors.Is(err, usecase.ErrOshiNewsNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrOshiForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidOshiCreator),
+		errors.Is(err, usecase.ErrInvalidOshiImage),
+		errors.Is(err, entity.ErrInvalidOshiName),
+		errors.Is(err, entity.ErrOshiFieldTooLong),
+		errors.Is(err, entity.ErrTooManyOshiImages),
+		errors.Is(err, entity.ErrTooManyOshiTags),
+		errors.Is(err, entity.ErrInvalidOshiTag),
+		errors.Is(err, entity.ErrInvalidNewsTitle),
+		errors.Is(err, entity.ErrNewsBodyTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "oshi operation failed"})
+	}
+}
+
+// RegisterRoutes は推しを管理するルートを登録します
+func (h *OshiHandler) RegisterRoutes(r *gin.RouterGroup) {
+	oshis := r.Group("/oshis")
+	{
+		oshis.POST("", h.CreateOshi)
+		oshis.PATCH("/:id", h.UpdateOshi)
+		oshis.DELETE("/:id", h.DeleteOshi)
+		oshis.POST("/:id/images", h.UploadImage)
+		oshis.POST("/:id/news", h.PostNews)
+		oshis.DELETE("/:id/news/:newsId", h.DeleteNews)
+	}
+}
+
+// RegisterPublicRoutes は認証が任意のルートを登録します
+func (h *OshiHandler) RegisterPublicRoutes(r *gin.RouterGroup) {
+	r.GET("/oshis", h.ListOshis)
+	r.GET("/oshis/:id", h.GetOshi)
+	r.GET("/oshis/:id/news", h.ListNews)
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"kimiyomi/backend/src/api/middleware"
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProfileHandler はユーザープロフィール関連のAPIハンドラーです
+type ProfileHandler struct {
+	profileUseCase *usecase.ProfileUseCase
+}
+
+// NewProfileHandler は新しいProfileHandlerを作成します
+func NewProfileHandler(profileUseCase *usecase.ProfileUseCase) *ProfileHandler {
+	return &ProfileHandler{
+		profileUseCase: profileUseCase,
+	}
+}
+
+// MeResponse はログイン中のユーザーの情報です
+type MeResponse struct {
+	ID      string              `json:"id"`
+	Email   string              `json:"email"`
+	Role    string              `json:"role"`
+	Profile *entity.UserProfile `json:"profile"`
+}
+
+// UpdateProfileRequest はプロフィール更新のリクエストです
+// 指定した項目のみ更新します
+type UpdateProfileRequest struct {
+	DisplayName  *string                `json:"display_name"`
+	Bio          *string                `json:"bio"`
+	Birthday     *string                `json:"birthday"`
+	FavoriteOshi *[]string              `json:"favorite_oshi"`
+	Locale       *string                `json:"locale"`
+	Timezone     *string                `json:"timezone"`
+	Privacy      *entity.ProfilePrivacy `json:"privacy"`
+}
+
+// GetMe はログイン中のユーザーのプロフィールを返します
+func (h *ProfileHandler) GetMe(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	user, profile, err := h.profileUseCase.GetMe(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MeResponse{
+		ID:      user.ID,
+		Email:   user.Email,
+		Role:    user.Role,
+		Profile: profile,
+	})
+}
+
+// UpdateMe はログイン中のユーザーのプロフィールを更新します
+func (h *ProfileHandler) UpdateMe(c *gin.Context) {
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	profile, err := h.profileUseCase.UpdateProfile(c.Request.Context(), usecase.UpdateProfileInput{
+		UserID:       userID.(string),
+		DisplayName:  req.DisplayName,
+		Bio:          req.Bio,
+		Birthday:     req.Birthday,
+		FavoriteOshi: req.FavoriteOshi,
+		Locale:       req.Locale,
+		Timezone:     req.Timezone,
+		Privacy:      req.Privacy,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// UploadAvatar はアバター画像をアップロードします
+func (h *ProfileHandler) UploadAvatar(c *gin.Context) {
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "avatar is required"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	profile, err := h.profileUseCase.UploadAvatar(c.Request.Context(), userID.(string), file)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// GetUser は指定したユーザーの公開プロフィールを返します
+// ユーザー情報の閲覧権限を持つユーザーには非公開の項目も返します
+// 閲覧者をブロックしているユーザーのプロフィールは 404 を返します
+func (h *ProfileHandler) GetUser(c *gin.Context) {
+	viewerID := c.GetString("user_id")
+	targetID := c.Param("id")
+	includePrivate := viewerID == targetID || middleware.HasPermission(c, auth.PermUserReadAny)
+
+	profile, err := h.profileUseCase.GetPublicProfile(c.Request.Context(), viewerID, targetID, includePrivate)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *ProfileHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidUserInput),
+		errors.Is(err, usecase.ErrInvalidAvatar),
+		errors.Is(err, entity.ErrInvalidUserID),
+		errors.Is(err, entity.ErrDisplayNameTooLong),
+		errors.Is(err, entity.ErrBioTooLong),
+		errors.Is(err, entity.ErrInvalidBirthday),
+		errors.Is(err, entity.ErrInvalidLocale),
+		errors.Is(err, entity.ErrInvalidTimezone),
+		errors.Is(err, entity.ErrTooManyFavoriteOshi),
+		errors.Is(err, entity.ErrInvalidOshiName):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "profile operation failed"})
+	}
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *ProfileHandler) RegisterRoutes(r *gin.RouterGroup) {
+	me := r.Group("/me")
+	{
+		me.GET("", h.GetMe)
+		me.PATCH("", h.UpdateMe)
+		me.POST("/avatar", h.UploadAvatar)
+	}
+}
+
+// RegisterPublicRoutes は認証が任意のルートを登録します
+func (h *ProfileHandler) RegisterPublicRoutes(r *gin.RouterGroup) {
+	r.GET("/users/:id", h.GetUser)
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// realtimeHeartbeatInterval は接続を保つために送る空のメッセージの間隔です
+	realtimeHeartbeatInterval = 25 * time.Second
+	// realtimeWriteTimeout は1件のイベントの書き込みの期限です
+	realtimeWriteTimeout = 10 * time.Second
+	// realtimeProtocol は WebSocket で応答するサブプロトコルです
+	realtimeProtocol = "kimiyomi.v1"
+)
+
+// realtimeUpgrader は WebSocket の接続を確立します
+// 認証は Cookie ではなくトークンで行うため、他のオリジンからの接続も受け付けます
+var realtimeUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+	Subprotocols:    []string{realtimeProtocol},
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// RealtimeHandler はリアルタイム配信に関するAPIハンドラーです
+type RealtimeHandler struct {
+	realtimeUseCase *usecase.RealtimeUseCase
+}
+
+// NewRealtimeHandler は新しいRealtimeHandlerを作成します
+func NewRealtimeHandler(realtimeUseCase *usecase.RealtimeUseCase) *RealtimeHandler {
+	return &RealtimeHandler{
+		realtimeUseCase: realtimeUseCase,
+	}
+}
+
+// Stream はユーザー宛てのイベントを Server-Sent Events で配信します
+// leaderboards に推しのIDをカンマ区切りで指定すると、そのランキングの更新も配信します
+func (h *RealtimeHandler) Stream(c *gin.Context) {
+	events, unsubscribe, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer unsubscribe()
+
+	controller := http.NewResponseController(c.Writer)
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	write := func(frame string) bool {
+		// サーバー全体の WriteTimeout で切断されないよう、書き込みごとに期限を延ばす
+		_ = controller.SetWriteDeadline(time.Now().Add(realtimeWriteTimeout))
+		if _, err := c.Writer.WriteString(frame); err != nil {
+			return false
+		}
+		c.Writer.Flush()
+		return true
+	}
+
+	if !write(": connected\n\n") {
+		return
+	}
+
+	heartbeat := time.NewTicker(realtimeHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if !write(fmt.Sprintf("event: %s\ndata: %s\n\n", event.Type, data)) {
+				return
+			}
+		case <-heartbeat.C:
+			if !write(": ping\n\n") {
+				return
+			}
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// Connect はユーザー宛てのイベントを WebSocket で配信します
+// ブラウザからはサブプロトコルに kimiyomi.v1 と bearer.<トークン> を指定して接続します
+func (h *RealtimeHandler) Connect(c *gin.Context) {
+	events, unsubscribe, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer unsubscribe()
+
+	conn, err := realtimeUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade がエラーのレスポンスを返している
+		return
+	}
+	defer conn.Close()
+
+	// クライアントからのメッセージは使わないが、切断と pong を検知するために読み続ける
+	closed := make(chan struct{})
+	conn.SetReadLimit(512)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * realtimeHeartbeatInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * realtimeHeartbeatInterval))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(realtimeHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+					time.Now().Add(realtimeWriteTimeout))
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(realtimeWriteTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(realtimeWriteTimeout)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// subscribe はクエリパラメーターの購読対象を読み取って購読します
+// 失敗した場合はエラーのレスポンスを返して false を返します
+func (h *RealtimeHandler) subscribe(c *gin.Context) (<-chan *entity.RealtimeEvent, func(), bool) {
+	var oshiIDs []uuid.UUID
+	if raw := c.Query("leaderboards"); raw != "" {
+		for _, value := range strings.Split(raw, ",") {
+			id, err := uuid.Parse(strings.TrimSpace(value))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid oshi id in leaderboards"})
+				return nil, nil, false
+			}
+			oshiIDs = append(oshiIDs, id)
+		}
+	}
+
+	events, unsubscribe, err := h.realtimeUseCase.Subscribe(c.GetString("user_id"), oshiIDs)
+	if err != nil {
+		h.handleError(c, err)
+		return nil, nil, false
+	}
+	return events, unsubscribe, true
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *RealtimeHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrTooManyRealtimeTopics):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "realtime operation failed"})
+	}
+}
+
+// RegisterRoutes はリアルタイム配信のルートを登録します
+// WebSocket のトークンを読み取れるよう、middleware.WebSocketToken を認証より前に登録したグループに登録します
+func (h *RealtimeHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/events", h.Stream)
+	r.GET("/ws", h.Connect)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleHandler はロール管理関連のAPIハンドラーです
+type RoleHandler struct {
+	roleUseCase *usecase.RoleUseCase
+}
+
+// NewRoleHandler は新しいRoleHandlerを作成します
+func NewRoleHandler(roleUseCase *usecase.RoleUseCase) *RoleHandler {
+	return &RoleHandler{
+		roleUseCase: roleUseCase,
+	}
+}
+
+// AssignRoleRequest はロール割り当てのリクエストです
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=fan creator moderator admin"`
+}
+
+// ListRoles はロールと権限の対応表を返します
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	c.JSON(http.StatusOK, h.roleUseCase.ListRoles())
+}
+
+// AssignRole はユーザーにロールを割り当てます
+func (h *RoleHandler) AssignRole(c *gin.Context) {
+	var req AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actorID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	user, err := h.roleUseCase.AssignRole(c.Request.Context(), usecase.AssignRoleInput{
+		ActorID: actorID.(string),
+		UserID:  c.Param("id"),
+		Role:    req.Role,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrInvalidRole), errors.Is(err, usecase.ErrCannotChangeOwnRole):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// RegisterRoutes はルートを登録します
+func (h *RoleHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/roles", h.ListRoles)
+	r.PUT("/users/:id/role", h.AssignRole)
+}
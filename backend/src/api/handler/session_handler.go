@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SessionHandler はログイン中の端末の管理に関するAPIハンドラーです
+type SessionHandler struct {
+	sessionUseCase *usecase.SessionUseCase
+}
+
+// NewSessionHandler は新しいSessionHandlerを作成します
+func NewSessionHandler(sessionUseCase *usecase.SessionUseCase) *SessionHandler {
+	return &SessionHandler{
+		sessionUseCase: sessionUseCase,
+	}
+}
+
+// SessionResponse はセッション一覧の要素です
+type SessionResponse struct {
+	*entity.DeviceSession
+	Current bool `json:"current"`
+}
+
+// UpdateFCMTokenRequest はプッシュ通知用トークン更新のリクエストです
+type UpdateFCMTokenRequest struct {
+	FCMToken string `json:"fcm_token" binding:"required"`
+}
+
+// ListSessions はログイン中の端末の一覧を返します
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	sessions, err := h.sessionUseCase.ListSessions(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list sessions"})
+		return
+	}
+
+	currentID := c.GetString("session_id")
+	res := make([]SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		res = append(res, SessionResponse{
+			DeviceSession: s,
+			Current:       s.ID.String() == currentID,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": res})
+}
+
+// RevokeSession は指定した端末をログアウトさせます
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.sessionUseCase.RevokeSession(c.Request.Context(), userID.(string), sessionID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateFCMToken は現在の端末のプッシュ通知用トークンを更新します
+func (h *SessionHandler) UpdateFCMToken(c *gin.Context) {
+	var req UpdateFCMTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	sessionID, err := uuid.Parse(c.GetString("session_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no device session is associated with this token"})
+		return
+	}
+
+	if err := h.sessionUseCase.UpdateFCMToken(c.Request.Context(), userID.(string), sessionID, req.FCMToken); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListSecurityEvents は最近のセキュリティイベントを返します
+func (h *SessionHandler) ListSecurityEvents(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	events, err := h.sessionUseCase.ListSecurityEvents(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list security events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *SessionHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "session operation failed"})
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *SessionHandler) RegisterRoutes(r *gin.RouterGroup) {
+	me := r.Group("/me")
+	{
+		me.GET("/sessions", h.ListSessions)
+		me.DELETE("/sessions/:id", h.RevokeSession)
+		me.PUT("/sessions/current/fcm-token", h.UpdateFCMToken)
+		me.GET("/security-events", h.ListSecurityEvents)
+	}
+}
+
+// deviceInfoFromRequest はリクエストヘッダーからログイン元の端末情報を取得します
+// アプリは X-Device-ID、X-App-Version、X-FCM-Token ヘッダーで端末情報を送信します
+func deviceInfoFromRequest(c *gin.Context) usecase.DeviceInfo {
+	return usecase.DeviceInfo{
+		DeviceID:   c.GetHeader("X-Device-ID"),
+		UserAgent:  c.Request.UserAgent(),
+		IPAddress:  c.ClientIP(),
+		AppVersion: c.GetHeader("X-App-Version"),
+		FCMToken:   c.GetHeader("X-FCM-Token"),
+	}
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// sharePageTemplate は共有ページのHTMLです
+// SNS のクローラーが OGP と Twitter カードのメタデータを読み取れるようにサーバー側で描画します
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} | キミヨミ</title>
+<meta name="description" content="{{.Summary}}">
+<meta property="og:type" content="article">
+<meta property="og:site_name" content="キミヨミ">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Summary}}">
+<meta property="og:url" content="{{.URL}}">
+<meta property="og:image" content="{{.ImageURL}}">
+<meta property="og:image:width" content="1200">
+<meta property="og:image:height" content="630">
+<meta name="twitter:card" content="summary_large_image">
+<meta name="twitter:title" content="{{.Title}}">
+<meta name="twitter:description" content="{{.Summary}}">
+<meta name="twitter:image" content="{{.ImageURL}}">
+</head>
+<body>
+<main>
+<img src="{{.ImageURL}}" alt="{{.Title}}" width="600" height="315">
+<h1>{{.Title}}</h1>
+{{if .Score}}<p>{{.Score}}点</p>{{end}}
+<p>{{.Summary}}</p>
+</main>
+</body>
+</html>
+`))
+
+// ShareHandler は診断結果の共有リンクに関するAPIハンドラーです
+type ShareHandler struct {
+	shareUseCase *usecase.ShareUseCase
+}
+
+// NewShareHandler は新しいShareHandlerを作成します
+func NewShareHandler(shareUseCase *usecase.ShareUseCase) *ShareHandler {
+	return &ShareHandler{
+		shareUseCase: shareUseCase,
+	}
+}
+
+// CreateShareLinkRequest は共有リンク作成のリクエストです
+type CreateShareLinkRequest struct {
+	Kind          entity.ShareKind `json:"kind" binding:"required"`
+	ResultID      string           `json:"result_id" binding:"required"`
+	Title         string           `json:"title" binding:"required"`
+	Summary       string           `json:"summary"`
+	Score         *int             `json:"score"`
+	OshiID        *uuid.UUID       `json:"oshi_id"`
+	ExpiresInDays int              `json:"expires_in_days"`
+}
+
+// CreateShareLink は診断結果の共有リンクを作成します
+func (h *ShareHandler) CreateShareLink(c *gin.Context) {
+	var req CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	link, err := h.shareUseCase.CreateShareLink(c.Request.Context(), usecase.CreateShareLinkInput{
+		UserID:        c.GetString("user_id"),
+		Kind:          req.Kind,
+		ResultID:      req.ResultID,
+		Title:         req.Title,
+		Summary:       req.Summary,
+		Score:         req.Score,
+		OshiID:        req.OshiID,
+		ExpiresInDays: req.ExpiresInDays,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+// ListShareLinks はログイン中のユーザーの共有リンクの一覧を返します
+func (h *ShareHandler) ListShareLinks(c *gin.Context) {
+	links, err := h.shareUseCase.ListMyShareLinks(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"shares": links})
+}
+
+// RevokeShareLink は共有リンクを無効にします
+func (h *ShareHandler) RevokeShareLink(c *gin.Context) {
+	if err := h.shareUseCase.RevokeShareLink(c.Request.Context(), c.GetString("user_id"), c.Param("slug")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetShare は公開中の共有リンクの診断結果を返します
+func (h *ShareHandler) GetShare(c *gin.Context) {
+	share, err := h.shareUseCase.GetPublicShare(c.Request.Context(), c.GetString("user_id"), c.Param("slug"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, share)
+}
+
+// SharePage は OGP のメタデータを含む共有ページを返します
+func (h *ShareHandler) SharePage(c *gin.Context) {
+	share, err := h.shareUseCase.GetPublicShare(c.Request.Context(), "", c.Param("slug"))
+	if err != nil {
+		h.handlePageError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Status(http.StatusOK)
+	if err := sharePageTemplate.Execute(c.Writer, share); err != nil {
+		c.Error(err)
+	}
+}
+
+// CardImage はシェアカード画像の署名付きURLへリダイレクトします
+func (h *ShareHandler) CardImage(c *gin.Context) {
+	url, err := h.shareUseCase.GetCardImageURL(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		h.handlePageError(c, err)
+		return
+	}
+
+	// 署名付きURLの有効期限より短い間だけキャッシュさせる
+	c.Header("Cache-Control", "public, max-age=1800")
+	c.Redirect(http.StatusFound, url)
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *ShareHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrShareLinkNotFound),
+		errors.Is(err, usecase.ErrOshiNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrShareLinkGone):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrInvalidShareKind),
+		errors.Is(err, entity.ErrInvalidShareResult):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "share operation failed"})
+	}
+}
+
+// handlePageError は共有ページのエラーをテキストのレスポンスに変換します
+func (h *ShareHandler) handlePageError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrShareLinkNotFound):
+		c.String(http.StatusNotFound, "共有リンクが見つかりません")
+	case errors.Is(err, usecase.ErrShareLinkGone):
+		c.String(http.StatusGone, "この共有リンクは公開を終了しました")
+	default:
+		c.String(http.StatusInternalServerError, "共有ページを表示できませんでした")
+	}
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *ShareHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/shares", h.CreateShareLink)
+	r.GET("/me/shares", h.ListShareLinks)
+	r.DELETE("/shares/:slug", h.RevokeShareLink)
+}
+
+// RegisterPublicRoutes は認証不要のAPIのルートを登録します
+func (h *ShareHandler) RegisterPublicRoutes(r *gin.RouterGroup) {
+	r.GET("/shares/:slug", h.GetShare)
+}
+
+// RegisterPageRoutes は共有ページと画像のルートを登録します
+func (h *ShareHandler) RegisterPageRoutes(r *gin.RouterGroup) {
+	r.GET("/:slug", h.SharePage)
+	r.GET("/:slug/card.png", h.CardImage)
+}
@@ -110,7 +110,7 @@ func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
 
 // HandleWebhook はStripeのWebhookを処理します
 func (h *SubscriptionHandler) HandleWebhook(c *gin.Context) {
-	payload, err := c.GetRawData()
+	_, err := c.GetRawData()
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read payload"})
 		return
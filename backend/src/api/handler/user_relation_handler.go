@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserRelationHandler はユーザー間のブロック・ミュートに関するAPIハンドラーです
+type UserRelationHandler struct {
+	relationUseCase *usecase.UserRelationUseCase
+}
+
+// NewUserRelationHandler は新しいUserRelationHandlerを作成します
+func NewUserRelationHandler(relationUseCase *usecase.UserRelationUseCase) *UserRelationHandler {
+	return &UserRelationHandler{
+		relationUseCase: relationUseCase,
+	}
+}
+
+// Block はユーザーをブロックします
+func (h *UserRelationHandler) Block(c *gin.Context) {
+	relation, err := h.relationUseCase.Block(c.Request.Context(), c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, relation)
+}
+
+// Unblock はユーザーのブロックを解除します
+func (h *UserRelationHandler) Unblock(c *gin.Context) {
+	if err := h.relationUseCase.Unblock(c.Request.Context(), c.GetString("user_id"), c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Mute はユーザーをミュートします
+func (h *UserRelationHandler) Mute(c *gin.Context) {
+	relation, err := h.relationUseCase.Mute(c.Request.Context(), c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, relation)
+}
+
+// Unmute はユーザーのミュートを解除します
+func (h *UserRelationHandler) Unmute(c *gin.Context) {
+	if err := h.relationUseCase.Unmute(c.Request.Context(), c.GetString("user_id"), c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListBlocks はブロック中のユーザーの一覧を返します
+func (h *UserRelationHandler) ListBlocks(c *gin.Context) {
+	relations, err := h.relationUseCase.List(c.Request.Context(), c.GetString("user_id"), entity.UserRelationBlock)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"blocks": relations})
+}
+
+// ListMutes はミュート中のユーザーの一覧を返します
+func (h *UserRelationHandler) ListMutes(c *gin.Context) {
+	relations, err := h.relationUseCase.List(c.Request.Context(), c.GetString("user_id"), entity.UserRelationMute)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"mutes": relations})
+}
+
+// handleError はユースケースのエラーをHTTPレスポンスに変換します
+func (h *UserRelationHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrInvalidUserRelation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "user relation operation failed"})
+	}
+}
+
+// RegisterRoutes は認証が必要なルートを登録します
+func (h *UserRelationHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/users/:id/block", h.Block)
+	r.DELETE("/users/:id/block", h.Unblock)
+	r.POST("/users/:id/mute", h.Mute)
+	r.DELETE("/users/:id/mute", h.Unmute)
+	r.GET("/me/blocks", h.ListBlocks)
+	r.GET("/me/mutes", h.ListMutes)
+}
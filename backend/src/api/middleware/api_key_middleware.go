@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"kimiyomi/backend/src/domain/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyMiddleware はAPIキーによる認証とキーごとのレート制限を提供します
+type APIKeyMiddleware struct {
+	authenticator auth.APIKeyAuthenticator
+	limiter       *rateLimiter
+}
+
+// NewAPIKeyMiddleware は新しいAPIKeyMiddlewareを作成します
+func NewAPIKeyMiddleware(authenticator auth.APIKeyAuthenticator) *APIKeyMiddleware {
+	return &APIKeyMiddleware{
+		authenticator: authenticator,
+		limiter:       newRateLimiter(time.Minute),
+	}
+}
+
+// AuthRequired はAPIキーまたはJWTによる認証が必要なエンドポイントに使用するミドルウェアです
+// X-API-Key ヘッダー、または APIKeyPrefix で始まる Bearer トークンが指定された場合はAPIキーとして検証し、
+// それ以外は fallback（JWTの検証）に処理を委ねます
+// APIキーは scopes を全て持つ場合のみ受け付け、scopes を指定しないエンドポイントではAPIキーを拒否します
+func (m *APIKeyMiddleware) AuthRequired(fallback gin.HandlerFunc, scopes ...auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := extractAPIKey(c)
+		if rawKey == "" {
+			fallback(c)
+			return
+		}
+		if len(scopes) == 0 {
+			c.JSON(http.StatusForbidden, gin.H{"error": "this endpoint does not accept api keys"})
+			c.Abort()
+			return
+		}
+
+		principal, err := m.authenticator.AuthenticateAPIKey(c.Request.Context(), rawKey)
+		if err != nil {
+			status := http.StatusUnauthorized
+			if !errors.Is(err, auth.ErrInvalidAPIKey) && !errors.Is(err, auth.ErrAPIKeyExpired) {
+				status = http.StatusInternalServerError
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		// キーごとのレート制限
+		remaining, retryAfter, ok := m.limiter.allow(principal.KeyID, principal.RateLimitPerMinute, time.Now())
+		c.Header("X-RateLimit-Limit", strconv.Itoa(principal.RateLimitPerMinute))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		if !ok {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			c.Abort()
+			return
+		}
+
+		for _, scope := range scopes {
+			if !containsString(principal.Permissions, string(scope)) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "api key does not have the required scope"})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set("user_id", principal.UserID)
+		c.Set("role", principal.Role)
+		c.Set("permissions", principal.Permissions)
+		c.Set("api_key_id", principal.KeyID)
+		if principal.OrganizationID != "" {
+			c.Set("api_key_organization_id", principal.OrganizationID)
+		}
+		c.Next()
+	}
+}
+
+// IsAPIKeyRequest はリクエストがAPIキーで認証されたかどうかを確認します
+func IsAPIKeyRequest(c *gin.Context) bool {
+	_, exists := c.Get("api_key_id")
+	return exists
+}
+
+// APIKeyOrganizationID は組織が所有するAPIキーで認証された場合に、キーを所有する組織のIDを返します
+// それ以外の場合は空文字を返します
+func APIKeyOrganizationID(c *gin.Context) string {
+	return c.GetString("api_key_organization_id")
+}
+
+// extractAPIKey はリクエストからAPIキーを取り出します
+func extractAPIKey(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	parts := strings.Split(c.GetHeader("Authorization"), " ")
+	if len(parts) == 2 && parts[0] == "Bearer" && strings.HasPrefix(parts[1], auth.APIKeyPrefix) {
+		return parts[1]
+	}
+	return ""
+}
+
+// rateLimiter は固定ウィンドウ方式のインメモリなレート制限です
+// 複数レプリカ構成ではレプリカごとに制限がかかります
+type rateLimiter struct {
+	window  time.Duration
+	mu      sync.Mutex
+	buckets map[string]*rateBucket
+}
+
+// rateBucket はウィンドウ内のリクエスト数です
+type rateBucket struct {
+	start time.Time
+	count int
+}
+
+// newRateLimiter は新しいrateLimiterを作成します
+func newRateLimiter(window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		window:  window,
+		buckets: map[string]*rateBucket{},
+	}
+}
+
+// allow はリクエストを許可するかどうかを判定し、残り回数と次のウィンドウまでの時間を返します
+func (l *rateLimiter) allow(key string, limit int, now time.Time) (int, time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok || now.Sub(b.start) >= l.window {
+		// 古いウィンドウを掃除する
+		for k, v := range l.buckets {
+			if now.Sub(v.start) >= l.window {
+				delete(l.buckets, k)
+			}
+		}
+		b = &rateBucket{start: now}
+		l.buckets[key] = b
+	}
+
+	retryAfter := b.start.Add(l.window).Sub(now)
+	if b.count >= limit {
+		return 0, retryAfter, false
+	}
+	b.count++
+	return limit - b.count, retryAfter, true
+}
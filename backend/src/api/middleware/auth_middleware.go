@@ -12,12 +12,18 @@ import (
 // AuthMiddleware は認証ミドルウェアを提供します
 type AuthMiddleware struct {
 	tokenService auth.TokenService
+	roleVersions auth.RoleVersionProvider
+	sessions     auth.SessionChecker
 }
 
 // NewAuthMiddleware は新しいAuthMiddlewareを作成します
-func NewAuthMiddleware(tokenService auth.TokenService) *AuthMiddleware {
+// roleVersions を指定した場合、ロール変更前に発行されたトークンを拒否します
+// sessions を指定した場合、失効したデバイスセッションのトークンを拒否します
+func NewAuthMiddleware(tokenService auth.TokenService, roleVersions auth.RoleVersionProvider, sessions auth.SessionChecker) *AuthMiddleware {
 	return &AuthMiddleware{
 		tokenService: tokenService,
+		roleVersions: roleVersions,
+		sessions:     sessions,
 	}
 }
 
@@ -47,27 +53,34 @@ func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 			return
 		}
 
+		// ロール変更やログアウトにより失効したトークンの拒否
+		if !m.isCurrentRole(c, claims) || !m.isActiveSession(c, claims) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "token has been revoked"})
+			c.Abort()
+			return
+		}
+
 		// ユーザー情報をコンテキストに設定
-		c.Set("user_id", claims.UserID)
-		c.Set("role", claims.Role)
+		setClaims(c, claims)
 		c.Next()
 	}
 }
 
-// RoleRequired は特定のロールが必要なエンドポイントに使用するミドルウェアです
-func (m *AuthMiddleware) RoleRequired(requiredRole string) gin.HandlerFunc {
+// RequirePermission は指定したすべての権限が必要なエンドポイントに使用するミドルウェアです
+func (m *AuthMiddleware) RequirePermission(perms ...auth.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("role")
-		if !exists {
+		if _, exists := c.Get("user_id"); !exists {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 			c.Abort()
 			return
 		}
 
-		if role.(string) != requiredRole {
-			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
-			c.Abort()
-			return
+		for _, perm := range perms {
+			if !HasPermission(c, perm) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+				c.Abort()
+				return
+			}
 		}
 
 		c.Next()
@@ -90,13 +103,90 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 		}
 
 		claims, err := m.tokenService.ValidateToken(parts[1])
-		if err != nil {
+		if err != nil || !m.isCurrentRole(c, claims) || !m.isActiveSession(c, claims) {
 			c.Next()
 			return
 		}
 
-		c.Set("user_id", claims.UserID)
-		c.Set("role", claims.Role)
+		setClaims(c, claims)
+		c.Next()
+	}
+}
+
+// HasPermission はリクエストのユーザーが指定した権限を持つかどうかを確認します
+func HasPermission(c *gin.Context, perm auth.Permission) bool {
+	perms, exists := c.Get("permissions")
+	if !exists {
+		return false
+	}
+	return containsString(perms.([]string), string(perm))
+}
+
+// isCurrentRole はトークンのロールバージョンが最新かどうかを確認します
+func (m *AuthMiddleware) isCurrentRole(c *gin.Context, claims *auth.Claims) bool {
+	if m.roleVersions == nil {
+		return true
+	}
+	current, err := m.roleVersions.CurrentRoleVersion(c.Request.Context(), claims.UserID)
+	if err != nil {
+		return false
+	}
+	return claims.RoleVersion >= current
+}
+
+// isActiveSession はトークンのデバイスセッションが失効していないかどうかを確認します
+// セッションIDを持たないトークンはログアウトで失効させられないため拒否します
+func (m *AuthMiddleware) isActiveSession(c *gin.Context, claims *auth.Claims) bool {
+	if m.sessions == nil {
+		return true
+	}
+	if claims.SessionID == "" {
+		return false
+	}
+	active, err := m.sessions.IsSessionActive(c.Request.Context(), claims.SessionID)
+	return err == nil && active
+}
+
+// WebSocketTokenProtocolPrefix は WebSocket のサブプロトコルでトークンを渡す場合の接頭辞です
+const WebSocketTokenProtocolPrefix = "bearer."
+
+// WebSocketToken は WebSocket のサブプロトコル（bearer.<トークン>）で渡されたトークンを Authorization ヘッダーとして扱うミドルウェアです
+// ブラウザの WebSocket はヘッダーを指定できないため、アクセスログに残るクエリパラメーターの代わりに使います
+// 認証のミドルウェアより前に登録します
+func WebSocketToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			for _, header := range c.Request.Header.Values("Sec-WebSocket-Protocol") {
+				for _, protocol := range strings.Split(header, ",") {
+					protocol = strings.TrimSpace(protocol)
+					if strings.HasPrefix(protocol, WebSocketTokenProtocolPrefix) {
+						c.Request.Header.Set("Authorization", "Bearer "+strings.TrimPrefix(protocol, WebSocketTokenProtocolPrefix))
+					}
+				}
+			}
+		}
 		c.Next()
 	}
 }
+
+// setClaims はトークンのユーザー情報をコンテキストに設定します
+func setClaims(c *gin.Context, claims *auth.Claims) {
+	perms := claims.Permissions
+	if perms == nil {
+		perms = auth.PermissionStrings(claims.Role)
+	}
+	c.Set("user_id", claims.UserID)
+	c.Set("role", claims.Role)
+	c.Set("permissions", perms)
+	c.Set("session_id", claims.SessionID)
+}
+
+// containsString はスライスに値が含まれるかどうかを確認します
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
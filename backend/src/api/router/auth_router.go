@@ -8,11 +8,40 @@ import (
 )
 
 // SetupAuthRoutes は認証関連のルーティングを設定します
-func SetupAuthRoutes(router *gin.Engine, authHandler *handler.AuthHandler, authMiddleware *middleware.AuthMiddleware) {
+func SetupAuthRoutes(
+	router *gin.Engine,
+	authHandler *handler.AuthHandler,
+	mfaHandler *handler.MFAHandler,
+	oauthHandler *handler.OAuthHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
 	auth := router.Group("/auth")
 	{
 		auth.POST("/login", authHandler.Login)
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/refresh", authHandler.RefreshToken)
 	}
+
+	// 二要素認証
+	mfa := auth.Group("/mfa")
+	{
+		mfa.POST("/verify", mfaHandler.Verify)
+		mfa.POST("/enroll", authMiddleware.OptionalAuth(), mfaHandler.Enroll)
+		mfa.POST("/confirm", authMiddleware.OptionalAuth(), mfaHandler.Confirm)
+		mfa.GET("", authMiddleware.AuthRequired(), mfaHandler.GetStatus)
+		mfa.POST("/disable", authMiddleware.AuthRequired(), mfaHandler.Disable)
+		mfa.POST("/recovery-codes", authMiddleware.AuthRequired(), mfaHandler.RegenerateRecoveryCodes)
+	}
+
+	// ソーシャルログイン（OAuth2/OIDC）
+	oauth := auth.Group("/oauth")
+	{
+		oauth.GET("/providers", oauthHandler.ListProviders)
+		oauth.GET("/identities", authMiddleware.AuthRequired(), oauthHandler.ListIdentities)
+		oauth.GET("/:provider/authorize", oauthHandler.Authorize)
+		oauth.POST("/:provider/callback", oauthHandler.Callback)
+		oauth.POST("/:provider/link", authMiddleware.AuthRequired(), oauthHandler.Link)
+		oauth.POST("/:provider/link/callback", authMiddleware.AuthRequired(), oauthHandler.LinkCallback)
+		oauth.DELETE("/:provider", authMiddleware.AuthRequired(), oauthHandler.Unlink)
+	}
 }
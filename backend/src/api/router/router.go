@@ -3,27 +3,97 @@ package router
 import (
 	"kimiyomi/backend/src/api/handler"
 	"kimiyomi/backend/src/api/middleware"
+	"kimiyomi/backend/src/domain/auth"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Router はアプリケーションのルーティングを管理します
 type Router struct {
-	engine         *gin.Engine
-	authHandler    *handler.AuthHandler
-	authMiddleware *middleware.AuthMiddleware
+	engine              *gin.Engine
+	authHandler         *handler.AuthHandler
+	mfaHandler          *handler.MFAHandler
+	oauthHandler        *handler.OAuthHandler
+	roleHandler         *handler.RoleHandler
+	apiKeyHandler       *handler.APIKeyHandler
+	sessionHandler      *handler.SessionHandler
+	profileHandler      *handler.ProfileHandler
+	accountHandler      *handler.AccountHandler
+	oshiHandler         *handler.OshiHandler
+	orgHandler          *handler.OrganizationHandler
+	feedHandler         *handler.FeedHandler
+	engagementHandler   *handler.EngagementHandler
+	leaderboardHandler  *handler.LeaderboardHandler
+	achievementHandler  *handler.AchievementHandler
+	shareHandler        *handler.ShareHandler
+	communityHandler    *handler.CommunityHandler
+	contentHandler      *handler.ContentHandler
+	moderationHandler   *handler.ContentModerationHandler
+	relationHandler     *handler.UserRelationHandler
+	messageHandler      *handler.MessageHandler
+	realtimeHandler     *handler.RealtimeHandler
+	notificationHandler *handler.NotificationHandler
+	emailHandler        *handler.EmailHandler
+	authMiddleware      *middleware.AuthMiddleware
+	apiKeyMiddleware    *middleware.APIKeyMiddleware
 }
 
 // NewRouter は新しいRouterを作成します
 func NewRouter(
 	engine *gin.Engine,
 	authHandler *handler.AuthHandler,
+	mfaHandler *handler.MFAHandler,
+	oauthHandler *handler.OAuthHandler,
+	roleHandler *handler.RoleHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	sessionHandler *handler.SessionHandler,
+	profileHandler *handler.ProfileHandler,
+	accountHandler *handler.AccountHandler,
+	oshiHandler *handler.OshiHandler,
+	orgHandler *handler.OrganizationHandler,
+	feedHandler *handler.FeedHandler,
+	engagementHandler *handler.EngagementHandler,
+	leaderboardHandler *handler.LeaderboardHandler,
+	achievementHandler *handler.AchievementHandler,
+	shareHandler *handler.ShareHandler,
+	communityHandler *handler.CommunityHandler,
+	contentHandler *handler.ContentHandler,
+	moderationHandler *handler.ContentModerationHandler,
+	relationHandler *handler.UserRelationHandler,
+	messageHandler *handler.MessageHandler,
+	realtimeHandler *handler.RealtimeHandler,
+	notificationHandler *handler.NotificationHandler,
+	emailHandler *handler.EmailHandler,
 	authMiddleware *middleware.AuthMiddleware,
+	apiKeyMiddleware *middleware.APIKeyMiddleware,
 ) *Router {
 	return &Router{
-		engine:         engine,
-		authHandler:    authHandler,
-		authMiddleware: authMiddleware,
+		engine:              engine,
+		authHandler:         authHandler,
+		mfaHandler:          mfaHandler,
+		oauthHandler:        oauthHandler,
+		roleHandler:         roleHandler,
+		apiKeyHandler:       apiKeyHandler,
+		sessionHandler:      sessionHandler,
+		profileHandler:      profileHandler,
+		accountHandler:      accountHandler,
+		oshiHandler:         oshiHandler,
+		orgHandler:          orgHandler,
+		feedHandler:         feedHandler,
+		engagementHandler:   engagementHandler,
+		leaderboardHandler:  leaderboardHandler,
+		achievementHandler:  achievementHandler,
+		shareHandler:        shareHandler,
+		communityHandler:    communityHandler,
+		contentHandler:      contentHandler,
+		moderationHandler:   moderationHandler,
+		relationHandler:     relationHandler,
+		messageHandler:      messageHandler,
+		realtimeHandler:     realtimeHandler,
+		notificationHandler: notificationHandler,
+		emailHandler:        emailHandler,
+		authMiddleware:      authMiddleware,
+		apiKeyMiddleware:    apiKeyMiddleware,
 	}
 }
 
@@ -37,7 +107,112 @@ func (r *Router) Setup() {
 	r.engine.Use(middleware.CORS())
 
 	// 認証関連のルーティング
-	SetupAuthRoutes(r.engine, r.authHandler, r.authMiddleware)
+	SetupAuthRoutes(r.engine, r.authHandler, r.mfaHandler, r.oauthHandler, r.authMiddleware)
+
+	// 認証が任意の公開API
+	public := r.engine.Group("/api/v1")
+	public.Use(r.authMiddleware.OptionalAuth())
+	{
+		r.profileHandler.RegisterPublicRoutes(public)
+		r.oshiHandler.RegisterPublicRoutes(public)
+		r.leaderboardHandler.RegisterPublicRoutes(public)
+		r.shareHandler.RegisterPublicRoutes(public)
+		r.communityHandler.RegisterPublicRoutes(public)
+		// メールの配信停止と送信サーバーからのバウンスの通知
+		r.emailHandler.RegisterPublicRoutes(public)
+	}
+
+	// 診断結果の共有ページ（SNS のクローラー向け）
+	r.shareHandler.RegisterPageRoutes(r.engine.Group("/s"))
+
+	// JWTによる認証。APIキーは AuthRequired にスコープを指定したグループでのみ受け付け、それ以外では拒否する
+	jwtAuth := r.authMiddleware.AuthRequired()
+
+	// リアルタイム配信（SSE・WebSocket）
+	// WebSocket はサブプロトコルでトークンを受け取るため、認証より前に読み取る
+	realtime := r.engine.Group("/api/v1/realtime")
+	realtime.Use(middleware.WebSocketToken(), r.apiKeyMiddleware.AuthRequired(jwtAuth))
+	r.realtimeHandler.RegisterRoutes(realtime)
+
+	// 認証が必要なAPI（JWTのみ）
+	api := r.engine.Group("/api/v1")
+	api.Use(r.apiKeyMiddleware.AuthRequired(jwtAuth))
+	{
+		// プロフィールとログイン中の端末の管理
+		r.profileHandler.RegisterRoutes(api)
+		r.sessionHandler.RegisterRoutes(api)
+
+		// ユーザーのブロック・ミュート
+		r.relationHandler.RegisterRoutes(api)
+
+		// 退会とデータエクスポート
+		r.accountHandler.RegisterRoutes(api)
+
+		// グループ・事務所とメンバーの管理
+		r.orgHandler.RegisterRoutes(api)
+
+		// 推しのフォローとホームフィード
+		r.feedHandler.RegisterRoutes(api)
+
+		// 推し活の行動の記録と推し活レベル
+		r.engagementHandler.RegisterRoutes(api)
+		r.leaderboardHandler.RegisterRoutes(api)
+		r.achievementHandler.RegisterRoutes(api)
+
+		// 診断結果の共有リンク
+		r.shareHandler.RegisterRoutes(api)
+
+		// 推しのページのコミュニティ
+		r.communityHandler.RegisterRoutes(api)
+
+		// コンテンツの通報
+		r.moderationHandler.RegisterRoutes(api)
+
+		// ファンとキャストのメッセージ
+		r.messageHandler.RegisterRoutes(api)
+
+		// 通知の受信箱とプッシュ通知の端末・通知設定
+		r.notificationHandler.RegisterRoutes(api)
+
+		// メールの種類ごとの配信設定
+		r.emailHandler.RegisterRoutes(api)
+
+		// モデレーター向けの不正アカウントの管理
+		moderation := api.Group("/moderation", r.authMiddleware.RequirePermission(auth.PermCommunityModerate))
+		r.leaderboardHandler.RegisterModerationRoutes(moderation)
+
+		// モデレーター向けのコンテンツの審査
+		contentModeration := api.Group("/moderation", r.authMiddleware.RequirePermission(auth.PermContentModerate))
+		r.moderationHandler.RegisterModerationRoutes(contentModeration)
+
+		// APIキーの管理（クリエイター・管理者向け）
+		apiKeyManager := api.Group("", r.authMiddleware.RequirePermission(auth.PermAPIKeyManage))
+		r.apiKeyHandler.RegisterRoutes(apiKeyManager)
+
+		// 管理者向けのロール管理
+		admin := api.Group("/admin", r.authMiddleware.RequirePermission(auth.PermUserManageRoles))
+		r.roleHandler.RegisterRoutes(admin)
+		r.messageHandler.RegisterAdminRoutes(admin)
+	}
+
+	// 外部システムとの連携にも使うAPI（JWTまたは対応するスコープを持つAPIキー）
+	// コンテンツの閲覧
+	contentReader := r.engine.Group("/api/v1", r.apiKeyMiddleware.AuthRequired(jwtAuth, auth.PermContentRead))
+	r.contentHandler.RegisterRoutes(contentReader)
+
+	// コンテンツの投稿・編集（クリエイター・管理者向け）
+	contentWriter := r.engine.Group("/api/v1",
+		r.apiKeyMiddleware.AuthRequired(jwtAuth, auth.PermContentCreate),
+		r.authMiddleware.RequirePermission(auth.PermContentCreate),
+	)
+	r.contentHandler.RegisterWriteRoutes(contentWriter)
+
+	// 推しカタログの管理（クリエイター・管理者向け）
+	oshiManager := r.engine.Group("/api/v1",
+		r.apiKeyMiddleware.AuthRequired(jwtAuth, auth.PermOshiManage),
+		r.authMiddleware.RequirePermission(auth.PermOshiManage),
+	)
+	r.oshiHandler.RegisterRoutes(oshiManager)
 
 	// ヘルスチェック
 	r.engine.GET("/health", func(c *gin.Context) {
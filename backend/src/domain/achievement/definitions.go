@@ -0,0 +1,51 @@
+package achievement
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+//go:embed badges.json
+var defaultDefinitions []byte
+
+// DefaultDefinitions は badges.json に定義されたバッジの一覧を返します
+func DefaultDefinitions() ([]*entity.BadgeDefinition, error) {
+	return LoadDefinitions(defaultDefinitions)
+}
+
+// LoadDefinitions はJSON形式のバッジの定義を読み込み、検証します
+func LoadDefinitions(data []byte) ([]*entity.BadgeDefinition, error) {
+	var definitions []*entity.BadgeDefinition
+	if err := json.Unmarshal(data, &definitions); err != nil {
+		return nil, fmt.Errorf("failed to decode badge definitions: %w", err)
+	}
+
+	seen := make(map[string]bool, len(definitions))
+	for _, def := range definitions {
+		if def.ID == "" || def.Name == "" {
+			return nil, fmt.Errorf("badge definition requires id and name")
+		}
+		if seen[def.ID] {
+			return nil, fmt.Errorf("duplicate badge definition: %s", def.ID)
+		}
+		seen[def.ID] = true
+
+		if def.Threshold <= 0 || def.RewardPoints < 0 {
+			return nil, fmt.Errorf("invalid threshold or reward for badge: %s", def.ID)
+		}
+		switch def.Metric {
+		case entity.BadgeMetricActivityCount:
+			if !entity.IsValidActivityType(def.ActivityType) {
+				return nil, fmt.Errorf("invalid activity type for badge: %s", def.ID)
+			}
+		case entity.BadgeMetricStreakDays:
+		default:
+			return nil, fmt.Errorf("invalid metric for badge: %s", def.ID)
+		}
+	}
+
+	return definitions, nil
+}
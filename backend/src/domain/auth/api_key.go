@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// APIKeyPrefix はAPIキーの先頭に付く識別子です
+// Bearer トークンがこの識別子で始まる場合はJWTではなくAPIキーとして扱います
+const APIKeyPrefix = "kmy_"
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	ErrAPIKeyExpired = errors.New("api key has expired")
+)
+
+// APIKeyScopes はAPIキーに付与できるスコープの一覧です
+// APIキーの実効権限は、スコープと所有者の現在のロールの権限の積集合になります
+var APIKeyScopes = []Permission{
+	PermContentRead,
+	PermContentCreate,
+	PermStatsRead,
+	PermOshiManage,
+}
+
+// IsValidAPIKeyScope はAPIキーに付与できるスコープかどうかを確認します
+func IsValidAPIKeyScope(scope string) bool {
+	for _, s := range APIKeyScopes {
+		if string(s) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyPrincipal はAPIキーで認証された主体を表します
+type APIKeyPrincipal struct {
+	KeyID  string
+	UserID string
+	// OrganizationID は組織が所有するキーの場合に、キーを利用できる組織のIDを表します
+	OrganizationID     string
+	Role               string
+	Permissions        []string
+	RateLimitPerMinute int
+}
+
+// APIKeyAuthenticator はAPIキーを検証するインターフェースです
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, rawKey string) (*APIKeyPrincipal, error)
+}
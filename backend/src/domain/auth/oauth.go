@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+)
+
+// OAuthUserInfo は外部IdPから取得したユーザー情報です
+type OAuthUserInfo struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// OAuthProvider は外部IdPとの認可コードフロー（PKCE）を扱うインターフェースです
+type OAuthProvider interface {
+	// Name はプロバイダー名を返します
+	Name() string
+
+	// AuthCodeURL は認可エンドポイントへのURLを生成します
+	AuthCodeURL(state, nonce, codeVerifier string) string
+
+	// Exchange は認可コードをトークンに交換し、ユーザー情報を返します
+	// OIDCプロバイダーの場合はIDトークンの署名とnonceも検証します
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*OAuthUserInfo, error)
+}
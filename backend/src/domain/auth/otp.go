@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"time"
+)
+
+// OTPService はワンタイムパスワード（TOTP）の生成と検証を行うインターフェースです
+type OTPService interface {
+	// GenerateSecret は新しい共有シークレットを生成します
+	GenerateSecret() (string, error)
+
+	// ProvisioningURI は認証アプリのQRコードに埋め込む otpauth:// URI を生成します
+	ProvisioningURI(secret, accountName string) string
+
+	// Validate はコードを検証し、一致したタイムステップを返します
+	Validate(secret, code string, at time.Time) (int64, bool)
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+// Permission は操作の権限を表す型です
+type Permission string
+
+const (
+	// PermContentRead はコンテンツを閲覧する権限です
+	PermContentRead Permission = "content:read"
+
+	// PermContentCreate はコンテンツを投稿する権限です
+	PermContentCreate Permission = "content:create"
+
+	// PermContentManageAny は他のユーザーのコンテンツを編集・削除する権限です
+	PermContentManageAny Permission = "content:manage_any"
+
+	// PermStatsRead は自分のコンテンツの統計を閲覧する権限です
+	PermStatsRead Permission = "stats:read"
+
+	// PermContentModerate はコンテンツを審査する権限です
+	PermContentModerate Permission = "content:moderate"
+
+	// PermCommunityModerate はコミュニティの投稿やコメントを審査する権限です
+	PermCommunityModerate Permission = "community:moderate"
+
+	// PermUserReadAny は他のユーザーの非公開情報を閲覧する権限です
+	PermUserReadAny Permission = "user:read_any"
+
+	// PermOshiManage は推しを登録し、自分が登録または紐付けられた推しを編集する権限です
+	PermOshiManage Permission = "oshi:manage"
+
+	// PermOshiManageAny は全ての推しを編集・削除する権限です
+	PermOshiManageAny Permission = "oshi:manage_any"
+
+	// PermUserManageRoles はユーザーのロールを変更する権限です
+	PermUserManageRoles Permission = "user:manage_roles"
+
+	// PermAPIKeyManage は外部システム連携用のAPIキーを発行・失効する権限です
+	PermAPIKeyManage Permission = "api_key:manage"
+)
+
+// rolePermissions はロールごとの権限の対応表です
+var rolePermissions = map[string][]Permission{
+	entity.RoleFan: {
+		PermContentRead,
+	},
+	entity.RoleCreator: {
+		PermContentRead,
+		PermContentCreate,
+		PermStatsRead,
+		PermOshiManage,
+		PermAPIKeyManage,
+	},
+	entity.RoleModerator: {
+		PermContentRead,
+		PermContentModerate,
+		PermCommunityModerate,
+		PermUserReadAny,
+	},
+	entity.RoleAdmin: {
+		PermContentRead,
+		PermContentCreate,
+		PermContentManageAny,
+		PermStatsRead,
+		PermContentModerate,
+		PermCommunityModerate,
+		PermUserReadAny,
+		PermOshiManage,
+		PermOshiManageAny,
+		PermUserManageRoles,
+		PermAPIKeyManage,
+	},
+}
+
+// PermissionsForRole はロールに割り当てられた権限の一覧を返します
+func PermissionsForRole(role string) []Permission {
+	perms := rolePermissions[role]
+	out := make([]Permission, len(perms))
+	copy(out, perms)
+	return out
+}
+
+// RolePermissions は全ロールと権限の対応表を返します
+func RolePermissions() map[string][]Permission {
+	out := make(map[string][]Permission, len(rolePermissions))
+	for role := range rolePermissions {
+		out[role] = PermissionsForRole(role)
+	}
+	return out
+}
+
+// HasPermission はクレームに指定された権限が含まれるかどうかを確認します
+func (c *Claims) HasPermission(perm Permission) bool {
+	for _, p := range c.Permissions {
+		if Permission(p) == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionStrings はロールの権限をクレームに埋め込む文字列の一覧で返します
+func PermissionStrings(role string) []string {
+	perms := rolePermissions[role]
+	out := make([]string, len(perms))
+	for i, p := range perms {
+		out[i] = string(p)
+	}
+	return out
+}
+
+// RoleVersionProvider はユーザーの現在のロールバージョンを提供するインターフェースです
+// トークンに埋め込まれたロールバージョンが古い場合、そのトークンは無効として扱います
+type RoleVersionProvider interface {
+	CurrentRoleVersion(ctx context.Context, userID string) (int, error)
+}
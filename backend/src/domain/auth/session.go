@@ -0,0 +1,9 @@
+package auth
+
+import "context"
+
+// SessionChecker はデバイスセッションが有効かどうかを確認するインターフェースです
+// 失効したセッションに紐付くアクセストークンは有効期限内でも拒否します
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+}
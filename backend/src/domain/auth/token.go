@@ -10,20 +10,45 @@ import (
 // Claims はJWTのクレーム情報を表します
 type Claims struct {
 	jwt.RegisteredClaims
-	UserID string `json:"user_id"`
-	Role   string `json:"role"`
+	UserID      string   `json:"user_id"`
+	Role        string   `json:"role"`
+	RoleVersion int      `json:"rv"`
+	Permissions []string `json:"perms,omitempty"`
+	SessionID   string   `json:"sid,omitempty"`
+	Purpose     string   `json:"purpose,omitempty"`
 }
 
+const (
+	// PurposeMFAChallenge は二要素認証の検証待ちであることを示すトークン用途です
+	PurposeMFAChallenge = "mfa_challenge"
+
+	// PurposeMFAEnrollment は二要素認証の登録待ちであることを示すトークン用途です
+	PurposeMFAEnrollment = "mfa_enrollment"
+
+	// MFATokenDuration は二要素認証用トークンの有効期間です
+	MFATokenDuration = 5 * time.Minute
+)
+
+// ErrInvalidTokenPurpose はトークンの用途が一致しない場合のエラーです
+var ErrInvalidTokenPurpose = errors.New("invalid token purpose")
+
 // TokenService はトークン生成と検証を行うインターフェースです
 type TokenService interface {
 	// GenerateToken はJWTトークンを生成します
-	GenerateToken(userID string, role string) (string, error)
+	// ロールの権限とロールバージョン、デバイスセッションIDをクレームに埋め込みます
+	GenerateToken(userID string, role string, roleVersion int, sessionID string) (string, error)
 
 	// ValidateToken はトークンを検証し、クレーム情報を返します
 	ValidateToken(tokenString string) (*Claims, error)
 
 	// RefreshToken は新しいトークンを生成します
 	RefreshToken(tokenString string) (string, error)
+
+	// GenerateMFAToken は二要素認証フロー用の短命なトークンを生成します
+	GenerateMFAToken(userID string, role string, roleVersion int, purpose string) (string, error)
+
+	// ValidateMFAToken は二要素認証フロー用のトークンを検証します
+	ValidateMFAToken(tokenString string, purpose string) (*Claims, error)
 }
 
 // JWTConfig はJWT設定を表します
@@ -43,15 +68,18 @@ func NewJWTService(config JWTConfig) TokenService {
 }
 
 // GenerateToken はJWTトークンを生成します
-func (s *JWTService) GenerateToken(userID string, role string) (string, error) {
+func (s *JWTService) GenerateToken(userID string, role string, roleVersion int, sessionID string) (string, error) {
 	claims := &Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.config.TokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
-		UserID: userID,
-		Role:   role,
+		UserID:      userID,
+		Role:        role,
+		RoleVersion: roleVersion,
+		Permissions: PermissionStrings(role),
+		SessionID:   sessionID,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -60,6 +88,18 @@ func (s *JWTService) GenerateToken(userID string, role string) (string, error) {
 
 // ValidateToken はトークンを検証し、クレーム情報を返します
 func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, ErrInvalidTokenPurpose
+	}
+	return claims, nil
+}
+
+// parse はトークンの署名と有効期限を検証し、クレーム情報を返します
+func (s *JWTService) parse(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.config.SecretKey), nil
 	})
@@ -82,5 +122,35 @@ func (s *JWTService) RefreshToken(tokenString string) (string, error) {
 		return "", err
 	}
 
-	return s.GenerateToken(claims.UserID, claims.Role)
+	return s.GenerateToken(claims.UserID, claims.Role, claims.RoleVersion, claims.SessionID)
+}
+
+// GenerateMFAToken は二要素認証フロー用の短命なトークンを生成します
+func (s *JWTService) GenerateMFAToken(userID string, role string, roleVersion int, purpose string) (string, error) {
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(MFATokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+		UserID:      userID,
+		Role:        role,
+		RoleVersion: roleVersion,
+		Purpose:     purpose,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.config.SecretKey))
+}
+
+// ValidateMFAToken は二要素認証フロー用のトークンを検証します
+func (s *JWTService) ValidateMFAToken(tokenString string, purpose string) (*Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if purpose == "" || claims.Purpose != purpose {
+		return nil, ErrInvalidTokenPurpose
+	}
+	return claims, nil
 }
@@ -0,0 +1,180 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+//go:embed templates
+var defaultTemplates embed.FS
+
+// テンプレートのファイル名は {名前}.{言語}.html と {名前}.{言語}.txt です
+// .txt には件名（subject）と本文（body）、.html には本文（body）を define で定義し、
+// それぞれ layout.html と layout.txt の中に埋め込みます
+const (
+	htmlLayoutFile = "layout.html"
+	textLayoutFile = "layout.txt"
+)
+
+// localizedTemplate は1つの言語のメールのテンプレートです
+type localizedTemplate struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// Rendered はテンプレートから作成したメールの件名と本文です
+type Rendered struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Templates はメールのテンプレートの集合です
+// テンプレートのパラメータは {{.name}} で参照し、指定されていないパラメータはエラーになります
+// locale と unsubscribe_url（配信停止のURL、無い場合は空）は常に参照できます
+type Templates struct {
+	templates map[string]map[string]*localizedTemplate
+}
+
+// DefaultTemplates は templates ディレクトリに定義されたテンプレートを返します
+func DefaultTemplates() (*Templates, error) {
+	sub, err := fs.Sub(defaultTemplates, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open email templates: %w", err)
+	}
+	return LoadTemplates(sub)
+}
+
+// LoadTemplates はディレクトリのテンプレートを読み込み、検証します
+// 全てのテンプレートに日本語のHTMLとテキストの両方が必要です
+func LoadTemplates(fsys fs.FS) (*Templates, error) {
+	htmlLayout, err := fs.ReadFile(fsys, htmlLayoutFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email layout: %w", err)
+	}
+	textLayout, err := fs.ReadFile(fsys, textLayoutFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email layout: %w", err)
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+
+	templates := make(map[string]map[string]*localizedTemplate)
+	for _, entry := range entries {
+		file := entry.Name()
+		if entry.IsDir() || file == htmlLayoutFile || file == textLayoutFile {
+			continue
+		}
+		ext := path.Ext(file)
+		parts := strings.Split(strings.TrimSuffix(file, ext), ".")
+		if len(parts) != 2 || (ext != ".html" && ext != ".txt") {
+			return nil, fmt.Errorf("unexpected email template file: %s", file)
+		}
+		name, locale := parts[0], parts[1]
+		if locale != entity.LocaleJa && locale != entity.LocaleEn {
+			return nil, fmt.Errorf("unsupported locale %s for email template: %s", locale, name)
+		}
+
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read email template %s: %w", file, err)
+		}
+
+		if templates[name] == nil {
+			templates[name] = make(map[string]*localizedTemplate)
+		}
+		if templates[name][locale] == nil {
+			templates[name][locale] = &localizedTemplate{}
+		}
+		tmpl := templates[name][locale]
+
+		switch ext {
+		case ".html":
+			tmpl.html, err = htmltemplate.New(file).Option("missingkey=error").Parse(string(htmlLayout))
+			if err == nil {
+				_, err = tmpl.html.Parse(string(data))
+			}
+		case ".txt":
+			tmpl.text, err = texttemplate.New(file).Option("missingkey=error").Parse(string(textLayout))
+			if err == nil {
+				_, err = tmpl.text.Parse(string(data))
+			}
+			if err == nil && tmpl.text.Lookup("subject") == nil {
+				err = fmt.Errorf("subject is not defined")
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse email template %s: %w", file, err)
+		}
+	}
+
+	for name, locales := range templates {
+		if _, ok := locales[entity.LocaleJa]; !ok {
+			return nil, fmt.Errorf("email template %s requires %s", name, entity.LocaleJa)
+		}
+		for locale, tmpl := range locales {
+			if tmpl.html == nil || tmpl.text == nil {
+				return nil, fmt.Errorf("email template %s (%s) requires both html and txt", name, locale)
+			}
+		}
+	}
+
+	return &Templates{templates: templates}, nil
+}
+
+// Has はテンプレートが定義されているかどうかを確認します
+func (t *Templates) Has(name string) bool {
+	_, ok := t.templates[name]
+	return ok
+}
+
+// Render はテンプレートから指定した言語の件名と本文を作成します
+// 指定した言語の文面が無い場合は日本語の文面を使います
+func (t *Templates) Render(name, locale string, params map[string]string) (*Rendered, error) {
+	locales, ok := t.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("email template not found: %s", name)
+	}
+	tmpl, ok := locales[locale]
+	if !ok {
+		locale = entity.LocaleJa
+		tmpl = locales[locale]
+	}
+
+	data := make(map[string]string, len(params)+2)
+	for k, v := range params {
+		data[k] = v
+	}
+	data["locale"] = locale
+	if _, ok := data["unsubscribe_url"]; !ok {
+		data["unsubscribe_url"] = ""
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render email subject %s: %w", name, err)
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render email text %s: %w", name, err)
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render email html %s: %w", name, err)
+	}
+
+	return &Rendered{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		HTML:    html.String(),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
@@ -0,0 +1,216 @@
+package engagement
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+// streakBreakdownKey は連続日数のボーナスを内訳に含める際のキーです
+const streakBreakdownKey = "streak"
+
+// Config はエンゲージメントの算出方法の設定です
+type Config struct {
+	// Weights は行動の種類ごとの基本点です
+	Weights map[entity.ActivityType]float64
+	// HalfLife は行動の点数が半分になるまでの期間です
+	HalfLife time.Duration
+	// Window はスコアの算出対象とする期間です。これより古い行動は無視します
+	Window time.Duration
+	// DailyCap は1日あたりに点数を数える行動の種類ごとの上限回数です（0 は無制限）
+	DailyCap int
+	// StreakBonus は連続して行動した1日あたりのボーナス点です
+	StreakBonus float64
+	// MaxStreakDays はボーナスの対象とする連続日数の上限です
+	MaxStreakDays int
+	// LevelThresholds は各レベルに到達するために必要なスコアです（昇順、先頭がレベル1）
+	LevelThresholds []float64
+	// FactorScale は相性スコアに使う補正係数が 0.5 になるスコアです
+	FactorScale float64
+	// Location は日付の区切りに使うタイムゾーンです
+	Location *time.Location
+}
+
+// DefaultConfig は既定の算出方法の設定を返します
+func DefaultConfig() Config {
+	return Config{
+		Weights: map[entity.ActivityType]float64{
+			entity.ActivityTypeView:      1,
+			entity.ActivityTypeShare:     3,
+			entity.ActivityTypeDiagnosis: 5,
+			entity.ActivityTypePurchase:  20,
+		},
+		HalfLife:        30 * 24 * time.Hour,
+		Window:          180 * 24 * time.Hour,
+		DailyCap:        10,
+		StreakBonus:     2,
+		MaxStreakDays:   30,
+		LevelThresholds: []float64{0, 10, 30, 60, 100, 160, 250, 400, 600, 900},
+		FactorScale:     100,
+		Location:        time.FixedZone("JST", 9*60*60),
+	}
+}
+
+// Scorer は行動の記録からエンゲージメントを算出します
+type Scorer struct {
+	config Config
+}
+
+// NewScorer は新しいScorerを作成します
+func NewScorer(config Config) *Scorer {
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+	return &Scorer{config: config}
+}
+
+// Since は算出対象とする行動の開始日時を返します
+func (s *Scorer) Since(now time.Time) time.Time {
+	return now.Add(-s.config.Window)
+}
+
+// Points は行動の種類ごとの減衰前の基本点を返します
+func (s *Scorer) Points(activityType entity.ActivityType) float64 {
+	return s.config.Weights[activityType]
+}
+
+// DailyCap は1日あたりに点数を数える行動の種類ごとの上限回数を返します（0 は無制限）
+func (s *Scorer) DailyCap() int {
+	return s.config.DailyCap
+}
+
+// Location は日付の区切りに使うタイムゾーンを返します
+func (s *Scorer) Location() *time.Location {
+	return s.config.Location
+}
+
+// StartOfDay は日付の区切りに使うタイムゾーンでの当日の開始日時を返します
+func (s *Scorer) StartOfDay(now time.Time) time.Time {
+	t := now.In(s.config.Location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.config.Location)
+}
+
+// Score は1人のユーザーの1人の推しに対する行動の記録からスコアとレベルを算出します
+// 行動の点数は経過時間に応じて減衰し、同じ日の同じ種類の行動は DailyCap 回まで数えます
+func (s *Scorer) Score(events []*entity.ActivityEvent, now time.Time) *entity.EngagementScore {
+	result := &entity.EngagementScore{
+		Breakdown: make(map[string]float64),
+	}
+
+	since := s.Since(now)
+	dailyCounts := make(map[string]int)
+	activeDays := make(map[string]bool)
+	for _, event := range events {
+		if event.OccurredAt.Before(since) || event.OccurredAt.After(now) {
+			continue
+		}
+		if result.LastActiveAt == nil || event.OccurredAt.After(*result.LastActiveAt) {
+			occurredAt := event.OccurredAt
+			result.LastActiveAt = &occurredAt
+		}
+
+		day := s.day(event.OccurredAt)
+		activeDays[day] = true
+
+		key := day + "|" + string(event.Type)
+		dailyCounts[key]++
+		if s.config.DailyCap > 0 && dailyCounts[key] > s.config.DailyCap {
+			continue
+		}
+
+		points := s.config.Weights[event.Type] * s.decay(now.Sub(event.OccurredAt))
+		result.Breakdown[string(event.Type)] += points
+		result.Score += points
+	}
+
+	result.StreakDays = s.streak(activeDays, now)
+	if result.StreakDays > 0 && s.config.StreakBonus > 0 {
+		days := result.StreakDays
+		if s.config.MaxStreakDays > 0 && days > s.config.MaxStreakDays {
+			days = s.config.MaxStreakDays
+		}
+		bonus := float64(days) * s.config.StreakBonus
+		result.Breakdown[streakBreakdownKey] = bonus
+		result.Score += bonus
+	}
+
+	result.Score = round(result.Score)
+	for key, points := range result.Breakdown {
+		result.Breakdown[key] = round(points)
+	}
+	result.Level, result.NextLevelScore = s.level(result.Score)
+
+	return result
+}
+
+// StreakDays は行動の記録から今日または昨日まで連続して行動した日数を返します
+func (s *Scorer) StreakDays(events []*entity.ActivityEvent, now time.Time) int {
+	activeDays := make(map[string]bool)
+	for _, event := range events {
+		activeDays[s.day(event.OccurredAt)] = true
+	}
+	return s.streak(activeDays, now)
+}
+
+// Factor はスコアを相性スコアの補正に使う 0 以上 1 未満の係数に変換します
+func (s *Scorer) Factor(score float64) float64 {
+	if score <= 0 || s.config.FactorScale <= 0 {
+		return 0
+	}
+	return score / (score + s.config.FactorScale)
+}
+
+// decay は経過時間に応じた減衰率を返します
+func (s *Scorer) decay(age time.Duration) float64 {
+	if s.config.HalfLife <= 0 || age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(s.config.HalfLife))
+}
+
+// streak は今日または昨日まで連続して行動した日数を返します
+func (s *Scorer) streak(activeDays map[string]bool, now time.Time) int {
+	current := now.In(s.config.Location)
+	if !activeDays[s.day(current)] {
+		// 今日まだ行動していなくても昨日まで続いていれば連続として扱う
+		current = current.AddDate(0, 0, -1)
+	}
+
+	days := 0
+	for activeDays[s.day(current)] {
+		days++
+		current = current.AddDate(0, 0, -1)
+	}
+	return days
+}
+
+// level はスコアに対応するレベルと次のレベルに必要なスコアを返します
+func (s *Scorer) level(score float64) (int, *float64) {
+	thresholds := s.config.LevelThresholds
+	if len(thresholds) == 0 {
+		return 1, nil
+	}
+
+	// score 以下の閾値の個数がレベルになる
+	level := sort.Search(len(thresholds), func(i int) bool { return thresholds[i] > score })
+	if level == 0 {
+		level = 1
+	}
+	if level >= len(thresholds) {
+		return len(thresholds), nil
+	}
+	next := thresholds[level]
+	return level, &next
+}
+
+// day は日付の区切りに使う文字列を返します
+func (s *Scorer) day(t time.Time) string {
+	return t.In(s.config.Location).Format("2006-01-02")
+}
+
+// round はスコアを小数点以下2桁に丸めます
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
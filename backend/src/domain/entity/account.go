@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountDeletionGracePeriod は退会申請から実際にデータを削除するまでの猶予期間です
+const AccountDeletionGracePeriod = 30 * 24 * time.Hour
+
+// DataExportTTL はエクスポートしたデータをダウンロードできる期間です
+const DataExportTTL = 7 * 24 * time.Hour
+
+// AccountDeletionStatus は退会申請の状態を表す型です
+type AccountDeletionStatus string
+
+const (
+	AccountDeletionStatusScheduled AccountDeletionStatus = "scheduled"
+	AccountDeletionStatusCancelled AccountDeletionStatus = "cancelled"
+	AccountDeletionStatusCompleted AccountDeletionStatus = "completed"
+)
+
+// AccountDeletion は退会申請を表すエンティティです
+type AccountDeletion struct {
+	ID          uuid.UUID             `json:"id"`
+	UserID      string                `json:"user_id"`
+	Status      AccountDeletionStatus `json:"status"`
+	Reason      string                `json:"reason,omitempty"`
+	ScheduledAt time.Time             `json:"scheduled_at"` // この日時を過ぎるとデータを削除する
+	CancelledAt *time.Time            `json:"cancelled_at,omitempty"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+// NewAccountDeletion は猶予期間付きの新しい退会申請を作成します
+func NewAccountDeletion(userID, reason string) *AccountDeletion {
+	now := time.Now()
+	return &AccountDeletion{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Status:      AccountDeletionStatusScheduled,
+		Reason:      reason,
+		ScheduledAt: now.Add(AccountDeletionGracePeriod),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// IsScheduled は削除が予定されている状態かどうかを確認します
+func (d *AccountDeletion) IsScheduled() bool {
+	return d.Status == AccountDeletionStatusScheduled
+}
+
+// Cancel は退会申請を取り消します
+func (d *AccountDeletion) Cancel() {
+	now := time.Now()
+	d.Status = AccountDeletionStatusCancelled
+	d.CancelledAt = &now
+	d.UpdatedAt = now
+}
+
+// Complete は退会処理を完了にします
+func (d *AccountDeletion) Complete() {
+	now := time.Now()
+	d.Status = AccountDeletionStatusCompleted
+	d.CompletedAt = &now
+	d.UpdatedAt = now
+}
+
+// DataExportStatus はデータエクスポートの状態を表す型です
+type DataExportStatus string
+
+const (
+	DataExportStatusPending DataExportStatus = "pending"
+	DataExportStatusReady   DataExportStatus = "ready"
+	DataExportStatusFailed  DataExportStatus = "failed"
+)
+
+// DataExport はユーザーデータのエクスポート要求を表すエンティティです
+type DataExport struct {
+	ID        uuid.UUID        `json:"id"`
+	UserID    string           `json:"user_id"`
+	Status    DataExportStatus `json:"status"`
+	FileKey   string           `json:"-"`
+	Error     string           `json:"error,omitempty"`
+	ExpiresAt *time.Time       `json:"expires_at,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// NewDataExport は新しいDataExportエンティティを作成します
+func NewDataExport(userID string) *DataExport {
+	now := time.Now()
+	return &DataExport{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    DataExportStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// MarkReady はエクスポートファイルの作成完了を記録します
+func (e *DataExport) MarkReady(fileKey string) {
+	now := time.Now()
+	expiresAt := now.Add(DataExportTTL)
+	e.Status = DataExportStatusReady
+	e.FileKey = fileKey
+	e.Error = ""
+	e.ExpiresAt = &expiresAt
+	e.UpdatedAt = now
+}
+
+// MarkFailed はエクスポートの失敗を記録します
+func (e *DataExport) MarkFailed(reason string) {
+	e.Status = DataExportStatusFailed
+	e.Error = reason
+	e.UpdatedAt = time.Now()
+}
+
+// IsDownloadable はエクスポートファイルをダウンロードできるかどうかを確認します
+func (e *DataExport) IsDownloadable(now time.Time) bool {
+	return e.Status == DataExportStatusReady && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
+
+// AccountFlag は不正行為の疑いなどでモデレーターが指定したアカウントの記録です
+// 指定されたアカウントはランキングなどの集計から除外されます
+type AccountFlag struct {
+	UserID    string    `json:"user_id"`
+	Reason    string    `json:"reason"`
+	FlaggedBy string    `json:"flagged_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewAccountFlag は新しいAccountFlagエンティティを作成します
+func NewAccountFlag(userID, reason, flaggedBy string) *AccountFlag {
+	return &AccountFlag{
+		UserID:    userID,
+		Reason:    reason,
+		FlaggedBy: flaggedBy,
+		CreatedAt: time.Now(),
+	}
+}
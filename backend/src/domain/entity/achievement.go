@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BadgeMetric はバッジの獲得条件に使う指標を表す型です
+type BadgeMetric string
+
+const (
+	// BadgeMetricActivityCount は指定した種類の行動の累計回数です
+	BadgeMetricActivityCount BadgeMetric = "activity_count"
+	// BadgeMetricStreakDays はいずれかの推しに対して連続して行動した日数です
+	BadgeMetricStreakDays BadgeMetric = "streak_days"
+)
+
+// BadgeDefinition はバッジの定義です
+type BadgeDefinition struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	Metric       BadgeMetric  `json:"metric"`
+	ActivityType ActivityType `json:"activity_type,omitempty"` // activity_count の場合のみ
+	Threshold    int          `json:"threshold"`
+	RewardPoints int64        `json:"reward_points,omitempty"`
+}
+
+// UserBadge はユーザーが獲得したバッジです
+type UserBadge struct {
+	UserID    string    `json:"user_id"`
+	BadgeID   string    `json:"badge_id"`
+	AwardedAt time.Time `json:"awarded_at"`
+}
+
+// NewUserBadge は新しいUserBadgeエンティティを作成します
+func NewUserBadge(userID, badgeID string) *UserBadge {
+	return &UserBadge{
+		UserID:    userID,
+		BadgeID:   badgeID,
+		AwardedAt: time.Now(),
+	}
+}
+
+// PointLedgerEntry はユーザーの推し活ポイントの増減の記録です
+// Reference は同じ理由による二重計上を防ぐための識別子です
+type PointLedgerEntry struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    string    `json:"user_id"`
+	Amount    int64     `json:"amount"`
+	Reason    string    `json:"reason"`
+	Reference string    `json:"reference"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewPointLedgerEntry は新しいPointLedgerEntryエンティティを作成します
+func NewPointLedgerEntry(userID string, amount int64, reason, reference string) *PointLedgerEntry {
+	return &PointLedgerEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Amount:    amount,
+		Reason:    reason,
+		Reference: reference,
+		CreatedAt: time.Now(),
+	}
+}
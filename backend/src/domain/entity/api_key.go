@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey はサーバー間連携に使用するAPIキーを表すエンティティです
+// シークレットはハッシュのみを保存し、平文は発行時に一度だけ返します
+// OrganizationID が設定されたキーは組織が所有し、UserID は発行した組織の管理者を表します
+type APIKey struct {
+	ID                 uuid.UUID  `json:"id"`
+	UserID             string     `json:"user_id"`
+	OrganizationID     *uuid.UUID `json:"organization_id,omitempty"`
+	Name               string     `json:"name"`
+	Prefix             string     `json:"prefix"`
+	SecretHash         string     `json:"-"`
+	Scopes             []string   `json:"scopes"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// NewAPIKey は新しいAPIKeyエンティティを作成します
+func NewAPIKey(userID, name, prefix, secretHash string, scopes []string, rateLimitPerMinute int, expiresAt *time.Time) *APIKey {
+	return &APIKey{
+		ID:                 uuid.New(),
+		UserID:             userID,
+		Name:               name,
+		Prefix:             prefix,
+		SecretHash:         secretHash,
+		Scopes:             scopes,
+		RateLimitPerMinute: rateLimitPerMinute,
+		ExpiresAt:          expiresAt,
+		CreatedAt:          time.Now(),
+	}
+}
+
+// IsOrganizationOwned はAPIキーが組織の所有かどうかを確認します
+func (k *APIKey) IsOrganizationOwned() bool {
+	return k.OrganizationID != nil
+}
+
+// IsExpired はAPIキーの有効期限が切れているかどうかを確認します
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && now.After(*k.ExpiresAt)
+}
+
+// IsRevoked はAPIキーが失効済みかどうかを確認します
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Revoke はAPIキーを失効させます
+func (k *APIKey) Revoke() {
+	now := time.Now()
+	k.RevokedAt = &now
+}
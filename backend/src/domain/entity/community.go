@@ -0,0 +1,194 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxPostBodyLength    = 2000
+	maxPostImages        = 4
+	maxCommentBodyLength = 1000
+)
+
+// ReactionType はリアクションの種類を表す型です
+type ReactionType string
+
+const (
+	// ReactionTypeLike はいいねです
+	ReactionTypeLike ReactionType = "like"
+	// ReactionTypeLove は大好きです
+	ReactionTypeLove ReactionType = "love"
+	// ReactionTypeCheer は応援です
+	ReactionTypeCheer ReactionType = "cheer"
+	// ReactionTypeWow はすごいです
+	ReactionTypeWow ReactionType = "wow"
+	// ReactionTypeCry は泣けるです
+	ReactionTypeCry ReactionType = "cry"
+)
+
+// IsValidReactionType は有効なリアクションの種類かどうかを確認します
+func IsValidReactionType(reaction ReactionType) bool {
+	switch reaction {
+	case ReactionTypeLike, ReactionTypeLove, ReactionTypeCheer, ReactionTypeWow, ReactionTypeCry:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReactionTargetType はリアクションの対象の種類を表す型です
+type ReactionTargetType string
+
+const (
+	// ReactionTargetPost は投稿へのリアクションです
+	ReactionTargetPost ReactionTargetType = "post"
+	// ReactionTargetComment はコメントへのリアクションです
+	ReactionTargetComment ReactionTargetType = "comment"
+)
+
+// CommunityPost は推しのページのコミュニティへの投稿を表すエンティティです
+// 削除された投稿は本文と画像を消した上で削除日時を記録します
+type CommunityPost struct {
+	ID           uuid.UUID  `json:"id"`
+	OshiID       uuid.UUID  `json:"oshi_id"`
+	UserID       string     `json:"user_id"`
+	Body         string     `json:"body"`
+	ImageURLs    []string   `json:"image_urls"`
+	CommentCount int        `json:"comment_count"`
+	HiddenAt     *time.Time `json:"hidden_at,omitempty"` // モデレーターにより非表示にされた日時
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// NewCommunityPost は新しい投稿を作成します
+func NewCommunityPost(oshiID uuid.UUID, userID, body string, imageURLs []string) (*CommunityPost, error) {
+	now := time.Now()
+	post := &CommunityPost{
+		ID:        uuid.New(),
+		OshiID:    oshiID,
+		UserID:    userID,
+		Body:      strings.TrimSpace(body),
+		ImageURLs: imageURLs,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if post.ImageURLs == nil {
+		post.ImageURLs = []string{}
+	}
+	if err := post.Validate(); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// Validate は投稿の妥当性を検証します
+// 本文と画像のどちらかは必須です
+func (p *CommunityPost) Validate() error {
+	if p.Body == "" && len(p.ImageURLs) == 0 {
+		return ErrInvalidPostBody
+	}
+	if utf8.RuneCountInString(p.Body) > maxPostBodyLength {
+		return ErrInvalidPostBody
+	}
+	if len(p.ImageURLs) > maxPostImages {
+		return ErrTooManyPostImages
+	}
+	return nil
+}
+
+// IsDeleted は投稿が削除されているかどうかを確認します
+func (p *CommunityPost) IsDeleted() bool {
+	return p.DeletedAt != nil
+}
+
+// SoftDelete は投稿の本文と画像を消して削除済みにし、消した画像のURLを返します
+func (p *CommunityPost) SoftDelete() []string {
+	images := p.ImageURLs
+	now := time.Now()
+	p.Body = ""
+	p.ImageURLs = []string{}
+	p.DeletedAt = &now
+	p.UpdatedAt = now
+	return images
+}
+
+// CommunityComment は投稿へのコメントを表すエンティティです
+// 返信は ParentID に返信先のスレッドの先頭のコメントを持ちます
+type CommunityComment struct {
+	ID         uuid.UUID  `json:"id"`
+	PostID     uuid.UUID  `json:"post_id"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+	UserID     string     `json:"user_id"`
+	Body       string     `json:"body"`
+	ReplyCount int        `json:"reply_count"`
+	HiddenAt   *time.Time `json:"hidden_at,omitempty"` // モデレーターにより非表示にされた日時
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NewCommunityComment は新しいコメントを作成します
+func NewCommunityComment(postID uuid.UUID, parentID *uuid.UUID, userID, body string) (*CommunityComment, error) {
+	now := time.Now()
+	comment := &CommunityComment{
+		ID:        uuid.New(),
+		PostID:    postID,
+		ParentID:  parentID,
+		UserID:    userID,
+		Body:      strings.TrimSpace(body),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if comment.Body == "" || utf8.RuneCountInString(comment.Body) > maxCommentBodyLength {
+		return nil, ErrInvalidCommentBody
+	}
+	return comment, nil
+}
+
+// IsDeleted はコメントが削除されているかどうかを確認します
+func (c *CommunityComment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// SoftDelete はコメントの本文を消して削除済みにします
+// 返信のスレッドを保つため、コメント自体は残します
+func (c *CommunityComment) SoftDelete() {
+	now := time.Now()
+	c.Body = ""
+	c.DeletedAt = &now
+	c.UpdatedAt = now
+}
+
+// Reaction は投稿やコメントへのリアクションを表すエンティティです
+type Reaction struct {
+	TargetType ReactionTargetType `json:"target_type"`
+	TargetID   uuid.UUID          `json:"target_id"`
+	UserID     string             `json:"user_id"`
+	Reaction   ReactionType       `json:"reaction"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// NewReaction は新しいリアクションを作成します
+func NewReaction(targetType ReactionTargetType, targetID uuid.UUID, userID string, reaction ReactionType) (*Reaction, error) {
+	if !IsValidReactionType(reaction) {
+		return nil, ErrInvalidReactionType
+	}
+	return &Reaction{
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     userID,
+		Reaction:   reaction,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// ReactionSummary は投稿やコメントのリアクションの集計です
+type ReactionSummary struct {
+	Counts map[ReactionType]int `json:"counts"`
+	Mine   []ReactionType       `json:"mine"` // 閲覧者自身のリアクション
+}
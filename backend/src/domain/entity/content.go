@@ -15,17 +15,46 @@ const (
 	ContentTypeVideo ContentType = "video"
 )
 
+// ModerationStatus はコンテンツの審査状態を表す型です
+type ModerationStatus string
+
+const (
+	// ModerationStatusPending は審査待ちです
+	ModerationStatusPending ModerationStatus = "pending"
+	// ModerationStatusApproved は承認済みで公開中です
+	ModerationStatusApproved ModerationStatus = "approved"
+	// ModerationStatusRejected は審査で却下されました
+	ModerationStatusRejected ModerationStatus = "rejected"
+	// ModerationStatusTakenDown は公開後に取り下げられました
+	ModerationStatusTakenDown ModerationStatus = "taken_down"
+)
+
+// moderationTransitions は審査状態ごとに遷移できる状態です
+var moderationTransitions = map[ModerationStatus][]ModerationStatus{
+	ModerationStatusPending:   {ModerationStatusApproved, ModerationStatusRejected},
+	ModerationStatusApproved:  {ModerationStatusTakenDown},
+	ModerationStatusRejected:  {ModerationStatusApproved},
+	ModerationStatusTakenDown: {ModerationStatusApproved},
+}
+
 // Content はデジタルコンテンツを表すエンティティです
 type Content struct {
-	ID          uuid.UUID       `json:"id"`
-	UserID      uuid.UUID       `json:"user_id"`
-	Title       string          `json:"title"`
-	Description string          `json:"description"`
-	ContentType ContentType     `json:"content_type"`
-	FilePath    string          `json:"file_path"`
-	Price       decimal.Decimal `json:"price"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID               uuid.UUID        `json:"id"`
+	UserID           uuid.UUID        `json:"user_id"`
+	OshiID           *uuid.UUID       `json:"oshi_id,omitempty"`
+	Title            string           `json:"title"`
+	Description      string           `json:"description"`
+	ContentType      ContentType      `json:"content_type"`
+	FilePath         string           `json:"file_path"`
+	Price            decimal.Decimal  `json:"price"`
+	ModerationStatus ModerationStatus `json:"moderation_status"`
+	HiddenAt         *time.Time       `json:"hidden_at,omitempty"`    // 通報が一定数に達して自動で非表示にされた日時
+	ReportCount      int              `json:"report_count"`           // 未処理の通報の件数
+	PublishAt        *time.Time       `json:"publish_at,omitempty"`   // 公開日時（未指定の場合は承認後すぐに公開）
+	UnpublishAt      *time.Time       `json:"unpublish_at,omitempty"` // 公開終了日時（未指定の場合は公開を続ける）
+	ReleasedAt       *time.Time       `json:"released_at,omitempty"`  // 初めて公開され、推しのフォロワーに知らせた日時
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
 }
 
 // NewContent は新しいContentエンティティを作成します
@@ -52,15 +81,16 @@ func NewContent(
 
 	now := time.Now()
 	return &Content{
-		ID:          uuid.New(),
-		UserID:      userID,
-		Title:       title,
-		Description: description,
-		ContentType: contentType,
-		FilePath:    filePath,
-		Price:       price,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:               uuid.New(),
+		UserID:           userID,
+		Title:            title,
+		Description:      description,
+		ContentType:      contentType,
+		FilePath:         filePath,
+		Price:            price,
+		ModerationStatus: ModerationStatusPending,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}, nil
 }
 
@@ -94,6 +124,24 @@ func (c *Content) UpdateContent(
 	return nil
 }
 
+// ReplaceFile はコンテンツのファイルを差し替えます
+// 差し替えたファイルは審査を受けていないため、審査待ちに戻します
+// 却下されたコンテンツは差し替えて再審査を受けられますが、取り下げられたコンテンツは差し替えられません
+func (c *Content) ReplaceFile(contentType ContentType, filePath string) error {
+	if !isValidContentType(contentType) {
+		return ErrInvalidContentType
+	}
+	if c.ModerationStatus == ModerationStatusTakenDown {
+		return ErrContentTakenDown
+	}
+
+	c.ContentType = contentType
+	c.FilePath = filePath
+	c.ModerationStatus = ModerationStatusPending
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
 // Validate はコンテンツの妥当性を検証します
 func (c *Content) Validate() error {
 	if c.ID == uuid.Nil {
@@ -113,3 +161,65 @@ func (c *Content) Validate() error {
 	}
 	return nil
 }
+
+// IsPublished はコンテンツが承認済みで、通報により非表示にされていないかどうかを確認します
+func (c *Content) IsPublished() bool {
+	return c.ModerationStatus == ModerationStatusApproved && c.HiddenAt == nil
+}
+
+// SetAvailability はコンテンツの公開期間を設定します
+// 公開終了日時は公開日時より後でなければなりません
+func (c *Content) SetAvailability(publishAt, unpublishAt *time.Time) error {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return ErrInvalidAvailabilityWindow
+	}
+
+	c.PublishAt = publishAt
+	c.UnpublishAt = unpublishAt
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsAvailableAt は指定した日時がコンテンツの公開期間内かどうかを確認します
+func (c *Content) IsAvailableAt(t time.Time) bool {
+	if c.PublishAt != nil && t.Before(*c.PublishAt) {
+		return false
+	}
+	if c.UnpublishAt != nil && !t.Before(*c.UnpublishAt) {
+		return false
+	}
+	return true
+}
+
+// IsVisibleAt は指定した日時にコンテンツが投稿者以外に公開されているかどうかを確認します
+// 審査で承認済みで、通報により非表示にされておらず、公開期間内である必要があります
+func (c *Content) IsVisibleAt(t time.Time) bool {
+	return c.IsPublished() && c.IsAvailableAt(t)
+}
+
+// TransitionModeration は審査状態を変更します
+// 遷移できない状態が指定された場合はエラーを返します
+func (c *Content) TransitionModeration(to ModerationStatus) error {
+	for _, allowed := range moderationTransitions[c.ModerationStatus] {
+		if allowed == to {
+			c.ModerationStatus = to
+			c.HiddenAt = nil
+			c.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrInvalidModerationTransition
+}
+
+// Hide は通報によりコンテンツを非表示にします
+func (c *Content) Hide() {
+	now := time.Now()
+	c.HiddenAt = &now
+	c.UpdatedAt = now
+}
+
+// Unhide は通報による非表示を解除します
+func (c *Content) Unhide() {
+	c.HiddenAt = nil
+	c.UpdatedAt = time.Now()
+}
@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailCategory はメールの種類を表す型です
+// ユーザーは配信停止できる種類のメールを種類ごとに停止できます
+type EmailCategory string
+
+const (
+	// EmailCategorySecurity はメールアドレスの確認やパスワードの再設定など、アカウントの安全のためのメールで、配信停止できません
+	EmailCategorySecurity EmailCategory = "security"
+	// EmailCategoryBilling は領収書などの支払いに関するメールです
+	EmailCategoryBilling EmailCategory = "billing"
+	// EmailCategoryReminder はサブスクリプションの更新のお知らせなどのリマインダーです
+	EmailCategoryReminder EmailCategory = "reminder"
+)
+
+// EmailCategories は全てのメールの種類です
+var EmailCategories = []EmailCategory{
+	EmailCategorySecurity,
+	EmailCategoryBilling,
+	EmailCategoryReminder,
+}
+
+// IsValidEmailCategory はメールの種類が有効かどうかを確認します
+func IsValidEmailCategory(category EmailCategory) bool {
+	for _, c := range EmailCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Unsubscribable はユーザーが配信停止できる種類かどうかを返します
+func (c EmailCategory) Unsubscribable() bool {
+	return c != EmailCategorySecurity
+}
+
+// EmailStatus はメールの送信状況を表す型です
+type EmailStatus string
+
+const (
+	// EmailStatusQueued は送信待ちです（一時的な失敗による再試行待ちを含む）
+	EmailStatusQueued EmailStatus = "queued"
+	// EmailStatusSent は送信サーバーに受け付けられました
+	EmailStatusSent EmailStatus = "sent"
+	// EmailStatusFailed は送信サーバーに拒否されたか、再試行の上限に達しました
+	EmailStatusFailed EmailStatus = "failed"
+	// EmailStatusSuppressed は宛先が配信停止またはバウンスしているため送信しませんでした
+	EmailStatusSuppressed EmailStatus = "suppressed"
+	// EmailStatusBounced は送信後に宛先からバウンスが報告されました
+	EmailStatusBounced EmailStatus = "bounced"
+)
+
+// Email はユーザーに送るメールとその送信記録を表すエンティティです
+// 本文は送信時に宛先の言語でテンプレートから作成します
+type Email struct {
+	ID                uuid.UUID         `json:"id"`
+	UserID            string            `json:"user_id,omitempty"`
+	To                string            `json:"to"`
+	Category          EmailCategory     `json:"category"`
+	Template          string            `json:"template"`
+	Locale            string            `json:"locale"`
+	Params            map[string]string `json:"params"`
+	Status            EmailStatus       `json:"status"`
+	Attempts          int               `json:"attempts"`
+	LastError         string            `json:"last_error,omitempty"`
+	ProviderMessageID string            `json:"provider_message_id,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+	SentAt            *time.Time        `json:"sent_at,omitempty"`
+}
+
+// NewEmail は新しい送信待ちのメールを作成します
+func NewEmail(userID, to string, category EmailCategory, template, locale string, params map[string]string) (*Email, error) {
+	address, err := NormalizeEmailAddress(to)
+	if err != nil {
+		return nil, err
+	}
+	if !IsValidEmailCategory(category) {
+		return nil, ErrInvalidEmailCategory
+	}
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	return &Email{
+		ID:        uuid.New(),
+		UserID:    userID,
+		To:        address,
+		Category:  category,
+		Template:  template,
+		Locale:    locale,
+		Params:    params,
+		Status:    EmailStatusQueued,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// NormalizeEmailAddress はメールアドレスを検証し、配信停止の照合に使う小文字の形式に変換します
+func NormalizeEmailAddress(address string) (string, error) {
+	parsed, err := mail.ParseAddress(strings.TrimSpace(address))
+	if err != nil || parsed.Name != "" {
+		return "", ErrInvalidEmailAddress
+	}
+	return strings.ToLower(parsed.Address), nil
+}
+
+// SuppressionReason はメールアドレスへの送信を停止した理由を表す型です
+type SuppressionReason string
+
+const (
+	// SuppressionReasonBounce は宛先が存在しないなど恒久的に届かないことが報告されました
+	SuppressionReasonBounce SuppressionReason = "bounce"
+	// SuppressionReasonComplaint は受信者が迷惑メールとして報告しました
+	SuppressionReasonComplaint SuppressionReason = "complaint"
+	// SuppressionReasonUnsubscribe は受信者が配信停止しました
+	SuppressionReasonUnsubscribe SuppressionReason = "unsubscribe"
+)
+
+// EmailSuppression はメールアドレスへの送信の停止を表すエンティティです
+// Category が空の場合は全ての種類のメールを停止します
+type EmailSuppression struct {
+	Email     string            `json:"email"`
+	Category  EmailCategory     `json:"category,omitempty"`
+	Reason    SuppressionReason `json:"reason"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// NewEmailSuppression は新しい送信の停止を作成します
+func NewEmailSuppression(address string, category EmailCategory, reason SuppressionReason) (*EmailSuppression, error) {
+	normalized, err := NormalizeEmailAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if category != "" && !IsValidEmailCategory(category) {
+		return nil, ErrInvalidEmailCategory
+	}
+
+	return &EmailSuppression{
+		Email:     normalized,
+		Category:  category,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}, nil
+}
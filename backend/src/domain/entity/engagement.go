@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActivityType は推し活の行動の種類を表す型です
+type ActivityType string
+
+const (
+	// ActivityTypeView はコンテンツや推しのページの閲覧です
+	ActivityTypeView ActivityType = "view"
+	// ActivityTypePurchase はコンテンツの購入です
+	ActivityTypePurchase ActivityType = "purchase"
+	// ActivityTypeDiagnosis は診断の実行です
+	ActivityTypeDiagnosis ActivityType = "diagnosis"
+	// ActivityTypeShare はSNSなどへのシェアです
+	ActivityTypeShare ActivityType = "share"
+)
+
+// IsValidActivityType は有効な行動の種類かどうかを確認します
+func IsValidActivityType(activityType ActivityType) bool {
+	switch activityType {
+	case ActivityTypeView, ActivityTypePurchase, ActivityTypeDiagnosis, ActivityTypeShare:
+		return true
+	default:
+		return false
+	}
+}
+
+// ActivityEvent はユーザーの推しに対する行動の記録です
+type ActivityEvent struct {
+	ID         uuid.UUID    `json:"id"`
+	UserID     string       `json:"user_id"`
+	OshiID     uuid.UUID    `json:"oshi_id"`
+	Type       ActivityType `json:"type"`
+	OccurredAt time.Time    `json:"occurred_at"`
+}
+
+// NewActivityEvent は新しい行動の記録を作成します
+func NewActivityEvent(userID string, oshiID uuid.UUID, activityType ActivityType) (*ActivityEvent, error) {
+	if !IsValidActivityType(activityType) {
+		return nil, ErrInvalidActivityType
+	}
+	return &ActivityEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		OshiID:     oshiID,
+		Type:       activityType,
+		OccurredAt: time.Now(),
+	}, nil
+}
+
+// EngagementScore はユーザーの推しに対するエンゲージメント（推し活レベル）です
+type EngagementScore struct {
+	OshiID         uuid.UUID          `json:"oshi_id"`
+	Score          float64            `json:"score"`
+	Level          int                `json:"level"`
+	NextLevelScore *float64           `json:"next_level_score,omitempty"` // 最大レベルの場合は nil
+	StreakDays     int                `json:"streak_days"`
+	Breakdown      map[string]float64 `json:"breakdown"` // 行動の種類ごとの内訳（連続日数のボーナスは streak）
+	LastActiveAt   *time.Time         `json:"last_active_at,omitempty"`
+}
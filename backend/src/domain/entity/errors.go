@@ -29,4 +29,124 @@ var (
 
 	// ErrInvalidEndDate は無効な終了日が指定された場合のエラーです
 	ErrInvalidEndDate = errors.New("invalid end date")
+
+	// ErrDisplayNameTooLong は表示名が長すぎる場合のエラーです
+	ErrDisplayNameTooLong = errors.New("display name is too long")
+
+	// ErrBioTooLong は自己紹介が長すぎる場合のエラーです
+	ErrBioTooLong = errors.New("bio is too long")
+
+	// ErrInvalidBirthday は無効な誕生日が指定された場合のエラーです
+	ErrInvalidBirthday = errors.New("invalid birthday")
+
+	// ErrInvalidLocale は対応していないロケールが指定された場合のエラーです
+	ErrInvalidLocale = errors.New("invalid locale")
+
+	// ErrInvalidTimezone は無効なタイムゾーンが指定された場合のエラーです
+	ErrInvalidTimezone = errors.New("invalid timezone")
+
+	// ErrTooManyFavoriteOshi は推しの登録数が上限を超えた場合のエラーです
+	ErrTooManyFavoriteOshi = errors.New("too many favorite oshi")
+
+	// ErrInvalidOshiName は無効な推しの名前が指定された場合のエラーです
+	ErrInvalidOshiName = errors.New("invalid oshi name")
+
+	// ErrOshiFieldTooLong は推しの項目が長すぎる場合のエラーです
+	ErrOshiFieldTooLong = errors.New("oshi field is too long")
+
+	// ErrTooManyOshiImages は推しのプロフィール画像が上限を超えた場合のエラーです
+	ErrTooManyOshiImages = errors.New("too many oshi profile images")
+
+	// ErrTooManyOshiTags は推しのタグが上限を超えた場合のエラーです
+	ErrTooManyOshiTags = errors.New("too many oshi tags")
+
+	// ErrInvalidOshiTag は無効なタグが指定された場合のエラーです
+	ErrInvalidOshiTag = errors.New("invalid oshi tag")
+
+	// ErrInvalidOrganizationName は無効な組織名が指定された場合のエラーです
+	ErrInvalidOrganizationName = errors.New("invalid organization name")
+
+	// ErrInvalidOrganizationType は無効な組織の種類が指定された場合のエラーです
+	ErrInvalidOrganizationType = errors.New("invalid organization type")
+
+	// ErrOrganizationDescriptionTooLong は組織の説明が長すぎる場合のエラーです
+	ErrOrganizationDescriptionTooLong = errors.New("organization description is too long")
+
+	// ErrInvalidOrganizationRole は無効な組織内の役割が指定された場合のエラーです
+	ErrInvalidOrganizationRole = errors.New("invalid organization role")
+
+	// ErrInvalidNewsTitle は無効なお知らせのタイトルが指定された場合のエラーです
+	ErrInvalidNewsTitle = errors.New("invalid news title")
+
+	// ErrNewsBodyTooLong はお知らせの本文が長すぎる場合のエラーです
+	ErrNewsBodyTooLong = errors.New("news body is too long")
+
+	// ErrInvalidActivityType は無効な行動の種類が指定された場合のエラーです
+	ErrInvalidActivityType = errors.New("invalid activity type")
+
+	// ErrInvalidShareKind は無効な共有の種類が指定された場合のエラーです
+	ErrInvalidShareKind = errors.New("invalid share kind")
+
+	// ErrInvalidShareResult は共有する結果の内容が無効な場合のエラーです
+	ErrInvalidShareResult = errors.New("invalid share result")
+
+	// ErrInvalidPostBody は投稿の本文が無効な場合のエラーです
+	ErrInvalidPostBody = errors.New("post must have a body of up to 2000 characters or an image")
+
+	// ErrTooManyPostImages は投稿の画像が上限を超えた場合のエラーです
+	ErrTooManyPostImages = errors.New("too many post images")
+
+	// ErrInvalidCommentBody はコメントの本文が無効な場合のエラーです
+	ErrInvalidCommentBody = errors.New("comment must have a body of up to 1000 characters")
+
+	// ErrInvalidReactionType は無効なリアクションの種類が指定された場合のエラーです
+	ErrInvalidReactionType = errors.New("invalid reaction type")
+
+	// ErrInvalidModerationTransition は審査状態を遷移できない場合のエラーです
+	ErrInvalidModerationTransition = errors.New("invalid moderation status transition")
+
+	// ErrInvalidReportReason は無効な通報理由が指定された場合のエラーです
+	ErrInvalidReportReason = errors.New("invalid report reason")
+
+	// ErrReportDetailsTooLong は通報の詳細が長すぎる場合のエラーです
+	ErrReportDetailsTooLong = errors.New("report details are too long")
+
+	// ErrModerationReasonTooLong は審査の理由が長すぎる場合のエラーです
+	ErrModerationReasonTooLong = errors.New("moderation reason is too long")
+
+	// ErrInvalidUserRelation は無効なブロック・ミュートが指定された場合のエラーです
+	ErrInvalidUserRelation = errors.New("invalid user relation")
+
+	// ErrInvalidConversation は無効なメッセージのやり取りが指定された場合のエラーです
+	ErrInvalidConversation = errors.New("invalid conversation")
+
+	// ErrInvalidMessageBody はメッセージの本文が無効な場合のエラーです
+	ErrInvalidMessageBody = errors.New("message must have a body of up to 2000 characters or an attachment")
+
+	// ErrTooManyMessageAttachments はメッセージの添付が上限を超えた場合のエラーです
+	ErrTooManyMessageAttachments = errors.New("too many message attachments")
+
+	// ErrInvalidDeviceToken は無効なプッシュ通知のデバイストークンが指定された場合のエラーです
+	ErrInvalidDeviceToken = errors.New("invalid device token")
+
+	// ErrInvalidDevicePlatform は無効な端末の種類が指定された場合のエラーです
+	ErrInvalidDevicePlatform = errors.New("device platform must be ios, android or web")
+
+	// ErrInvalidNotificationSettings は通知設定が無効な場合のエラーです
+	ErrInvalidNotificationSettings = errors.New("invalid notification settings")
+
+	// ErrInvalidEmailAddress は無効なメールアドレスが指定された場合のエラーです
+	ErrInvalidEmailAddress = errors.New("invalid email address")
+
+	// ErrInvalidEmailCategory は無効なメールの種類が指定された場合のエラーです
+	ErrInvalidEmailCategory = errors.New("invalid email category")
+
+	// ErrInvalidAvailabilityWindow は公開終了日時が公開日時より前に指定された場合のエラーです
+	ErrInvalidAvailabilityWindow = errors.New("unpublish_at must be after publish_at")
+
+	// ErrContentTakenDown は取り下げられたコンテンツのファイルを差し替えようとした場合のエラーです
+	ErrContentTakenDown = errors.New("taken down content cannot be resubmitted")
+
+	// ErrUserNotFound はユーザーが存在しない場合のエラーです
+	ErrUserNotFound = errors.New("user not found")
 )
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxOshiNewsTitleLength = 100
+	maxOshiNewsBodyLength  = 5000
+)
+
+// OshiFollow はファンが推しをフォローしていることを表すエンティティです
+type OshiFollow struct {
+	UserID    string    `json:"user_id"`
+	OshiID    uuid.UUID `json:"oshi_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewOshiFollow は新しいOshiFollowエンティティを作成します
+func NewOshiFollow(userID string, oshiID uuid.UUID) *OshiFollow {
+	return &OshiFollow{
+		UserID:    userID,
+		OshiID:    oshiID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// OshiNews は推しに関するお知らせを表すエンティティです
+type OshiNews struct {
+	ID        uuid.UUID `json:"id"`
+	OshiID    uuid.UUID `json:"oshi_id"`
+	AuthorID  string    `json:"author_id"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewOshiNews は新しいお知らせを作成します
+func NewOshiNews(oshiID uuid.UUID, authorID, title, body string) (*OshiNews, error) {
+	news := &OshiNews{
+		ID:        uuid.New(),
+		OshiID:    oshiID,
+		AuthorID:  authorID,
+		Title:     strings.TrimSpace(title),
+		Body:      strings.TrimSpace(body),
+		CreatedAt: time.Now(),
+	}
+	if news.Title == "" || utf8.RuneCountInString(news.Title) > maxOshiNewsTitleLength {
+		return nil, ErrInvalidNewsTitle
+	}
+	if utf8.RuneCountInString(news.Body) > maxOshiNewsBodyLength {
+		return nil, ErrNewsBodyTooLong
+	}
+	return news, nil
+}
+
+// FeedItemType はホームフィードの項目の種類を表す型です
+type FeedItemType string
+
+const (
+	// FeedItemTypeContent はフォロー中の推しの新着コンテンツです
+	FeedItemTypeContent FeedItemType = "content"
+	// FeedItemTypeNews はフォロー中の推しのお知らせです
+	FeedItemTypeNews FeedItemType = "news"
+	// FeedItemTypeCompatibilityPrompt はフォロー中の推しとの相性診断のおすすめです
+	FeedItemTypeCompatibilityPrompt FeedItemType = "compatibility_prompt"
+)
+
+// FeedEntry はホームフィードの項目の参照です
+// 表示に必要なデータは種類ごとに別途取得します
+type FeedEntry struct {
+	Type      FeedItemType
+	ID        uuid.UUID
+	OshiID    uuid.UUID
+	CreatedAt time.Time
+}
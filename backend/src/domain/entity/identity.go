@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity は外部IdPのアカウントとユーザーの紐付けを表すエンティティです
+type UserIdentity struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    string    `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"-"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewUserIdentity は新しいUserIdentityエンティティを作成します
+func NewUserIdentity(userID, provider, subject, email string) *UserIdentity {
+	now := time.Now()
+	return &UserIdentity{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// OAuthState は認可リクエストの検証に使用する一時的な状態を表すエンティティです
+type OAuthState struct {
+	State        string    `json:"-"`
+	Provider     string    `json:"provider"`
+	Nonce        string    `json:"-"`
+	CodeVerifier string    `json:"-"`
+	LinkUserID   string    `json:"-"`
+	BindingHash  string    `json:"-"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewOAuthState は新しいOAuthStateエンティティを作成します
+// linkUserID を指定した場合は既存ユーザーへの紐付けフローとして扱います
+// bindingHash はフローを開始したクライアントに渡した値のハッシュで、コールバックが同じクライアントからのものかを確認します
+func NewOAuthState(state, provider, nonce, codeVerifier, linkUserID, bindingHash string, ttl time.Duration) *OAuthState {
+	now := time.Now()
+	return &OAuthState{
+		State:        state,
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		LinkUserID:   linkUserID,
+		BindingHash:  bindingHash,
+		ExpiresAt:    now.Add(ttl),
+		CreatedAt:    now,
+	}
+}
+
+// IsExpired は状態の有効期限が切れているかどうかを確認します
+func (s *OAuthState) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobStatus はバックグラウンドジョブの状態を表す型です
+type JobStatus string
+
+const (
+	JobStatusPending JobStatus = "pending"
+	JobStatusRunning JobStatus = "running"
+	JobStatusDone    JobStatus = "done"
+	JobStatusFailed  JobStatus = "failed"
+)
+
+// DefaultJobMaxAttempts はジョブの既定の最大試行回数です
+const DefaultJobMaxAttempts = 5
+
+// Job はバックグラウンドで実行するジョブを表すエンティティです
+type Job struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      JobStatus       `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// NewJob は新しいJobエンティティを作成します
+func NewJob(jobType string, payload json.RawMessage, runAt time.Time) *Job {
+	now := time.Now()
+	if runAt.IsZero() {
+		runAt = now
+	}
+	return &Job{
+		ID:          uuid.New(),
+		Type:        jobType,
+		Payload:     payload,
+		Status:      JobStatusPending,
+		MaxAttempts: DefaultJobMaxAttempts,
+		RunAt:       runAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// CanRetry は再試行できるかどうかを確認します
+func (j *Job) CanRetry() bool {
+	return j.Attempts < j.MaxAttempts
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaderboardPeriod はランキングの集計期間を表す型です
+type LeaderboardPeriod string
+
+const (
+	// LeaderboardPeriodDaily は日間ランキングです
+	LeaderboardPeriodDaily LeaderboardPeriod = "daily"
+	// LeaderboardPeriodWeekly は週間ランキングです（月曜日始まり）
+	LeaderboardPeriodWeekly LeaderboardPeriod = "weekly"
+	// LeaderboardPeriodMonthly は月間ランキングです
+	LeaderboardPeriodMonthly LeaderboardPeriod = "monthly"
+	// LeaderboardPeriodAllTime は累計ランキングです
+	LeaderboardPeriodAllTime LeaderboardPeriod = "all_time"
+)
+
+// LeaderboardPeriods は全ての集計期間です
+var LeaderboardPeriods = []LeaderboardPeriod{
+	LeaderboardPeriodDaily,
+	LeaderboardPeriodWeekly,
+	LeaderboardPeriodMonthly,
+	LeaderboardPeriodAllTime,
+}
+
+// IsValidLeaderboardPeriod は有効な集計期間かどうかを確認します
+func IsValidLeaderboardPeriod(period LeaderboardPeriod) bool {
+	for _, p := range LeaderboardPeriods {
+		if p == period {
+			return true
+		}
+	}
+	return false
+}
+
+// Start は now を含む集計期間の開始日時を返します
+// 累計ランキングの場合はゼロ値の日時を返します
+func (p LeaderboardPeriod) Start(now time.Time, loc *time.Location) time.Time {
+	t := now.In(loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	switch p {
+	case LeaderboardPeriodDaily:
+		return day
+	case LeaderboardPeriodWeekly:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case LeaderboardPeriodMonthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Time{}
+	}
+}
+
+// LeaderboardBucket はランキングの集計単位です
+type LeaderboardBucket struct {
+	Period      LeaderboardPeriod
+	PeriodStart time.Time
+}
+
+// LeaderboardEntry はランキングの1行です
+type LeaderboardEntry struct {
+	Rank        int     `json:"rank"`
+	UserID      string  `json:"user_id"`
+	DisplayName string  `json:"display_name"`
+	AvatarURL   string  `json:"avatar_url,omitempty"`
+	Points      float64 `json:"points"`
+}
+
+// LeaderboardPosition はランキングでのユーザー自身の順位です
+type LeaderboardPosition struct {
+	OshiID     uuid.UUID         `json:"oshi_id"`
+	Period     LeaderboardPeriod `json:"period"`
+	Points     float64           `json:"points"`
+	Rank       int               `json:"rank,omitempty"` // ポイントが無い場合は 0
+	Total      int               `json:"total"`
+	Percentile float64           `json:"percentile"` // 自分以下の順位のファンの割合（%）
+	Visible    bool              `json:"visible"`    // 他のユーザーのランキングに表示されているかどうか
+}
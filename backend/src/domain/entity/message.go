@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxMessageBodyLength  = 2000
+	maxMessageAttachments = 4
+)
+
+// MessageCharge はファンのメッセージの送信に消費した枠を表す型です
+type MessageCharge string
+
+const (
+	// MessageChargeNone は枠を消費しないメッセージです（キャストからの返信）
+	MessageChargeNone MessageCharge = "none"
+	// MessageChargePlan はプランの月間の送信枠を消費したメッセージです
+	MessageChargePlan MessageCharge = "plan"
+	// MessageChargeTicket は購入済みのメッセージチケットを消費したメッセージです
+	MessageChargeTicket MessageCharge = "ticket"
+)
+
+// Conversation はファンとキャストの1対1のメッセージのやり取りを表すエンティティです
+// 1組のファンとキャストにつき1つだけ作成します
+type Conversation struct {
+	ID                uuid.UUID  `json:"id"`
+	FanID             string     `json:"fan_id"`
+	CreatorID         string     `json:"creator_id"`
+	FanPlan           PlanType   `json:"fan_plan,omitempty"` // ファンが最後にメッセージを送った時点のプラン
+	LastMessageAt     *time.Time `json:"last_message_at,omitempty"`
+	LastSenderID      string     `json:"last_sender_id,omitempty"`
+	FanLastReadAt     *time.Time `json:"fan_last_read_at,omitempty"`
+	CreatorLastReadAt *time.Time `json:"creator_last_read_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"` // 最後にメッセージが送られた日時（受信箱の並び順に使う）
+}
+
+// NewConversation は新しいやり取りを作成します
+func NewConversation(fanID, creatorID string) (*Conversation, error) {
+	if fanID == "" || creatorID == "" || fanID == creatorID {
+		return nil, ErrInvalidConversation
+	}
+
+	now := time.Now()
+	return &Conversation{
+		ID:        uuid.New(),
+		FanID:     fanID,
+		CreatorID: creatorID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsParticipant はユーザーがやり取りの参加者かどうかを確認します
+func (c *Conversation) IsParticipant(userID string) bool {
+	return userID == c.FanID || userID == c.CreatorID
+}
+
+// CounterpartID はユーザーのやり取りの相手のIDを返します
+func (c *Conversation) CounterpartID(userID string) string {
+	if userID == c.FanID {
+		return c.CreatorID
+	}
+	return c.FanID
+}
+
+// LastReadAtOf は参加者が最後にやり取りを既読にした日時を返します
+func (c *Conversation) LastReadAtOf(userID string) *time.Time {
+	if userID == c.FanID {
+		return c.FanLastReadAt
+	}
+	return c.CreatorLastReadAt
+}
+
+// DirectMessage はやり取りの中のメッセージを表すエンティティです
+// 退会などで削除されたメッセージは本文と添付を消した上で削除日時を記録します
+type DirectMessage struct {
+	ID             uuid.UUID     `json:"id"`
+	ConversationID uuid.UUID     `json:"conversation_id"`
+	SenderID       string        `json:"sender_id"`
+	Body           string        `json:"body"`
+	AttachmentURLs []string      `json:"attachment_urls"`
+	Charge         MessageCharge `json:"charge"`
+	DeletedAt      *time.Time    `json:"deleted_at,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+// NewDirectMessage は新しいメッセージを作成します
+func NewDirectMessage(conversationID uuid.UUID, senderID, body string, attachmentURLs []string) (*DirectMessage, error) {
+	message := &DirectMessage{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Body:           strings.TrimSpace(body),
+		AttachmentURLs: attachmentURLs,
+		Charge:         MessageChargeNone,
+		CreatedAt:      time.Now(),
+	}
+	if message.AttachmentURLs == nil {
+		message.AttachmentURLs = []string{}
+	}
+	if err := message.Validate(); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// Validate はメッセージの妥当性を検証します
+// 本文と添付のどちらかは必須です
+func (m *DirectMessage) Validate() error {
+	if m.Body == "" && len(m.AttachmentURLs) == 0 {
+		return ErrInvalidMessageBody
+	}
+	if utf8.RuneCountInString(m.Body) > maxMessageBodyLength {
+		return ErrInvalidMessageBody
+	}
+	if len(m.AttachmentURLs) > maxMessageAttachments {
+		return ErrTooManyMessageAttachments
+	}
+	return nil
+}
+
+// MessageQuota はファンのメッセージの送信枠の状況です
+type MessageQuota struct {
+	Plan         PlanType  `json:"plan,omitempty"` // 有効なサブスクリプションが無い場合は空
+	MonthlyLimit int       `json:"monthly_limit"`
+	Used         int       `json:"used"`
+	Remaining    int       `json:"remaining"`
+	Tickets      int       `json:"tickets"`
+	ResetsAt     time.Time `json:"resets_at"`
+}
+
+// CanSend はプランの枠かチケットでメッセージを送信できるかどうかを確認します
+func (q *MessageQuota) CanSend() bool {
+	return q.Remaining > 0 || q.Tickets > 0
+}
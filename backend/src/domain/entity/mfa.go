@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserMFA はユーザーの二要素認証（TOTP）設定を表すエンティティです
+type UserMFA struct {
+	UserID         string     `json:"user_id"`
+	Secret         string     `json:"-"`
+	Enabled        bool       `json:"enabled"`
+	LastUsedStep   int64      `json:"-"`
+	FailedAttempts int        `json:"-"`
+	LockedUntil    *time.Time `json:"-"`
+	ConfirmedAt    *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// NewUserMFA は登録確認前のUserMFAエンティティを作成します
+func NewUserMFA(userID, secret string) *UserMFA {
+	now := time.Now()
+	return &UserMFA{
+		UserID:    userID,
+		Secret:    secret,
+		Enabled:   false,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Confirm は二要素認証の登録を確定します
+func (m *UserMFA) Confirm(step int64) {
+	now := time.Now()
+	m.Enabled = true
+	m.LastUsedStep = step
+	m.ConfirmedAt = &now
+	m.UpdatedAt = now
+}
+
+// IsLockedAt は指定した時刻に失敗回数の上限で検証がロックされているかどうかを確認します
+func (m *UserMFA) IsLockedAt(t time.Time) bool {
+	return m.LockedUntil != nil && t.Before(*m.LockedUntil)
+}
+
+// MFARecoveryCode は二要素認証のリカバリーコードを表すエンティティです
+type MFARecoveryCode struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    string     `json:"user_id"`
+	CodeHash  string     `json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewMFARecoveryCode は新しいMFARecoveryCodeエンティティを作成します
+func NewMFARecoveryCode(userID, codeHash string) *MFARecoveryCode {
+	return &MFARecoveryCode{
+		ID:        uuid.New(),
+		UserID:    userID,
+		CodeHash:  codeHash,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxReportDetailsLength    = 1000
+	maxModerationReasonLength = 500
+)
+
+// ReportReason はコンテンツの通報理由を表す型です
+type ReportReason string
+
+const (
+	// ReportReasonCopyright は著作権・肖像権の侵害です
+	ReportReasonCopyright ReportReason = "copyright"
+	// ReportReasonInappropriate は不適切な内容です
+	ReportReasonInappropriate ReportReason = "inappropriate"
+	// ReportReasonSpam はスパムや詐欺です
+	ReportReasonSpam ReportReason = "spam"
+	// ReportReasonHarassment は誹謗中傷や嫌がらせです
+	ReportReasonHarassment ReportReason = "harassment"
+	// ReportReasonOther はその他の理由です
+	ReportReasonOther ReportReason = "other"
+)
+
+// IsValidReportReason は有効な通報理由かどうかを確認します
+func IsValidReportReason(reason ReportReason) bool {
+	switch reason {
+	case ReportReasonCopyright, ReportReasonInappropriate, ReportReasonSpam, ReportReasonHarassment, ReportReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReportStatus は通報の処理状態を表す型です
+type ReportStatus string
+
+const (
+	// ReportStatusOpen は未処理です
+	ReportStatusOpen ReportStatus = "open"
+	// ReportStatusResolved は通報を受けてコンテンツに対応しました
+	ReportStatusResolved ReportStatus = "resolved"
+	// ReportStatusDismissed は問題なしとして却下しました
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+// ContentReport はユーザーからのコンテンツの通報を表すエンティティです
+type ContentReport struct {
+	ID         uuid.UUID    `json:"id"`
+	ContentID  uuid.UUID    `json:"content_id"`
+	ReporterID string       `json:"reporter_id"`
+	Reason     ReportReason `json:"reason"`
+	Details    string       `json:"details"`
+	Status     ReportStatus `json:"status"`
+	ResolvedBy *string      `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time   `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// NewContentReport は新しい通報を作成します
+func NewContentReport(contentID uuid.UUID, reporterID string, reason ReportReason, details string) (*ContentReport, error) {
+	if !IsValidReportReason(reason) {
+		return nil, ErrInvalidReportReason
+	}
+	details = strings.TrimSpace(details)
+	if utf8.RuneCountInString(details) > maxReportDetailsLength {
+		return nil, ErrReportDetailsTooLong
+	}
+
+	return &ContentReport{
+		ID:         uuid.New(),
+		ContentID:  contentID,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Details:    details,
+		Status:     ReportStatusOpen,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// ModerationAction は審査の操作を表す型です
+type ModerationAction string
+
+const (
+	// ModerationActionApprove は審査待ちまたは却下済みのコンテンツを承認します
+	ModerationActionApprove ModerationAction = "approve"
+	// ModerationActionReject は審査待ちのコンテンツを却下します
+	ModerationActionReject ModerationAction = "reject"
+	// ModerationActionTakeDown は公開中のコンテンツを取り下げます
+	ModerationActionTakeDown ModerationAction = "take_down"
+	// ModerationActionRestore は取り下げたコンテンツを再公開します
+	ModerationActionRestore ModerationAction = "restore"
+	// ModerationActionDismissReports は通報を問題なしとして却下し、非表示を解除します
+	ModerationActionDismissReports ModerationAction = "dismiss_reports"
+	// ModerationActionAutoHide は通報が一定数に達したためシステムが非表示にしたことを表します
+	ModerationActionAutoHide ModerationAction = "auto_hide"
+	// ModerationActionResubmit は投稿者がファイルを差し替えて審査待ちに戻したことを表します
+	ModerationActionResubmit ModerationAction = "resubmit"
+)
+
+// ModerationEvent はコンテンツの審査の履歴を表すエンティティです
+// ActorID が nil の場合はシステムによる操作です
+type ModerationEvent struct {
+	ID         uuid.UUID        `json:"id"`
+	ContentID  uuid.UUID        `json:"content_id"`
+	ActorID    *string          `json:"actor_id,omitempty"`
+	Action     ModerationAction `json:"action"`
+	FromStatus ModerationStatus `json:"from_status"`
+	ToStatus   ModerationStatus `json:"to_status"`
+	Reason     string           `json:"reason"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// NewModerationEvent は新しい審査の履歴を作成します
+func NewModerationEvent(contentID uuid.UUID, actorID *string, action ModerationAction, from, to ModerationStatus, reason string) (*ModerationEvent, error) {
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > maxModerationReasonLength {
+		return nil, ErrModerationReasonTooLong
+	}
+
+	return &ModerationEvent{
+		ID:         uuid.New(),
+		ContentID:  contentID,
+		ActorID:    actorID,
+		Action:     action,
+		FromStatus: from,
+		ToStatus:   to,
+		Reason:     reason,
+		CreatedAt:  time.Now(),
+	}, nil
+}
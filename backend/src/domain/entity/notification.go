@@ -0,0 +1,325 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationCategory は通知の種類を表す型です
+// ユーザーは種類ごとにプッシュ通知を受け取るかどうかを設定できます
+type NotificationCategory string
+
+const (
+	// NotificationCategoryMessage はキャストやファンからのメッセージです
+	NotificationCategoryMessage NotificationCategory = "message"
+	// NotificationCategoryCommunity はコミュニティの投稿へのコメントや返信です
+	NotificationCategoryCommunity NotificationCategory = "community"
+	// NotificationCategoryAchievement はバッジの獲得などの推し活の実績です
+	NotificationCategoryAchievement NotificationCategory = "achievement"
+	// NotificationCategoryContent は推しの新着コンテンツです
+	NotificationCategoryContent NotificationCategory = "content"
+	// NotificationCategoryModeration は投稿したコンテンツの審査結果です
+	NotificationCategoryModeration NotificationCategory = "moderation"
+	// NotificationCategorySystem はアカウントやセキュリティに関するお知らせで、無効にできません
+	NotificationCategorySystem NotificationCategory = "system"
+)
+
+// NotificationCategories は全ての通知の種類です
+var NotificationCategories = []NotificationCategory{
+	NotificationCategoryMessage,
+	NotificationCategoryCommunity,
+	NotificationCategoryAchievement,
+	NotificationCategoryContent,
+	NotificationCategoryModeration,
+	NotificationCategorySystem,
+}
+
+// IsValidNotificationCategory は通知の種類が有効かどうかを確認します
+func IsValidNotificationCategory(category NotificationCategory) bool {
+	for _, c := range NotificationCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// DevicePlatform はプッシュ通知を受け取る端末の種類を表す型です
+type DevicePlatform string
+
+const (
+	DevicePlatformIOS     DevicePlatform = "ios"
+	DevicePlatformAndroid DevicePlatform = "android"
+	DevicePlatformWeb     DevicePlatform = "web"
+)
+
+const maxDeviceTokenLength = 4096
+
+// DeviceToken はプッシュ通知の送信先の端末を表すエンティティです
+// 同じトークンが別のユーザーで登録された場合は、そのユーザーの端末として扱います
+type DeviceToken struct {
+	ID         uuid.UUID      `json:"id"`
+	UserID     string         `json:"user_id"`
+	Token      string         `json:"-"`
+	Platform   DevicePlatform `json:"platform"`
+	CreatedAt  time.Time      `json:"created_at"`
+	LastSeenAt time.Time      `json:"last_seen_at"`
+}
+
+// NewDeviceToken は新しいデバイストークンを作成します
+func NewDeviceToken(userID, token string, platform DevicePlatform) (*DeviceToken, error) {
+	if token == "" || len(token) > maxDeviceTokenLength {
+		return nil, ErrInvalidDeviceToken
+	}
+	switch platform {
+	case DevicePlatformIOS, DevicePlatformAndroid, DevicePlatformWeb:
+	default:
+		return nil, ErrInvalidDevicePlatform
+	}
+
+	now := time.Now()
+	return &DeviceToken{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Token:      token,
+		Platform:   platform,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}, nil
+}
+
+// QuietHours はプッシュ通知を送らない時間帯です（ユーザーのタイムゾーンの "HH:MM" 形式）
+// Start が End より後の場合は日付をまたぐ時間帯として扱います
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// minutes は時間帯の開始と終了を0時からの分に変換します
+func (q *QuietHours) minutes() (int, int, error) {
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseClock(q.End)
+	if err != nil {
+		return 0, 0, err
+	}
+	if start == end {
+		return 0, 0, ErrInvalidNotificationSettings
+	}
+	return start, end, nil
+}
+
+// parseClock は "HH:MM" 形式の時刻を0時からの分に変換します
+func parseClock(value string) (int, error) {
+	if len(value) != 5 || value[2] != ':' {
+		return 0, ErrInvalidNotificationSettings
+	}
+	hour, err := strconv.Atoi(value[:2])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, ErrInvalidNotificationSettings
+	}
+	minute, err := strconv.Atoi(value[3:])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, ErrInvalidNotificationSettings
+	}
+	return hour*60 + minute, nil
+}
+
+// NotificationSettings はユーザーの通知設定を表すエンティティです
+// 通知の言語とタイムゾーンはプロフィールの設定を使います
+type NotificationSettings struct {
+	UserID             string                 `json:"user_id"`
+	QuietHours         *QuietHours            `json:"quiet_hours"`
+	DisabledCategories []NotificationCategory `json:"disabled_categories"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+}
+
+// DefaultNotificationSettings は通知設定を保存していないユーザーの設定を返します
+func DefaultNotificationSettings(userID string) *NotificationSettings {
+	return &NotificationSettings{
+		UserID:             userID,
+		DisabledCategories: []NotificationCategory{},
+		UpdatedAt:          time.Now(),
+	}
+}
+
+// Validate は通知設定の妥当性を検証します
+func (s *NotificationSettings) Validate() error {
+	if s.QuietHours != nil {
+		if _, _, err := s.QuietHours.minutes(); err != nil {
+			return err
+		}
+	}
+	for _, category := range s.DisabledCategories {
+		if !IsValidNotificationCategory(category) || category == NotificationCategorySystem {
+			return ErrInvalidNotificationSettings
+		}
+	}
+	return nil
+}
+
+// Allows は通知の種類のプッシュ通知を受け取るかどうかを確認します
+func (s *NotificationSettings) Allows(category NotificationCategory) bool {
+	for _, disabled := range s.DisabledCategories {
+		if disabled == category {
+			return false
+		}
+	}
+	return true
+}
+
+// NextDeliveryTime は at 以降でプッシュ通知を送れる最も早い日時を返します
+// at が loc のタイムゾーンで通知を送らない時間帯に含まれる場合はその時間帯の終わりを返します
+func (s *NotificationSettings) NextDeliveryTime(at time.Time, loc *time.Location) time.Time {
+	if s.QuietHours == nil {
+		return at
+	}
+	start, end, err := s.QuietHours.minutes()
+	if err != nil {
+		return at
+	}
+
+	local := at.In(loc)
+	now := local.Hour()*60 + local.Minute()
+
+	if start < end {
+		if now >= start && now < end {
+			return time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+		}
+		return at
+	}
+	// 日付をまたぐ時間帯（例: 22:00〜07:00）
+	if now >= start {
+		return time.Date(local.Year(), local.Month(), local.Day()+1, end/60, end%60, 0, 0, loc)
+	}
+	if now < end {
+		return time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+	}
+	return at
+}
+
+// PushStatus はプッシュ通知の送信状況を表す型です
+type PushStatus string
+
+const (
+	// PushStatusPending は送信待ちです（まとめて送るための待機や、通知を送らない時間帯の待機を含む）
+	PushStatusPending PushStatus = "pending"
+	// PushStatusSending は送信処理中です
+	PushStatusSending PushStatus = "sending"
+	// PushStatusSent は1台以上の端末に送信しました
+	PushStatusSent PushStatus = "sent"
+	// PushStatusFailed は全ての端末への送信に失敗しました
+	PushStatusFailed PushStatus = "failed"
+	// PushStatusSkipped は送信先の端末が無いため送信しませんでした
+	PushStatusSkipped PushStatus = "skipped"
+)
+
+// PushNotification はユーザーに送るプッシュ通知を表すエンティティです
+// 文面は送信時にユーザーの言語でテンプレートから作成します
+// 同じテンプレートと CollapseKey の通知は、まとめて送る際に1件の通知として要約します
+type PushNotification struct {
+	ID           uuid.UUID            `json:"id"`
+	UserID       string               `json:"user_id"`
+	Category     NotificationCategory `json:"category"`
+	Template     string               `json:"template"`
+	CollapseKey  string               `json:"collapse_key,omitempty"`
+	Params       map[string]string    `json:"params"`
+	Data         map[string]string    `json:"data"`
+	Status       PushStatus           `json:"status"`
+	DeliverAfter time.Time            `json:"deliver_after"`
+	CreatedAt    time.Time            `json:"created_at"`
+	SentAt       *time.Time           `json:"sent_at,omitempty"`
+}
+
+// NewPushNotification は新しいプッシュ通知を作成します
+func NewPushNotification(userID string, category NotificationCategory, template, collapseKey string, params, data map[string]string, deliverAfter time.Time) *PushNotification {
+	if params == nil {
+		params = map[string]string{}
+	}
+	if data == nil {
+		data = map[string]string{}
+	}
+	return &PushNotification{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Category:     category,
+		Template:     template,
+		CollapseKey:  collapseKey,
+		Params:       params,
+		Data:         data,
+		Status:       PushStatusPending,
+		DeliverAfter: deliverAfter,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// PushMessage は端末に送るプッシュ通知の文面です
+type PushMessage struct {
+	Title    string               `json:"title"`
+	Body     string               `json:"body"`
+	Category NotificationCategory `json:"category"`
+	Data     map[string]string    `json:"data"`
+}
+
+// PushDeliveryStatus は端末ごとの送信結果を表す型です
+type PushDeliveryStatus string
+
+const (
+	PushDeliveryStatusSent PushDeliveryStatus = "sent"
+	// PushDeliveryStatusFailed は送信に失敗しました
+	PushDeliveryStatusFailed PushDeliveryStatus = "failed"
+	// PushDeliveryStatusInvalidToken はトークンが無効になっていたため、端末の登録を削除しました
+	PushDeliveryStatusInvalidToken PushDeliveryStatus = "invalid_token"
+)
+
+// PushDelivery は端末ごとのプッシュ通知の送信の記録です
+// まとめて送った場合は NotificationIDs に複数の通知が含まれます
+type PushDelivery struct {
+	ID                uuid.UUID          `json:"id"`
+	UserID            string             `json:"user_id"`
+	NotificationIDs   []uuid.UUID        `json:"notification_ids"`
+	DeviceTokenID     uuid.UUID          `json:"device_token_id"`
+	Platform          DevicePlatform     `json:"platform"`
+	Title             string             `json:"title"`
+	Body              string             `json:"body"`
+	Status            PushDeliveryStatus `json:"status"`
+	Error             string             `json:"error,omitempty"`
+	ProviderMessageID string             `json:"provider_message_id,omitempty"`
+	CreatedAt         time.Time          `json:"created_at"`
+}
+
+// Notification は通知の受信箱に保存するお知らせを表すエンティティです
+// 文面は表示する際に閲覧者の言語でテンプレートから作成します
+type Notification struct {
+	ID        uuid.UUID            `json:"id"`
+	UserID    string               `json:"user_id"`
+	Category  NotificationCategory `json:"category"`
+	Template  string               `json:"template"`
+	Params    map[string]string    `json:"params"`
+	Data      map[string]string    `json:"data"`
+	ReadAt    *time.Time           `json:"read_at,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// NewNotification は新しい未読のお知らせを作成します
+func NewNotification(userID string, category NotificationCategory, template string, params, data map[string]string) *Notification {
+	if params == nil {
+		params = map[string]string{}
+	}
+	if data == nil {
+		data = map[string]string{}
+	}
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Category:  category,
+		Template:  template,
+		Params:    params,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxOrganizationNameLength        = 100
+	maxOrganizationDescriptionLength = 2000
+
+	// OrganizationInvitationTTL は組織への招待の有効期間です
+	OrganizationInvitationTTL = 14 * 24 * time.Hour
+)
+
+// OrganizationType は組織の種類を表す型です
+type OrganizationType string
+
+const (
+	OrganizationTypeGroup  OrganizationType = "group"
+	OrganizationTypeAgency OrganizationType = "agency"
+)
+
+// OrganizationRole は組織内での役割を表す型です
+type OrganizationRole string
+
+const (
+	// OrganizationRoleOwner は組織の所有者です。組織の設定と全メンバーを管理できます
+	OrganizationRoleOwner OrganizationRole = "owner"
+	// OrganizationRoleManager はマネージャーです。メンバーを招待・除外し、メンバーのコンテンツを代理で管理できます
+	OrganizationRoleManager OrganizationRole = "manager"
+	// OrganizationRoleMember は所属するキャストです
+	OrganizationRoleMember OrganizationRole = "member"
+)
+
+// CanManageMembers はメンバーのコンテンツや売上を管理できる役割かどうかを確認します
+func (r OrganizationRole) CanManageMembers() bool {
+	return r == OrganizationRoleOwner || r == OrganizationRoleManager
+}
+
+// IsValidOrganizationRole は招待や役割変更で指定できる役割かどうかを確認します
+// 所有者は組織の作成者のみで、招待や役割変更では指定できません
+func IsValidOrganizationRole(role OrganizationRole) bool {
+	return role == OrganizationRoleManager || role == OrganizationRoleMember
+}
+
+// Organization はグループや事務所を表すエンティティです
+type Organization struct {
+	ID          uuid.UUID        `json:"id"`
+	Name        string           `json:"name"`
+	Type        OrganizationType `json:"type"`
+	Description string           `json:"description,omitempty"`
+	CreatedBy   string           `json:"created_by"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// NewOrganization は新しいOrganizationエンティティを作成します
+func NewOrganization(name string, orgType OrganizationType, description, createdBy string) (*Organization, error) {
+	now := time.Now()
+	org := &Organization{
+		ID:          uuid.New(),
+		Name:        strings.TrimSpace(name),
+		Type:        orgType,
+		Description: strings.TrimSpace(description),
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := org.Validate(); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// Validate は組織の妥当性を検証します
+func (o *Organization) Validate() error {
+	if o.Name == "" || utf8.RuneCountInString(o.Name) > maxOrganizationNameLength {
+		return ErrInvalidOrganizationName
+	}
+	if o.Type != OrganizationTypeGroup && o.Type != OrganizationTypeAgency {
+		return ErrInvalidOrganizationType
+	}
+	if utf8.RuneCountInString(o.Description) > maxOrganizationDescriptionLength {
+		return ErrOrganizationDescriptionTooLong
+	}
+	return nil
+}
+
+// Touch は更新日時を現在時刻にします
+func (o *Organization) Touch() {
+	o.UpdatedAt = time.Now()
+}
+
+// OrganizationMember は組織のメンバーを表すエンティティです
+type OrganizationMember struct {
+	OrganizationID uuid.UUID        `json:"organization_id"`
+	UserID         string           `json:"user_id"`
+	Role           OrganizationRole `json:"role"`
+	JoinedAt       time.Time        `json:"joined_at"`
+}
+
+// NewOrganizationMember は新しいOrganizationMemberエンティティを作成します
+func NewOrganizationMember(orgID uuid.UUID, userID string, role OrganizationRole) *OrganizationMember {
+	return &OrganizationMember{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           role,
+		JoinedAt:       time.Now(),
+	}
+}
+
+// OrganizationMembership はユーザーが所属する組織とその役割です
+type OrganizationMembership struct {
+	*Organization
+	Role OrganizationRole `json:"role"`
+}
+
+// OrganizationInvitationStatus は招待の状態を表す型です
+type OrganizationInvitationStatus string
+
+const (
+	OrganizationInvitationStatusPending  OrganizationInvitationStatus = "pending"
+	OrganizationInvitationStatusAccepted OrganizationInvitationStatus = "accepted"
+	OrganizationInvitationStatusDeclined OrganizationInvitationStatus = "declined"
+)
+
+// OrganizationInvitation は組織への招待を表すエンティティです
+type OrganizationInvitation struct {
+	ID             uuid.UUID                    `json:"id"`
+	OrganizationID uuid.UUID                    `json:"organization_id"`
+	UserID         string                       `json:"user_id"`
+	Role           OrganizationRole             `json:"role"`
+	Status         OrganizationInvitationStatus `json:"status"`
+	InvitedBy      string                       `json:"invited_by"`
+	ExpiresAt      time.Time                    `json:"expires_at"`
+	RespondedAt    *time.Time                   `json:"responded_at,omitempty"`
+	CreatedAt      time.Time                    `json:"created_at"`
+}
+
+// NewOrganizationInvitation は新しい招待を作成します
+func NewOrganizationInvitation(orgID uuid.UUID, userID string, role OrganizationRole, invitedBy string) *OrganizationInvitation {
+	now := time.Now()
+	return &OrganizationInvitation{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           role,
+		Status:         OrganizationInvitationStatusPending,
+		InvitedBy:      invitedBy,
+		ExpiresAt:      now.Add(OrganizationInvitationTTL),
+		CreatedAt:      now,
+	}
+}
+
+// IsPending は招待が回答待ちで有効期限内かどうかを確認します
+func (i *OrganizationInvitation) IsPending(now time.Time) bool {
+	return i.Status == OrganizationInvitationStatusPending && now.Before(i.ExpiresAt)
+}
+
+// Respond は招待への回答を記録します
+func (i *OrganizationInvitation) Respond(accept bool) {
+	now := time.Now()
+	i.Status = OrganizationInvitationStatusDeclined
+	if accept {
+		i.Status = OrganizationInvitationStatusAccepted
+	}
+	i.RespondedAt = &now
+}
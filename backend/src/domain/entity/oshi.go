@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxOshiFieldLength       = 100
+	maxOshiDescriptionLength = 2000
+	maxOshiImages            = 10
+	maxOshiTags              = 20
+	maxOshiTagLength         = 30
+)
+
+// Oshi は推し（キャスト・アイドル）を表すエンティティです
+type Oshi struct {
+	ID                      uuid.UUID `json:"id"`
+	Name                    string    `json:"name"`
+	GroupName               string    `json:"group_name,omitempty"`
+	Agency                  string    `json:"agency,omitempty"`
+	Description             string    `json:"description,omitempty"`
+	ProfileImages           []string  `json:"profile_images"`
+	Tags                    []string  `json:"tags"`
+	CreatorUserID           *string   `json:"creator_user_id,omitempty"` // 本人のクリエイターアカウント
+	OfficialDiagnosisResult string    `json:"official_diagnosis_result,omitempty"`
+	FollowerCount           int       `json:"follower_count"`
+	CreatedBy               string    `json:"created_by"`
+	CreatedAt               time.Time `json:"created_at"`
+	UpdatedAt               time.Time `json:"updated_at"`
+}
+
+// NewOshi は新しいOshiエンティティを作成します
+func NewOshi(name, createdBy string) *Oshi {
+	now := time.Now()
+	return &Oshi{
+		ID:            uuid.New(),
+		Name:          strings.TrimSpace(name),
+		ProfileImages: []string{},
+		Tags:          []string{},
+		CreatedBy:     createdBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Validate は推しの妥当性を検証します
+func (o *Oshi) Validate() error {
+	if o.Name == "" || utf8.RuneCountInString(o.Name) > maxOshiNameLength {
+		return ErrInvalidOshiName
+	}
+	if utf8.RuneCountInString(o.GroupName) > maxOshiFieldLength ||
+		utf8.RuneCountInString(o.Agency) > maxOshiFieldLength ||
+		utf8.RuneCountInString(o.OfficialDiagnosisResult) > maxOshiFieldLength ||
+		utf8.RuneCountInString(o.Description) > maxOshiDescriptionLength {
+		return ErrOshiFieldTooLong
+	}
+	if len(o.ProfileImages) > maxOshiImages {
+		return ErrTooManyOshiImages
+	}
+	if len(o.Tags) > maxOshiTags {
+		return ErrTooManyOshiTags
+	}
+	for _, tag := range o.Tags {
+		if tag == "" || utf8.RuneCountInString(tag) > maxOshiTagLength {
+			return ErrInvalidOshiTag
+		}
+	}
+	return nil
+}
+
+// SetTags はタグを正規化して設定します
+// 前後の空白を除いて小文字にし、重複を取り除きます
+func (o *Oshi) SetTags(tags []string) {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeOshiTag(tag)
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	o.Tags = normalized
+}
+
+// NormalizeOshiTag はタグを検索・保存用の形式に変換します
+func NormalizeOshiTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// IsManagedBy は指定されたユーザーが推しを登録したか、本人として紐付けられているかを確認します
+func (o *Oshi) IsManagedBy(userID string) bool {
+	return o.CreatedBy == userID || (o.CreatorUserID != nil && *o.CreatorUserID == userID)
+}
+
+// Touch は更新日時を現在時刻にします
+func (o *Oshi) Touch() {
+	o.UpdatedAt = time.Now()
+}
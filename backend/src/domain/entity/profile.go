@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// LocaleJa は日本語ロケールです
+	LocaleJa = "ja"
+
+	// LocaleEn は英語ロケールです
+	LocaleEn = "en"
+
+	// DefaultTimezone はプロフィールの既定のタイムゾーンです
+	DefaultTimezone = "Asia/Tokyo"
+
+	maxDisplayNameLength = 50
+	maxBioLength         = 500
+	maxFavoriteOshi      = 20
+	maxOshiNameLength    = 100
+)
+
+// ProfilePrivacy はプロフィールの項目ごとの公開設定です
+// true の項目のみ他のユーザーに公開されます
+// LeaderboardOptOut が true の場合はランキングに表示しません
+type ProfilePrivacy struct {
+	BioPublic          bool `json:"bio_public"`
+	BirthdayPublic     bool `json:"birthday_public"`
+	FavoriteOshiPublic bool `json:"favorite_oshi_public"`
+	LeaderboardOptOut  bool `json:"leaderboard_opt_out"`
+}
+
+// DefaultProfilePrivacy は既定の公開設定を返します
+// 誕生日は個人情報のため既定で非公開です
+func DefaultProfilePrivacy() ProfilePrivacy {
+	return ProfilePrivacy{
+		BioPublic:          true,
+		BirthdayPublic:     false,
+		FavoriteOshiPublic: true,
+	}
+}
+
+// UserProfile はユーザーのプロフィールを表すエンティティです
+type UserProfile struct {
+	UserID       string         `json:"user_id"`
+	DisplayName  string         `json:"display_name"`
+	AvatarURL    string         `json:"avatar_url,omitempty"`
+	Bio          string         `json:"bio"`
+	Birthday     *time.Time     `json:"birthday,omitempty"`
+	FavoriteOshi []string       `json:"favorite_oshi"`
+	Locale       string         `json:"locale"`
+	Timezone     string         `json:"timezone"`
+	Privacy      ProfilePrivacy `json:"privacy"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// NewUserProfile は既定値で新しいUserProfileエンティティを作成します
+func NewUserProfile(userID, displayName string) *UserProfile {
+	now := time.Now()
+	return &UserProfile{
+		UserID:       userID,
+		DisplayName:  displayName,
+		FavoriteOshi: []string{},
+		Locale:       LocaleJa,
+		Timezone:     DefaultTimezone,
+		Privacy:      DefaultProfilePrivacy(),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// IsValidLocale はロケールが対応しているかどうかを確認します
+func IsValidLocale(locale string) bool {
+	return locale == LocaleJa || locale == LocaleEn
+}
+
+// Validate はプロフィールの妥当性を検証します
+func (p *UserProfile) Validate() error {
+	if p.UserID == "" {
+		return ErrInvalidUserID
+	}
+	if utf8.RuneCountInString(p.DisplayName) > maxDisplayNameLength {
+		return ErrDisplayNameTooLong
+	}
+	if utf8.RuneCountInString(p.Bio) > maxBioLength {
+		return ErrBioTooLong
+	}
+	if p.Birthday != nil && (p.Birthday.After(time.Now()) || p.Birthday.Year() < 1900) {
+		return ErrInvalidBirthday
+	}
+	if len(p.FavoriteOshi) > maxFavoriteOshi {
+		return ErrTooManyFavoriteOshi
+	}
+	for _, oshi := range p.FavoriteOshi {
+		if oshi == "" || utf8.RuneCountInString(oshi) > maxOshiNameLength {
+			return ErrInvalidOshiName
+		}
+	}
+	if !IsValidLocale(p.Locale) {
+		return ErrInvalidLocale
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil || p.Timezone == "" {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
+
+// Touch は更新日時を現在時刻にします
+func (p *UserProfile) Touch() {
+	p.UpdatedAt = time.Now()
+}
+
+// PublicProfile は他のユーザーに公開するプロフィールです
+type PublicProfile struct {
+	UserID       string     `json:"user_id"`
+	DisplayName  string     `json:"display_name"`
+	AvatarURL    string     `json:"avatar_url,omitempty"`
+	Bio          string     `json:"bio,omitempty"`
+	Birthday     *time.Time `json:"birthday,omitempty"`
+	FavoriteOshi []string   `json:"favorite_oshi,omitempty"`
+}
+
+// Public は公開設定に従って公開用のプロフィールを返します
+// includePrivate が true の場合は公開設定に関わらずすべての項目を含めます
+func (p *UserProfile) Public(includePrivate bool) *PublicProfile {
+	pub := &PublicProfile{
+		UserID:      p.UserID,
+		DisplayName: p.DisplayName,
+		AvatarURL:   p.AvatarURL,
+	}
+	if includePrivate || p.Privacy.BioPublic {
+		pub.Bio = p.Bio
+	}
+	if includePrivate || p.Privacy.BirthdayPublic {
+		pub.Birthday = p.Birthday
+	}
+	if includePrivate || p.Privacy.FavoriteOshiPublic {
+		pub.FavoriteOshi = p.FavoriteOshi
+	}
+	return pub
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RealtimeEventType はリアルタイムに配信するイベントの種類を表す型です
+type RealtimeEventType string
+
+const (
+	// RealtimeEventNotification はユーザーへのお知らせです
+	RealtimeEventNotification RealtimeEventType = "notification"
+	// RealtimeEventNotificationsRead は通知の受信箱の既読です（他の端末の未読件数の更新に使います）
+	RealtimeEventNotificationsRead RealtimeEventType = "notifications.read"
+	// RealtimeEventPointsBalance は推し活ポイントの残高の変化です
+	RealtimeEventPointsBalance RealtimeEventType = "points.balance"
+	// RealtimeEventLeaderboard は推しのランキングの更新です
+	RealtimeEventLeaderboard RealtimeEventType = "leaderboard.updated"
+	// RealtimeEventMessage はやり取りへのメッセージの送信です
+	RealtimeEventMessage RealtimeEventType = "message.created"
+	// RealtimeEventConversationRead はやり取りの相手の既読です
+	RealtimeEventConversationRead RealtimeEventType = "conversation.read"
+)
+
+// RealtimeEvent はリアルタイムに配信するイベントです
+// Truncated が true の場合は配信できる大きさを超えたため Data を省いており、クライアントはAPIから取得し直します
+type RealtimeEvent struct {
+	Type       RealtimeEventType `json:"type"`
+	Data       json.RawMessage   `json:"data,omitempty"`
+	Truncated  bool              `json:"truncated,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+// NewRealtimeEvent は data をJSONにしたイベントを作成します
+func NewRealtimeEvent(eventType RealtimeEventType, data interface{}) (*RealtimeEvent, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode realtime event: %w", err)
+	}
+
+	return &RealtimeEvent{
+		Type:       eventType,
+		Data:       raw,
+		OccurredAt: time.Now(),
+	}, nil
+}
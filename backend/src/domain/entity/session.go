@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeviceSession はログイン中の端末とリフレッシュトークンを表すエンティティです
+// リフレッシュトークンはハッシュのみを保存し、リフレッシュのたびにローテーションします
+type DeviceSession struct {
+	ID               uuid.UUID  `json:"id"`
+	UserID           string     `json:"user_id"`
+	RefreshTokenHash string     `json:"-"`
+	DeviceID         string     `json:"device_id,omitempty"`
+	UserAgent        string     `json:"user_agent"`
+	IPAddress        string     `json:"ip_address"`
+	AppVersion       string     `json:"app_version,omitempty"`
+	FCMToken         string     `json:"-"`
+	LastSeenAt       time.Time  `json:"last_seen_at"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// NewDeviceSession は新しいDeviceSessionエンティティを作成します
+func NewDeviceSession(userID, refreshTokenHash, deviceID, userAgent, ipAddress, appVersion, fcmToken string, ttl time.Duration) *DeviceSession {
+	now := time.Now()
+	return &DeviceSession{
+		ID:               uuid.New(),
+		UserID:           userID,
+		RefreshTokenHash: refreshTokenHash,
+		DeviceID:         deviceID,
+		UserAgent:        userAgent,
+		IPAddress:        ipAddress,
+		AppVersion:       appVersion,
+		FCMToken:         fcmToken,
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(ttl),
+		CreatedAt:        now,
+	}
+}
+
+// IsActive はセッションが失効しておらず有効期限内かどうかを確認します
+func (s *DeviceSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Rotate はリフレッシュトークンを差し替え、端末情報と有効期限を更新します
+func (s *DeviceSession) Rotate(refreshTokenHash, userAgent, ipAddress, appVersion string, ttl time.Duration) {
+	now := time.Now()
+	s.RefreshTokenHash = refreshTokenHash
+	s.UserAgent = userAgent
+	s.IPAddress = ipAddress
+	if appVersion != "" {
+		s.AppVersion = appVersion
+	}
+	s.LastSeenAt = now
+	s.ExpiresAt = now.Add(ttl)
+}
+
+// SecurityEventType はセキュリティイベントの種類を表す型です
+type SecurityEventType string
+
+const (
+	// SecurityEventNewDeviceLogin は初めての端末からのログインです
+	SecurityEventNewDeviceLogin SecurityEventType = "new_device_login"
+
+	// SecurityEventSessionRevoked はセッションの手動ログアウトです
+	SecurityEventSessionRevoked SecurityEventType = "session_revoked"
+
+	// SecurityEventRefreshTokenReused は使用済みのリフレッシュトークンが再び使われたため、セッションを失効させたことを表します
+	SecurityEventRefreshTokenReused SecurityEventType = "refresh_token_reused"
+)
+
+// SecurityEvent はアカウントのセキュリティに関わる出来事を表すエンティティです
+type SecurityEvent struct {
+	ID        uuid.UUID         `json:"id"`
+	UserID    string            `json:"user_id"`
+	Type      SecurityEventType `json:"type"`
+	SessionID *uuid.UUID        `json:"session_id,omitempty"`
+	UserAgent string            `json:"user_agent"`
+	IPAddress string            `json:"ip_address"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// NewSecurityEvent は新しいSecurityEventエンティティを作成します
+func NewSecurityEvent(userID string, eventType SecurityEventType, session *DeviceSession) *SecurityEvent {
+	event := &SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      eventType,
+		CreatedAt: time.Now(),
+	}
+	if session != nil {
+		id := session.ID
+		event.SessionID = &id
+		event.UserAgent = session.UserAgent
+		event.IPAddress = session.IPAddress
+	}
+	return event
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxShareTitleLength   = 100
+	maxShareSummaryLength = 300
+	maxShareResultIDLen   = 100
+)
+
+// ShareKind は共有する結果の種類を表す型です
+type ShareKind string
+
+const (
+	// ShareKindDiagnosis は診断結果です
+	ShareKindDiagnosis ShareKind = "diagnosis"
+	// ShareKindCompatibility は相性診断の結果です
+	ShareKindCompatibility ShareKind = "compatibility"
+)
+
+// ShareLink は診断結果を公開するための共有リンクです
+// 公開内容は作成時点の結果の写しで、元の結果が変わっても変化しません
+type ShareLink struct {
+	ID        uuid.UUID  `json:"id"`
+	Slug      string     `json:"slug"`
+	UserID    string     `json:"user_id"`
+	Kind      ShareKind  `json:"kind"`
+	ResultID  string     `json:"result_id"`
+	OshiID    *uuid.UUID `json:"oshi_id,omitempty"`
+	Title     string     `json:"title"`
+	Summary   string     `json:"summary"`
+	Score     *int       `json:"score,omitempty"`
+	ImageKey  string     `json:"-"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewShareLink は新しい共有リンクを作成します
+func NewShareLink(userID, slug string, kind ShareKind, resultID, title, summary string, score *int, expiresAt *time.Time) (*ShareLink, error) {
+	link := &ShareLink{
+		ID:        uuid.New(),
+		Slug:      slug,
+		UserID:    userID,
+		Kind:      kind,
+		ResultID:  strings.TrimSpace(resultID),
+		Title:     strings.TrimSpace(title),
+		Summary:   strings.TrimSpace(summary),
+		Score:     score,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+	if err := link.Validate(); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// Validate は共有リンクの妥当性を検証します
+func (l *ShareLink) Validate() error {
+	if l.Kind != ShareKindDiagnosis && l.Kind != ShareKindCompatibility {
+		return ErrInvalidShareKind
+	}
+	if l.ResultID == "" || utf8.RuneCountInString(l.ResultID) > maxShareResultIDLen {
+		return ErrInvalidShareResult
+	}
+	if l.Title == "" || utf8.RuneCountInString(l.Title) > maxShareTitleLength {
+		return ErrInvalidShareResult
+	}
+	if utf8.RuneCountInString(l.Summary) > maxShareSummaryLength {
+		return ErrInvalidShareResult
+	}
+	if l.Score != nil && (*l.Score < 0 || *l.Score > 100) {
+		return ErrInvalidShareResult
+	}
+	return nil
+}
+
+// IsActive は共有リンクが公開中かどうかを確認します
+func (l *ShareLink) IsActive(now time.Time) bool {
+	if l.RevokedAt != nil {
+		return false
+	}
+	return l.ExpiresAt == nil || now.Before(*l.ExpiresAt)
+}
+
+// Revoke は共有リンクを無効にします
+func (l *ShareLink) Revoke() {
+	now := time.Now()
+	l.RevokedAt = &now
+}
+
+// ShareCard はシェアカード画像に描画する内容です
+type ShareCard struct {
+	Label     string
+	Title     string
+	Summary   string
+	Score     *int
+	ScoreUnit string
+	Footer    string
+}
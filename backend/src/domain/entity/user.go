@@ -1,33 +1,74 @@
 package entity
 
 import (
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RoleFan は一般ユーザー（ファン）のロールです
+	RoleFan = "fan"
+
+	// RoleCreator はコンテンツを投稿するキャストのロールです
+	RoleCreator = "creator"
+
+	// RoleModerator はコンテンツやコミュニティを審査するロールです
+	RoleModerator = "moderator"
+
+	// RoleAdmin は管理者のロールです
+	RoleAdmin = "admin"
 )
 
+// placeholderEmailDomain はメールアドレスを持たないユーザーに設定する仮のアドレスのドメインです
+// RFC 2606 で予約されたドメインのため、配信されることはありません
+const placeholderEmailDomain = "@invalid"
+
+// IsValidRole はロールが有効かどうかを確認します
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleFan, RoleCreator, RoleModerator, RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
 // User はユーザー情報を表すエンティティです
 type User struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Password  string    `json:"-"` // パスワードはJSONにシリアライズしない
-	Name      string    `json:"name"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          string     `json:"id"`
+	Email       string     `json:"email"`
+	Password    string     `json:"-"` // パスワードはJSONにシリアライズしない
+	Name        string     `json:"name"`
+	Role        string     `json:"role"`
+	RoleVersion int        `json:"-"` // ロール変更のたびに増加し、古いトークンの無効化に使用する
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
 // NewUser は新しいUserエンティティを作成します
 func NewUser(email, password, name string) *User {
 	now := time.Now()
 	return &User{
-		Email:     email,
-		Password:  password,
-		Name:      name,
-		Role:      "user",
-		CreatedAt: now,
-		UpdatedAt: now,
+		Email:       email,
+		Password:    password,
+		Name:        name,
+		Role:        RoleFan,
+		RoleVersion: 1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
 	}
 }
 
+// NewUserWithoutEmail はメールアドレスを持たない新しいUserエンティティを作成します
+// email 列は一意の必須項目のため、配信されない仮のアドレスを設定します
+func NewUserWithoutEmail(name string) *User {
+	return NewUser(fmt.Sprintf("unverified+%s%s", uuid.New(), placeholderEmailDomain), "", name)
+}
+
 // UpdatePassword はユーザーのパスワードを更新します
 func (u *User) UpdatePassword(password string) {
 	u.Password = password
@@ -41,7 +82,37 @@ func (u *User) UpdateName(name string) {
 }
 
 // UpdateRole はユーザーのロールを更新します
+// ロールバージョンを進めることで、変更前に発行されたトークンを無効化します
 func (u *User) UpdateRole(role string) {
 	u.Role = role
+	u.RoleVersion++
 	u.UpdatedAt = time.Now()
 }
+
+// RequiresMFA はユーザーのロールで二要素認証が必須かどうかを確認します
+func (u *User) RequiresMFA() bool {
+	return u.Role == RoleCreator || u.Role == RoleModerator || u.Role == RoleAdmin
+}
+
+// HasEmail はユーザーが配信できるメールアドレスを持っているかどうかを確認します
+func (u *User) HasEmail() bool {
+	return u.Email != "" && !strings.HasSuffix(u.Email, placeholderEmailDomain)
+}
+
+// IsDeleted はユーザーが退会済みかどうかを確認します
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// Tombstone は退会したユーザーの個人情報を消去し、削除済みの状態にします
+// 行自体は決済履歴などの参照整合性のために残します
+func (u *User) Tombstone() {
+	now := time.Now()
+	u.Email = fmt.Sprintf("deleted+%s%s", u.ID, placeholderEmailDomain)
+	u.Password = ""
+	u.Name = "退会済みユーザー"
+	u.Role = RoleFan
+	u.RoleVersion++
+	u.DeletedAt = &now
+	u.UpdatedAt = now
+}
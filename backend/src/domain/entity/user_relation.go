@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+)
+
+// UserRelationType はユーザー間の関係の種類を表す型です
+type UserRelationType string
+
+const (
+	// UserRelationBlock はブロックです
+	// ブロックしたユーザーとされたユーザーは互いの投稿やランキング、共有ページが表示されなくなり、
+	// ブロックされたユーザーはブロックしたユーザーのプロフィールを閲覧できなくなります
+	UserRelationBlock UserRelationType = "block"
+	// UserRelationMute はミュートです
+	// ミュートしたユーザーにだけ相手の投稿やランキングが表示されなくなり、相手には通知されません
+	UserRelationMute UserRelationType = "mute"
+)
+
+// UserRelation はユーザーが他のユーザーに設定したブロックまたはミュートを表すエンティティです
+// 1組のユーザーにつき1つの関係のみ持ち、ミュート中のユーザーをブロックするとブロックに置き換わります
+type UserRelation struct {
+	UserID    string           `json:"user_id"`
+	TargetID  string           `json:"target_id"`
+	Type      UserRelationType `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NewUserRelation は新しいユーザー間の関係を作成します
+func NewUserRelation(userID, targetID string, relationType UserRelationType) (*UserRelation, error) {
+	if relationType != UserRelationBlock && relationType != UserRelationMute {
+		return nil, ErrInvalidUserRelation
+	}
+	if userID == "" || targetID == "" || userID == targetID {
+		return nil, ErrInvalidUserRelation
+	}
+
+	return &UserRelation{
+		UserID:    userID,
+		TargetID:  targetID,
+		Type:      relationType,
+		CreatedAt: time.Now(),
+	}, nil
+}
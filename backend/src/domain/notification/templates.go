@@ -0,0 +1,112 @@
+package notification
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+//go:embed templates.json
+var defaultTemplates []byte
+
+// BatchTemplateKey は同じテンプレートの通知をまとめて送る際に使うテンプレートの接尾辞です
+const BatchTemplateKey = ".batch"
+
+// GenericBatchTemplate は種類の異なる通知をまとめて送る際のテンプレートです
+const GenericBatchTemplate = "batch"
+
+// localizedTemplate は1つの言語の通知の文面のテンプレートです
+type localizedTemplate struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// compiledTemplate は解析済みの文面のテンプレートです
+type compiledTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+// Templates はプッシュ通知の文面のテンプレートの集合です
+// テンプレートのパラメータは {{.name}} で参照し、指定されていないパラメータはエラーになります
+type Templates struct {
+	templates map[string]map[string]*compiledTemplate
+}
+
+// DefaultTemplates は templates.json に定義されたテンプレートを返します
+func DefaultTemplates() (*Templates, error) {
+	return LoadTemplates(defaultTemplates)
+}
+
+// LoadTemplates はJSON形式のテンプレートを読み込み、検証します
+// 全てのテンプレートに日本語の文面が必要です
+func LoadTemplates(data []byte) (*Templates, error) {
+	var definitions map[string]map[string]localizedTemplate
+	if err := json.Unmarshal(data, &definitions); err != nil {
+		return nil, fmt.Errorf("failed to decode notification templates: %w", err)
+	}
+	if _, ok := definitions[GenericBatchTemplate]; !ok {
+		return nil, fmt.Errorf("notification template %q is required", GenericBatchTemplate)
+	}
+
+	templates := make(map[string]map[string]*compiledTemplate, len(definitions))
+	for key, locales := range definitions {
+		if _, ok := locales[entity.LocaleJa]; !ok {
+			return nil, fmt.Errorf("notification template %s requires %s", key, entity.LocaleJa)
+		}
+
+		compiled := make(map[string]*compiledTemplate, len(locales))
+		for locale, def := range locales {
+			if locale != entity.LocaleJa && locale != entity.LocaleEn {
+				return nil, fmt.Errorf("unsupported locale %s for notification template: %s", locale, key)
+			}
+			if def.Title == "" {
+				return nil, fmt.Errorf("notification template %s (%s) requires title", key, locale)
+			}
+			title, err := template.New(key + ".title").Option("missingkey=error").Parse(def.Title)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse notification template %s (%s): %w", key, locale, err)
+			}
+			body, err := template.New(key + ".body").Option("missingkey=error").Parse(def.Body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse notification template %s (%s): %w", key, locale, err)
+			}
+			compiled[locale] = &compiledTemplate{title: title, body: body}
+		}
+		templates[key] = compiled
+	}
+
+	return &Templates{templates: templates}, nil
+}
+
+// Has はテンプレートが定義されているかどうかを確認します
+func (t *Templates) Has(key string) bool {
+	_, ok := t.templates[key]
+	return ok
+}
+
+// Render はテンプレートから指定した言語の件名と本文を作成します
+// 指定した言語の文面が無い場合は日本語の文面を使います
+func (t *Templates) Render(key, locale string, params map[string]string) (string, string, error) {
+	locales, ok := t.templates[key]
+	if !ok {
+		return "", "", fmt.Errorf("notification template not found: %s", key)
+	}
+	tmpl, ok := locales[locale]
+	if !ok {
+		tmpl = locales[entity.LocaleJa]
+	}
+
+	var title, body bytes.Buffer
+	if err := tmpl.title.Execute(&title, params); err != nil {
+		return "", "", fmt.Errorf("failed to render notification template %s: %w", key, err)
+	}
+	if err := tmpl.body.Execute(&body, params); err != nil {
+		return "", "", fmt.Errorf("failed to render notification template %s: %w", key, err)
+	}
+	return title.String(), body.String(), nil
+}
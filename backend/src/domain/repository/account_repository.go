@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// AccountDeletionRepository は退会申請の永続化を担当するインターフェースです
+type AccountDeletionRepository interface {
+	// Create は新しい退会申請を保存します
+	Create(ctx context.Context, deletion *entity.AccountDeletion) error
+
+	// FindByID は指定されたIDの退会申請を取得します
+	// 見つからない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.AccountDeletion, error)
+
+	// FindScheduledByUserID は指定されたユーザーの削除予定の退会申請を取得します
+	// 見つからない場合は nil を返します
+	FindScheduledByUserID(ctx context.Context, userID string) (*entity.AccountDeletion, error)
+
+	// Update は退会申請を更新します
+	Update(ctx context.Context, deletion *entity.AccountDeletion) error
+}
+
+// DataExportRepository はデータエクスポート要求の永続化を担当するインターフェースです
+type DataExportRepository interface {
+	// Create は新しいエクスポート要求を保存します
+	Create(ctx context.Context, export *entity.DataExport) error
+
+	// FindByID は指定されたIDのエクスポート要求を取得します
+	// 見つからない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.DataExport, error)
+
+	// FindLatestByUserID は指定されたユーザーの最新のエクスポート要求を取得します
+	// 見つからない場合は nil を返します
+	FindLatestByUserID(ctx context.Context, userID string) (*entity.DataExport, error)
+
+	// Update はエクスポート要求を更新します
+	Update(ctx context.Context, export *entity.DataExport) error
+}
+
+// AccountRepository はアカウント全体にまたがるデータ操作を担当するインターフェースです
+type AccountRepository interface {
+	// Purge はユーザーの個人データを削除し、ユーザーを削除済みの状態で保存します
+	// 全ての操作は1つのトランザクションで行います
+	Purge(ctx context.Context, user *entity.User) error
+}
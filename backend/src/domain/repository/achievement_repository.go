@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+// BadgeRepository はユーザーが獲得したバッジの永続化を担当するインターフェースです
+type BadgeRepository interface {
+	// Award はバッジを付与します
+	// 既に獲得している場合は false を返します
+	Award(ctx context.Context, badge *entity.UserBadge) (bool, error)
+
+	// ListByUserID はユーザーが獲得したバッジを獲得順に取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.UserBadge, error)
+
+	// DeleteAllByUserID はユーザーが獲得した全てのバッジを削除します
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
+
+// PointLedgerRepository は推し活ポイントの台帳の永続化を担当するインターフェースです
+type PointLedgerRepository interface {
+	// Book はポイントの増減を記録します
+	// 同じユーザーで同じ Reference の記録が既にある場合は記録せず false を返します
+	Book(ctx context.Context, entry *entity.PointLedgerEntry) (bool, error)
+
+	// Balance はユーザーのポイント残高を取得します
+	Balance(ctx context.Context, userID string) (int64, error)
+
+	// ListByUserID はユーザーのポイントの増減を新しい順に取得します
+	ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.PointLedgerEntry, error)
+
+	// DeleteAllByUserID はユーザーの全てのポイントの記録を削除します
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ActivityRepository は推し活の行動の記録の永続化を担当するインターフェースです
+type ActivityRepository interface {
+	// Create は行動の記録を保存します
+	Create(ctx context.Context, event *entity.ActivityEvent) error
+
+	// ListByUserAndOshi はユーザーの推しに対する since 以降の行動の記録を古い順に取得します
+	ListByUserAndOshi(ctx context.Context, userID string, oshiID uuid.UUID, since time.Time) ([]*entity.ActivityEvent, error)
+
+	// ListByUser はユーザーの since 以降の行動の記録を古い順に取得します
+	ListByUser(ctx context.Context, userID string, since time.Time) ([]*entity.ActivityEvent, error)
+
+	// CountSince はユーザーの推しに対する since 以降の指定した種類の行動の件数を取得します
+	CountSince(ctx context.Context, userID string, oshiID uuid.UUID, activityType entity.ActivityType, since time.Time) (int, error)
+
+	// CountByUser はユーザーの全ての推しに対する指定した種類の行動の累計件数を取得します
+	CountByUser(ctx context.Context, userID string, activityType entity.ActivityType) (int, error)
+
+	// DeleteAllByUserID はユーザーの全ての行動の記録を削除します
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyRepository はAPIキーの永続化を担当するインターフェースです
+type APIKeyRepository interface {
+	// Create は新しいAPIキーを作成します
+	Create(ctx context.Context, key *entity.APIKey) error
+
+	// FindByPrefix はプレフィックスでAPIキーを取得します
+	// APIキーが存在しない場合は nil を返します
+	FindByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+
+	// FindByID はIDでAPIキーを取得します
+	// APIキーが存在しない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.APIKey, error)
+
+	// FindByUserID は指定されたユーザーが個人で所有するAPIキー一覧を取得します
+	FindByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error)
+
+	// FindByOrganizationID は指定された組織が所有するAPIキー一覧を取得します
+	FindByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entity.APIKey, error)
+
+	// Revoke はAPIキーを失効させます
+	Revoke(ctx context.Context, id uuid.UUID) error
+
+	// TouchLastUsed は最終使用日時を更新します
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// CommunityFilter はコミュニティの一覧取得の条件です
+// 削除された投稿と、閲覧者以外の非表示にされた投稿、閲覧者がブロック・ミュートした投稿者と閲覧者をブロックした投稿者の投稿は常に除外します
+type CommunityFilter struct {
+	ViewerID string // 未ログインの場合は空
+}
+
+// CommunityPostRepository はコミュニティの投稿の永続化を担当するインターフェースです
+type CommunityPostRepository interface {
+	// Create は新しい投稿を保存します
+	Create(ctx context.Context, post *entity.CommunityPost) error
+
+	// FindByID は指定されたIDの投稿を取得します
+	// 見つからない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.CommunityPost, error)
+
+	// ListByOshiID は推しのページの投稿を新しい順に取得します
+	// cursor が nil の場合は最新の投稿から取得します
+	ListByOshiID(ctx context.Context, oshiID uuid.UUID, filter CommunityFilter, cursor *FeedCursor, limit int) ([]*entity.CommunityPost, error)
+
+	// ListByUserID はユーザーの削除されていない投稿を新しい順に取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.CommunityPost, error)
+
+	// Update は投稿を更新します
+	Update(ctx context.Context, post *entity.CommunityPost) error
+
+	// SoftDeleteAllByUserID はユーザーの全ての投稿の本文と画像を消して削除済みにします
+	SoftDeleteAllByUserID(ctx context.Context, userID string) error
+}
+
+// CommunityCommentRepository は投稿へのコメントの永続化を担当するインターフェースです
+// 投稿のコメント数と返信数はコメントの追加・削除と同じトランザクションで更新します
+type CommunityCommentRepository interface {
+	// Create は新しいコメントを保存し、投稿のコメント数と返信先の返信数を増やします
+	Create(ctx context.Context, comment *entity.CommunityComment) error
+
+	// FindByID は指定されたIDのコメントを取得します
+	// 見つからない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.CommunityComment, error)
+
+	// ListByPostID は投稿のコメントを古い順に取得します
+	// parentID が nil の場合はスレッドの先頭のコメントを、指定した場合はその返信を取得します
+	// 削除されたコメントは返信が残っている場合のみ含めます
+	// cursor が nil の場合は最初のコメントから取得します
+	ListByPostID(ctx context.Context, postID uuid.UUID, parentID *uuid.UUID, filter CommunityFilter, cursor *FeedCursor, limit int) ([]*entity.CommunityComment, error)
+
+	// ListByUserID はユーザーの削除されていないコメントを新しい順に取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.CommunityComment, error)
+
+	// SoftDelete はコメントを削除済みとして保存し、投稿のコメント数と返信先の返信数を減らします
+	SoftDelete(ctx context.Context, comment *entity.CommunityComment) error
+
+	// SoftDeleteAllByUserID はユーザーの全てのコメントの本文を消して削除済みにします
+	SoftDeleteAllByUserID(ctx context.Context, userID string) error
+}
+
+// ReactionRepository は投稿やコメントへのリアクションの永続化を担当するインターフェースです
+type ReactionRepository interface {
+	// Add はリアクションを保存します
+	// 既に同じリアクションをしている場合は false を返します
+	Add(ctx context.Context, reaction *entity.Reaction) (bool, error)
+
+	// Remove はリアクションを取り消します
+	// リアクションしていない場合は false を返します
+	Remove(ctx context.Context, targetType entity.ReactionTargetType, targetID uuid.UUID, userID string, reaction entity.ReactionType) (bool, error)
+
+	// Summaries は対象ごとのリアクションの集計と閲覧者自身のリアクションをまとめて取得します
+	// viewerID が空の場合は閲覧者自身のリアクションを含めません
+	Summaries(ctx context.Context, targetType entity.ReactionTargetType, targetIDs []uuid.UUID, viewerID string) (map[uuid.UUID]*entity.ReactionSummary, error)
+
+	// ListByUserID はユーザーのリアクションを新しい順に取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.Reaction, error)
+
+	// DeleteAllByUserID はユーザーの全てのリアクションを削除します
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
@@ -2,27 +2,83 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"kimiyomi/backend/src/domain/entity"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
+// ContentSort はコンテンツの一覧の並び順を表す型です
+type ContentSort string
+
+const (
+	// ContentSortNewest は公開日時（未指定の場合は作成日時）の新しい順です
+	ContentSortNewest ContentSort = "newest"
+	// ContentSortOldest は公開日時（未指定の場合は作成日時）の古い順です
+	ContentSortOldest ContentSort = "oldest"
+	// ContentSortPriceAsc は価格の安い順です
+	ContentSortPriceAsc ContentSort = "price_asc"
+	// ContentSortPriceDesc は価格の高い順です
+	ContentSortPriceDesc ContentSort = "price_desc"
+)
+
+// ContentFilter はコンテンツの一覧取得の条件です
+// OwnerID を指定しない場合は公開中のコンテンツのみを対象とし、閲覧者がブロック・ミュートした投稿者と閲覧者をブロックした投稿者のものを除外します
+type ContentFilter struct {
+	ViewerID    string
+	OwnerID     string // 指定した場合は投稿者のコンテンツを審査中・公開期間外のものも含めて対象にします
+	OshiID      *uuid.UUID
+	ContentType entity.ContentType
+	MinPrice    *decimal.Decimal
+	MaxPrice    *decimal.Decimal
+	Free        *bool  // true で無料、false で有料のコンテンツに絞り込みます
+	Query       string // タイトル・説明の全文検索（全ての語を含むもの）
+	Sort        ContentSort
+}
+
+// ContentCursor はコンテンツの一覧のページ位置です
+// 並び順に応じて公開日時または価格と、IDの組で位置を表します
+type ContentCursor struct {
+	AvailableAt time.Time
+	Price       decimal.Decimal
+	ID          uuid.UUID
+}
+
 // ContentRepository はコンテンツの永続化を担当するインターフェースです
 type ContentRepository interface {
 	// Create は新しいコンテンツを作成します
 	Create(ctx context.Context, content *entity.Content) error
 
-	// FindByID は指定されたIDのコンテンツを取得します
+	// FindByID は指定されたIDのコンテンツを取得します（存在しない場合は nil を返します）
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Content, error)
 
+	// FindByIDs は指定されたIDのコンテンツをまとめて取得します
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Content, error)
+
+	// List は条件に一致するコンテンツを指定した順に取得します
+	List(ctx context.Context, filter ContentFilter, cursor *ContentCursor, limit int) ([]*entity.Content, error)
+
+	// ListUnindexed は検索用の索引が作成されていないコンテンツを取得します
+	ListUnindexed(ctx context.Context, limit int) ([]*entity.Content, error)
+
+	// UpdateSearchIndex はコンテンツの検索用の索引を更新します
+	UpdateSearchIndex(ctx context.Context, content *entity.Content) error
+
 	// FindByUserID は指定されたユーザーIDのコンテンツ一覧を取得します
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Content, error)
 
 	// Update は既存のコンテンツを更新します
-	Update(ctx context.Context, content *entity.Content) error
+	// ファイルが差し替えられた場合は審査状態を審査待ちに戻し、取り下げ済みの場合は entity.ErrContentTakenDown を返します
+	// event を指定した場合は審査の履歴も同じトランザクションで保存します
+	Update(ctx context.Context, content *entity.Content, event *entity.ModerationEvent) error
+
+	// MarkReleased はコンテンツを公開済みとして記録します
+	// 既に公開済みとして記録されている場合は false を返します
+	MarkReleased(ctx context.Context, id uuid.UUID) (bool, error)
 
-	// Delete は指定されたIDのコンテンツを削除します
+	// Delete は指定されたIDのコンテンツを診断との紐付けと共に削除します
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// FindByDiagnosisID は指定された診断IDに紐付けられたコンテンツ一覧を取得します
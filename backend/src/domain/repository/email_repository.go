@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// EmailRepository はメールとその送信記録の永続化を担当するインターフェースです
+type EmailRepository interface {
+	// Create はメールを保存します
+	Create(ctx context.Context, email *entity.Email) error
+
+	// FindByID は指定されたIDのメールを取得します
+	// 見つからない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Email, error)
+
+	// FindByProviderMessageID は送信サーバーが発行したIDのメールを取得します
+	// 見つからない場合は nil を返します
+	FindByProviderMessageID(ctx context.Context, messageID string) (*entity.Email, error)
+
+	// UpdateDelivery はメールの送信状況、試行回数、エラー、送信サーバーのID、送信日時を更新します
+	UpdateDelivery(ctx context.Context, email *entity.Email) error
+
+	// ListByUserID はユーザーに送ったメールを新しい順に取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.Email, error)
+
+	// DeleteAllByUserID はユーザーに送ったメールの記録を削除します
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
+
+// EmailSuppressionRepository はメールアドレスへの送信の停止の永続化を担当するインターフェースです
+type EmailSuppressionRepository interface {
+	// Add は送信の停止を保存します。既に停止している場合は何もしません
+	Add(ctx context.Context, suppression *entity.EmailSuppression) error
+
+	// IsSuppressed はメールアドレスへの指定した種類のメールの送信が停止されているかどうかを確認します
+	// 全ての種類を停止している場合も true を返します
+	IsSuppressed(ctx context.Context, email string, category entity.EmailCategory) (bool, error)
+
+	// ListByEmail はメールアドレスへの送信の停止を取得します
+	ListByEmail(ctx context.Context, email string) ([]*entity.EmailSuppression, error)
+
+	// RemoveUnsubscribe は配信停止による指定した種類のメールの送信の停止を解除します
+	// バウンスや迷惑メールの報告による停止は解除しません
+	RemoveUnsubscribe(ctx context.Context, email string, category entity.EmailCategory) error
+
+	// DeleteByEmail はメールアドレスへの全ての送信の停止を削除します
+	DeleteByEmail(ctx context.Context, email string) error
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// FollowRepository は推しのフォローの永続化を担当するインターフェースです
+type FollowRepository interface {
+	// Follow はフォローを登録し、推しのフォロワー数を増やします
+	// 既にフォローしている場合は false を返します
+	Follow(ctx context.Context, follow *entity.OshiFollow) (bool, error)
+
+	// Unfollow はフォローを解除し、推しのフォロワー数を減らします
+	// フォローしていない場合は false を返します
+	Unfollow(ctx context.Context, userID string, oshiID uuid.UUID) (bool, error)
+
+	// IsFollowing はユーザーが推しをフォローしているかどうかを確認します
+	IsFollowing(ctx context.Context, userID string, oshiID uuid.UUID) (bool, error)
+
+	// ListByUserID はユーザーのフォロー一覧を新しい順に取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.OshiFollow, error)
+
+	// ListFollowerIDs は推しのフォロワーのIDを after より後からID順に取得します
+	// excludeHiddenFrom を指定した場合は、そのユーザーをブロック・ミュートしているフォロワーとそのユーザーがブロックしたフォロワーを除外します
+	ListFollowerIDs(ctx context.Context, oshiID uuid.UUID, excludeHiddenFrom, after string, limit int) ([]string, error)
+
+	// DeleteAllByUserID はユーザーの全てのフォローを解除し、各推しのフォロワー数を減らします
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
+
+// OshiNewsRepository は推しのお知らせの永続化を担当するインターフェースです
+type OshiNewsRepository interface {
+	// Create はお知らせを保存します
+	Create(ctx context.Context, news *entity.OshiNews) error
+
+	// FindByID は指定されたIDのお知らせを取得します
+	// 見つからない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.OshiNews, error)
+
+	// FindByIDs は指定されたIDのお知らせをまとめて取得します
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.OshiNews, error)
+
+	// ListByOshiID は推しのお知らせを新しい順に取得します
+	ListByOshiID(ctx context.Context, oshiID uuid.UUID, limit, offset int) ([]*entity.OshiNews, error)
+
+	// Delete はお知らせを削除します
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// FeedCursor はホームフィードやコミュニティの一覧のページ位置です
+// 作成日時とIDの組で、一覧の並び順でこの位置より後の項目を取得します
+type FeedCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// FeedRepository はホームフィードの取得を担当するインターフェースです
+type FeedRepository interface {
+	// ListEntries はユーザーがフォローしている推しのフィード項目を新しい順に取得します
+	// cursor が nil の場合は最新の項目から取得します
+	ListEntries(ctx context.Context, userID string, cursor *FeedCursor, limit int) ([]*entity.FeedEntry, error)
+}
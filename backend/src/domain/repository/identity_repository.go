@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+// IdentityRepository は外部IdPアカウントの紐付けの永続化を担当するインターフェースです
+type IdentityRepository interface {
+	// Create は新しい紐付けを作成します
+	Create(ctx context.Context, identity *entity.UserIdentity) error
+
+	// FindByProviderSubject はプロバイダーとサブジェクトで紐付けを取得します
+	// 紐付けが存在しない場合は nil を返します
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+
+	// FindByUserID は指定されたユーザーの紐付け一覧を取得します
+	FindByUserID(ctx context.Context, userID string) ([]*entity.UserIdentity, error)
+
+	// Delete は指定されたユーザーとプロバイダーの紐付けを削除します
+	Delete(ctx context.Context, userID, provider string) error
+}
+
+// OAuthStateRepository は認可リクエストの状態の永続化を担当するインターフェースです
+type OAuthStateRepository interface {
+	// Create は新しい状態を保存します
+	Create(ctx context.Context, state *entity.OAuthState) error
+
+	// Consume は状態を取得して削除します（一度しか使用できません）
+	// 状態が存在しない場合は nil を返します
+	Consume(ctx context.Context, state string) (*entity.OAuthState, error)
+
+	// DeleteExpired は有効期限切れの状態を削除します
+	DeleteExpired(ctx context.Context) error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// JobRepository はバックグラウンドジョブの永続化を担当するインターフェースです
+type JobRepository interface {
+	// Enqueue は新しいジョブを登録します
+	Enqueue(ctx context.Context, job *entity.Job) error
+
+	// ClaimNext は実行可能なジョブを1件取得して実行中にします
+	// 複数のワーカーが同時に呼び出しても同じジョブを取得しません
+	// 実行可能なジョブが無い場合は nil を返します
+	ClaimNext(ctx context.Context, types []string, now time.Time) (*entity.Job, error)
+
+	// MarkDone はジョブを完了にします
+	MarkDone(ctx context.Context, id uuid.UUID) error
+
+	// MarkFailed はジョブの失敗を記録します
+	// retryAt が nil の場合は再試行しません
+	MarkFailed(ctx context.Context, id uuid.UUID, lastError string, retryAt *time.Time) error
+
+	// RequeueStale は一定時間以上実行中のままのジョブを再実行可能にします
+	RequeueStale(ctx context.Context, olderThan time.Time) error
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// LeaderboardRepository は推しごとのファンランキングの集計を担当するインターフェースです
+// ポイントは行動の記録ごとに集計単位へ加算し、ランキングの取得時に全件を再集計しません
+// 退会・ランキング非表示・不正の疑いで指定されたアカウントは取得時に除外します
+type LeaderboardRepository interface {
+	// AddPoints はユーザーの推しに対するポイントを各集計単位に加算します
+	AddPoints(ctx context.Context, userID string, oshiID uuid.UUID, points float64, buckets []entity.LeaderboardBucket) error
+
+	// ListTop は集計単位のランキングを上位から取得します
+	// viewerID を指定した場合は閲覧者がブロック・ミュートしたファンと閲覧者をブロックしたファンを除外します
+	ListTop(ctx context.Context, oshiID uuid.UUID, bucket entity.LeaderboardBucket, viewerID string, limit, offset int) ([]*entity.LeaderboardEntry, error)
+
+	// FindPosition はユーザーのポイントと、除外されていないファンのうちユーザーより上位の人数と総数を取得します
+	FindPosition(ctx context.Context, userID string, oshiID uuid.UUID, bucket entity.LeaderboardBucket) (points float64, ahead int, total int, err error)
+}
+
+// AccountFlagRepository は不正の疑いで指定されたアカウントの永続化を担当するインターフェースです
+type AccountFlagRepository interface {
+	// Save は指定を作成または更新します
+	Save(ctx context.Context, flag *entity.AccountFlag) error
+
+	// Delete は指定を解除します
+	Delete(ctx context.Context, userID string) error
+
+	// IsFlagged はアカウントが指定されているかどうかを確認します
+	IsFlagged(ctx context.Context, userID string) (bool, error)
+
+	// List は指定されたアカウントの一覧を新しい順に取得します
+	List(ctx context.Context, limit, offset int) ([]*entity.AccountFlag, error)
+}
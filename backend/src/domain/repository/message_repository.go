@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// InboxRole は受信箱をファンとキャストのどちらの立場で表示するかを表す型です
+type InboxRole string
+
+const (
+	// InboxRoleFan は閲覧者がファンとして始めたやり取りです
+	InboxRoleFan InboxRole = "fan"
+	// InboxRoleCreator は閲覧者がキャストとして受けたやり取りです
+	InboxRoleCreator InboxRole = "creator"
+)
+
+// InboxFilter は受信箱の一覧取得の条件です
+// 閲覧者がブロック・ミュートした相手と閲覧者をブロックした相手とのやり取りは常に除外します
+type InboxFilter struct {
+	ViewerID      string
+	Role          InboxRole
+	UnreadOnly    bool            // 閲覧者の未読のメッセージがあるやり取りのみ
+	AwaitingReply bool            // 最後のメッセージが相手からのやり取りのみ
+	FanPlan       entity.PlanType // ファンのプランで絞り込む場合に指定します
+}
+
+// ConversationRepository はファンとキャストのやり取りの永続化を担当するインターフェースです
+type ConversationRepository interface {
+	// FindOrCreate は同じファンとキャストのやり取りがあればそれを返し、無ければ保存して返します
+	FindOrCreate(ctx context.Context, conversation *entity.Conversation) (*entity.Conversation, error)
+
+	// FindByID は指定されたIDのやり取りを取得します
+	// 見つからない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Conversation, error)
+
+	// ListInbox は受信箱のやり取りを最後のメッセージが新しい順に取得します
+	// cursor が nil の場合は最新のやり取りから取得します
+	ListInbox(ctx context.Context, filter InboxFilter, cursor *FeedCursor, limit int) ([]*entity.Conversation, error)
+
+	// CountUnread はやり取りごとの閲覧者の未読のメッセージの件数を取得します
+	CountUnread(ctx context.Context, viewerID string, conversationIDs []uuid.UUID) (map[uuid.UUID]int, error)
+
+	// MarkRead は参加者がやり取りを readAt の時点まで既読にしたことを記録します
+	MarkRead(ctx context.Context, conversationID uuid.UUID, userID string, readAt time.Time) error
+
+	// ListByUserID はユーザーが参加しているやり取りを取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.Conversation, error)
+}
+
+// DirectMessageRepository はやり取りの中のメッセージの永続化を担当するインターフェースです
+type DirectMessageRepository interface {
+	// Create はキャストのメッセージを保存し、やり取りの最後のメッセージを更新します
+	Create(ctx context.Context, conversation *entity.Conversation, message *entity.DirectMessage) error
+
+	// CreateChargedByFan はファンのメッセージを保存し、送信枠を消費します
+	// periodStart 以降にプランの枠で送った件数が monthlyLimit 未満ならプランの枠を、そうでなければチケットを1枚消費します
+	// 同じファンの同時送信で枠を超えないよう、ファンごとに排他して件数を数えます
+	// どちらの枠も無い場合は保存せず false を返します
+	CreateChargedByFan(ctx context.Context, conversation *entity.Conversation, message *entity.DirectMessage, monthlyLimit int, periodStart time.Time) (bool, error)
+
+	// ListByConversationID はやり取りのメッセージを新しい順に取得します
+	// cursor が nil の場合は最新のメッセージから取得します
+	ListByConversationID(ctx context.Context, conversationID uuid.UUID, cursor *FeedCursor, limit int) ([]*entity.DirectMessage, error)
+
+	// ListSince はユーザーが参加している全てのやり取りの cursor より後のメッセージを古い順に取得します
+	ListSince(ctx context.Context, userID string, cursor FeedCursor, limit int) ([]*entity.DirectMessage, error)
+
+	// CountPlanChargedSince は periodStart 以降にファンがプランの枠で送ったメッセージの件数を取得します
+	CountPlanChargedSince(ctx context.Context, fanID string, periodStart time.Time) (int, error)
+
+	// ListBySenderID はユーザーが送った削除されていないメッセージを新しい順に取得します
+	ListBySenderID(ctx context.Context, senderID string) ([]*entity.DirectMessage, error)
+
+	// SoftDeleteAllBySenderID はユーザーが送った全てのメッセージの本文と添付を消して削除済みにします
+	SoftDeleteAllBySenderID(ctx context.Context, senderID string) error
+}
+
+// MessageTicketRepository はメッセージチケットの残り枚数の永続化を担当するインターフェースです
+type MessageTicketRepository interface {
+	// Balance はユーザーのチケットの残り枚数を取得します
+	Balance(ctx context.Context, userID string) (int, error)
+
+	// Add はユーザーにチケットを追加し、追加後の残り枚数を返します
+	Add(ctx context.Context, userID string, quantity int) (int, error)
+
+	// DeleteByUserID はユーザーのチケットを削除します
+	DeleteByUserID(ctx context.Context, userID string) error
+}
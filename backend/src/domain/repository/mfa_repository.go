@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+// MFARepository は二要素認証設定の永続化を担当するインターフェースです
+type MFARepository interface {
+	// FindByUserID は指定されたユーザーの二要素認証設定を取得します
+	// 設定が存在しない場合は nil を返します
+	FindByUserID(ctx context.Context, userID string) (*entity.UserMFA, error)
+
+	// Save は二要素認証設定を作成または更新します
+	Save(ctx context.Context, mfa *entity.UserMFA) error
+
+	// UseStep は使用済みのタイムステップを記録し、失敗回数をリセットします
+	// 同じコードの再利用を防ぐため、記録済みのステップ以前の場合は記録せずに false を返します
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+
+	// RecordFailedAttempt は検証の失敗を記録します
+	// 失敗回数が maxAttempts に達した場合は lockUntil まで検証をロックし、失敗回数をリセットします
+	RecordFailedAttempt(ctx context.Context, userID string, maxAttempts int, lockUntil time.Time) error
+
+	// ResetFailedAttempts は検証の失敗回数をリセットします
+	ResetFailedAttempts(ctx context.Context, userID string) error
+
+	// Delete は二要素認証設定とリカバリーコードを削除します
+	Delete(ctx context.Context, userID string) error
+
+	// ReplaceRecoveryCodes はユーザーのリカバリーコードを置き換えます
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*entity.MFARecoveryCode) error
+
+	// UseRecoveryCode は未使用のリカバリーコードを使用済みにします
+	// 該当するコードが存在しない場合は false を返します
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+
+	// CountUnusedRecoveryCodes は未使用のリカバリーコード数を取得します
+	CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error)
+}
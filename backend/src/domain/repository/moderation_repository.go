@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ModerationQueue は審査キューの種類を表す型です
+type ModerationQueue string
+
+const (
+	// ModerationQueuePending は審査待ちのコンテンツです
+	ModerationQueuePending ModerationQueue = "pending"
+	// ModerationQueueReported は未処理の通報があるコンテンツです（通報の多い順）
+	ModerationQueueReported ModerationQueue = "reported"
+)
+
+// ContentModerationRepository はコンテンツの通報と審査の永続化を担当するインターフェースです
+type ContentModerationRepository interface {
+	// CreateReport は通報を保存し、コンテンツの未処理の通報の件数を増やします
+	// 同じユーザーが同じコンテンツを既に通報している場合は保存せず false を返します
+	// 戻り値の件数は保存後の未処理の通報の件数です
+	CreateReport(ctx context.Context, report *entity.ContentReport) (created bool, reportCount int, err error)
+
+	// ListReports はコンテンツへの通報を新しい順に取得します
+	ListReports(ctx context.Context, contentID uuid.UUID) ([]*entity.ContentReport, error)
+
+	// ListReportsByReporterID はユーザーが行った通報を新しい順に取得します
+	ListReportsByReporterID(ctx context.Context, reporterID string) ([]*entity.ContentReport, error)
+
+	// ClearReportDetailsByReporterID はユーザーが行った通報の詳細を消去します
+	// 審査の記録を保つため、通報そのものは残します
+	ClearReportDetailsByReporterID(ctx context.Context, reporterID string) error
+
+	// SaveDecision はコンテンツの審査状態と非表示の状態、審査の履歴を1つのトランザクションで保存します
+	// reportStatus が空でない場合は未処理の通報をその状態にして、未処理の通報の件数を0にします
+	SaveDecision(ctx context.Context, content *entity.Content, event *entity.ModerationEvent, reportStatus entity.ReportStatus) error
+
+	// ListEvents はコンテンツの審査の履歴を古い順に取得します
+	ListEvents(ctx context.Context, contentID uuid.UUID) ([]*entity.ModerationEvent, error)
+
+	// ListQueue は審査キューのコンテンツを取得します
+	ListQueue(ctx context.Context, queue ModerationQueue, limit, offset int) ([]*entity.Content, error)
+}
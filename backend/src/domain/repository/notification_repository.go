@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// DeviceTokenRepository はプッシュ通知の送信先の端末の永続化を担当するインターフェースです
+type DeviceTokenRepository interface {
+	// Save は端末を保存します
+	// 同じトークンが登録済みの場合は、ユーザーと端末の種類と最終利用日時を更新して登録済みの端末を返します
+	Save(ctx context.Context, device *entity.DeviceToken) (*entity.DeviceToken, error)
+
+	// ListByUserID はユーザーの端末を登録が新しい順に取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.DeviceToken, error)
+
+	// Delete はユーザーの端末を削除します
+	// 削除した場合は true を返します
+	Delete(ctx context.Context, userID string, id uuid.UUID) (bool, error)
+
+	// DeleteByIDs は無効になった端末をまとめて削除します
+	DeleteByIDs(ctx context.Context, ids []uuid.UUID) error
+
+	// DeleteAllByUserID はユーザーの全ての端末を削除します
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
+
+// NotificationSettingsRepository は通知設定の永続化を担当するインターフェースです
+type NotificationSettingsRepository interface {
+	// FindByUserID はユーザーの通知設定を取得します
+	// 保存されていない場合は nil を返します
+	FindByUserID(ctx context.Context, userID string) (*entity.NotificationSettings, error)
+
+	// Save は通知設定を保存します
+	Save(ctx context.Context, settings *entity.NotificationSettings) error
+
+	// DeleteByUserID はユーザーの通知設定を削除します
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+// PushNotificationRepository はプッシュ通知と送信記録の永続化を担当するインターフェースです
+type PushNotificationRepository interface {
+	// Create はプッシュ通知を保存します
+	Create(ctx context.Context, notification *entity.PushNotification) error
+
+	// ClaimDue は送信時刻が dueBy 以前のユーザーの送信待ちの通知を送信処理中にして取得します
+	// staleBefore より前に送信処理中になったまま残っている通知も取得し直します
+	ClaimDue(ctx context.Context, userID string, dueBy, staleBefore time.Time) ([]*entity.PushNotification, error)
+
+	// Complete は送信処理中の通知の送信結果を記録します
+	Complete(ctx context.Context, ids []uuid.UUID, status entity.PushStatus, at time.Time) error
+
+	// Release は送信処理中の通知を deliverAfter 以降に送る送信待ちに戻します
+	Release(ctx context.Context, ids []uuid.UUID, deliverAfter time.Time) error
+
+	// CreateDeliveries は端末ごとの送信記録を保存します
+	CreateDeliveries(ctx context.Context, deliveries []*entity.PushDelivery) error
+
+	// ListDeliveriesByUserID はユーザーの送信記録を新しい順に取得します
+	ListDeliveriesByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.PushDelivery, error)
+
+	// ListByUserID はユーザーのプッシュ通知を作成が古い順に取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.PushNotification, error)
+
+	// DeleteAllByUserID はユーザーのプッシュ通知と送信記録を削除します
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
+
+// NotificationRepository は通知の受信箱のお知らせの永続化を担当するインターフェースです
+type NotificationRepository interface {
+	// Create はお知らせを保存します
+	Create(ctx context.Context, notification *entity.Notification) error
+
+	// ListByUserID はユーザーのお知らせを新しい順に取得します
+	// cursor が nil の場合は最新のお知らせから取得し、unreadOnly が true の場合は未読のお知らせのみを取得します
+	ListByUserID(ctx context.Context, userID string, unreadOnly bool, cursor *FeedCursor, limit int) ([]*entity.Notification, error)
+
+	// CountUnread はユーザーの未読のお知らせの件数を取得します
+	CountUnread(ctx context.Context, userID string) (int, error)
+
+	// MarkRead はユーザーのお知らせを既読にします
+	// お知らせが見つからない場合は false を返します
+	MarkRead(ctx context.Context, userID string, id uuid.UUID, readAt time.Time) (bool, error)
+
+	// MarkAllRead はユーザーの全ての未読のお知らせを既読にします
+	MarkAllRead(ctx context.Context, userID string, readAt time.Time) error
+
+	// ListAllByUserID はユーザーの全てのお知らせを新しい順に取得します
+	ListAllByUserID(ctx context.Context, userID string) ([]*entity.Notification, error)
+
+	// DeleteAllByUserID はユーザーの全てのお知らせを削除します
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
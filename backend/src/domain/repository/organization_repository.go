@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationRepository は組織とメンバーの永続化を担当するインターフェースです
+type OrganizationRepository interface {
+	// Create は組織を作成し、作成者を所有者として登録します
+	Create(ctx context.Context, org *entity.Organization, owner *entity.OrganizationMember) error
+
+	// FindByID は指定されたIDの組織を取得します
+	// 見つからない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
+
+	// ListByUserID は指定されたユーザーが所属する組織の一覧を取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.OrganizationMembership, error)
+
+	// Update は組織を更新します
+	Update(ctx context.Context, org *entity.Organization) error
+
+	// Delete は組織とメンバー、招待を削除します
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// AddMember はメンバーを追加します。既に所属している場合は役割を更新します
+	AddMember(ctx context.Context, member *entity.OrganizationMember) error
+
+	// FindMember は組織のメンバーを取得します
+	// 所属していない場合は nil を返します
+	FindMember(ctx context.Context, orgID uuid.UUID, userID string) (*entity.OrganizationMember, error)
+
+	// ListMembers は組織のメンバー一覧を取得します
+	ListMembers(ctx context.Context, orgID uuid.UUID) ([]*entity.OrganizationMember, error)
+
+	// UpdateMemberRole はメンバーの役割を変更します
+	UpdateMemberRole(ctx context.Context, orgID uuid.UUID, userID string, role entity.OrganizationRole) error
+
+	// RemoveMember はメンバーを組織から外します
+	RemoveMember(ctx context.Context, orgID uuid.UUID, userID string) error
+
+	// IsManagerOf は managerID のユーザーが、memberID のユーザーが所属する組織の所有者またはマネージャーかどうかを確認します
+	IsManagerOf(ctx context.Context, managerID, memberID string) (bool, error)
+}
+
+// OrganizationInvitationRepository は組織への招待の永続化を担当するインターフェースです
+type OrganizationInvitationRepository interface {
+	// Create は招待を保存します
+	Create(ctx context.Context, invitation *entity.OrganizationInvitation) error
+
+	// FindByID は指定されたIDの招待を取得します
+	// 見つからない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.OrganizationInvitation, error)
+
+	// FindPending は組織からユーザーへの回答待ちの招待を取得します
+	// 見つからない場合は nil を返します
+	FindPending(ctx context.Context, orgID uuid.UUID, userID string) (*entity.OrganizationInvitation, error)
+
+	// ListPendingByUserID はユーザー宛ての回答待ちの招待一覧を取得します
+	ListPendingByUserID(ctx context.Context, userID string) ([]*entity.OrganizationInvitation, error)
+
+	// Update は招待を更新します
+	Update(ctx context.Context, invitation *entity.OrganizationInvitation) error
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// OshiFilter は推しの一覧取得の条件です
+type OshiFilter struct {
+	Query     string // 名前・グループ名・事務所名の部分一致
+	GroupName string
+	Tag       string
+	Limit     int
+	Offset    int
+}
+
+// OshiRepository は推しの永続化を担当するインターフェースです
+type OshiRepository interface {
+	// Create は新しい推しを保存します
+	Create(ctx context.Context, oshi *entity.Oshi) error
+
+	// FindByID は指定されたIDの推しを取得します
+	// 見つからない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Oshi, error)
+
+	// FindByIDs は指定されたIDの推しをまとめて取得します
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Oshi, error)
+
+	// List は条件に一致する推しの一覧と総件数を取得します
+	List(ctx context.Context, filter OshiFilter) ([]*entity.Oshi, int, error)
+
+	// Update は推しを更新します
+	Update(ctx context.Context, oshi *entity.Oshi) error
+
+	// Delete は推しを削除します
+	Delete(ctx context.Context, id uuid.UUID) error
+}
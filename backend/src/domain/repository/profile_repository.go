@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+// ProfileRepository はユーザープロフィールの永続化を担当するインターフェースです
+type ProfileRepository interface {
+	// FindByUserID は指定されたユーザーのプロフィールを取得します
+	// プロフィールが存在しない場合は nil を返します
+	FindByUserID(ctx context.Context, userID string) (*entity.UserProfile, error)
+
+	// Save はプロフィールを作成または更新します
+	Save(ctx context.Context, profile *entity.UserProfile) error
+}
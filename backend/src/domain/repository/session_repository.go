@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// SessionRepository はデバイスセッションの永続化を担当するインターフェースです
+type SessionRepository interface {
+	// Create は新しいセッションを作成します
+	Create(ctx context.Context, session *entity.DeviceSession) error
+
+	// FindByID は指定されたIDのセッションを取得します
+	// セッションが存在しない場合は nil を返します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.DeviceSession, error)
+
+	// FindByRefreshTokenHash はリフレッシュトークンのハッシュでセッションを取得します
+	// セッションが存在しない場合は nil を返します
+	FindByRefreshTokenHash(ctx context.Context, hash string) (*entity.DeviceSession, error)
+
+	// FindByConsumedRefreshTokenHash はローテーションで使用済みになったリフレッシュトークンのハッシュでセッションを取得します
+	// セッションが存在しない場合は nil を返します
+	FindByConsumedRefreshTokenHash(ctx context.Context, hash string) (*entity.DeviceSession, error)
+
+	// FindActiveByUserID は指定されたユーザーの有効なセッション一覧を取得します
+	FindActiveByUserID(ctx context.Context, userID string) ([]*entity.DeviceSession, error)
+
+	// HasKnownDevice は端末IDまたはユーザーエージェントが一致する過去のセッションがあるかどうかを確認します
+	HasKnownDevice(ctx context.Context, userID, deviceID, userAgent string) (bool, error)
+
+	// Update は既存のセッションを更新します
+	Update(ctx context.Context, session *entity.DeviceSession) error
+
+	// Rotate はセッションのリフレッシュトークンを差し替え、差し替え前のトークンを使用済みとして記録します
+	// 差し替え前のトークンが既に他のリクエストで使用された場合は更新せず false を返します
+	Rotate(ctx context.Context, session *entity.DeviceSession, previousHash string) (bool, error)
+
+	// Revoke は指定されたユーザーのセッションを失効させます
+	Revoke(ctx context.Context, userID string, id uuid.UUID) error
+
+	// RevokeAllByUserID は指定されたユーザーのすべてのセッションを失効させます
+	RevokeAllByUserID(ctx context.Context, userID string) error
+}
+
+// SecurityEventRepository はセキュリティイベントの永続化を担当するインターフェースです
+type SecurityEventRepository interface {
+	// Create は新しいセキュリティイベントを記録します
+	Create(ctx context.Context, event *entity.SecurityEvent) error
+
+	// FindByUserID は指定されたユーザーのセキュリティイベントを新しい順に取得します
+	FindByUserID(ctx context.Context, userID string, limit int) ([]*entity.SecurityEvent, error)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+// ShareLinkRepository は共有リンクの永続化を担当するインターフェースです
+type ShareLinkRepository interface {
+	// Create は新しい共有リンクを保存します
+	Create(ctx context.Context, link *entity.ShareLink) error
+
+	// FindBySlug は指定されたスラッグの共有リンクを取得します
+	// 見つからない場合は nil を返します
+	FindBySlug(ctx context.Context, slug string) (*entity.ShareLink, error)
+
+	// FindVisibleBySlug は閲覧者に表示できる共有リンクを取得します
+	// 作成者を閲覧者がブロック・ミュートしている場合と、作成者が閲覧者をブロックしている場合は nil を返します
+	// viewerID が空の場合は FindBySlug と同じです
+	FindVisibleBySlug(ctx context.Context, slug, viewerID string) (*entity.ShareLink, error)
+
+	// ListByUserID はユーザーの共有リンクを新しい順に取得します
+	ListByUserID(ctx context.Context, userID string) ([]*entity.ShareLink, error)
+
+	// Update は共有リンクのカード画像と無効化日時を更新します
+	Update(ctx context.Context, link *entity.ShareLink) error
+
+	// DeleteAllByUserID はユーザーの全ての共有リンクを削除します
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
@@ -19,6 +19,9 @@ type SubscriptionRepository interface {
 	// FindByUserID は指定されたユーザーIDのアクティブなサブスクリプションを取得します
 	FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Subscription, error)
 
+	// ListByUserID は指定されたユーザーIDのサブスクリプション履歴を新しい順に取得します
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Subscription, error)
+
 	// Update は既存のサブスクリプションを更新します
 	Update(ctx context.Context, subscription *entity.Subscription) error
 
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+// UserRelationRepository はユーザー間のブロック・ミュートの永続化を担当するインターフェースです
+// 一覧の取得では、閲覧者がブロック・ミュートしたユーザーと閲覧者をブロックしたユーザーを各リポジトリのクエリで除外します
+type UserRelationRepository interface {
+	// Save はブロックまたはミュートを保存します
+	// 同じ相手に既に関係がある場合は種類を置き換えます
+	Save(ctx context.Context, relation *entity.UserRelation) error
+
+	// Delete は指定した種類の関係を削除します
+	// 削除した場合は true を返します
+	Delete(ctx context.Context, userID, targetID string, relationType entity.UserRelationType) (bool, error)
+
+	// ListByUserID はユーザーが設定した指定の種類の関係を新しい順に取得します
+	ListByUserID(ctx context.Context, userID string, relationType entity.UserRelationType) ([]*entity.UserRelation, error)
+
+	// IsHidden は閲覧者に対象のユーザーの投稿などを表示しないかどうかを確認します
+	// 閲覧者が対象をブロック・ミュートしている場合と、対象が閲覧者をブロックしている場合に true を返します
+	IsHidden(ctx context.Context, viewerID, targetID string) (bool, error)
+
+	// IsBlocked は blockerID のユーザーが blockedID のユーザーをブロックしているかどうかを確認します
+	IsBlocked(ctx context.Context, blockerID, blockedID string) (bool, error)
+
+	// HasAny はユーザーに一覧から除外するユーザーが1人でもいるかどうかを確認します
+	HasAny(ctx context.Context, viewerID string) (bool, error)
+
+	// DeleteAllByUserID はユーザーが設定した関係と、ユーザーに設定された関係を全て削除します
+	DeleteAllByUserID(ctx context.Context, userID string) error
+}
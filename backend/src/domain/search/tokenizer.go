@@ -0,0 +1,107 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize は検索の照合に使う形式に文字列を変換します
+// 全角英数字と半角カナを NFKC で揃え、英字を小文字に、カタカナをひらがなにし、空白を1つにまとめます
+func Normalize(s string) string {
+	s = strings.ToLower(norm.NFKC.String(s))
+
+	var b strings.Builder
+	b.Grow(len(s))
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = b.Len() > 0
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(toHiragana(r))
+	}
+
+	return b.String()
+}
+
+// Terms は検索語を正規化して、重複の無い語の一覧に分割します
+// 語は空白と記号で区切ります
+func Terms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, term := range strings.FieldsFunc(Normalize(query), isSeparator) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// DocumentTokens は検索対象のテキストの重複の無いトークンの一覧を返します
+// 日本語は単語の区切りが無いため、形態素解析の代わりに隣り合う2文字（バイグラム）をトークンとし、
+// データベースの拡張機能（pg_bigm や pg_trgm）に頼らずに配列の GIN インデックスで絞り込めるようにします
+// 1文字の検索語にも一致するよう、バイグラムに加えて1文字のトークンも含めます
+func DocumentTokens(text string) []string {
+	seen := make(map[string]bool)
+	tokens := []string{}
+	add := func(token string) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	for _, term := range strings.FieldsFunc(Normalize(text), isSeparator) {
+		runes := []rune(term)
+		for i := range runes {
+			add(string(runes[i]))
+			if i+1 < len(runes) {
+				add(string(runes[i : i+2]))
+			}
+		}
+	}
+
+	return tokens
+}
+
+// QueryTokens は検索語に一致する文書が必ず含むトークンの一覧を返します
+// 1文字の語は1文字のトークン、2文字以上の語はバイグラムにします
+func QueryTokens(terms []string) []string {
+	seen := make(map[string]bool)
+	tokens := []string{}
+	for _, term := range terms {
+		runes := []rune(term)
+		if len(runes) == 1 && !seen[term] {
+			seen[term] = true
+			tokens = append(tokens, term)
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			token := string(runes[i : i+2])
+			if !seen[token] {
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+// isSeparator は語の区切りとして扱う文字かどうかを確認します
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != 'ー'
+}
+
+// toHiragana はカタカナをひらがなに変換します
+func toHiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - ('ァ' - 'ぁ')
+	}
+	return r
+}
@@ -22,15 +22,18 @@ func NewJWTTokenService(config *auth.JWTConfig) auth.TokenService {
 }
 
 // GenerateToken は新しいJWTトークンを生成します
-func (s *JWTTokenService) GenerateToken(userID string, role string) (string, error) {
+func (s *JWTTokenService) GenerateToken(userID string, role string, roleVersion int, sessionID string) (string, error) {
 	claims := &auth.Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.config.TokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
-		UserID: userID,
-		Role:   role,
+		UserID:      userID,
+		Role:        role,
+		RoleVersion: roleVersion,
+		Permissions: auth.PermissionStrings(role),
+		SessionID:   sessionID,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -39,6 +42,18 @@ func (s *JWTTokenService) GenerateToken(userID string, role string) (string, err
 
 // ValidateToken はトークンを検証し、含まれる情報を返します
 func (s *JWTTokenService) ValidateToken(tokenString string) (*auth.Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, auth.ErrInvalidTokenPurpose
+	}
+	return claims, nil
+}
+
+// parse はトークンの署名と有効期限を検証し、クレーム情報を返します
+func (s *JWTTokenService) parse(tokenString string) (*auth.Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &auth.Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -64,5 +79,35 @@ func (s *JWTTokenService) RefreshToken(tokenString string) (string, error) {
 		return "", err
 	}
 
-	return s.GenerateToken(claims.UserID, claims.Role)
+	return s.GenerateToken(claims.UserID, claims.Role, claims.RoleVersion, claims.SessionID)
+}
+
+// GenerateMFAToken は二要素認証フロー用の短命なトークンを生成します
+func (s *JWTTokenService) GenerateMFAToken(userID string, role string, roleVersion int, purpose string) (string, error) {
+	claims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(auth.MFATokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+		UserID:      userID,
+		Role:        role,
+		RoleVersion: roleVersion,
+		Purpose:     purpose,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.config.SecretKey))
+}
+
+// ValidateMFAToken は二要素認証フロー用のトークンを検証します
+func (s *JWTTokenService) ValidateMFAToken(tokenString string, purpose string) (*auth.Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if purpose == "" || claims.Purpose != purpose {
+		return nil, auth.ErrInvalidTokenPurpose
+	}
+	return claims, nil
 }
@@ -24,14 +24,17 @@ func NewTokenService(secretKey string, tokenExpiryHours int, refreshTokenExpiryH
 }
 
 // GenerateToken はJWTトークンを生成します
-func (s *TokenService) GenerateToken(userID string, role string) (string, error) {
+func (s *TokenService) GenerateToken(userID string, role string, roleVersion int, sessionID string) (string, error) {
 	claims := &domainAuth.Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.tokenExpiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
-		UserID: userID,
-		Role:   role,
+		UserID:      userID,
+		Role:        role,
+		RoleVersion: roleVersion,
+		Permissions: domainAuth.PermissionStrings(role),
+		SessionID:   sessionID,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -40,6 +43,18 @@ func (s *TokenService) GenerateToken(userID string, role string) (string, error)
 
 // ValidateToken はJWTトークンを検証します
 func (s *TokenService) ValidateToken(tokenString string) (*domainAuth.Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, domainAuth.ErrInvalidTokenPurpose
+	}
+	return claims, nil
+}
+
+// parse はトークンの署名と有効期限を検証し、クレーム情報を返します
+func (s *TokenService) parse(tokenString string) (*domainAuth.Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &domainAuth.Claims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.secretKey), nil
 	})
@@ -62,5 +77,34 @@ func (s *TokenService) RefreshToken(tokenString string) (string, error) {
 		return "", err
 	}
 
-	return s.GenerateToken(claims.UserID, claims.Role)
+	return s.GenerateToken(claims.UserID, claims.Role, claims.RoleVersion, claims.SessionID)
+}
+
+// GenerateMFAToken は二要素認証フロー用の短命なトークンを生成します
+func (s *TokenService) GenerateMFAToken(userID string, role string, roleVersion int, purpose string) (string, error) {
+	claims := &domainAuth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(domainAuth.MFATokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+		UserID:      userID,
+		Role:        role,
+		RoleVersion: roleVersion,
+		Purpose:     purpose,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.secretKey))
+}
+
+// ValidateMFAToken は二要素認証フロー用のトークンを検証します
+func (s *TokenService) ValidateMFAToken(tokenString string, purpose string) (*domainAuth.Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if purpose == "" || claims.Purpose != purpose {
+		return nil, domainAuth.ErrInvalidTokenPurpose
+	}
+	return claims, nil
 }
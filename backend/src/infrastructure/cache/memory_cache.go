@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryCache はプロセス内に値を一定時間保持するキャッシュです
+// 複数のサーバーで共有されないため、多少古い値を返しても問題ない用途に使います
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// NewMemoryCache は新しいMemoryCacheを作成します
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryCacheEntry),
+	}
+}
+
+// Get はキーに対応する有効期限内の値を返します
+func (c *MemoryCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set はキーに値を ttl の間保持します
+// 期限切れの値は書き込みのたびに削除します
+func (c *MemoryCache) Set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = memoryCacheEntry{value: value, expiresAt: now.Add(ttl)}
+}
@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"kimiyomi/backend/src/usecase"
+)
+
+// FileSender はメールを Maildir 形式のディレクトリに .eml ファイルとして書き出すEmailSenderの実装です
+// SMTPサーバーが無いローカル環境で使い、書き出したメールはメールクライアントで開いて確認できます
+type FileSender struct {
+	dir    string
+	logger *log.Logger
+}
+
+// NewFileSender は新しいFileSenderを作成し、dir の下に Maildir の tmp、new、cur ディレクトリを作ります
+// logger が nil の場合はログに出力しません
+func NewFileSender(dir string, logger *log.Logger) (*FileSender, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create mail directory: %w", err)
+		}
+	}
+	return &FileSender{dir: dir, logger: logger}, nil
+}
+
+// Send はメールを tmp に書き出してから new に移動し、発行したメッセージIDを返します
+func (s *FileSender) Send(ctx context.Context, message *usecase.OutgoingEmail) (string, error) {
+	data, messageID, err := buildMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%d.%s.eml", time.Now().UnixNano(), strings.SplitN(messageID, "@", 2)[0])
+	tmpPath := filepath.Join(s.dir, "tmp", name)
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(s.dir, "new", name)); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write email: %w", err)
+	}
+
+	if s.logger != nil {
+		s.logger.Printf("email to %s written to %s: %s", message.To, filepath.Join(s.dir, "new", name), message.Subject)
+	}
+
+	return messageID, nil
+}
@@ -0,0 +1,88 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+
+	"kimiyomi/backend/src/usecase"
+
+	"github.com/google/uuid"
+)
+
+// buildMessage はメールをHTMLとテキストの multipart/alternative 形式のMIMEメッセージに変換します
+// 送信サーバーがメッセージIDを返さない場合でもバウンスと照合できるよう、Message-ID はここで発行して返します
+func buildMessage(message *usecase.OutgoingEmail) ([]byte, string, error) {
+	from, err := mail.ParseAddress(message.From)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid from address: %w", err)
+	}
+	domain := "localhost"
+	if at := strings.LastIndex(from.Address, "@"); at >= 0 {
+		domain = from.Address[at+1:]
+	}
+	messageID := uuid.New().String() + "@" + domain
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	var buf bytes.Buffer
+	headers := map[string]string{
+		"From":         from.String(),
+		"To":           message.To,
+		"Subject":      mime.BEncoding.Encode("UTF-8", message.Subject),
+		"Date":         time.Now().Format(time.RFC1123Z),
+		"Message-ID":   "<" + messageID + ">",
+		"MIME-Version": "1.0",
+		"Content-Type": `multipart/alternative; boundary="` + writer.Boundary() + `"`,
+	}
+	for k, v := range message.Headers {
+		headers[textproto.CanonicalMIMEHeaderKey(k)] = v
+	}
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "%s: %s\r\n", k, strings.NewReplacer("\r", "", "\n", "").Replace(headers[k]))
+	}
+	buf.WriteString("\r\n")
+
+	if err := writePart(writer, "text/plain; charset=UTF-8", message.Text); err != nil {
+		return nil, "", err
+	}
+	if err := writePart(writer, "text/html; charset=UTF-8", message.HTML); err != nil {
+		return nil, "", err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to build email: %w", err)
+	}
+	buf.Write(body.Bytes())
+
+	return buf.Bytes(), messageID, nil
+}
+
+// writePart はMIMEメッセージに quoted-printable で符号化した本文を追加します
+func writePart(writer *multipart.Writer, contentType, content string) error {
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(content, "\n", "\r\n"))); err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+	return qp.Close()
+}
@@ -0,0 +1,117 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+
+	"kimiyomi/backend/src/usecase"
+)
+
+// SMTPSender はSMTPサーバーにメールを送信するEmailSenderの実装です
+// サーバーが対応している場合は STARTTLS で暗号化し、ユーザー名が指定されている場合は PLAIN 認証を行います
+type SMTPSender struct {
+	host     string
+	port     string
+	username string
+	password string
+}
+
+// NewSMTPSender は新しいSMTPSenderを作成します
+func NewSMTPSender(host, port, username, password string) *SMTPSender {
+	if port == "" {
+		port = "587"
+	}
+	return &SMTPSender{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+	}
+}
+
+// Send はメールをSMTPサーバーに送信し、発行したメッセージIDを返します
+// 宛先やメッセージが5xxの応答で拒否された場合は usecase.ErrEmailRejected をラップしたエラーを返します
+func (s *SMTPSender) Send(ctx context.Context, message *usecase.OutgoingEmail) (string, error) {
+	data, messageID, err := buildMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.host, s.port))
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return "", fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return "", fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return "", fmt.Errorf("failed to authenticate to smtp server: %w", err)
+		}
+	}
+
+	from, err := envelopeAddress(message.From)
+	if err != nil {
+		return "", err
+	}
+	if err := client.Mail(from); err != nil {
+		return "", fmt.Errorf("failed to set smtp sender: %w", err)
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return "", rejection("failed to set smtp recipient", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return "", rejection("failed to start smtp data", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", fmt.Errorf("failed to write smtp data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", rejection("failed to send smtp data", err)
+	}
+
+	// メッセージは受け付けられているため、切断の失敗は無視する
+	_ = client.Quit()
+
+	return messageID, nil
+}
+
+// rejection はSMTPサーバーの恒久的なエラー（5xx）を usecase.ErrEmailRejected としてラップします
+func rejection(msg string, err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 && protoErr.Code < 600 {
+		return fmt.Errorf("%s: %w: %v", msg, usecase.ErrEmailRejected, err)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// envelopeAddress は表示名付きのアドレスからエンベロープに使うメールアドレスを取り出します
+func envelopeAddress(address string) (string, error) {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid from address: %w", err)
+	}
+	return parsed.Address, nil
+}
@@ -0,0 +1,231 @@
+package oauthtest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const stubKeyID = "stub-key"
+
+// User はスタブIdPでログインするユーザーと、IDトークンに含めるクレームです
+type User struct {
+	Subject string
+	Email   string
+	// EmailVerified は email_verified クレームの値です（nil の場合はクレームを含めません）
+	EmailVerified interface{}
+	Name          string
+	// Nonce を指定した場合は認可リクエストの nonce の代わりにIDトークンに含めます
+	Nonce string
+}
+
+// authorization は発行済みの認可コードに紐付く認可リクエストです
+type authorization struct {
+	challenge string
+	nonce     string
+	user      User
+}
+
+// StubIdP はテスト用のローカルなOIDCプロバイダーです
+// トークンエンドポイントではPKCEのコード検証子を検証し、RS256で署名したIDトークンを発行します
+type StubIdP struct {
+	Server       *httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]*authorization
+}
+
+// NewStubIdP は新しいStubIdPを起動します。テストの終了時に停止します
+func NewStubIdP(t testing.TB, clientID, clientSecret string) *StubIdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	s := &StubIdP{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		codes:        map[string]*authorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", s.handleToken)
+	mux.HandleFunc("/jwks", s.handleJWKS)
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Server.Close)
+
+	return s
+}
+
+// Issuer はIDトークンの発行者を返します
+func (s *StubIdP) Issuer() string {
+	return s.Server.URL
+}
+
+// AuthURL は認可エンドポイントのURLを返します
+func (s *StubIdP) AuthURL() string {
+	return s.Server.URL + "/authorize"
+}
+
+// TokenURL はトークンエンドポイントのURLを返します
+func (s *StubIdP) TokenURL() string {
+	return s.Server.URL + "/token"
+}
+
+// JWKSURL は公開鍵セットのURLを返します
+func (s *StubIdP) JWKSURL() string {
+	return s.Server.URL + "/jwks"
+}
+
+// Authorize は認可エンドポイントでユーザーがログインして同意した場合の処理を行い、認可コードと state を返します
+// authCodeURL には AuthCodeURL が生成したURLを指定します
+func (s *StubIdP) Authorize(authCodeURL string, user User) (code, state string, err error) {
+	u, err := url.Parse(authCodeURL)
+	if err != nil {
+		return "", "", err
+	}
+	q := u.Query()
+	if q.Get("client_id") != s.ClientID {
+		return "", "", fmt.Errorf("unexpected client_id: %s", q.Get("client_id"))
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		return "", "", errors.New("PKCE S256 code challenge is required")
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	code = base64.RawURLEncoding.EncodeToString(buf)
+
+	s.mu.Lock()
+	s.codes[code] = &authorization{
+		challenge: q.Get("code_challenge"),
+		nonce:     q.Get("nonce"),
+		user:      user,
+	}
+	s.mu.Unlock()
+
+	return code, q.Get("state"), nil
+}
+
+// handleToken は認可コードをIDトークンに交換します
+func (s *StubIdP) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, "invalid_request")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeTokenError(w, "unsupported_grant_type")
+		return
+	}
+	if r.PostForm.Get("client_id") != s.ClientID || r.PostForm.Get("client_secret") != s.ClientSecret {
+		writeTokenError(w, "invalid_client")
+		return
+	}
+
+	// 認可コードは一度しか使用できない
+	code := r.PostForm.Get("code")
+	s.mu.Lock()
+	authz, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+	if !ok {
+		writeTokenError(w, "invalid_grant")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != authz.challenge {
+		writeTokenError(w, "invalid_grant")
+		return
+	}
+
+	idToken, err := s.signIDToken(authz)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "stub-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// signIDToken は認可リクエストのユーザーのIDトークンを発行します
+func (s *StubIdP) signIDToken(authz *authorization) (string, error) {
+	now := time.Now()
+	nonce := authz.nonce
+	if authz.user.Nonce != "" {
+		nonce = authz.user.Nonce
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   s.Issuer(),
+		"aud":   s.ClientID,
+		"sub":   authz.user.Subject,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": nonce,
+	}
+	if authz.user.Email != "" {
+		claims["email"] = authz.user.Email
+	}
+	if authz.user.EmailVerified != nil {
+		claims["email_verified"] = authz.user.EmailVerified
+	}
+	if authz.user.Name != "" {
+		claims["name"] = authz.user.Name
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = stubKeyID
+	return token.SignedString(s.key)
+}
+
+// handleJWKS は公開鍵セットを返します
+func (s *StubIdP) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	pub := s.key.PublicKey
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": stubKeyID,
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// writeTokenError はトークンエンドポイントのエラーレスポンスを返します
+func writeTokenError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
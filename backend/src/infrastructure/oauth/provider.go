@@ -0,0 +1,204 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"kimiyomi/backend/src/domain/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// ProviderConfig は外部IdPの設定を表します
+// エンドポイントはすべて設定可能なため、ローカルのスタブIdPにも向けられます
+type ProviderConfig struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string
+	TokenURL     string
+	Scopes       []string
+
+	// BasicAuth はトークンエンドポイントでBasic認証を使用するかどうかです
+	BasicAuth bool
+
+	// OIDC の場合はIDトークンを検証してユーザー情報を取得します
+	OIDC    bool
+	Issuer  string
+	JWKSURL string
+	// HMACIDToken はIDトークンがクライアントシークレットでHS256署名されるかどうかです
+	HMACIDToken bool
+
+	// UserInfoURL はOIDCでないプロバイダーのユーザー情報エンドポイントです
+	UserInfoURL string
+	// ParseUserInfo はユーザー情報エンドポイントのレスポンスを変換します
+	ParseUserInfo func(body []byte) (*auth.OAuthUserInfo, error)
+}
+
+// Provider は汎用的なOAuth2/OIDCプロバイダーの実装です
+type Provider struct {
+	config     ProviderConfig
+	oauth      *oauth2.Config
+	jwks       *jwksCache
+	httpClient *http.Client
+}
+
+// NewProvider は新しいProviderを作成します
+func NewProvider(config ProviderConfig) auth.OAuthProvider {
+	authStyle := oauth2.AuthStyleInParams
+	if config.BasicAuth {
+		authStyle = oauth2.AuthStyleInHeader
+	}
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+
+	p := &Provider{
+		config: config,
+		oauth: &oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Scopes:       config.Scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:   config.AuthURL,
+				TokenURL:  config.TokenURL,
+				AuthStyle: authStyle,
+			},
+		},
+		httpClient: httpClient,
+	}
+	if config.OIDC {
+		p.jwks = newJWKSCache(config.JWKSURL, httpClient)
+	}
+	return p
+}
+
+// Name はプロバイダー名を返します
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// AuthCodeURL は認可エンドポイントへのURLを生成します
+func (p *Provider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	opts := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(codeVerifier)}
+	if p.config.OIDC {
+		opts = append(opts, oauth2.SetAuthURLParam("nonce", nonce))
+	}
+	return p.oauth.AuthCodeURL(state, opts...)
+}
+
+// Exchange は認可コードをトークンに交換し、ユーザー情報を返します
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*auth.OAuthUserInfo, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+
+	token, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	if p.config.OIDC {
+		rawIDToken, ok := token.Extra("id_token").(string)
+		if !ok || rawIDToken == "" {
+			return nil, errors.New("id_token is missing")
+		}
+		return p.verifyIDToken(ctx, rawIDToken, nonce)
+	}
+
+	return p.fetchUserInfo(ctx, token)
+}
+
+// idTokenClaims はIDトークンのクレームです
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string      `json:"nonce"`
+	Email         string      `json:"email"`
+	EmailVerified interface{} `json:"email_verified"`
+	Name          string      `json:"name"`
+}
+
+// verifyIDToken はIDトークンの署名、発行者、対象者、nonceを検証します
+func (p *Provider) verifyIDToken(ctx context.Context, rawIDToken, nonce string) (*auth.OAuthUserInfo, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			// LINEなど、チャネルシークレットでHS256署名するプロバイダー向け
+			if !p.config.HMACIDToken || p.config.ClientSecret == "" {
+				return nil, errors.New("hmac signed id_token is not allowed")
+			}
+			return []byte(p.config.ClientSecret), nil
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			kid, _ := token.Header["kid"].(string)
+			return p.jwks.key(ctx, kid)
+		default:
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	},
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token: %w", err)
+	}
+
+	if nonce == "" || claims.Nonce != nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+
+	info := &auth.OAuthUserInfo{
+		Subject: claims.Subject,
+		Email:   claims.Email,
+		Name:    claims.Name,
+	}
+	// email_verified が無い場合は未検証として扱う
+	switch v := claims.EmailVerified.(type) {
+	case bool:
+		info.EmailVerified = v
+	case string:
+		// Appleは "true" / "false" の文字列で返す
+		info.EmailVerified = v == "true"
+	}
+
+	if info.Subject == "" {
+		return nil, errors.New("id_token subject is missing")
+	}
+	return info, nil
+}
+
+// fetchUserInfo はユーザー情報エンドポイントからユーザー情報を取得します
+func (p *Provider) fetchUserInfo(ctx context.Context, token *oauth2.Token) (*auth.OAuthUserInfo, error) {
+	if p.config.UserInfoURL == "" || p.config.ParseUserInfo == nil {
+		return nil, errors.New("userinfo endpoint is not configured")
+	}
+
+	res, err := p.oauth.Client(ctx, token).Get(p.config.UserInfoURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch userinfo: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("userinfo endpoint returned status %d", res.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read userinfo: %w", err)
+	}
+
+	info, err := p.config.ParseUserInfo(body)
+	if err != nil {
+		return nil, err
+	}
+	if info.Subject == "" {
+		return nil, errors.New("userinfo subject is missing")
+	}
+	return info, nil
+}
@@ -0,0 +1,143 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/infrastructure/oauth/oauthtest"
+)
+
+const (
+	testVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testNonce    = "test-nonce"
+	testState    = "test-state"
+)
+
+func newStubProvider(t *testing.T) (*oauthtest.StubIdP, auth.OAuthProvider) {
+	t.Helper()
+
+	idp := oauthtest.NewStubIdP(t, "client-id", "client-secret")
+	provider := NewProvider(ProviderConfig{
+		Name:         "stub",
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  "http://localhost/callback",
+		AuthURL:      idp.AuthURL(),
+		TokenURL:     idp.TokenURL(),
+		Scopes:       []string{"openid", "email", "profile"},
+		OIDC:         true,
+		Issuer:       idp.Issuer(),
+		JWKSURL:      idp.JWKSURL(),
+	})
+	return idp, provider
+}
+
+func TestAuthCodeURLSendsS256ChallengeAndNonce(t *testing.T) {
+	_, provider := newStubProvider(t)
+
+	u, err := url.Parse(provider.AuthCodeURL(testState, testNonce, testVerifier))
+	if err != nil {
+		t.Fatalf("failed to parse auth code url: %v", err)
+	}
+	q := u.Query()
+
+	sum := sha256.Sum256([]byte(testVerifier))
+	if want := base64.RawURLEncoding.EncodeToString(sum[:]); q.Get("code_challenge") != want {
+		t.Errorf("code_challenge = %q, want %q", q.Get("code_challenge"), want)
+	}
+	if q.Get("code_challenge_method") != "S256" {
+		t.Errorf("code_challenge_method = %q, want S256", q.Get("code_challenge_method"))
+	}
+	if q.Get("nonce") != testNonce {
+		t.Errorf("nonce = %q, want %q", q.Get("nonce"), testNonce)
+	}
+	if q.Get("state") != testState {
+		t.Errorf("state = %q, want %q", q.Get("state"), testState)
+	}
+	if strings.Contains(u.String(), testVerifier) {
+		t.Error("auth code url must not contain the code verifier")
+	}
+}
+
+func TestExchangeVerifiesPKCE(t *testing.T) {
+	idp, provider := newStubProvider(t)
+	authCodeURL := provider.AuthCodeURL(testState, testNonce, testVerifier)
+
+	code, _, err := idp.Authorize(authCodeURL, oauthtest.User{Subject: "sub-1"})
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+	if _, err := provider.Exchange(context.Background(), code, "wrong-verifier-wrong-verifier-wrong-verifier", testNonce); err == nil {
+		t.Fatal("exchange with a wrong code verifier must fail")
+	}
+
+	code, _, err = idp.Authorize(authCodeURL, oauthtest.User{Subject: "sub-1"})
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+	info, err := provider.Exchange(context.Background(), code, testVerifier, testNonce)
+	if err != nil {
+		t.Fatalf("exchange with the code verifier failed: %v", err)
+	}
+	if info.Subject != "sub-1" {
+		t.Errorf("subject = %q, want sub-1", info.Subject)
+	}
+}
+
+func TestExchangeRejectsNonceMismatch(t *testing.T) {
+	idp, provider := newStubProvider(t)
+
+	code, _, err := idp.Authorize(provider.AuthCodeURL(testState, testNonce, testVerifier), oauthtest.User{
+		Subject: "sub-1",
+		Nonce:   "another-nonce",
+	})
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+
+	if _, err := provider.Exchange(context.Background(), code, testVerifier, testNonce); err == nil || !strings.Contains(err.Error(), "nonce") {
+		t.Fatalf("exchange error = %v, want nonce mismatch", err)
+	}
+}
+
+func TestExchangeEmailVerified(t *testing.T) {
+	tests := []struct {
+		name          string
+		emailVerified interface{}
+		want          bool
+	}{
+		{"bool true", true, true},
+		{"bool false", false, false},
+		{"string true", "true", true},
+		{"string false", "false", false},
+		{"missing", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp, provider := newStubProvider(t)
+
+			code, _, err := idp.Authorize(provider.AuthCodeURL(testState, testNonce, testVerifier), oauthtest.User{
+				Subject:       "sub-1",
+				Email:         "fan@example.com",
+				EmailVerified: tt.emailVerified,
+			})
+			if err != nil {
+				t.Fatalf("failed to authorize: %v", err)
+			}
+
+			info, err := provider.Exchange(context.Background(), code, testVerifier, testNonce)
+			if err != nil {
+				t.Fatalf("exchange failed: %v", err)
+			}
+			if info.EmailVerified != tt.want {
+				t.Errorf("EmailVerified = %v, want %v", info.EmailVerified, tt.want)
+			}
+		})
+	}
+}
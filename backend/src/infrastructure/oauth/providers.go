@@ -0,0 +1,130 @@
+package oauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"kimiyomi/backend/src/domain/auth"
+)
+
+const (
+	ProviderGoogle = "google"
+	ProviderApple  = "apple"
+	ProviderLINE   = "line"
+	ProviderX      = "x"
+)
+
+// GoogleConfig はGoogleのOIDC設定を返します
+func GoogleConfig(clientID, clientSecret, redirectURL string) ProviderConfig {
+	return ProviderConfig{
+		Name:         ProviderGoogle,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		AuthURL:      "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenURL:     "https://oauth2.googleapis.com/token",
+		Scopes:       []string{"openid", "email", "profile"},
+		OIDC:         true,
+		Issuer:       "https://accounts.google.com",
+		JWKSURL:      "https://www.googleapis.com/oauth2/v3/certs",
+	}
+}
+
+// AppleConfig はSign in with AppleのOIDC設定を返します
+// clientSecret にはAppleの秘密鍵で署名したクライアントシークレット（JWT）を指定します
+func AppleConfig(clientID, clientSecret, redirectURL string) ProviderConfig {
+	return ProviderConfig{
+		Name:         ProviderApple,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		AuthURL:      "https://appleid.apple.com/auth/authorize",
+		TokenURL:     "https://appleid.apple.com/auth/token",
+		Scopes:       []string{"openid", "email", "name"},
+		OIDC:         true,
+		Issuer:       "https://appleid.apple.com",
+		JWKSURL:      "https://appleid.apple.com/auth/keys",
+	}
+}
+
+// LINEConfig はLINEログインのOIDC設定を返します
+// LINEはメールアドレスをユーザーが許可した場合にしか返さず、検証済みであることも保証しないため、
+// IDトークンに email_verified が無い限り新規登録やメールアドレスによる既存アカウントへの紐付けには使えません
+func LINEConfig(clientID, clientSecret, redirectURL string) ProviderConfig {
+	return ProviderConfig{
+		Name:         ProviderLINE,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		AuthURL:      "https://access.line.me/oauth2/v2.1/authorize",
+		TokenURL:     "https://api.line.me/oauth2/v2.1/token",
+		Scopes:       []string{"openid", "profile", "email"},
+		OIDC:         true,
+		Issuer:       "https://access.line.me",
+		JWKSURL:      "https://api.line.me/oauth2/v2.1/certs",
+		HMACIDToken:  true,
+	}
+}
+
+// XConfig はX（旧Twitter）のOAuth2設定を返します
+// XはOIDCに対応しておらずメールアドレスも返さないため、既存アカウントへの紐付けでのみ利用できます
+func XConfig(clientID, clientSecret, redirectURL string) ProviderConfig {
+	return ProviderConfig{
+		Name:          ProviderX,
+		ClientID:      clientID,
+		ClientSecret:  clientSecret,
+		RedirectURL:   redirectURL,
+		AuthURL:       "https://twitter.com/i/oauth2/authorize",
+		TokenURL:      "https://api.twitter.com/2/oauth2/token",
+		Scopes:        []string{"users.read", "tweet.read"},
+		BasicAuth:     true,
+		UserInfoURL:   "https://api.twitter.com/2/users/me",
+		ParseUserInfo: parseXUserInfo,
+	}
+}
+
+// parseXUserInfo はXのユーザー情報レスポンスを変換します
+func parseXUserInfo(body []byte) (*auth.OAuthUserInfo, error) {
+	var v struct {
+		Data struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("failed to decode userinfo: %w", err)
+	}
+	return &auth.OAuthUserInfo{
+		Subject: v.Data.ID,
+		Name:    v.Data.Name,
+	}, nil
+}
+
+// ProvidersFromEnv は環境変数でクライアントIDが設定されているプロバイダーを作成します
+// 例: OAUTH_GOOGLE_CLIENT_ID, OAUTH_GOOGLE_CLIENT_SECRET, OAUTH_GOOGLE_REDIRECT_URL
+func ProvidersFromEnv() []auth.OAuthProvider {
+	presets := []struct {
+		env    string
+		config func(clientID, clientSecret, redirectURL string) ProviderConfig
+	}{
+		{"GOOGLE", GoogleConfig},
+		{"APPLE", AppleConfig},
+		{"LINE", LINEConfig},
+		{"X", XConfig},
+	}
+
+	var providers []auth.OAuthProvider
+	for _, p := range presets {
+		clientID := os.Getenv("OAUTH_" + p.env + "_CLIENT_ID")
+		if clientID == "" {
+			continue
+		}
+		providers = append(providers, NewProvider(p.config(
+			clientID,
+			os.Getenv("OAUTH_"+p.env+"_CLIENT_SECRET"),
+			os.Getenv("OAUTH_"+p.env+"_REDIRECT_URL"),
+		)))
+	}
+	return providers
+}
@@ -0,0 +1,298 @@
+package ogimage
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// カードの大きさ（OpenGraph と Twitter Card の推奨サイズ）
+const (
+	cardWidth   = 1200
+	cardHeight  = 630
+	cardPadding = 72
+)
+
+//go:embed fonts
+var embeddedFonts embed.FS
+
+var (
+	backgroundTop    = color.RGBA{R: 0xff, G: 0x7e, B: 0xb3, A: 0xff}
+	backgroundBottom = color.RGBA{R: 0x7a, G: 0x5c, B: 0xff, A: 0xff}
+	textColor        = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	subTextColor     = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xdd}
+)
+
+// Renderer は診断結果のシェアカードをPNG画像として描画します
+// 埋め込んだフォントを優先し、グリフが無い文字は次のフォントで描画します
+type Renderer struct {
+	fonts []*sfnt.Font
+}
+
+// NewRenderer は埋め込まれたフォントを読み込んで新しいRendererを作成します
+func NewRenderer() (*Renderer, error) {
+	fonts, err := loadEmbeddedFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	// 欧文用のフォントを最後の代替として使う
+	for _, src := range [][]byte{gobold.TTF, goregular.TTF} {
+		f, err := opentype.Parse(src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse fallback font: %w", err)
+		}
+		fonts = append(fonts, f)
+	}
+
+	return &Renderer{fonts: fonts}, nil
+}
+
+// Render はシェアカードを描画してPNG形式で返します
+func (r *Renderer) Render(card entity.ShareCard) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	fillGradient(img)
+
+	labelFace, err := r.newFaces(30)
+	if err != nil {
+		return nil, err
+	}
+	titleFace, err := r.newFaces(60)
+	if err != nil {
+		return nil, err
+	}
+	scoreFace, err := r.newFaces(120)
+	if err != nil {
+		return nil, err
+	}
+	bodyFace, err := r.newFaces(34)
+	if err != nil {
+		return nil, err
+	}
+
+	maxWidth := fixed.I(cardWidth - cardPadding*2)
+	y := cardPadding + 30
+
+	labelFace.draw(img, card.Label, cardPadding, y, subTextColor)
+	y += 40
+
+	if card.Score != nil {
+		maxWidth = fixed.I(cardWidth - cardPadding*3 - 260)
+		score := fmt.Sprintf("%d", *card.Score)
+		scoreWidth := scoreFace.measure(score)
+		scoreFace.draw(img, score, cardWidth-cardPadding-scoreWidth.Ceil(), cardPadding+150, textColor)
+		labelFace.draw(img, card.ScoreUnit, cardWidth-cardPadding-labelFace.measure(card.ScoreUnit).Ceil(), cardPadding+195, subTextColor)
+	}
+
+	for _, line := range wrap(titleFace, card.Title, maxWidth, 2) {
+		y += 76
+		titleFace.draw(img, line, cardPadding, y, textColor)
+	}
+
+	y += 24
+	for _, line := range wrap(bodyFace, card.Summary, fixed.I(cardWidth-cardPadding*2), 4) {
+		y += 50
+		bodyFace.draw(img, line, cardPadding, y, subTextColor)
+	}
+
+	labelFace.draw(img, card.Footer, cardPadding, cardHeight-cardPadding+10, textColor)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode card image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// newFaces は指定サイズの代替フォント付きの書体を作成します
+func (r *Renderer) newFaces(size float64) (*fallbackFace, error) {
+	ff := &fallbackFace{fonts: r.fonts, buf: &sfnt.Buffer{}}
+	for _, f := range r.fonts {
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create font face: %w", err)
+		}
+		ff.faces = append(ff.faces, face)
+	}
+	return ff, nil
+}
+
+// fallbackFace は文字ごとにグリフを持つ最初のフォントを選んで描画する書体です
+type fallbackFace struct {
+	fonts []*sfnt.Font
+	faces []font.Face
+	buf   *sfnt.Buffer
+}
+
+// faceFor は文字のグリフを持つ最初の書体を返します
+func (f *fallbackFace) faceFor(r rune) font.Face {
+	for i, ft := range f.fonts {
+		if idx, err := ft.GlyphIndex(f.buf, r); err == nil && idx != 0 {
+			return f.faces[i]
+		}
+	}
+	return f.faces[len(f.faces)-1]
+}
+
+// measure は文字列の描画幅を返します
+func (f *fallbackFace) measure(s string) fixed.Int26_6 {
+	var width fixed.Int26_6
+	for _, r := range s {
+		advance, _ := f.faceFor(r).GlyphAdvance(r)
+		width += advance
+	}
+	return width
+}
+
+// draw は文字列をベースライン (x, y) から描画します
+func (f *fallbackFace) draw(dst draw.Image, s string, x, y int, c color.Color) {
+	d := &font.Drawer{
+		Dst: dst,
+		Src: image.NewUniform(c),
+		Dot: fixed.P(x, y),
+	}
+	for _, r := range s {
+		d.Face = f.faceFor(r)
+		d.DrawString(string(r))
+	}
+}
+
+// wrap は文字列を描画幅に収まるよう折り返し、最大 maxLines 行に切り詰めます
+// 行内に空白があれば最後の空白で折り返し、無ければ文字単位で折り返します（日本語は空白で区切らないため）
+func wrap(f *fallbackFace, s string, maxWidth fixed.Int26_6, maxLines int) []string {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return nil
+	}
+
+	var lines []string
+	var line []rune
+	var width fixed.Int26_6
+	for _, r := range s {
+		advance := f.measure(string(r))
+		if width+advance > maxWidth && len(line) > 0 {
+			if r == ' ' {
+				lines = append(lines, string(line))
+				line, width = nil, 0
+				continue
+			}
+			if i := lastSpace(line); i > 0 {
+				lines = append(lines, string(line[:i]))
+				line = append([]rune(nil), line[i+1:]...)
+			} else {
+				lines = append(lines, string(line))
+				line = nil
+			}
+			width = f.measure(string(line))
+		}
+		line = append(line, r)
+		width += advance
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := []rune(lines[maxLines-1])
+		ellipsis := f.measure("…")
+		for len(last) > 0 && f.measure(string(last))+ellipsis > maxWidth {
+			last = last[:len(last)-1]
+		}
+		lines[maxLines-1] = string(last) + "…"
+	}
+	return lines
+}
+
+// lastSpace は最後の空白の位置を返します（無い場合は -1）
+func lastSpace(line []rune) int {
+	for i := len(line) - 1; i >= 0; i-- {
+		if line[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+// fillGradient は背景を上から下へのグラデーションで塗ります
+func fillGradient(img *image.RGBA) {
+	for y := 0; y < cardHeight; y++ {
+		t := float64(y) / float64(cardHeight-1)
+		c := color.RGBA{
+			R: lerp(backgroundTop.R, backgroundBottom.R, t),
+			G: lerp(backgroundTop.G, backgroundBottom.G, t),
+			B: lerp(backgroundTop.B, backgroundBottom.B, t),
+			A: 0xff,
+		}
+		draw.Draw(img, image.Rect(0, y, cardWidth, y+1), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t)
+}
+
+// loadEmbeddedFonts は fonts ディレクトリに埋め込まれたフォントをファイル名順に読み込みます
+// フォントコレクション（.ttc）は先頭のフォントを使います
+func loadEmbeddedFonts() ([]*sfnt.Font, error) {
+	var names []string
+	err := fs.WalkDir(embeddedFonts, "fonts", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(path.Ext(p)) {
+		case ".ttf", ".otf", ".ttc":
+			names = append(names, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embedded fonts: %w", err)
+	}
+	sort.Strings(names)
+
+	var fonts []*sfnt.Font
+	for _, name := range names {
+		src, err := embeddedFonts.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read font %s: %w", name, err)
+		}
+
+		var f *sfnt.Font
+		if strings.EqualFold(path.Ext(name), ".ttc") {
+			collection, err := opentype.ParseCollection(src)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse font %s: %w", name, err)
+			}
+			f, err = collection.Font(0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse font %s: %w", name, err)
+			}
+		} else {
+			f, err = opentype.Parse(src)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse font %s: %w", name, err)
+			}
+		}
+		fonts = append(fonts, f)
+	}
+
+	return fonts, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stripe/stripe-go/v76"
 	"github.com/stripe/stripe-go/v76/customer"
 	"github.com/stripe/stripe-go/v76/subscription"
+	"github.com/stripe/stripe-go/v76/webhook"
 )
 
 // StripeService はStripeを使用した決済サービスの実装です
@@ -87,16 +88,13 @@ func (s *StripeService) CancelSubscription(ctx context.Context, subscriptionID u
 // getOrCreateCustomer は顧客を取得または作成します
 func (s *StripeService) getOrCreateCustomer(ctx context.Context, userID uuid.UUID) (string, error) {
 	// 既存の顧客を検索
-	params := &stripe.CustomerListParams{
-		Filters: stripe.Filters{
-			stripe.Filter{
-				Key:   "metadata['user_id']",
-				Value: userID.String(),
-			},
+	params := &stripe.CustomerSearchParams{
+		SearchParams: stripe.SearchParams{
+			Query: fmt.Sprintf("metadata['user_id']:'%s'", userID.String()),
 		},
 	}
 
-	customers := customer.List(params)
+	customers := customer.Search(params)
 	for customers.Next() {
 		return customers.Customer().ID, nil
 	}
@@ -127,7 +125,7 @@ func (s *StripeService) getStripeSubscriptionID(subscriptionID uuid.UUID) (strin
 // HandleWebhook はStripeのWebhookを処理します
 func (s *StripeService) HandleWebhook(payload []byte, signature string) error {
 	// Webhookイベントを検証
-	event, err := stripe.ConstructEvent(payload, signature, s.apiKey)
+	event, err := webhook.ConstructEvent(payload, signature, s.apiKey)
 	if err != nil {
 		return fmt.Errorf("failed to verify webhook signature: %w", err)
 	}
@@ -0,0 +1,317 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// AccountDeletionRepository はPostgreSQLを使用したAccountDeletionRepositoryの実装です
+type AccountDeletionRepository struct {
+	db *sql.DB
+}
+
+// NewAccountDeletionRepository は新しいAccountDeletionRepositoryを作成します
+func NewAccountDeletionRepository(db *sql.DB) repository.AccountDeletionRepository {
+	return &AccountDeletionRepository{db: db}
+}
+
+const accountDeletionColumns = `id, user_id, status, reason, scheduled_at, cancelled_at, completed_at, created_at, updated_at`
+
+// Create は新しい退会申請を保存します
+func (r *AccountDeletionRepository) Create(ctx context.Context, deletion *entity.AccountDeletion) error {
+	query := `
+		INSERT INTO account_deletions (` + accountDeletionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		deletion.ID,
+		deletion.UserID,
+		deletion.Status,
+		deletion.Reason,
+		deletion.ScheduledAt,
+		deletion.CancelledAt,
+		deletion.CompletedAt,
+		deletion.CreatedAt,
+		deletion.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create account deletion: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID は指定されたIDの退会申請を取得します
+func (r *AccountDeletionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.AccountDeletion, error) {
+	query := `SELECT ` + accountDeletionColumns + ` FROM account_deletions WHERE id = $1`
+	return r.findOne(ctx, query, id)
+}
+
+// FindScheduledByUserID は指定されたユーザーの削除予定の退会申請を取得します
+func (r *AccountDeletionRepository) FindScheduledByUserID(ctx context.Context, userID string) (*entity.AccountDeletion, error) {
+	query := `
+		SELECT ` + accountDeletionColumns + `
+		FROM account_deletions
+		WHERE user_id = $1 AND status = 'scheduled'
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	return r.findOne(ctx, query, userID)
+}
+
+// Update は退会申請を更新します
+func (r *AccountDeletionRepository) Update(ctx context.Context, deletion *entity.AccountDeletion) error {
+	query := `
+		UPDATE account_deletions
+		SET status = $1, cancelled_at = $2, completed_at = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		deletion.Status,
+		deletion.CancelledAt,
+		deletion.CompletedAt,
+		deletion.UpdatedAt,
+		deletion.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update account deletion: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("account deletion not found")
+	}
+
+	return nil
+}
+
+func (r *AccountDeletionRepository) findOne(ctx context.Context, query string, arg interface{}) (*entity.AccountDeletion, error) {
+	deletion := &entity.AccountDeletion{}
+	var cancelledAt, completedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&deletion.ID,
+		&deletion.UserID,
+		&deletion.Status,
+		&deletion.Reason,
+		&deletion.ScheduledAt,
+		&cancelledAt,
+		&completedAt,
+		&deletion.CreatedAt,
+		&deletion.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account deletion: %w", err)
+	}
+
+	if cancelledAt.Valid {
+		deletion.CancelledAt = &cancelledAt.Time
+	}
+	if completedAt.Valid {
+		deletion.CompletedAt = &completedAt.Time
+	}
+
+	return deletion, nil
+}
+
+// DataExportRepository はPostgreSQLを使用したDataExportRepositoryの実装です
+type DataExportRepository struct {
+	db *sql.DB
+}
+
+// NewDataExportRepository は新しいDataExportRepositoryを作成します
+func NewDataExportRepository(db *sql.DB) repository.DataExportRepository {
+	return &DataExportRepository{db: db}
+}
+
+const dataExportColumns = `id, user_id, status, file_key, error, expires_at, created_at, updated_at`
+
+// Create は新しいエクスポート要求を保存します
+func (r *DataExportRepository) Create(ctx context.Context, export *entity.DataExport) error {
+	query := `
+		INSERT INTO data_exports (` + dataExportColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		export.ID,
+		export.UserID,
+		export.Status,
+		export.FileKey,
+		export.Error,
+		export.ExpiresAt,
+		export.CreatedAt,
+		export.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID は指定されたIDのエクスポート要求を取得します
+func (r *DataExportRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.DataExport, error) {
+	query := `SELECT ` + dataExportColumns + ` FROM data_exports WHERE id = $1`
+	return r.findOne(ctx, query, id)
+}
+
+// FindLatestByUserID は指定されたユーザーの最新のエクスポート要求を取得します
+func (r *DataExportRepository) FindLatestByUserID(ctx context.Context, userID string) (*entity.DataExport, error) {
+	query := `
+		SELECT ` + dataExportColumns + `
+		FROM data_exports
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	return r.findOne(ctx, query, userID)
+}
+
+// Update はエクスポート要求を更新します
+func (r *DataExportRepository) Update(ctx context.Context, export *entity.DataExport) error {
+	query := `
+		UPDATE data_exports
+		SET status = $1, file_key = $2, error = $3, expires_at = $4, updated_at = $5
+		WHERE id = $6
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		export.Status,
+		export.FileKey,
+		export.Error,
+		export.ExpiresAt,
+		export.UpdatedAt,
+		export.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update data export: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("data export not found")
+	}
+
+	return nil
+}
+
+func (r *DataExportRepository) findOne(ctx context.Context, query string, arg interface{}) (*entity.DataExport, error) {
+	export := &entity.DataExport{}
+	var expiresAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.FileKey,
+		&export.Error,
+		&expiresAt,
+		&export.CreatedAt,
+		&export.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data export: %w", err)
+	}
+
+	if expiresAt.Valid {
+		export.ExpiresAt = &expiresAt.Time
+	}
+
+	return export, nil
+}
+
+// AccountRepository はPostgreSQLを使用したAccountRepositoryの実装です
+type AccountRepository struct {
+	db *sql.DB
+}
+
+// NewAccountRepository は新しいAccountRepositoryを作成します
+func NewAccountRepository(db *sql.DB) repository.AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+// purgeStatements は退会時にユーザーの個人データを削除するSQLです
+// ユーザーに紐づくテーブルを追加した場合はここにも追加してください
+// 決済履歴（subscriptions）は会計上の保存義務があるため削除せず、匿名化したユーザー行に紐づけたまま残します
+var purgeStatements = []struct {
+	name  string
+	query string
+}{
+	{"profile", `DELETE FROM user_profiles WHERE user_id = $1`},
+	{"identities", `DELETE FROM user_identities WHERE user_id = $1`},
+	{"oauth states", `DELETE FROM oauth_states WHERE link_user_id = $1`},
+	{"recovery codes", `DELETE FROM user_mfa_recovery_codes WHERE user_id = $1`},
+	{"mfa settings", `DELETE FROM user_mfa WHERE user_id = $1`},
+	{"api keys", `DELETE FROM api_keys WHERE user_id = $1`},
+	{"security events", `DELETE FROM security_events WHERE user_id = $1`},
+	{"consumed refresh tokens", `DELETE FROM consumed_refresh_tokens WHERE session_id IN (SELECT id FROM device_sessions WHERE user_id = $1)`},
+	{"sessions", `DELETE FROM device_sessions WHERE user_id = $1`},
+	{"diagnosis contents", `DELETE FROM diagnosis_contents WHERE content_id IN (SELECT id FROM contents WHERE user_id = $1)`},
+	{"contents", `DELETE FROM contents WHERE user_id = $1`},
+	{"oshi links", `UPDATE oshis SET creator_user_id = NULL WHERE creator_user_id = $1`},
+	{"organization invitations", `DELETE FROM organization_invitations WHERE user_id = $1`},
+	{"organization memberships", `DELETE FROM organization_members WHERE user_id = $1`},
+	{"leaderboard scores", `DELETE FROM leaderboard_scores WHERE user_id = $1`},
+	{"account flags", `DELETE FROM account_flags WHERE user_id = $1`},
+	{"notifications", `DELETE FROM notifications WHERE user_id = $1`},
+	{"push deliveries", `DELETE FROM push_deliveries WHERE user_id = $1`},
+	{"push notifications", `DELETE FROM push_notifications WHERE user_id = $1`},
+	{"device tokens", `DELETE FROM device_tokens WHERE user_id = $1`},
+	{"notification settings", `DELETE FROM notification_settings WHERE user_id = $1`},
+	{"emails", `DELETE FROM emails WHERE user_id = $1`},
+}
+
+// Purge はユーザーの個人データを削除し、ユーザーを削除済みの状態で保存します
+func (r *AccountRepository) Purge(ctx context.Context, user *entity.User) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range purgeStatements {
+		if _, err := tx.ExecContext(ctx, stmt.query, user.ID); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", stmt.name, err)
+		}
+	}
+
+	query := `
+		UPDATE users
+		SET email = $1, password = $2, name = $3, role = $4, role_version = $5, deleted_at = $6, updated_at = $7
+		WHERE id = $8
+	`
+	_, err = tx.ExecContext(ctx, query,
+		user.Email,
+		user.Password,
+		user.Name,
+		user.Role,
+		user.RoleVersion,
+		user.DeletedAt,
+		user.UpdatedAt,
+		user.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to tombstone user: %w", err)
+	}
+
+	return tx.Commit()
+}
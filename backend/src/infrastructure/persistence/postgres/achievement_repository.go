@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+// BadgeRepository はPostgreSQLを使用したBadgeRepositoryの実装です
+type BadgeRepository struct {
+	db *sql.DB
+}
+
+// NewBadgeRepository は新しいBadgeRepositoryを作成します
+func NewBadgeRepository(db *sql.DB) repository.BadgeRepository {
+	return &BadgeRepository{db: db}
+}
+
+// Award はバッジを付与します
+func (r *BadgeRepository) Award(ctx context.Context, badge *entity.UserBadge) (bool, error) {
+	query := `
+		INSERT INTO user_badges (user_id, badge_id, awarded_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, badge_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, badge.UserID, badge.BadgeID, badge.AwardedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to award badge: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ListByUserID はユーザーが獲得したバッジを獲得順に取得します
+func (r *BadgeRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.UserBadge, error) {
+	query := `
+		SELECT user_id, badge_id, awarded_at
+		FROM user_badges
+		WHERE user_id = $1
+		ORDER BY awarded_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list badges: %w", err)
+	}
+	defer rows.Close()
+
+	var badges []*entity.UserBadge
+	for rows.Next() {
+		badge := &entity.UserBadge{}
+		if err := rows.Scan(&badge.UserID, &badge.BadgeID, &badge.AwardedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan badge: %w", err)
+		}
+		badges = append(badges, badge)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating badges: %w", err)
+	}
+
+	return badges, nil
+}
+
+// DeleteAllByUserID はユーザーが獲得した全てのバッジを削除します
+func (r *BadgeRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM user_badges WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete badges: %w", err)
+	}
+
+	return nil
+}
+
+// PointLedgerRepository はPostgreSQLを使用したPointLedgerRepositoryの実装です
+type PointLedgerRepository struct {
+	db *sql.DB
+}
+
+// NewPointLedgerRepository は新しいPointLedgerRepositoryを作成します
+func NewPointLedgerRepository(db *sql.DB) repository.PointLedgerRepository {
+	return &PointLedgerRepository{db: db}
+}
+
+// Book はポイントの増減を記録します
+func (r *PointLedgerRepository) Book(ctx context.Context, entry *entity.PointLedgerEntry) (bool, error) {
+	query := `
+		INSERT INTO point_ledger_entries (id, user_id, amount, reason, reference, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, reference) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		entry.ID,
+		entry.UserID,
+		entry.Amount,
+		entry.Reason,
+		entry.Reference,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to book points: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Balance はユーザーのポイント残高を取得します
+func (r *PointLedgerRepository) Balance(ctx context.Context, userID string) (int64, error) {
+	var balance int64
+	err := r.db.QueryRowContext(ctx, `SELECT COALESCE(SUM(amount), 0) FROM point_ledger_entries WHERE user_id = $1`, userID).Scan(&balance)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get point balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+// ListByUserID はユーザーのポイントの増減を新しい順に取得します
+func (r *PointLedgerRepository) ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.PointLedgerEntry, error) {
+	query := `
+		SELECT id, user_id, amount, reason, reference, created_at
+		FROM point_ledger_entries
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list point ledger: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entity.PointLedgerEntry
+	for rows.Next() {
+		entry := &entity.PointLedgerEntry{}
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.Amount, &entry.Reason, &entry.Reference, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan point ledger entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating point ledger: %w", err)
+	}
+
+	return entries, nil
+}
+
+// DeleteAllByUserID はユーザーの全てのポイントの記録を削除します
+func (r *PointLedgerRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM point_ledger_entries WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete point ledger: %w", err)
+	}
+
+	return nil
+}
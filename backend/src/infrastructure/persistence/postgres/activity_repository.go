@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// ActivityRepository はPostgreSQLを使用したActivityRepositoryの実装です
+type ActivityRepository struct {
+	db *sql.DB
+}
+
+// NewActivityRepository は新しいActivityRepositoryを作成します
+func NewActivityRepository(db *sql.DB) repository.ActivityRepository {
+	return &ActivityRepository{db: db}
+}
+
+// Create は行動の記録を保存します
+func (r *ActivityRepository) Create(ctx context.Context, event *entity.ActivityEvent) error {
+	query := `
+		INSERT INTO activity_events (id, user_id, oshi_id, activity_type, occurred_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, event.ID, event.UserID, event.OshiID, event.Type, event.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to create activity event: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUserAndOshi はユーザーの推しに対する since 以降の行動の記録を古い順に取得します
+func (r *ActivityRepository) ListByUserAndOshi(ctx context.Context, userID string, oshiID uuid.UUID, since time.Time) ([]*entity.ActivityEvent, error) {
+	query := `
+		SELECT id, user_id, oshi_id, activity_type, occurred_at
+		FROM activity_events
+		WHERE user_id = $1 AND oshi_id = $2 AND occurred_at >= $3
+		ORDER BY occurred_at
+	`
+	return r.list(ctx, query, userID, oshiID, since)
+}
+
+// ListByUser はユーザーの since 以降の行動の記録を古い順に取得します
+func (r *ActivityRepository) ListByUser(ctx context.Context, userID string, since time.Time) ([]*entity.ActivityEvent, error) {
+	query := `
+		SELECT id, user_id, oshi_id, activity_type, occurred_at
+		FROM activity_events
+		WHERE user_id = $1 AND occurred_at >= $2
+		ORDER BY occurred_at
+	`
+	return r.list(ctx, query, userID, since)
+}
+
+// CountSince はユーザーの推しに対する since 以降の指定した種類の行動の件数を取得します
+func (r *ActivityRepository) CountSince(ctx context.Context, userID string, oshiID uuid.UUID, activityType entity.ActivityType, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM activity_events
+		WHERE user_id = $1 AND oshi_id = $2 AND activity_type = $3 AND occurred_at >= $4
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID, oshiID, activityType, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count activity events: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountByUser はユーザーの全ての推しに対する指定した種類の行動の累計件数を取得します
+func (r *ActivityRepository) CountByUser(ctx context.Context, userID string, activityType entity.ActivityType) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM activity_events
+		WHERE user_id = $1 AND activity_type = $2
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID, activityType).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count activity events: %w", err)
+	}
+
+	return count, nil
+}
+
+// DeleteAllByUserID はユーザーの全ての行動の記録を削除します
+func (r *ActivityRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM activity_events WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete activity events: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ActivityRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entity.ActivityEvent, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list activity events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entity.ActivityEvent
+	for rows.Next() {
+		event := &entity.ActivityEvent{}
+		if err := rows.Scan(&event.ID, &event.UserID, &event.OshiID, &event.Type, &event.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan activity event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating activity events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// APIKeyRepository はPostgreSQLを使用したAPIKeyRepositoryの実装です
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository は新しいAPIKeyRepositoryを作成します
+func NewAPIKeyRepository(db *sql.DB) repository.APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create は新しいAPIキーを作成します
+func (r *APIKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	query := `
+		INSERT INTO api_keys (
+			id, user_id, organization_id, name, prefix, secret_hash, scopes, rate_limit_per_minute, expires_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		key.ID,
+		key.UserID,
+		key.OrganizationID,
+		key.Name,
+		key.Prefix,
+		key.SecretHash,
+		pq.Array(key.Scopes),
+		key.RateLimitPerMinute,
+		key.ExpiresAt,
+		key.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+// FindByPrefix はプレフィックスでAPIキーを取得します
+func (r *APIKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	query := `
+		SELECT id, user_id, organization_id, name, prefix, secret_hash, scopes, rate_limit_per_minute,
+			expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys
+		WHERE prefix = $1
+	`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, prefix))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// FindByID はIDでAPIキーを取得します
+func (r *APIKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.APIKey, error) {
+	query := `
+		SELECT id, user_id, organization_id, name, prefix, secret_hash, scopes, rate_limit_per_minute,
+			expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys
+		WHERE id = $1
+	`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// FindByUserID は指定されたユーザーが個人で所有するAPIキー一覧を取得します
+func (r *APIKeyRepository) FindByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error) {
+	query := `
+		SELECT id, user_id, organization_id, name, prefix, secret_hash, scopes, rate_limit_per_minute,
+			expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys
+		WHERE user_id = $1 AND organization_id IS NULL
+		ORDER BY created_at DESC
+	`
+
+	return r.findAll(ctx, query, userID)
+}
+
+// FindByOrganizationID は指定された組織が所有するAPIキー一覧を取得します
+func (r *APIKeyRepository) FindByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entity.APIKey, error) {
+	query := `
+		SELECT id, user_id, organization_id, name, prefix, secret_hash, scopes, rate_limit_per_minute,
+			expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys
+		WHERE organization_id = $1
+		ORDER BY created_at DESC
+	`
+
+	return r.findAll(ctx, query, orgID)
+}
+
+// findAll はクエリに一致するAPIキー一覧を取得します
+func (r *APIKeyRepository) findAll(ctx context.Context, query string, args ...interface{}) ([]*entity.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*entity.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke はAPIキーを失効させます
+func (r *APIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("api key not found")
+	}
+
+	return nil
+}
+
+// TouchLastUsed は最終使用日時を更新します
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, usedAt); err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", err)
+	}
+
+	return nil
+}
+
+// rowScanner は sql.Row と sql.Rows に共通するスキャン処理です
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAPIKey は1行分のAPIキーを読み込みます
+func scanAPIKey(row rowScanner) (*entity.APIKey, error) {
+	key := &entity.APIKey{}
+	var orgID uuid.NullUUID
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&orgID,
+		&key.Name,
+		&key.Prefix,
+		&key.SecretHash,
+		pq.Array(&key.Scopes),
+		&key.RateLimitPerMinute,
+		&expiresAt,
+		&lastUsedAt,
+		&revokedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID.Valid {
+		key.OrganizationID = &orgID.UUID
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return key, nil
+}
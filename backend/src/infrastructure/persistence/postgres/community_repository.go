@@ -0,0 +1,596 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// CommunityPostRepository はPostgreSQLを使用したCommunityPostRepositoryの実装です
+type CommunityPostRepository struct {
+	db *sql.DB
+}
+
+// NewCommunityPostRepository は新しいCommunityPostRepositoryを作成します
+func NewCommunityPostRepository(db *sql.DB) repository.CommunityPostRepository {
+	return &CommunityPostRepository{db: db}
+}
+
+const communityPostColumns = `id, oshi_id, user_id, body, image_urls, comment_count, hidden_at, deleted_at, created_at, updated_at`
+
+// Create は新しい投稿を保存します
+func (r *CommunityPostRepository) Create(ctx context.Context, post *entity.CommunityPost) error {
+	query := `
+		INSERT INTO community_posts (` + communityPostColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		post.ID,
+		post.OshiID,
+		post.UserID,
+		post.Body,
+		pq.Array(post.ImageURLs),
+		post.CommentCount,
+		post.HiddenAt,
+		post.DeletedAt,
+		post.CreatedAt,
+		post.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create community post: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID は指定されたIDの投稿を取得します
+func (r *CommunityPostRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.CommunityPost, error) {
+	query := `SELECT ` + communityPostColumns + ` FROM community_posts WHERE id = $1`
+
+	post, err := scanCommunityPost(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find community post: %w", err)
+	}
+
+	return post, nil
+}
+
+// ListByOshiID は推しのページの投稿を新しい順に取得します
+func (r *CommunityPostRepository) ListByOshiID(ctx context.Context, oshiID uuid.UUID, filter repository.CommunityFilter, cursor *repository.FeedCursor, limit int) ([]*entity.CommunityPost, error) {
+	cursorTime, cursorID := cursorArgs(cursor)
+	query := `
+		SELECT ` + communityPostColumns + `
+		FROM community_posts
+		WHERE oshi_id = $1
+		AND deleted_at IS NULL
+		AND (hidden_at IS NULL OR user_id = NULLIF($2, '')::uuid)
+		AND ` + hiddenFromViewer("user_id", "$2") + `
+		AND ($3::timestamptz IS NULL OR (created_at, id) < ($3::timestamptz, $4::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5
+	`
+	return r.list(ctx, query, oshiID, filter.ViewerID, cursorTime, cursorID, limit)
+}
+
+// ListByUserID はユーザーの削除されていない投稿を新しい順に取得します
+func (r *CommunityPostRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.CommunityPost, error) {
+	query := `
+		SELECT ` + communityPostColumns + `
+		FROM community_posts
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+	`
+	return r.list(ctx, query, userID)
+}
+
+// Update は投稿を更新します
+func (r *CommunityPostRepository) Update(ctx context.Context, post *entity.CommunityPost) error {
+	query := `
+		UPDATE community_posts
+		SET body = $1, image_urls = $2, hidden_at = $3, deleted_at = $4, updated_at = $5
+		WHERE id = $6
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		post.Body,
+		pq.Array(post.ImageURLs),
+		post.HiddenAt,
+		post.DeletedAt,
+		post.UpdatedAt,
+		post.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update community post: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("community post not found")
+	}
+
+	return nil
+}
+
+// SoftDeleteAllByUserID はユーザーの全ての投稿の本文と画像を消して削除済みにします
+func (r *CommunityPostRepository) SoftDeleteAllByUserID(ctx context.Context, userID string) error {
+	query := `
+		UPDATE community_posts
+		SET body = '', image_urls = '{}', deleted_at = $2, updated_at = $2
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`
+	if _, err := r.db.ExecContext(ctx, query, userID, time.Now()); err != nil {
+		return fmt.Errorf("failed to delete community posts: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CommunityPostRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entity.CommunityPost, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list community posts: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []*entity.CommunityPost
+	for rows.Next() {
+		post, err := scanCommunityPost(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan community post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating community posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+func scanCommunityPost(s rowScanner) (*entity.CommunityPost, error) {
+	post := &entity.CommunityPost{}
+	var hiddenAt, deletedAt sql.NullTime
+	err := s.Scan(
+		&post.ID,
+		&post.OshiID,
+		&post.UserID,
+		&post.Body,
+		pq.Array(&post.ImageURLs),
+		&post.CommentCount,
+		&hiddenAt,
+		&deletedAt,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.ImageURLs == nil {
+		post.ImageURLs = []string{}
+	}
+	if hiddenAt.Valid {
+		post.HiddenAt = &hiddenAt.Time
+	}
+	if deletedAt.Valid {
+		post.DeletedAt = &deletedAt.Time
+	}
+
+	return post, nil
+}
+
+// CommunityCommentRepository はPostgreSQLを使用したCommunityCommentRepositoryの実装です
+type CommunityCommentRepository struct {
+	db *sql.DB
+}
+
+// NewCommunityCommentRepository は新しいCommunityCommentRepositoryを作成します
+func NewCommunityCommentRepository(db *sql.DB) repository.CommunityCommentRepository {
+	return &CommunityCommentRepository{db: db}
+}
+
+const communityCommentColumns = `id, post_id, parent_id, user_id, body, reply_count, hidden_at, deleted_at, created_at, updated_at`
+
+// Create は新しいコメントを保存し、投稿のコメント数と返信先の返信数を増やします
+func (r *CommunityCommentRepository) Create(ctx context.Context, comment *entity.CommunityComment) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO community_comments (` + communityCommentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		comment.ID,
+		comment.PostID,
+		comment.ParentID,
+		comment.UserID,
+		comment.Body,
+		comment.ReplyCount,
+		comment.HiddenAt,
+		comment.DeletedAt,
+		comment.CreatedAt,
+		comment.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create community comment: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE community_posts SET comment_count = comment_count + 1 WHERE id = $1`, comment.PostID); err != nil {
+		return fmt.Errorf("failed to increment comment count: %w", err)
+	}
+	if comment.ParentID != nil {
+		if _, err := tx.ExecContext(ctx, `UPDATE community_comments SET reply_count = reply_count + 1 WHERE id = $1`, *comment.ParentID); err != nil {
+			return fmt.Errorf("failed to increment reply count: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindByID は指定されたIDのコメントを取得します
+func (r *CommunityCommentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.CommunityComment, error) {
+	query := `SELECT ` + communityCommentColumns + ` FROM community_comments WHERE id = $1`
+
+	comment, err := scanCommunityComment(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find community comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// ListByPostID は投稿のコメントを古い順に取得します
+func (r *CommunityCommentRepository) ListByPostID(ctx context.Context, postID uuid.UUID, parentID *uuid.UUID, filter repository.CommunityFilter, cursor *repository.FeedCursor, limit int) ([]*entity.CommunityComment, error) {
+	var parent uuid.NullUUID
+	if parentID != nil {
+		parent = uuid.NullUUID{UUID: *parentID, Valid: true}
+	}
+	cursorTime, cursorID := cursorArgs(cursor)
+	query := `
+		SELECT ` + communityCommentColumns + `
+		FROM community_comments
+		WHERE post_id = $1
+		AND parent_id IS NOT DISTINCT FROM $2::uuid
+		AND (deleted_at IS NULL OR reply_count > 0)
+		AND (hidden_at IS NULL OR user_id = NULLIF($3, '')::uuid)
+		AND ` + hiddenFromViewer("user_id", "$3") + `
+		AND ($4::timestamptz IS NULL OR (created_at, id) > ($4::timestamptz, $5::uuid))
+		ORDER BY created_at, id
+		LIMIT $6
+	`
+	return r.list(ctx, query, postID, parent, filter.ViewerID, cursorTime, cursorID, limit)
+}
+
+// ListByUserID はユーザーの削除されていないコメントを新しい順に取得します
+func (r *CommunityCommentRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.CommunityComment, error) {
+	query := `
+		SELECT ` + communityCommentColumns + `
+		FROM community_comments
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+	`
+	return r.list(ctx, query, userID)
+}
+
+// SoftDelete はコメントを削除済みとして保存し、投稿のコメント数と返信先の返信数を減らします
+func (r *CommunityCommentRepository) SoftDelete(ctx context.Context, comment *entity.CommunityComment) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE community_comments
+		SET body = $1, deleted_at = $2, updated_at = $3
+		WHERE id = $4 AND deleted_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, query, comment.Body, comment.DeletedAt, comment.UpdatedAt, comment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete community comment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		// 既に削除済み
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE community_posts SET comment_count = GREATEST(comment_count - 1, 0) WHERE id = $1`, comment.PostID); err != nil {
+		return fmt.Errorf("failed to decrement comment count: %w", err)
+	}
+	if comment.ParentID != nil {
+		if _, err := tx.ExecContext(ctx, `UPDATE community_comments SET reply_count = GREATEST(reply_count - 1, 0) WHERE id = $1`, *comment.ParentID); err != nil {
+			return fmt.Errorf("failed to decrement reply count: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SoftDeleteAllByUserID はユーザーの全てのコメントの本文を消して削除済みにします
+func (r *CommunityCommentRepository) SoftDeleteAllByUserID(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	statements := []struct {
+		name  string
+		query string
+	}{
+		{"comment counts", `
+			UPDATE community_posts p
+			SET comment_count = GREATEST(p.comment_count - d.n, 0)
+			FROM (
+				SELECT post_id, COUNT(*) AS n FROM community_comments
+				WHERE user_id = $1 AND deleted_at IS NULL
+				GROUP BY post_id
+			) d
+			WHERE p.id = d.post_id
+		`},
+		{"reply counts", `
+			UPDATE community_comments c
+			SET reply_count = GREATEST(c.reply_count - d.n, 0)
+			FROM (
+				SELECT parent_id, COUNT(*) AS n FROM community_comments
+				WHERE user_id = $1 AND deleted_at IS NULL AND parent_id IS NOT NULL
+				GROUP BY parent_id
+			) d
+			WHERE c.id = d.parent_id
+		`},
+		{"comments", `
+			UPDATE community_comments
+			SET body = '', deleted_at = now(), updated_at = now()
+			WHERE user_id = $1 AND deleted_at IS NULL
+		`},
+	}
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt.query, userID); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", stmt.name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *CommunityCommentRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entity.CommunityComment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list community comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*entity.CommunityComment
+	for rows.Next() {
+		comment, err := scanCommunityComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan community comment: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating community comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+func scanCommunityComment(s rowScanner) (*entity.CommunityComment, error) {
+	comment := &entity.CommunityComment{}
+	var parentID uuid.NullUUID
+	var hiddenAt, deletedAt sql.NullTime
+	err := s.Scan(
+		&comment.ID,
+		&comment.PostID,
+		&parentID,
+		&comment.UserID,
+		&comment.Body,
+		&comment.ReplyCount,
+		&hiddenAt,
+		&deletedAt,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		comment.ParentID = &parentID.UUID
+	}
+	if hiddenAt.Valid {
+		comment.HiddenAt = &hiddenAt.Time
+	}
+	if deletedAt.Valid {
+		comment.DeletedAt = &deletedAt.Time
+	}
+
+	return comment, nil
+}
+
+// ReactionRepository はPostgreSQLを使用したReactionRepositoryの実装です
+type ReactionRepository struct {
+	db *sql.DB
+}
+
+// NewReactionRepository は新しいReactionRepositoryを作成します
+func NewReactionRepository(db *sql.DB) repository.ReactionRepository {
+	return &ReactionRepository{db: db}
+}
+
+// Add はリアクションを保存します
+func (r *ReactionRepository) Add(ctx context.Context, reaction *entity.Reaction) (bool, error) {
+	query := `
+		INSERT INTO community_reactions (target_type, target_id, user_id, reaction, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (target_type, target_id, user_id, reaction) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		reaction.TargetType,
+		reaction.TargetID,
+		reaction.UserID,
+		reaction.Reaction,
+		reaction.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Remove はリアクションを取り消します
+func (r *ReactionRepository) Remove(ctx context.Context, targetType entity.ReactionTargetType, targetID uuid.UUID, userID string, reaction entity.ReactionType) (bool, error) {
+	query := `
+		DELETE FROM community_reactions
+		WHERE target_type = $1 AND target_id = $2 AND user_id = $3 AND reaction = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, targetType, targetID, userID, reaction)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove reaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Summaries は対象ごとのリアクションの集計と閲覧者自身のリアクションをまとめて取得します
+func (r *ReactionRepository) Summaries(ctx context.Context, targetType entity.ReactionTargetType, targetIDs []uuid.UUID, viewerID string) (map[uuid.UUID]*entity.ReactionSummary, error) {
+	summaries := make(map[uuid.UUID]*entity.ReactionSummary, len(targetIDs))
+	for _, id := range targetIDs {
+		summaries[id] = &entity.ReactionSummary{
+			Counts: map[entity.ReactionType]int{},
+			Mine:   []entity.ReactionType{},
+		}
+	}
+	if len(targetIDs) == 0 {
+		return summaries, nil
+	}
+
+	query := `
+		SELECT target_id, reaction, COUNT(*), BOOL_OR(user_id = NULLIF($3, '')::uuid)
+		FROM community_reactions
+		WHERE target_type = $1 AND target_id = ANY($2::uuid[])
+		GROUP BY target_id, reaction
+		ORDER BY target_id, reaction
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, targetType, pq.Array(uuidStrings(targetIDs)), viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize reactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var targetID uuid.UUID
+		var reaction entity.ReactionType
+		var count int
+		var mine sql.NullBool
+		if err := rows.Scan(&targetID, &reaction, &count, &mine); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction summary: %w", err)
+		}
+
+		summary, ok := summaries[targetID]
+		if !ok {
+			continue
+		}
+		summary.Counts[reaction] = count
+		if mine.Valid && mine.Bool {
+			summary.Mine = append(summary.Mine, reaction)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reaction summaries: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// ListByUserID はユーザーのリアクションを新しい順に取得します
+func (r *ReactionRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.Reaction, error) {
+	query := `
+		SELECT target_type, target_id, user_id, reaction, created_at
+		FROM community_reactions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reactions: %w", err)
+	}
+	defer rows.Close()
+
+	var reactions []*entity.Reaction
+	for rows.Next() {
+		reaction := &entity.Reaction{}
+		if err := rows.Scan(&reaction.TargetType, &reaction.TargetID, &reaction.UserID, &reaction.Reaction, &reaction.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction: %w", err)
+		}
+		reactions = append(reactions, reaction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reactions: %w", err)
+	}
+
+	return reactions, nil
+}
+
+// DeleteAllByUserID はユーザーの全てのリアクションを削除します
+func (r *ReactionRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM community_reactions WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete reactions: %w", err)
+	}
+
+	return nil
+}
+
+// cursorArgs はページ位置をSQLの引数に変換します
+// cursor が nil の場合は NULL を返します
+func cursorArgs(cursor *repository.FeedCursor) (sql.NullTime, uuid.NullUUID) {
+	if cursor == nil {
+		return sql.NullTime{}, uuid.NullUUID{}
+	}
+	return sql.NullTime{Time: cursor.CreatedAt, Valid: true}, uuid.NullUUID{UUID: cursor.ID, Valid: true}
+}
@@ -4,13 +4,35 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"kimiyomi/backend/src/domain/entity"
 	"kimiyomi/backend/src/domain/repository"
+	"kimiyomi/backend/src/domain/search"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const contentColumns = `id, user_id, oshi_id, title, description, content_type, file_path, price,
+	moderation_status, hidden_at, report_count, publish_at, unpublish_at, released_at, created_at, updated_at`
+
+// qualifiedContentColumns は他のテーブルと結合する場合の contentColumns です
+const qualifiedContentColumns = `c.id, c.user_id, c.oshi_id, c.title, c.description, c.content_type, c.file_path, c.price,
+	c.moderation_status, c.hidden_at, c.report_count, c.publish_at, c.unpublish_at, c.released_at, c.created_at, c.updated_at`
+
+// contentVisible は投稿者以外に公開されているコンテンツに絞り込むSQLの条件を返します
+// 審査で承認済みで、通報により非表示にされておらず、公開期間内のコンテンツが対象です
+func contentVisible(alias string) string {
+	return alias + `.moderation_status = 'approved' AND ` + alias + `.hidden_at IS NULL
+		AND (` + alias + `.publish_at IS NULL OR ` + alias + `.publish_at <= NOW())
+		AND (` + alias + `.unpublish_at IS NULL OR ` + alias + `.unpublish_at > NOW())`
+}
+
+// contentAvailableAt はコンテンツの一覧の並び順に使う公開日時（未指定の場合は作成日時）の式です
+const contentAvailableAt = `COALESCE(c.publish_at, c.created_at)`
+
 // ContentRepository はPostgreSQLを使用したContentRepositoryの実装です
 type ContentRepository struct {
 	db *sql.DB
@@ -24,21 +46,30 @@ func NewContentRepository(db *sql.DB) repository.ContentRepository {
 // Create は新しいコンテンツを作成します
 func (r *ContentRepository) Create(ctx context.Context, content *entity.Content) error {
 	query := `
-		INSERT INTO contents (
-			id, user_id, title, description, content_type, file_path, price, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO contents (` + contentColumns + `, search_text, search_tokens)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	`
 
+	searchText, searchTokens := contentSearchDocument(content)
 	_, err := r.db.ExecContext(ctx, query,
 		content.ID,
 		content.UserID,
+		content.OshiID,
 		content.Title,
 		content.Description,
 		content.ContentType,
 		content.FilePath,
 		content.Price,
+		content.ModerationStatus,
+		content.HiddenAt,
+		content.ReportCount,
+		content.PublishAt,
+		content.UnpublishAt,
+		content.ReleasedAt,
 		content.CreatedAt,
 		content.UpdatedAt,
+		searchText,
+		pq.Array(searchTokens),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create content: %w", err)
@@ -50,25 +81,14 @@ func (r *ContentRepository) Create(ctx context.Context, content *entity.Content)
 // FindByID は指定されたIDのコンテンツを取得します
 func (r *ContentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Content, error) {
 	query := `
-		SELECT id, user_id, title, description, content_type, file_path, price, created_at, updated_at
+		SELECT ` + contentColumns + `
 		FROM contents
 		WHERE id = $1
 	`
 
-	content := &entity.Content{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&content.ID,
-		&content.UserID,
-		&content.Title,
-		&content.Description,
-		&content.ContentType,
-		&content.FilePath,
-		&content.Price,
-		&content.CreatedAt,
-		&content.UpdatedAt,
-	)
+	content, err := scanContent(r.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("content not found")
+		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to find content: %w", err)
@@ -77,10 +97,149 @@ func (r *ContentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity
 	return content, nil
 }
 
+// FindByIDs は指定されたIDのコンテンツをまとめて取得します
+func (r *ContentRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Content, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + contentColumns + `
+		FROM contents
+		WHERE id = ANY($1::uuid[])
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(uuidStrings(ids)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find contents: %w", err)
+	}
+	defer rows.Close()
+
+	var contents []*entity.Content
+	for rows.Next() {
+		content, err := scanContent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan content: %w", err)
+		}
+		contents = append(contents, content)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contents: %w", err)
+	}
+
+	return contents, nil
+}
+
+// List は条件に一致するコンテンツを指定した順に取得します
+func (r *ContentRepository) List(ctx context.Context, filter repository.ContentFilter, cursor *repository.ContentCursor, limit int) ([]*entity.Content, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.OwnerID != "" {
+		args = append(args, filter.OwnerID)
+		conditions = append(conditions, fmt.Sprintf("c.user_id = $%d", len(args)))
+	} else {
+		args = append(args, filter.ViewerID)
+		conditions = append(conditions, contentVisible("c"), hiddenFromViewer("c.user_id", fmt.Sprintf("$%d::text", len(args))))
+	}
+	if filter.OshiID != nil {
+		args = append(args, *filter.OshiID)
+		conditions = append(conditions, fmt.Sprintf("c.oshi_id = $%d", len(args)))
+	}
+	if filter.ContentType != "" {
+		args = append(args, filter.ContentType)
+		conditions = append(conditions, fmt.Sprintf("c.content_type = $%d", len(args)))
+	}
+	if filter.MinPrice != nil {
+		args = append(args, *filter.MinPrice)
+		conditions = append(conditions, fmt.Sprintf("c.price >= $%d", len(args)))
+	}
+	if filter.MaxPrice != nil {
+		args = append(args, *filter.MaxPrice)
+		conditions = append(conditions, fmt.Sprintf("c.price <= $%d", len(args)))
+	}
+	if filter.Free != nil {
+		if *filter.Free {
+			conditions = append(conditions, "c.price = 0")
+		} else {
+			conditions = append(conditions, "c.price > 0")
+		}
+	}
+	// トークンの配列の GIN インデックスで候補を絞り込み、正規化したテキストの部分一致で確かめる
+	if terms := search.Terms(filter.Query); len(terms) > 0 {
+		args = append(args, pq.Array(search.QueryTokens(terms)))
+		conditions = append(conditions, fmt.Sprintf("c.search_tokens @> $%d::text[]", len(args)))
+		for _, term := range terms {
+			args = append(args, "%"+escapeLike(term)+"%")
+			conditions = append(conditions, fmt.Sprintf("c.search_text LIKE $%d", len(args)))
+		}
+	}
+
+	sortKey, direction := contentAvailableAt, "DESC"
+	switch filter.Sort {
+	case repository.ContentSortOldest:
+		direction = "ASC"
+	case repository.ContentSortPriceAsc:
+		sortKey, direction = "c.price", "ASC"
+	case repository.ContentSortPriceDesc:
+		sortKey = "c.price"
+	}
+	if cursor != nil {
+		if sortKey == "c.price" {
+			args = append(args, cursor.Price)
+		} else {
+			args = append(args, cursor.AvailableAt)
+		}
+		args = append(args, cursor.ID)
+		operator := "<"
+		if direction == "ASC" {
+			operator = ">"
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, c.id) %s ($%d, $%d)", sortKey, operator, len(args)-1, len(args)))
+	}
+
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM contents c
+		WHERE %s
+		ORDER BY %s %s, c.id %s
+		LIMIT $%d
+	`, qualifiedContentColumns, strings.Join(conditions, " AND "), sortKey, direction, direction, len(args))
+
+	return r.list(ctx, query, args...)
+}
+
+// ListUnindexed は検索用の索引が作成されていないコンテンツを取得します
+func (r *ContentRepository) ListUnindexed(ctx context.Context, limit int) ([]*entity.Content, error) {
+	query := `
+		SELECT ` + contentColumns + `
+		FROM contents
+		WHERE search_tokens IS NULL
+		ORDER BY id
+		LIMIT $1
+	`
+	return r.list(ctx, query, limit)
+}
+
+// UpdateSearchIndex はコンテンツの検索用の索引を更新します
+func (r *ContentRepository) UpdateSearchIndex(ctx context.Context, content *entity.Content) error {
+	searchText, searchTokens := contentSearchDocument(content)
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE contents SET search_text = $1, search_tokens = $2 WHERE id = $3`,
+		searchText, pq.Array(searchTokens), content.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update content search index: %w", err)
+	}
+
+	return nil
+}
+
 // FindByUserID は指定されたユーザーIDのコンテンツ一覧を取得します
 func (r *ContentRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Content, error) {
 	query := `
-		SELECT id, user_id, title, description, content_type, file_path, price, created_at, updated_at
+		SELECT ` + contentColumns + `
 		FROM contents
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -94,18 +253,7 @@ func (r *ContentRepository) FindByUserID(ctx context.Context, userID uuid.UUID)
 
 	var contents []*entity.Content
 	for rows.Next() {
-		content := &entity.Content{}
-		err := rows.Scan(
-			&content.ID,
-			&content.UserID,
-			&content.Title,
-			&content.Description,
-			&content.ContentType,
-			&content.FilePath,
-			&content.Price,
-			&content.CreatedAt,
-			&content.UpdatedAt,
-		)
+		content, err := scanContent(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan content: %w", err)
 		}
@@ -120,40 +268,98 @@ func (r *ContentRepository) FindByUserID(ctx context.Context, userID uuid.UUID)
 }
 
 // Update は既存のコンテンツを更新します
-func (r *ContentRepository) Update(ctx context.Context, content *entity.Content) error {
+// ファイルが差し替えられた場合は審査状態を審査待ちに戻し、取り下げ済みの場合は entity.ErrContentTakenDown を返します
+// event を指定した場合は審査の履歴も同じトランザクションで保存します
+func (r *ContentRepository) Update(ctx context.Context, content *entity.Content, event *entity.ModerationEvent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 読み込み後に審査状態が変わっていても取り下げを上書きしないよう、行をロックして確認する
+	var filePath string
+	var status entity.ModerationStatus
+	err = tx.QueryRowContext(ctx, `SELECT file_path, moderation_status FROM contents WHERE id = $1 FOR UPDATE`, content.ID).Scan(&filePath, &status)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("content not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock content: %w", err)
+	}
+	if filePath != content.FilePath && status == entity.ModerationStatusTakenDown {
+		return entity.ErrContentTakenDown
+	}
+
 	query := `
 		UPDATE contents
-		SET title = $1, description = $2, price = $3, updated_at = $4
-		WHERE id = $5
+		SET oshi_id = $1, title = $2, description = $3, price = $4, publish_at = $5, unpublish_at = $6, updated_at = $7,
+			search_text = $9, search_tokens = $10, content_type = $11, file_path = $12,
+			moderation_status = CASE WHEN file_path <> $12 THEN 'pending' ELSE moderation_status END
+		WHERE id = $8
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	searchText, searchTokens := contentSearchDocument(content)
+	_, err = tx.ExecContext(ctx, query,
+		content.OshiID,
 		content.Title,
 		content.Description,
 		content.Price,
+		content.PublishAt,
+		content.UnpublishAt,
 		content.UpdatedAt,
 		content.ID,
+		searchText,
+		pq.Array(searchTokens),
+		content.ContentType,
+		content.FilePath,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update content: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	if event != nil {
+		// 履歴には読み込み時ではなく、ロックした時点の審査状態を記録する
+		event.FromStatus = status
+		if err := insertModerationEvent(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// MarkReleased はコンテンツを公開済みとして記録します
+// 既に公開済みとして記録されている場合は false を返します
+func (r *ContentRepository) MarkReleased(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `UPDATE contents SET released_at = NOW() WHERE id = $1 AND released_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return false, fmt.Errorf("failed to mark content released: %w", err)
 	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("content not found")
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
-	return nil
+	return rowsAffected > 0, nil
 }
 
-// Delete は指定されたIDのコンテンツを削除します
+// Delete は指定されたIDのコンテンツを診断との紐付けと共に削除します
 func (r *ContentRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM contents WHERE id = $1`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	if _, err := tx.ExecContext(ctx, `DELETE FROM diagnosis_contents WHERE content_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete diagnosis contents: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM contents WHERE id = $1`, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete content: %w", err)
 	}
@@ -166,13 +372,17 @@ func (r *ContentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("content not found")
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
 // FindByDiagnosisID は指定された診断IDに紐付けられたコンテンツ一覧を取得します
 func (r *ContentRepository) FindByDiagnosisID(ctx context.Context, diagnosisID uuid.UUID) ([]*entity.Content, error) {
 	query := `
-		SELECT c.id, c.user_id, c.title, c.description, c.content_type, c.file_path, c.price, c.created_at, c.updated_at
+		SELECT ` + qualifiedContentColumns + `
 		FROM contents c
 		INNER JOIN diagnosis_contents dc ON c.id = dc.content_id
 		WHERE dc.diagnosis_id = $1
@@ -187,18 +397,7 @@ func (r *ContentRepository) FindByDiagnosisID(ctx context.Context, diagnosisID u
 
 	var contents []*entity.Content
 	for rows.Next() {
-		content := &entity.Content{}
-		err := rows.Scan(
-			&content.ID,
-			&content.UserID,
-			&content.Title,
-			&content.Description,
-			&content.ContentType,
-			&content.FilePath,
-			&content.Price,
-			&content.CreatedAt,
-			&content.UpdatedAt,
-		)
+		content, err := scanContent(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan content: %w", err)
 		}
@@ -223,7 +422,7 @@ func (r *ContentRepository) AttachToDiagnosis(ctx context.Context, contentID, di
 		uuid.New(),
 		diagnosisID,
 		contentID,
-		sql.NullTime{Time: entity.Now(), Valid: true},
+		sql.NullTime{Time: time.Now(), Valid: true},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to attach content to diagnosis: %w", err)
@@ -254,3 +453,74 @@ func (r *ContentRepository) DetachFromDiagnosis(ctx context.Context, contentID,
 
 	return nil
 }
+
+// list はコンテンツの一覧を取得するクエリを実行します
+func (r *ContentRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entity.Content, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contents: %w", err)
+	}
+	defer rows.Close()
+
+	var contents []*entity.Content
+	for rows.Next() {
+		content, err := scanContent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan content: %w", err)
+		}
+		contents = append(contents, content)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contents: %w", err)
+	}
+
+	return contents, nil
+}
+
+// contentSearchDocument はコンテンツのタイトルと説明から検索用の正規化したテキストとトークンを作成します
+func contentSearchDocument(content *entity.Content) (string, []string) {
+	text := content.Title + " " + content.Description
+	return search.Normalize(text), search.DocumentTokens(text)
+}
+
+func scanContent(s rowScanner) (*entity.Content, error) {
+	content := &entity.Content{}
+	var hiddenAt, publishAt, unpublishAt, releasedAt sql.NullTime
+	err := s.Scan(
+		&content.ID,
+		&content.UserID,
+		&content.OshiID,
+		&content.Title,
+		&content.Description,
+		&content.ContentType,
+		&content.FilePath,
+		&content.Price,
+		&content.ModerationStatus,
+		&hiddenAt,
+		&content.ReportCount,
+		&publishAt,
+		&unpublishAt,
+		&releasedAt,
+		&content.CreatedAt,
+		&content.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if hiddenAt.Valid {
+		content.HiddenAt = &hiddenAt.Time
+	}
+	if publishAt.Valid {
+		content.PublishAt = &publishAt.Time
+	}
+	if unpublishAt.Valid {
+		content.UnpublishAt = &unpublishAt.Time
+	}
+	if releasedAt.Valid {
+		content.ReleasedAt = &releasedAt.Time
+	}
+
+	return content, nil
+}
@@ -0,0 +1,272 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// EmailRepository はPostgreSQLを使用したEmailRepositoryの実装です
+type EmailRepository struct {
+	db *sql.DB
+}
+
+// NewEmailRepository は新しいEmailRepositoryを作成します
+func NewEmailRepository(db *sql.DB) repository.EmailRepository {
+	return &EmailRepository{db: db}
+}
+
+const emailColumns = `id, user_id, to_address, category, template, locale, params, status, attempts, last_error, provider_message_id, created_at, sent_at`
+
+// Create はメールを保存します
+func (r *EmailRepository) Create(ctx context.Context, email *entity.Email) error {
+	query := `
+		INSERT INTO emails (` + emailColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	params, err := json.Marshal(email.Params)
+	if err != nil {
+		return fmt.Errorf("failed to encode email params: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		email.ID,
+		sql.NullString{String: email.UserID, Valid: email.UserID != ""},
+		email.To,
+		email.Category,
+		email.Template,
+		email.Locale,
+		params,
+		email.Status,
+		email.Attempts,
+		email.LastError,
+		email.ProviderMessageID,
+		email.CreatedAt,
+		email.SentAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create email: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID は指定されたIDのメールを取得します
+func (r *EmailRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Email, error) {
+	query := `SELECT ` + emailColumns + ` FROM emails WHERE id = $1`
+
+	email, err := scanEmail(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find email: %w", err)
+	}
+
+	return email, nil
+}
+
+// FindByProviderMessageID は送信サーバーが発行したIDのメールを取得します
+func (r *EmailRepository) FindByProviderMessageID(ctx context.Context, messageID string) (*entity.Email, error) {
+	query := `SELECT ` + emailColumns + ` FROM emails WHERE provider_message_id = $1 AND provider_message_id <> '' LIMIT 1`
+
+	email, err := scanEmail(r.db.QueryRowContext(ctx, query, messageID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find email: %w", err)
+	}
+
+	return email, nil
+}
+
+// UpdateDelivery はメールの送信状況、試行回数、エラー、送信サーバーのID、送信日時を更新します
+func (r *EmailRepository) UpdateDelivery(ctx context.Context, email *entity.Email) error {
+	query := `
+		UPDATE emails
+		SET status = $2, attempts = $3, last_error = $4, provider_message_id = $5, sent_at = $6
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		email.ID,
+		email.Status,
+		email.Attempts,
+		email.LastError,
+		email.ProviderMessageID,
+		email.SentAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update email delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUserID はユーザーに送ったメールを新しい順に取得します
+func (r *EmailRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.Email, error) {
+	query := `SELECT ` + emailColumns + ` FROM emails WHERE user_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list emails: %w", err)
+	}
+	defer rows.Close()
+
+	var emails []*entity.Email
+	for rows.Next() {
+		email, err := scanEmail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan email: %w", err)
+		}
+		emails = append(emails, email)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating emails: %w", err)
+	}
+
+	return emails, nil
+}
+
+// DeleteAllByUserID はユーザーに送ったメールの記録を削除します
+func (r *EmailRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM emails WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete emails: %w", err)
+	}
+
+	return nil
+}
+
+func scanEmail(s rowScanner) (*entity.Email, error) {
+	email := &entity.Email{}
+	var userID sql.NullString
+	var params []byte
+	var sentAt sql.NullTime
+	err := s.Scan(
+		&email.ID,
+		&userID,
+		&email.To,
+		&email.Category,
+		&email.Template,
+		&email.Locale,
+		&params,
+		&email.Status,
+		&email.Attempts,
+		&email.LastError,
+		&email.ProviderMessageID,
+		&email.CreatedAt,
+		&sentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	email.UserID = userID.String
+	if err := json.Unmarshal(params, &email.Params); err != nil {
+		return nil, fmt.Errorf("failed to decode email params: %w", err)
+	}
+	if sentAt.Valid {
+		email.SentAt = &sentAt.Time
+	}
+
+	return email, nil
+}
+
+// EmailSuppressionRepository はPostgreSQLを使用したEmailSuppressionRepositoryの実装です
+type EmailSuppressionRepository struct {
+	db *sql.DB
+}
+
+// NewEmailSuppressionRepository は新しいEmailSuppressionRepositoryを作成します
+func NewEmailSuppressionRepository(db *sql.DB) repository.EmailSuppressionRepository {
+	return &EmailSuppressionRepository{db: db}
+}
+
+// Add は送信の停止を保存します。既に停止している場合は何もしません
+func (r *EmailSuppressionRepository) Add(ctx context.Context, suppression *entity.EmailSuppression) error {
+	query := `
+		INSERT INTO email_suppressions (email, category, reason, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (email, category) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		suppression.Email,
+		suppression.Category,
+		suppression.Reason,
+		suppression.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add email suppression: %w", err)
+	}
+
+	return nil
+}
+
+// IsSuppressed はメールアドレスへの指定した種類のメールの送信が停止されているかどうかを確認します
+func (r *EmailSuppressionRepository) IsSuppressed(ctx context.Context, email string, category entity.EmailCategory) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM email_suppressions WHERE email = $1 AND category IN ('', $2))`
+
+	var suppressed bool
+	if err := r.db.QueryRowContext(ctx, query, email, category).Scan(&suppressed); err != nil {
+		return false, fmt.Errorf("failed to check email suppression: %w", err)
+	}
+
+	return suppressed, nil
+}
+
+// ListByEmail はメールアドレスへの送信の停止を取得します
+func (r *EmailSuppressionRepository) ListByEmail(ctx context.Context, email string) ([]*entity.EmailSuppression, error) {
+	query := `SELECT email, category, reason, created_at FROM email_suppressions WHERE email = $1 ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	var suppressions []*entity.EmailSuppression
+	for rows.Next() {
+		suppression := &entity.EmailSuppression{}
+		if err := rows.Scan(&suppression.Email, &suppression.Category, &suppression.Reason, &suppression.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan email suppression: %w", err)
+		}
+		suppressions = append(suppressions, suppression)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating email suppressions: %w", err)
+	}
+
+	return suppressions, nil
+}
+
+// RemoveUnsubscribe は配信停止による指定した種類のメールの送信の停止を解除します
+func (r *EmailSuppressionRepository) RemoveUnsubscribe(ctx context.Context, email string, category entity.EmailCategory) error {
+	query := `DELETE FROM email_suppressions WHERE email = $1 AND category = $2 AND reason = 'unsubscribe'`
+
+	if _, err := r.db.ExecContext(ctx, query, email, category); err != nil {
+		return fmt.Errorf("failed to remove email suppression: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByEmail はメールアドレスへの全ての送信の停止を削除します
+func (r *EmailSuppressionRepository) DeleteByEmail(ctx context.Context, email string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM email_suppressions WHERE email = $1`, email); err != nil {
+		return fmt.Errorf("failed to delete email suppressions: %w", err)
+	}
+
+	return nil
+}
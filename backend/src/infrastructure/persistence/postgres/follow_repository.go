@@ -0,0 +1,368 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// FollowRepository はPostgreSQLを使用したFollowRepositoryの実装です
+type FollowRepository struct {
+	db *sql.DB
+}
+
+// NewFollowRepository は新しいFollowRepositoryを作成します
+func NewFollowRepository(db *sql.DB) repository.FollowRepository {
+	return &FollowRepository{db: db}
+}
+
+// Follow はフォローを登録し、推しのフォロワー数を増やします
+func (r *FollowRepository) Follow(ctx context.Context, follow *entity.OshiFollow) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO oshi_follows (user_id, oshi_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, oshi_id) DO NOTHING
+	`
+	result, err := tx.ExecContext(ctx, query, follow.UserID, follow.OshiID, follow.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to follow oshi: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE oshis SET follower_count = follower_count + 1 WHERE id = $1`, follow.OshiID); err != nil {
+		return false, fmt.Errorf("failed to increment follower count: %w", err)
+	}
+
+	return true, tx.Commit()
+}
+
+// Unfollow はフォローを解除し、推しのフォロワー数を減らします
+func (r *FollowRepository) Unfollow(ctx context.Context, userID string, oshiID uuid.UUID) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM oshi_follows WHERE user_id = $1 AND oshi_id = $2`, userID, oshiID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unfollow oshi: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE oshis SET follower_count = GREATEST(follower_count - 1, 0) WHERE id = $1`, oshiID); err != nil {
+		return false, fmt.Errorf("failed to decrement follower count: %w", err)
+	}
+
+	return true, tx.Commit()
+}
+
+// IsFollowing はユーザーが推しをフォローしているかどうかを確認します
+func (r *FollowRepository) IsFollowing(ctx context.Context, userID string, oshiID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM oshi_follows WHERE user_id = $1 AND oshi_id = $2)`
+
+	var ok bool
+	if err := r.db.QueryRowContext(ctx, query, userID, oshiID).Scan(&ok); err != nil {
+		return false, fmt.Errorf("failed to check follow: %w", err)
+	}
+
+	return ok, nil
+}
+
+// ListByUserID はユーザーのフォロー一覧を新しい順に取得します
+func (r *FollowRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.OshiFollow, error) {
+	query := `
+		SELECT user_id, oshi_id, created_at
+		FROM oshi_follows
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list follows: %w", err)
+	}
+	defer rows.Close()
+
+	var follows []*entity.OshiFollow
+	for rows.Next() {
+		follow := &entity.OshiFollow{}
+		if err := rows.Scan(&follow.UserID, &follow.OshiID, &follow.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan follow: %w", err)
+		}
+		follows = append(follows, follow)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating follows: %w", err)
+	}
+
+	return follows, nil
+}
+
+// ListFollowerIDs は推しのフォロワーのIDを after より後からID順に取得します
+func (r *FollowRepository) ListFollowerIDs(ctx context.Context, oshiID uuid.UUID, excludeHiddenFrom, after string, limit int) ([]string, error) {
+	query := `
+		SELECT f.user_id
+		FROM oshi_follows f
+		WHERE f.oshi_id = $1
+		AND ($2 = '' OR f.user_id > $2::uuid)
+		AND ` + hiddenFromViewer("NULLIF($3, '')::uuid", "f.user_id::text") + `
+		ORDER BY f.user_id
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, oshiID, after, excludeHiddenFrom, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list followers: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan follower: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating followers: %w", err)
+	}
+
+	return ids, nil
+}
+
+// DeleteAllByUserID はユーザーの全てのフォローを解除し、各推しのフォロワー数を減らします
+func (r *FollowRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE oshis
+		SET follower_count = GREATEST(follower_count - 1, 0)
+		WHERE id IN (SELECT oshi_id FROM oshi_follows WHERE user_id = $1)
+	`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to decrement follower counts: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM oshi_follows WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete follows: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// OshiNewsRepository はPostgreSQLを使用したOshiNewsRepositoryの実装です
+type OshiNewsRepository struct {
+	db *sql.DB
+}
+
+// NewOshiNewsRepository は新しいOshiNewsRepositoryを作成します
+func NewOshiNewsRepository(db *sql.DB) repository.OshiNewsRepository {
+	return &OshiNewsRepository{db: db}
+}
+
+const oshiNewsColumns = `id, oshi_id, author_id, title, body, created_at`
+
+// Create はお知らせを保存します
+func (r *OshiNewsRepository) Create(ctx context.Context, news *entity.OshiNews) error {
+	query := `INSERT INTO oshi_news (` + oshiNewsColumns + `) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.ExecContext(ctx, query, news.ID, news.OshiID, news.AuthorID, news.Title, news.Body, news.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create oshi news: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID は指定されたIDのお知らせを取得します
+func (r *OshiNewsRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.OshiNews, error) {
+	query := `SELECT ` + oshiNewsColumns + ` FROM oshi_news WHERE id = $1`
+
+	news := &entity.OshiNews{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&news.ID,
+		&news.OshiID,
+		&news.AuthorID,
+		&news.Title,
+		&news.Body,
+		&news.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oshi news: %w", err)
+	}
+
+	return news, nil
+}
+
+// FindByIDs は指定されたIDのお知らせをまとめて取得します
+func (r *OshiNewsRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.OshiNews, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT ` + oshiNewsColumns + ` FROM oshi_news WHERE id = ANY($1::uuid[])`
+	return r.list(ctx, query, pq.Array(uuidStrings(ids)))
+}
+
+// ListByOshiID は推しのお知らせを新しい順に取得します
+func (r *OshiNewsRepository) ListByOshiID(ctx context.Context, oshiID uuid.UUID, limit, offset int) ([]*entity.OshiNews, error) {
+	query := `
+		SELECT ` + oshiNewsColumns + `
+		FROM oshi_news
+		WHERE oshi_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+	return r.list(ctx, query, oshiID, limit, offset)
+}
+
+// Delete はお知らせを削除します
+func (r *OshiNewsRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM oshi_news WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete oshi news: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OshiNewsRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entity.OshiNews, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oshi news: %w", err)
+	}
+	defer rows.Close()
+
+	var newsList []*entity.OshiNews
+	for rows.Next() {
+		news := &entity.OshiNews{}
+		if err := rows.Scan(&news.ID, &news.OshiID, &news.AuthorID, &news.Title, &news.Body, &news.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan oshi news: %w", err)
+		}
+		newsList = append(newsList, news)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating oshi news: %w", err)
+	}
+
+	return newsList, nil
+}
+
+// FeedRepository はPostgreSQLを使用したFeedRepositoryの実装です
+type FeedRepository struct {
+	db *sql.DB
+}
+
+// NewFeedRepository は新しいFeedRepositoryを作成します
+func NewFeedRepository(db *sql.DB) repository.FeedRepository {
+	return &FeedRepository{db: db}
+}
+
+// feedQuery はフォロー中の推しのフィード項目を読み取り時に集約するSQLです
+// 種類ごとに (oshi_id, created_at DESC, id DESC) のインデックスを使ってカーソル以降の上位 limit 件だけを取り出し、
+// 最後に全体を並べ替えて limit 件に絞ります。フォロー数が多くても各推しの先頭数件しか読みません
+// コンテンツは閲覧者がブロック・ミュートした投稿者と閲覧者をブロックした投稿者のもの、公開期間外のものを除外し、
+// 公開日時が指定されている場合は公開日時の位置に並べます
+var feedQuery = `
+	WITH followed AS (
+		SELECT oshi_id, created_at FROM oshi_follows WHERE user_id = $1
+	)
+	(
+		SELECT 'content' AS type, c.id, c.oshi_id, COALESCE(c.publish_at, c.created_at) AS created_at
+		FROM followed f
+		INNER JOIN contents c ON c.oshi_id = f.oshi_id
+		WHERE ` + contentVisible("c") + `
+		AND ` + hiddenFromViewer("c.user_id", "$1::text") + `
+		AND ($2::timestamptz IS NULL OR (COALESCE(c.publish_at, c.created_at), c.id) < ($2::timestamptz, $3::uuid))
+		ORDER BY COALESCE(c.publish_at, c.created_at) DESC, c.id DESC
+		LIMIT $4
+	)
+	UNION ALL
+	(
+		SELECT 'news' AS type, n.id, n.oshi_id, n.created_at
+		FROM followed f
+		INNER JOIN oshi_news n ON n.oshi_id = f.oshi_id
+		WHERE $2::timestamptz IS NULL OR (n.created_at, n.id) < ($2::timestamptz, $3::uuid)
+		ORDER BY n.created_at DESC, n.id DESC
+		LIMIT $4
+	)
+	UNION ALL
+	(
+		SELECT 'compatibility_prompt' AS type, f.oshi_id AS id, f.oshi_id, f.created_at
+		FROM followed f
+		INNER JOIN oshis o ON o.id = f.oshi_id
+		WHERE o.official_diagnosis_result <> ''
+		AND ($2::timestamptz IS NULL OR (f.created_at, f.oshi_id) < ($2::timestamptz, $3::uuid))
+		ORDER BY f.created_at DESC, f.oshi_id DESC
+		LIMIT $4
+	)
+	ORDER BY created_at DESC, id DESC
+	LIMIT $4
+`
+
+// ListEntries はユーザーがフォローしている推しのフィード項目を新しい順に取得します
+func (r *FeedRepository) ListEntries(ctx context.Context, userID string, cursor *repository.FeedCursor, limit int) ([]*entity.FeedEntry, error) {
+	var cursorTime sql.NullTime
+	var cursorID uuid.NullUUID
+	if cursor != nil {
+		cursorTime = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		cursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	rows, err := r.db.QueryContext(ctx, feedQuery, userID, cursorTime, cursorID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feed: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entity.FeedEntry
+	for rows.Next() {
+		entry := &entity.FeedEntry{}
+		if err := rows.Scan(&entry.Type, &entry.ID, &entry.OshiID, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan feed entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating feed: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+// IdentityRepository はPostgreSQLを使用したIdentityRepositoryの実装です
+type IdentityRepository struct {
+	db *sql.DB
+}
+
+// NewIdentityRepository は新しいIdentityRepositoryを作成します
+func NewIdentityRepository(db *sql.DB) repository.IdentityRepository {
+	return &IdentityRepository{db: db}
+}
+
+// Create は新しい紐付けを作成します
+func (r *IdentityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (
+			id, user_id, provider, subject, email, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+		identity.CreatedAt,
+		identity.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create identity: %w", err)
+	}
+
+	return nil
+}
+
+// FindByProviderSubject はプロバイダーとサブジェクトで紐付けを取得します
+func (r *IdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at, updated_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	identity := &entity.UserIdentity{}
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+// FindByUserID は指定されたユーザーの紐付け一覧を取得します
+func (r *IdentityRepository) FindByUserID(ctx context.Context, userID string) ([]*entity.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at, updated_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find identities: %w", err)
+	}
+	defer rows.Close()
+
+	var identities []*entity.UserIdentity
+	for rows.Next() {
+		identity := &entity.UserIdentity{}
+		err := rows.Scan(
+			&identity.ID,
+			&identity.UserID,
+			&identity.Provider,
+			&identity.Subject,
+			&identity.Email,
+			&identity.CreatedAt,
+			&identity.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan identity: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// Delete は指定されたユーザーとプロバイダーの紐付けを削除します
+func (r *IdentityRepository) Delete(ctx context.Context, userID, provider string) error {
+	query := `DELETE FROM user_identities WHERE user_id = $1 AND provider = $2`
+
+	result, err := r.db.ExecContext(ctx, query, userID, provider)
+	if err != nil {
+		return fmt.Errorf("failed to delete identity: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("identity not found")
+	}
+
+	return nil
+}
+
+// OAuthStateRepository はPostgreSQLを使用したOAuthStateRepositoryの実装です
+type OAuthStateRepository struct {
+	db *sql.DB
+}
+
+// NewOAuthStateRepository は新しいOAuthStateRepositoryを作成します
+func NewOAuthStateRepository(db *sql.DB) repository.OAuthStateRepository {
+	return &OAuthStateRepository{db: db}
+}
+
+// Create は新しい状態を保存します
+func (r *OAuthStateRepository) Create(ctx context.Context, state *entity.OAuthState) error {
+	query := `
+		INSERT INTO oauth_states (
+			state, provider, nonce, code_verifier, link_user_id, binding_hash, expires_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	var linkUserID sql.NullString
+	if state.LinkUserID != "" {
+		linkUserID = sql.NullString{String: state.LinkUserID, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		state.State,
+		state.Provider,
+		state.Nonce,
+		state.CodeVerifier,
+		linkUserID,
+		state.BindingHash,
+		state.ExpiresAt,
+		state.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create oauth state: %w", err)
+	}
+
+	return nil
+}
+
+// Consume は状態を取得して削除します
+func (r *OAuthStateRepository) Consume(ctx context.Context, state string) (*entity.OAuthState, error) {
+	query := `
+		DELETE FROM oauth_states
+		WHERE state = $1
+		RETURNING state, provider, nonce, code_verifier, link_user_id, binding_hash, expires_at, created_at
+	`
+
+	s := &entity.OAuthState{}
+	var linkUserID sql.NullString
+	err := r.db.QueryRowContext(ctx, query, state).Scan(
+		&s.State,
+		&s.Provider,
+		&s.Nonce,
+		&s.CodeVerifier,
+		&linkUserID,
+		&s.BindingHash,
+		&s.ExpiresAt,
+		&s.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume oauth state: %w", err)
+	}
+
+	s.LinkUserID = linkUserID.String
+	return s, nil
+}
+
+// DeleteExpired は有効期限切れの状態を削除します
+func (r *OAuthStateRepository) DeleteExpired(ctx context.Context) error {
+	query := `DELETE FROM oauth_states WHERE expires_at <= $1`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now()); err != nil {
+		return fmt.Errorf("failed to delete expired oauth states: %w", err)
+	}
+
+	return nil
+}
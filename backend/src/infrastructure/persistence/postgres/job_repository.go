@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// JobRepository はPostgreSQLを使用したJobRepositoryの実装です
+type JobRepository struct {
+	db *sql.DB
+}
+
+// NewJobRepository は新しいJobRepositoryを作成します
+func NewJobRepository(db *sql.DB) repository.JobRepository {
+	return &JobRepository{db: db}
+}
+
+// Enqueue は新しいジョブを登録します
+func (r *JobRepository) Enqueue(ctx context.Context, job *entity.Job) error {
+	query := `
+		INSERT INTO jobs (
+			id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		job.ID,
+		job.Type,
+		[]byte(job.Payload),
+		job.Status,
+		job.Attempts,
+		job.MaxAttempts,
+		job.RunAt,
+		job.LastError,
+		job.CreatedAt,
+		job.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimNext は実行可能なジョブを1件取得して実行中にします
+// FOR UPDATE SKIP LOCKED により、複数のワーカー間でジョブが重複しません
+func (r *JobRepository) ClaimNext(ctx context.Context, types []string, now time.Time) (*entity.Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'running', attempts = attempts + 1, updated_at = $1
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status = 'pending' AND run_at <= $1 AND type = ANY($2)
+			ORDER BY run_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at
+	`
+
+	job := &entity.Job{}
+	var payload []byte
+	err := r.db.QueryRowContext(ctx, query, now, pq.Array(types)).Scan(
+		&job.ID,
+		&job.Type,
+		&payload,
+		&job.Status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.RunAt,
+		&job.LastError,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	job.Payload = payload
+	return job, nil
+}
+
+// MarkDone はジョブを完了にします
+func (r *JobRepository) MarkDone(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE jobs SET status = 'done', last_error = '', updated_at = $2 WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark job done: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed はジョブの失敗を記録します
+func (r *JobRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, retryAt *time.Time) error {
+	query := `UPDATE jobs SET status = 'failed', last_error = $2, updated_at = $3 WHERE id = $1`
+	args := []interface{}{id, lastError, time.Now()}
+	if retryAt != nil {
+		query = `UPDATE jobs SET status = 'pending', last_error = $2, updated_at = $3, run_at = $4 WHERE id = $1`
+		args = append(args, *retryAt)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to mark job failed: %w", err)
+	}
+
+	return nil
+}
+
+// RequeueStale は一定時間以上実行中のままのジョブを再実行可能にします
+func (r *JobRepository) RequeueStale(ctx context.Context, olderThan time.Time) error {
+	query := `
+		UPDATE jobs
+		SET status = CASE WHEN attempts < max_attempts THEN 'pending' ELSE 'failed' END,
+			last_error = 'worker timed out',
+			updated_at = $2
+		WHERE status = 'running' AND updated_at < $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, olderThan, time.Now()); err != nil {
+		return fmt.Errorf("failed to requeue stale jobs: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// LeaderboardRepository はPostgreSQLを使用したLeaderboardRepositoryの実装です
+type LeaderboardRepository struct {
+	db *sql.DB
+}
+
+// NewLeaderboardRepository は新しいLeaderboardRepositoryを作成します
+func NewLeaderboardRepository(db *sql.DB) repository.LeaderboardRepository {
+	return &LeaderboardRepository{db: db}
+}
+
+// leaderboardVisible はランキングに表示するファンの条件です
+// ランキング非表示を選んだユーザーと不正の疑いで指定されたアカウントを除外します
+const leaderboardVisible = `
+	(p.privacy->>'leaderboard_opt_out') IS DISTINCT FROM 'true'
+	AND NOT EXISTS (SELECT 1 FROM account_flags af WHERE af.user_id = s.user_id)
+`
+
+// AddPoints はユーザーの推しに対するポイントを各集計単位に加算します
+func (r *LeaderboardRepository) AddPoints(ctx context.Context, userID string, oshiID uuid.UUID, points float64, buckets []entity.LeaderboardBucket) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO leaderboard_scores (oshi_id, period, period_start, user_id, points, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (oshi_id, period, period_start, user_id) DO UPDATE
+		SET points = leaderboard_scores.points + EXCLUDED.points,
+			updated_at = EXCLUDED.updated_at
+	`
+	now := time.Now()
+	for _, bucket := range buckets {
+		if _, err := tx.ExecContext(ctx, query, oshiID, bucket.Period, bucket.PeriodStart, userID, points, now); err != nil {
+			return fmt.Errorf("failed to add leaderboard points: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListTop は集計単位のランキングを上位から取得します
+// 順位は (oshi_id, period, period_start, points) のインデックスで集計単位内のみを走査して求めます
+// 閲覧者に表示しないファンは順位を求めた後に除外するため、他のファンの順位は変わりません
+func (r *LeaderboardRepository) ListTop(ctx context.Context, oshiID uuid.UUID, bucket entity.LeaderboardBucket, viewerID string, limit, offset int) ([]*entity.LeaderboardEntry, error) {
+	query := `
+		SELECT rank, user_id, display_name, avatar_url, points
+		FROM (
+			SELECT RANK() OVER (ORDER BY s.points DESC) AS rank,
+				s.user_id,
+				COALESCE(p.display_name, u.name) AS display_name,
+				COALESCE(p.avatar_url, '') AS avatar_url,
+				s.points
+			FROM leaderboard_scores s
+			INNER JOIN users u ON u.id = s.user_id
+			LEFT JOIN user_profiles p ON p.user_id = s.user_id
+			WHERE s.oshi_id = $1 AND s.period = $2 AND s.period_start = $3
+			AND u.deleted_at IS NULL
+			AND ` + leaderboardVisible + `
+		) ranked
+		WHERE ` + hiddenFromViewer("ranked.user_id", "$6") + `
+		ORDER BY rank, user_id
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, oshiID, bucket.Period, bucket.PeriodStart, limit, offset, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entity.LeaderboardEntry
+	for rows.Next() {
+		entry := &entity.LeaderboardEntry{}
+		if err := rows.Scan(&entry.Rank, &entry.UserID, &entry.DisplayName, &entry.AvatarURL, &entry.Points); err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating leaderboard: %w", err)
+	}
+
+	return entries, nil
+}
+
+// FindPosition はユーザーのポイントと、除外されていないファンのうちユーザーより上位の人数と総数を取得します
+func (r *LeaderboardRepository) FindPosition(ctx context.Context, userID string, oshiID uuid.UUID, bucket entity.LeaderboardBucket) (float64, int, int, error) {
+	var points float64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT points
+		FROM leaderboard_scores
+		WHERE oshi_id = $1 AND period = $2 AND period_start = $3 AND user_id = $4
+	`, oshiID, bucket.Period, bucket.PeriodStart, userID).Scan(&points)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, 0, 0, fmt.Errorf("failed to find leaderboard points: %w", err)
+	}
+
+	query := `
+		SELECT COUNT(*) FILTER (WHERE s.points > $4), COUNT(*)
+		FROM leaderboard_scores s
+		INNER JOIN users u ON u.id = s.user_id
+		LEFT JOIN user_profiles p ON p.user_id = s.user_id
+		WHERE s.oshi_id = $1 AND s.period = $2 AND s.period_start = $3
+		AND u.deleted_at IS NULL
+		AND ` + leaderboardVisible
+
+	var ahead, total int
+	if err := r.db.QueryRowContext(ctx, query, oshiID, bucket.Period, bucket.PeriodStart, points).Scan(&ahead, &total); err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to count leaderboard position: %w", err)
+	}
+
+	return points, ahead, total, nil
+}
+
+// AccountFlagRepository はPostgreSQLを使用したAccountFlagRepositoryの実装です
+type AccountFlagRepository struct {
+	db *sql.DB
+}
+
+// NewAccountFlagRepository は新しいAccountFlagRepositoryを作成します
+func NewAccountFlagRepository(db *sql.DB) repository.AccountFlagRepository {
+	return &AccountFlagRepository{db: db}
+}
+
+// Save は指定を作成または更新します
+func (r *AccountFlagRepository) Save(ctx context.Context, flag *entity.AccountFlag) error {
+	query := `
+		INSERT INTO account_flags (user_id, reason, flagged_by, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET reason = EXCLUDED.reason,
+			flagged_by = EXCLUDED.flagged_by
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, flag.UserID, flag.Reason, flag.FlaggedBy, flag.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save account flag: %w", err)
+	}
+
+	return nil
+}
+
+// Delete は指定を解除します
+func (r *AccountFlagRepository) Delete(ctx context.Context, userID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM account_flags WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete account flag: %w", err)
+	}
+
+	return nil
+}
+
+// IsFlagged はアカウントが指定されているかどうかを確認します
+func (r *AccountFlagRepository) IsFlagged(ctx context.Context, userID string) (bool, error) {
+	var ok bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM account_flags WHERE user_id = $1)`, userID).Scan(&ok); err != nil {
+		return false, fmt.Errorf("failed to check account flag: %w", err)
+	}
+
+	return ok, nil
+}
+
+// List は指定されたアカウントの一覧を新しい順に取得します
+func (r *AccountFlagRepository) List(ctx context.Context, limit, offset int) ([]*entity.AccountFlag, error) {
+	query := `
+		SELECT user_id, reason, flagged_by, created_at
+		FROM account_flags
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []*entity.AccountFlag
+	for rows.Next() {
+		flag := &entity.AccountFlag{}
+		if err := rows.Scan(&flag.UserID, &flag.Reason, &flag.FlaggedBy, &flag.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan account flag: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating account flags: %w", err)
+	}
+
+	return flags, nil
+}
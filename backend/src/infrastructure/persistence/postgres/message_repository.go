@@ -0,0 +1,529 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ConversationRepository はPostgreSQLを使用したConversationRepositoryの実装です
+type ConversationRepository struct {
+	db *sql.DB
+}
+
+// NewConversationRepository は新しいConversationRepositoryを作成します
+func NewConversationRepository(db *sql.DB) repository.ConversationRepository {
+	return &ConversationRepository{db: db}
+}
+
+const conversationColumns = `id, fan_id, creator_id, fan_plan, last_message_at, last_sender_id, fan_last_read_at, creator_last_read_at, created_at, updated_at`
+
+// FindOrCreate は同じファンとキャストのやり取りがあればそれを返し、無ければ保存して返します
+func (r *ConversationRepository) FindOrCreate(ctx context.Context, conversation *entity.Conversation) (*entity.Conversation, error) {
+	insertQuery := `
+		INSERT INTO conversations (id, fan_id, creator_id, fan_plan, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (fan_id, creator_id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, insertQuery,
+		conversation.ID,
+		conversation.FanID,
+		conversation.CreatorID,
+		conversation.FanPlan,
+		conversation.CreatedAt,
+		conversation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	query := `SELECT ` + conversationColumns + ` FROM conversations WHERE fan_id = $1 AND creator_id = $2`
+
+	found, err := scanConversation(r.db.QueryRowContext(ctx, query, conversation.FanID, conversation.CreatorID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find conversation: %w", err)
+	}
+
+	return found, nil
+}
+
+// FindByID は指定されたIDのやり取りを取得します
+func (r *ConversationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Conversation, error) {
+	query := `SELECT ` + conversationColumns + ` FROM conversations WHERE id = $1`
+
+	conversation, err := scanConversation(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// ListInbox は受信箱のやり取りを最後のメッセージが新しい順に取得します
+// キャストの受信箱にはファンがまだメッセージを送っていないやり取りを含めません
+func (r *ConversationRepository) ListInbox(ctx context.Context, filter repository.InboxFilter, cursor *repository.FeedCursor, limit int) ([]*entity.Conversation, error) {
+	viewerColumn, counterpartColumn, lastReadColumn := "fan_id", "creator_id", "fan_last_read_at"
+	if filter.Role == repository.InboxRoleCreator {
+		viewerColumn, counterpartColumn, lastReadColumn = "creator_id", "fan_id", "creator_last_read_at"
+	}
+
+	cursorTime, cursorID := cursorArgs(cursor)
+	query := `
+		SELECT ` + conversationColumns + `
+		FROM conversations
+		WHERE ` + viewerColumn + ` = $1
+		AND ` + hiddenFromViewer(counterpartColumn, "$1::text") + `
+		AND ($2::boolean = false OR last_message_at IS NOT NULL)
+		AND ($3::boolean = false OR (last_sender_id <> $1 AND (` + lastReadColumn + ` IS NULL OR ` + lastReadColumn + ` < last_message_at)))
+		AND ($4::boolean = false OR last_sender_id = ` + counterpartColumn + `)
+		AND ($5 = '' OR fan_plan = $5)
+		AND ($6::timestamptz IS NULL OR (updated_at, id) < ($6::timestamptz, $7::uuid))
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $8
+	`
+	return r.list(ctx, query,
+		filter.ViewerID,
+		filter.Role == repository.InboxRoleCreator,
+		filter.UnreadOnly,
+		filter.AwaitingReply,
+		string(filter.FanPlan),
+		cursorTime,
+		cursorID,
+		limit,
+	)
+}
+
+// CountUnread はやり取りごとの閲覧者の未読のメッセージの件数を取得します
+func (r *ConversationRepository) CountUnread(ctx context.Context, viewerID string, conversationIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(conversationIDs))
+	if len(conversationIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT m.conversation_id, COUNT(*)
+		FROM direct_messages m
+		JOIN conversations c ON c.id = m.conversation_id
+		WHERE m.conversation_id = ANY($2::uuid[])
+		AND m.sender_id <> $1
+		AND m.deleted_at IS NULL
+		AND m.created_at > COALESCE(
+			CASE WHEN c.fan_id = $1 THEN c.fan_last_read_at ELSE c.creator_last_read_at END,
+			'-infinity'::timestamptz
+		)
+		GROUP BY m.conversation_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, viewerID, pq.Array(uuidStrings(conversationIDs)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan unread count: %w", err)
+		}
+		counts[id] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating unread counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// MarkRead は参加者がやり取りを readAt の時点まで既読にしたことを記録します
+// 既に readAt より後まで既読にしている場合は変更しません
+func (r *ConversationRepository) MarkRead(ctx context.Context, conversationID uuid.UUID, userID string, readAt time.Time) error {
+	query := `
+		UPDATE conversations
+		SET fan_last_read_at = CASE WHEN fan_id = $2 THEN GREATEST(fan_last_read_at, $3) ELSE fan_last_read_at END,
+			creator_last_read_at = CASE WHEN creator_id = $2 THEN GREATEST(creator_last_read_at, $3) ELSE creator_last_read_at END
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, conversationID, userID, readAt); err != nil {
+		return fmt.Errorf("failed to mark conversation as read: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUserID はユーザーが参加しているやり取りを取得します
+func (r *ConversationRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.Conversation, error) {
+	query := `
+		SELECT ` + conversationColumns + `
+		FROM conversations
+		WHERE fan_id = $1 OR creator_id = $1
+		ORDER BY updated_at DESC, id DESC
+	`
+	return r.list(ctx, query, userID)
+}
+
+func (r *ConversationRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entity.Conversation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
+	defer rows.Close()
+
+	var conversations []*entity.Conversation
+	for rows.Next() {
+		conversation, err := scanConversation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan conversation: %w", err)
+		}
+		conversations = append(conversations, conversation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating conversations: %w", err)
+	}
+
+	return conversations, nil
+}
+
+func scanConversation(s rowScanner) (*entity.Conversation, error) {
+	conversation := &entity.Conversation{}
+	var lastMessageAt, fanLastReadAt, creatorLastReadAt sql.NullTime
+	var lastSenderID sql.NullString
+	err := s.Scan(
+		&conversation.ID,
+		&conversation.FanID,
+		&conversation.CreatorID,
+		&conversation.FanPlan,
+		&lastMessageAt,
+		&lastSenderID,
+		&fanLastReadAt,
+		&creatorLastReadAt,
+		&conversation.CreatedAt,
+		&conversation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastMessageAt.Valid {
+		conversation.LastMessageAt = &lastMessageAt.Time
+	}
+	conversation.LastSenderID = lastSenderID.String
+	if fanLastReadAt.Valid {
+		conversation.FanLastReadAt = &fanLastReadAt.Time
+	}
+	if creatorLastReadAt.Valid {
+		conversation.CreatorLastReadAt = &creatorLastReadAt.Time
+	}
+
+	return conversation, nil
+}
+
+// DirectMessageRepository はPostgreSQLを使用したDirectMessageRepositoryの実装です
+type DirectMessageRepository struct {
+	db *sql.DB
+}
+
+// NewDirectMessageRepository は新しいDirectMessageRepositoryを作成します
+func NewDirectMessageRepository(db *sql.DB) repository.DirectMessageRepository {
+	return &DirectMessageRepository{db: db}
+}
+
+const directMessageColumns = `id, conversation_id, sender_id, body, attachment_urls, charge, deleted_at, created_at`
+
+// Create はキャストのメッセージを保存し、やり取りの最後のメッセージを更新します
+func (r *DirectMessageRepository) Create(ctx context.Context, conversation *entity.Conversation, message *entity.DirectMessage) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertDirectMessage(ctx, tx, conversation, message); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CreateChargedByFan はファンのメッセージを保存し、送信枠を消費します
+func (r *DirectMessageRepository) CreateChargedByFan(ctx context.Context, conversation *entity.Conversation, message *entity.DirectMessage, monthlyLimit int, periodStart time.Time) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 同じファンの送信を直列にして、件数の確認と消費の間に他の送信が割り込まないようにする
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "direct_messages:"+message.SenderID); err != nil {
+		return false, fmt.Errorf("failed to lock message quota: %w", err)
+	}
+
+	var used int
+	countQuery := `
+		SELECT COUNT(*) FROM direct_messages
+		WHERE sender_id = $1 AND charge = 'plan' AND created_at >= $2
+	`
+	if err := tx.QueryRowContext(ctx, countQuery, message.SenderID, periodStart).Scan(&used); err != nil {
+		return false, fmt.Errorf("failed to count plan charged messages: %w", err)
+	}
+
+	if used < monthlyLimit {
+		message.Charge = entity.MessageChargePlan
+	} else {
+		ticketQuery := `
+			UPDATE message_tickets SET balance = balance - 1, updated_at = $2
+			WHERE user_id = $1 AND balance > 0
+		`
+		result, err := tx.ExecContext(ctx, ticketQuery, message.SenderID, message.CreatedAt)
+		if err != nil {
+			return false, fmt.Errorf("failed to consume message ticket: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return false, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return false, nil
+		}
+		message.Charge = entity.MessageChargeTicket
+	}
+
+	if err := insertDirectMessage(ctx, tx, conversation, message); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// insertDirectMessage はメッセージを保存し、やり取りの最後のメッセージと送信者の既読を更新します
+func insertDirectMessage(ctx context.Context, tx *sql.Tx, conversation *entity.Conversation, message *entity.DirectMessage) error {
+	query := `
+		INSERT INTO direct_messages (` + directMessageColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		message.ID,
+		message.ConversationID,
+		message.SenderID,
+		message.Body,
+		pq.Array(message.AttachmentURLs),
+		message.Charge,
+		message.DeletedAt,
+		message.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create direct message: %w", err)
+	}
+
+	updateQuery := `
+		UPDATE conversations
+		SET last_message_at = $2,
+			last_sender_id = $3,
+			fan_plan = $4,
+			fan_last_read_at = CASE WHEN fan_id = $3 THEN GREATEST(fan_last_read_at, $2) ELSE fan_last_read_at END,
+			creator_last_read_at = CASE WHEN creator_id = $3 THEN GREATEST(creator_last_read_at, $2) ELSE creator_last_read_at END,
+			updated_at = $2
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, updateQuery, conversation.ID, message.CreatedAt, message.SenderID, conversation.FanPlan); err != nil {
+		return fmt.Errorf("failed to update conversation: %w", err)
+	}
+
+	return nil
+}
+
+// ListByConversationID はやり取りのメッセージを新しい順に取得します
+func (r *DirectMessageRepository) ListByConversationID(ctx context.Context, conversationID uuid.UUID, cursor *repository.FeedCursor, limit int) ([]*entity.DirectMessage, error) {
+	cursorTime, cursorID := cursorArgs(cursor)
+	query := `
+		SELECT ` + directMessageColumns + `
+		FROM direct_messages
+		WHERE conversation_id = $1
+		AND ($2::timestamptz IS NULL OR (created_at, id) < ($2::timestamptz, $3::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`
+	return r.list(ctx, query, conversationID, cursorTime, cursorID, limit)
+}
+
+// ListSince はユーザーが参加している全てのやり取りの cursor より後のメッセージを古い順に取得します
+// ユーザーがブロック・ミュートした相手と、ユーザーをブロックした相手のメッセージは含めません
+func (r *DirectMessageRepository) ListSince(ctx context.Context, userID string, cursor repository.FeedCursor, limit int) ([]*entity.DirectMessage, error) {
+	query := `
+		SELECT m.id, m.conversation_id, m.sender_id, m.body, m.attachment_urls, m.charge, m.deleted_at, m.created_at
+		FROM direct_messages m
+		JOIN conversations c ON c.id = m.conversation_id
+		WHERE (c.fan_id = $1 OR c.creator_id = $1)
+		AND m.deleted_at IS NULL
+		AND ` + hiddenFromViewer("m.sender_id", "$1::text") + `
+		AND (m.created_at, m.id) > ($2::timestamptz, $3::uuid)
+		ORDER BY m.created_at ASC, m.id ASC
+		LIMIT $4
+	`
+	return r.list(ctx, query, userID, cursor.CreatedAt, cursor.ID, limit)
+}
+
+// CountPlanChargedSince は periodStart 以降にファンがプランの枠で送ったメッセージの件数を取得します
+func (r *DirectMessageRepository) CountPlanChargedSince(ctx context.Context, fanID string, periodStart time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM direct_messages
+		WHERE sender_id = $1 AND charge = 'plan' AND created_at >= $2
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, fanID, periodStart).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count plan charged messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListBySenderID はユーザーが送った削除されていないメッセージを新しい順に取得します
+func (r *DirectMessageRepository) ListBySenderID(ctx context.Context, senderID string) ([]*entity.DirectMessage, error) {
+	query := `
+		SELECT ` + directMessageColumns + `
+		FROM direct_messages
+		WHERE sender_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+	`
+	return r.list(ctx, query, senderID)
+}
+
+// SoftDeleteAllBySenderID はユーザーが送った全てのメッセージの本文と添付を消して削除済みにします
+// 送信枠の集計に使うため、消費した枠の種類は残します
+func (r *DirectMessageRepository) SoftDeleteAllBySenderID(ctx context.Context, senderID string) error {
+	query := `
+		UPDATE direct_messages
+		SET body = '', attachment_urls = '{}', deleted_at = $2
+		WHERE sender_id = $1 AND deleted_at IS NULL
+	`
+	if _, err := r.db.ExecContext(ctx, query, senderID, time.Now()); err != nil {
+		return fmt.Errorf("failed to delete direct messages: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DirectMessageRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entity.DirectMessage, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list direct messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*entity.DirectMessage
+	for rows.Next() {
+		message, err := scanDirectMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan direct message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating direct messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+func scanDirectMessage(s rowScanner) (*entity.DirectMessage, error) {
+	message := &entity.DirectMessage{}
+	var deletedAt sql.NullTime
+	err := s.Scan(
+		&message.ID,
+		&message.ConversationID,
+		&message.SenderID,
+		&message.Body,
+		pq.Array(&message.AttachmentURLs),
+		&message.Charge,
+		&deletedAt,
+		&message.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.AttachmentURLs == nil {
+		message.AttachmentURLs = []string{}
+	}
+	if deletedAt.Valid {
+		message.DeletedAt = &deletedAt.Time
+	}
+
+	return message, nil
+}
+
+// MessageTicketRepository はPostgreSQLを使用したMessageTicketRepositoryの実装です
+type MessageTicketRepository struct {
+	db *sql.DB
+}
+
+// NewMessageTicketRepository は新しいMessageTicketRepositoryを作成します
+func NewMessageTicketRepository(db *sql.DB) repository.MessageTicketRepository {
+	return &MessageTicketRepository{db: db}
+}
+
+// Balance はユーザーのチケットの残り枚数を取得します
+func (r *MessageTicketRepository) Balance(ctx context.Context, userID string) (int, error) {
+	query := `SELECT balance FROM message_tickets WHERE user_id = $1`
+
+	var balance int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&balance)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get message ticket balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+// Add はユーザーにチケットを追加し、追加後の残り枚数を返します
+func (r *MessageTicketRepository) Add(ctx context.Context, userID string, quantity int) (int, error) {
+	query := `
+		INSERT INTO message_tickets (user_id, balance, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET balance = message_tickets.balance + EXCLUDED.balance, updated_at = EXCLUDED.updated_at
+		RETURNING balance
+	`
+
+	var balance int
+	if err := r.db.QueryRowContext(ctx, query, userID, quantity, time.Now()).Scan(&balance); err != nil {
+		return 0, fmt.Errorf("failed to add message tickets: %w", err)
+	}
+
+	return balance, nil
+}
+
+// DeleteByUserID はユーザーのチケットを削除します
+func (r *MessageTicketRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	query := `DELETE FROM message_tickets WHERE user_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to delete message tickets: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+// MFARepository はPostgreSQLを使用したMFARepositoryの実装です
+type MFARepository struct {
+	db *sql.DB
+}
+
+// NewMFARepository は新しいMFARepositoryを作成します
+func NewMFARepository(db *sql.DB) repository.MFARepository {
+	return &MFARepository{db: db}
+}
+
+// FindByUserID は指定されたユーザーの二要素認証設定を取得します
+func (r *MFARepository) FindByUserID(ctx context.Context, userID string) (*entity.UserMFA, error) {
+	query := `
+		SELECT user_id, secret, enabled, last_used_step, failed_attempts, locked_until, confirmed_at, created_at, updated_at
+		FROM user_mfa
+		WHERE user_id = $1
+	`
+
+	mfa := &entity.UserMFA{}
+	var lockedUntil, confirmedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&mfa.UserID,
+		&mfa.Secret,
+		&mfa.Enabled,
+		&mfa.LastUsedStep,
+		&mfa.FailedAttempts,
+		&lockedUntil,
+		&confirmedAt,
+		&mfa.CreatedAt,
+		&mfa.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find mfa settings: %w", err)
+	}
+
+	if lockedUntil.Valid {
+		mfa.LockedUntil = &lockedUntil.Time
+	}
+	if confirmedAt.Valid {
+		mfa.ConfirmedAt = &confirmedAt.Time
+	}
+
+	return mfa, nil
+}
+
+// Save は二要素認証設定を作成または更新します
+func (r *MFARepository) Save(ctx context.Context, mfa *entity.UserMFA) error {
+	query := `
+		INSERT INTO user_mfa (
+			user_id, secret, enabled, last_used_step, failed_attempts, locked_until, confirmed_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret,
+			enabled = EXCLUDED.enabled,
+			last_used_step = EXCLUDED.last_used_step,
+			failed_attempts = EXCLUDED.failed_attempts,
+			locked_until = EXCLUDED.locked_until,
+			confirmed_at = EXCLUDED.confirmed_at,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		mfa.UserID,
+		mfa.Secret,
+		mfa.Enabled,
+		mfa.LastUsedStep,
+		mfa.FailedAttempts,
+		mfa.LockedUntil,
+		mfa.ConfirmedAt,
+		mfa.CreatedAt,
+		mfa.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save mfa settings: %w", err)
+	}
+
+	return nil
+}
+
+// UseStep は使用済みのタイムステップを記録し、失敗回数をリセットします
+// 同時に同じコードで検証された場合も一方だけが成功するよう、記録済みのステップより新しい場合のみ更新します
+func (r *MFARepository) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	query := `
+		UPDATE user_mfa
+		SET last_used_step = $1, failed_attempts = 0, locked_until = NULL, updated_at = $3
+		WHERE user_id = $2 AND last_used_step < $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, step, userID, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to use mfa step: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RecordFailedAttempt は検証の失敗を記録し、上限に達した場合は検証をロックします
+func (r *MFARepository) RecordFailedAttempt(ctx context.Context, userID string, maxAttempts int, lockUntil time.Time) error {
+	query := `
+		UPDATE user_mfa
+		SET failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+			locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END,
+			updated_at = $4
+		WHERE user_id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, maxAttempts, lockUntil, time.Now()); err != nil {
+		return fmt.Errorf("failed to record mfa failure: %w", err)
+	}
+
+	return nil
+}
+
+// ResetFailedAttempts は検証の失敗回数をリセットします
+func (r *MFARepository) ResetFailedAttempts(ctx context.Context, userID string) error {
+	query := `
+		UPDATE user_mfa
+		SET failed_attempts = 0, locked_until = NULL, updated_at = $2
+		WHERE user_id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, time.Now()); err != nil {
+		return fmt.Errorf("failed to reset mfa failures: %w", err)
+	}
+
+	return nil
+}
+
+// Delete は二要素認証設定とリカバリーコードを削除します
+func (r *MFARepository) Delete(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete mfa settings: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ReplaceRecoveryCodes はユーザーのリカバリーコードを置き換えます
+func (r *MFARepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*entity.MFARecoveryCode) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_mfa_recovery_codes (id, user_id, code_hash, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	for _, code := range codes {
+		if _, err := tx.ExecContext(ctx, query, code.ID, userID, code.CodeHash, code.CreatedAt); err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UseRecoveryCode は未使用のリカバリーコードを使用済みにします
+func (r *MFARepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	query := `
+		UPDATE user_mfa_recovery_codes
+		SET used_at = $1
+		WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CountUnusedRecoveryCodes は未使用のリカバリーコード数を取得します
+func (r *MFARepository) CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM user_mfa_recovery_codes
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,317 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// ContentModerationRepository はPostgreSQLを使用したContentModerationRepositoryの実装です
+type ContentModerationRepository struct {
+	db *sql.DB
+}
+
+// NewContentModerationRepository は新しいContentModerationRepositoryを作成します
+func NewContentModerationRepository(db *sql.DB) repository.ContentModerationRepository {
+	return &ContentModerationRepository{db: db}
+}
+
+const contentReportColumns = `id, content_id, reporter_id, reason, details, status, resolved_by, resolved_at, created_at`
+
+// CreateReport は通報を保存し、コンテンツの未処理の通報の件数を増やします
+func (r *ContentModerationRepository) CreateReport(ctx context.Context, report *entity.ContentReport) (bool, int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO content_reports (` + contentReportColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (content_id, reporter_id) DO NOTHING
+	`
+	result, err := tx.ExecContext(ctx, query,
+		report.ID,
+		report.ContentID,
+		report.ReporterID,
+		report.Reason,
+		report.Details,
+		report.Status,
+		report.ResolvedBy,
+		report.ResolvedAt,
+		report.CreatedAt,
+	)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to create content report: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	var reportCount int
+	if rowsAffected == 0 {
+		err = tx.QueryRowContext(ctx, `SELECT report_count FROM contents WHERE id = $1`, report.ContentID).Scan(&reportCount)
+	} else {
+		err = tx.QueryRowContext(ctx, `UPDATE contents SET report_count = report_count + 1 WHERE id = $1 RETURNING report_count`, report.ContentID).Scan(&reportCount)
+	}
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to update report count: %w", err)
+	}
+
+	return rowsAffected > 0, reportCount, tx.Commit()
+}
+
+// ListReports はコンテンツへの通報を新しい順に取得します
+func (r *ContentModerationRepository) ListReports(ctx context.Context, contentID uuid.UUID) ([]*entity.ContentReport, error) {
+	query := `
+		SELECT ` + contentReportColumns + `
+		FROM content_reports
+		WHERE content_id = $1
+		ORDER BY created_at DESC
+	`
+	return r.listReports(ctx, query, contentID)
+}
+
+// ListReportsByReporterID はユーザーが行った通報を新しい順に取得します
+func (r *ContentModerationRepository) ListReportsByReporterID(ctx context.Context, reporterID string) ([]*entity.ContentReport, error) {
+	query := `
+		SELECT ` + contentReportColumns + `
+		FROM content_reports
+		WHERE reporter_id = $1
+		ORDER BY created_at DESC
+	`
+	return r.listReports(ctx, query, reporterID)
+}
+
+// ClearReportDetailsByReporterID はユーザーが行った通報の詳細を消去します
+func (r *ContentModerationRepository) ClearReportDetailsByReporterID(ctx context.Context, reporterID string) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE content_reports SET details = '' WHERE reporter_id = $1`, reporterID); err != nil {
+		return fmt.Errorf("failed to clear content report details: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ContentModerationRepository) listReports(ctx context.Context, query string, args ...interface{}) ([]*entity.ContentReport, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*entity.ContentReport
+	for rows.Next() {
+		report, err := scanContentReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan content report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating content reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// SaveDecision はコンテンツの審査状態と非表示の状態、審査の履歴を1つのトランザクションで保存します
+func (r *ContentModerationRepository) SaveDecision(ctx context.Context, content *entity.Content, event *entity.ModerationEvent, reportStatus entity.ReportStatus) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if reportStatus != "" {
+		query := `
+			UPDATE content_reports
+			SET status = $1, resolved_by = $2, resolved_at = $3
+			WHERE content_id = $4 AND status = 'open'
+		`
+		if _, err := tx.ExecContext(ctx, query, reportStatus, event.ActorID, event.CreatedAt, content.ID); err != nil {
+			return fmt.Errorf("failed to resolve content reports: %w", err)
+		}
+		content.ReportCount = 0
+	}
+
+	query := `
+		UPDATE contents
+		SET moderation_status = $1, hidden_at = $2, report_count = $3, updated_at = $4
+		WHERE id = $5
+	`
+	result, err := tx.ExecContext(ctx, query,
+		content.ModerationStatus,
+		content.HiddenAt,
+		content.ReportCount,
+		content.UpdatedAt,
+		content.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update content moderation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("content not found")
+	}
+
+	if err := insertModerationEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertModerationEvent はトランザクション内で審査の履歴を保存します
+func insertModerationEvent(ctx context.Context, tx *sql.Tx, event *entity.ModerationEvent) error {
+	query := `
+		INSERT INTO content_moderation_events (id, content_id, actor_id, action, from_status, to_status, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := tx.ExecContext(ctx, query,
+		event.ID,
+		event.ContentID,
+		event.ActorID,
+		event.Action,
+		event.FromStatus,
+		event.ToStatus,
+		event.Reason,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create moderation event: %w", err)
+	}
+
+	return nil
+}
+
+// ListEvents はコンテンツの審査の履歴を古い順に取得します
+func (r *ContentModerationRepository) ListEvents(ctx context.Context, contentID uuid.UUID) ([]*entity.ModerationEvent, error) {
+	query := `
+		SELECT id, content_id, actor_id, action, from_status, to_status, reason, created_at
+		FROM content_moderation_events
+		WHERE content_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, contentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entity.ModerationEvent
+	for rows.Next() {
+		event := &entity.ModerationEvent{}
+		var actorID sql.NullString
+		err := rows.Scan(
+			&event.ID,
+			&event.ContentID,
+			&actorID,
+			&event.Action,
+			&event.FromStatus,
+			&event.ToStatus,
+			&event.Reason,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan moderation event: %w", err)
+		}
+		if actorID.Valid {
+			event.ActorID = &actorID.String
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating moderation events: %w", err)
+	}
+
+	return events, nil
+}
+
+// ListQueue は審査キューのコンテンツを取得します
+// 審査待ちは投稿の古い順、通報ありは通報の多い順に並べます
+func (r *ContentModerationRepository) ListQueue(ctx context.Context, queue repository.ModerationQueue, limit, offset int) ([]*entity.Content, error) {
+	var query string
+	switch queue {
+	case repository.ModerationQueueReported:
+		query = `
+			SELECT ` + contentColumns + `
+			FROM contents
+			WHERE report_count > 0
+			ORDER BY hidden_at IS NULL, report_count DESC, created_at
+			LIMIT $1 OFFSET $2
+		`
+	default:
+		query = `
+			SELECT ` + contentColumns + `
+			FROM contents
+			WHERE moderation_status = 'pending'
+			ORDER BY created_at
+			LIMIT $1 OFFSET $2
+		`
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation queue: %w", err)
+	}
+	defer rows.Close()
+
+	var contents []*entity.Content
+	for rows.Next() {
+		content, err := scanContent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan content: %w", err)
+		}
+		contents = append(contents, content)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating moderation queue: %w", err)
+	}
+
+	return contents, nil
+}
+
+func scanContentReport(s rowScanner) (*entity.ContentReport, error) {
+	report := &entity.ContentReport{}
+	var resolvedBy sql.NullString
+	var resolvedAt sql.NullTime
+	err := s.Scan(
+		&report.ID,
+		&report.ContentID,
+		&report.ReporterID,
+		&report.Reason,
+		&report.Details,
+		&report.Status,
+		&resolvedBy,
+		&resolvedAt,
+		&report.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolvedBy.Valid {
+		report.ResolvedBy = &resolvedBy.String
+	}
+	if resolvedAt.Valid {
+		report.ResolvedAt = &resolvedAt.Time
+	}
+
+	return report, nil
+}
@@ -0,0 +1,690 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// DeviceTokenRepository はPostgreSQLを使用したDeviceTokenRepositoryの実装です
+type DeviceTokenRepository struct {
+	db *sql.DB
+}
+
+// NewDeviceTokenRepository は新しいDeviceTokenRepositoryを作成します
+func NewDeviceTokenRepository(db *sql.DB) repository.DeviceTokenRepository {
+	return &DeviceTokenRepository{db: db}
+}
+
+const deviceTokenColumns = `id, user_id, token, platform, created_at, last_seen_at`
+
+// Save は端末を保存します
+// 同じトークンが登録済みの場合は、ユーザーと端末の種類と最終利用日時を更新して登録済みの端末を返します
+func (r *DeviceTokenRepository) Save(ctx context.Context, device *entity.DeviceToken) (*entity.DeviceToken, error) {
+	query := `
+		INSERT INTO device_tokens (` + deviceTokenColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (token) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			platform = EXCLUDED.platform,
+			last_seen_at = EXCLUDED.last_seen_at
+		RETURNING ` + deviceTokenColumns
+
+	saved, err := scanDeviceToken(r.db.QueryRowContext(ctx, query,
+		device.ID,
+		device.UserID,
+		device.Token,
+		device.Platform,
+		device.CreatedAt,
+		device.LastSeenAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save device token: %w", err)
+	}
+
+	return saved, nil
+}
+
+// ListByUserID はユーザーの端末を登録が新しい順に取得します
+func (r *DeviceTokenRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.DeviceToken, error) {
+	query := `SELECT ` + deviceTokenColumns + ` FROM device_tokens WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list device tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []*entity.DeviceToken
+	for rows.Next() {
+		device, err := scanDeviceToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan device token: %w", err)
+		}
+		devices = append(devices, device)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating device tokens: %w", err)
+	}
+
+	return devices, nil
+}
+
+// Delete はユーザーの端末を削除します
+func (r *DeviceTokenRepository) Delete(ctx context.Context, userID string, id uuid.UUID) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM device_tokens WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete device token: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// DeleteByIDs は無効になった端末をまとめて削除します
+func (r *DeviceTokenRepository) DeleteByIDs(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM device_tokens WHERE id = ANY($1::uuid[])`, pq.Array(uuidStrings(ids)))
+	if err != nil {
+		return fmt.Errorf("failed to delete device tokens: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAllByUserID はユーザーの全ての端末を削除します
+func (r *DeviceTokenRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM device_tokens WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete device tokens: %w", err)
+	}
+
+	return nil
+}
+
+func scanDeviceToken(s rowScanner) (*entity.DeviceToken, error) {
+	device := &entity.DeviceToken{}
+	err := s.Scan(
+		&device.ID,
+		&device.UserID,
+		&device.Token,
+		&device.Platform,
+		&device.CreatedAt,
+		&device.LastSeenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// NotificationSettingsRepository はPostgreSQLを使用したNotificationSettingsRepositoryの実装です
+type NotificationSettingsRepository struct {
+	db *sql.DB
+}
+
+// NewNotificationSettingsRepository は新しいNotificationSettingsRepositoryを作成します
+func NewNotificationSettingsRepository(db *sql.DB) repository.NotificationSettingsRepository {
+	return &NotificationSettingsRepository{db: db}
+}
+
+// FindByUserID はユーザーの通知設定を取得します
+func (r *NotificationSettingsRepository) FindByUserID(ctx context.Context, userID string) (*entity.NotificationSettings, error) {
+	query := `
+		SELECT user_id, quiet_start, quiet_end, disabled_categories, updated_at
+		FROM notification_settings
+		WHERE user_id = $1
+	`
+
+	settings := &entity.NotificationSettings{}
+	var quietStart, quietEnd sql.NullString
+	var disabled []string
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&settings.UserID,
+		&quietStart,
+		&quietEnd,
+		pq.Array(&disabled),
+		&settings.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification settings: %w", err)
+	}
+
+	if quietStart.Valid && quietEnd.Valid {
+		settings.QuietHours = &entity.QuietHours{Start: quietStart.String, End: quietEnd.String}
+	}
+	settings.DisabledCategories = make([]entity.NotificationCategory, 0, len(disabled))
+	for _, category := range disabled {
+		settings.DisabledCategories = append(settings.DisabledCategories, entity.NotificationCategory(category))
+	}
+
+	return settings, nil
+}
+
+// Save は通知設定を保存します
+func (r *NotificationSettingsRepository) Save(ctx context.Context, settings *entity.NotificationSettings) error {
+	query := `
+		INSERT INTO notification_settings (
+			user_id, quiet_start, quiet_end, disabled_categories, updated_at
+		) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET
+			quiet_start = EXCLUDED.quiet_start,
+			quiet_end = EXCLUDED.quiet_end,
+			disabled_categories = EXCLUDED.disabled_categories,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	var quietStart, quietEnd sql.NullString
+	if settings.QuietHours != nil {
+		quietStart = sql.NullString{String: settings.QuietHours.Start, Valid: true}
+		quietEnd = sql.NullString{String: settings.QuietHours.End, Valid: true}
+	}
+	disabled := make([]string, 0, len(settings.DisabledCategories))
+	for _, category := range settings.DisabledCategories {
+		disabled = append(disabled, string(category))
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		settings.UserID,
+		quietStart,
+		quietEnd,
+		pq.Array(disabled),
+		settings.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save notification settings: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByUserID はユーザーの通知設定を削除します
+func (r *NotificationSettingsRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM notification_settings WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification settings: %w", err)
+	}
+
+	return nil
+}
+
+// PushNotificationRepository はPostgreSQLを使用したPushNotificationRepositoryの実装です
+type PushNotificationRepository struct {
+	db *sql.DB
+}
+
+// NewPushNotificationRepository は新しいPushNotificationRepositoryを作成します
+func NewPushNotificationRepository(db *sql.DB) repository.PushNotificationRepository {
+	return &PushNotificationRepository{db: db}
+}
+
+const pushNotificationColumns = `id, user_id, category, template, collapse_key, params, data, status, deliver_after, created_at, sent_at`
+
+// Create はプッシュ通知を保存します
+func (r *PushNotificationRepository) Create(ctx context.Context, notification *entity.PushNotification) error {
+	query := `
+		INSERT INTO push_notifications (` + pushNotificationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	params, err := json.Marshal(notification.Params)
+	if err != nil {
+		return fmt.Errorf("failed to encode push notification params: %w", err)
+	}
+	data, err := json.Marshal(notification.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode push notification data: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		notification.ID,
+		notification.UserID,
+		notification.Category,
+		notification.Template,
+		notification.CollapseKey,
+		params,
+		data,
+		notification.Status,
+		notification.DeliverAfter,
+		notification.CreatedAt,
+		notification.SentAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create push notification: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue は送信時刻が dueBy 以前のユーザーの送信待ちの通知を送信処理中にして取得します
+// 同じユーザーのジョブが同時に実行されても同じ通知を二重に取得しないよう、行をロックして更新します
+func (r *PushNotificationRepository) ClaimDue(ctx context.Context, userID string, dueBy, staleBefore time.Time) ([]*entity.PushNotification, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE push_notifications
+			SET status = 'sending', claimed_at = NOW()
+			WHERE id IN (
+				SELECT id FROM push_notifications
+				WHERE user_id = $1
+				AND (
+					(status = 'pending' AND deliver_after <= $2)
+					OR (status = 'sending' AND claimed_at < $3)
+				)
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + pushNotificationColumns + `
+		)
+		SELECT ` + pushNotificationColumns + ` FROM claimed ORDER BY created_at, id
+	`
+
+	return r.list(ctx, query, userID, dueBy, staleBefore)
+}
+
+// Complete は送信処理中の通知の送信結果を記録します
+func (r *PushNotificationRepository) Complete(ctx context.Context, ids []uuid.UUID, status entity.PushStatus, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `UPDATE push_notifications SET status = $2, sent_at = $3, claimed_at = NULL WHERE id = ANY($1::uuid[])`
+
+	_, err := r.db.ExecContext(ctx, query, pq.Array(uuidStrings(ids)), status, at)
+	if err != nil {
+		return fmt.Errorf("failed to complete push notifications: %w", err)
+	}
+
+	return nil
+}
+
+// Release は送信処理中の通知を deliverAfter 以降に送る送信待ちに戻します
+func (r *PushNotificationRepository) Release(ctx context.Context, ids []uuid.UUID, deliverAfter time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE push_notifications
+		SET status = 'pending', claimed_at = NULL, deliver_after = GREATEST(deliver_after, $2)
+		WHERE id = ANY($1::uuid[])
+	`
+
+	_, err := r.db.ExecContext(ctx, query, pq.Array(uuidStrings(ids)), deliverAfter)
+	if err != nil {
+		return fmt.Errorf("failed to release push notifications: %w", err)
+	}
+
+	return nil
+}
+
+// CreateDeliveries は端末ごとの送信記録を保存します
+func (r *PushNotificationRepository) CreateDeliveries(ctx context.Context, deliveries []*entity.PushDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO push_deliveries (` + pushDeliveryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	for _, delivery := range deliveries {
+		_, err := tx.ExecContext(ctx, query,
+			delivery.ID,
+			delivery.UserID,
+			pq.Array(uuidStrings(delivery.NotificationIDs)),
+			delivery.DeviceTokenID,
+			delivery.Platform,
+			delivery.Title,
+			delivery.Body,
+			delivery.Status,
+			delivery.Error,
+			delivery.ProviderMessageID,
+			delivery.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create push delivery: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+const pushDeliveryColumns = `id, user_id, notification_ids, device_token_id, platform, title, body, status, error, provider_message_id, created_at`
+
+// ListDeliveriesByUserID はユーザーの送信記録を新しい順に取得します
+func (r *PushNotificationRepository) ListDeliveriesByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.PushDelivery, error) {
+	query := `
+		SELECT ` + pushDeliveryColumns + `
+		FROM push_deliveries
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entity.PushDelivery
+	for rows.Next() {
+		delivery := &entity.PushDelivery{}
+		var notificationIDs []string
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.UserID,
+			pq.Array(&notificationIDs),
+			&delivery.DeviceTokenID,
+			&delivery.Platform,
+			&delivery.Title,
+			&delivery.Body,
+			&delivery.Status,
+			&delivery.Error,
+			&delivery.ProviderMessageID,
+			&delivery.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan push delivery: %w", err)
+		}
+
+		delivery.NotificationIDs = make([]uuid.UUID, 0, len(notificationIDs))
+		for _, id := range notificationIDs {
+			parsed, err := uuid.Parse(id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse push notification id: %w", err)
+			}
+			delivery.NotificationIDs = append(delivery.NotificationIDs, parsed)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating push deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ListByUserID はユーザーのプッシュ通知を作成が古い順に取得します
+func (r *PushNotificationRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.PushNotification, error) {
+	query := `SELECT ` + pushNotificationColumns + ` FROM push_notifications WHERE user_id = $1 ORDER BY created_at, id`
+	return r.list(ctx, query, userID)
+}
+
+// DeleteAllByUserID はユーザーのプッシュ通知と送信記録を削除します
+func (r *PushNotificationRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM push_deliveries WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete push deliveries: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM push_notifications WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete push notifications: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PushNotificationRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entity.PushNotification, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*entity.PushNotification
+	for rows.Next() {
+		notification, err := scanPushNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan push notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating push notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func scanPushNotification(s rowScanner) (*entity.PushNotification, error) {
+	notification := &entity.PushNotification{}
+	var params, data []byte
+	var sentAt sql.NullTime
+	err := s.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Category,
+		&notification.Template,
+		&notification.CollapseKey,
+		&params,
+		&data,
+		&notification.Status,
+		&notification.DeliverAfter,
+		&notification.CreatedAt,
+		&sentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(params, &notification.Params); err != nil {
+		return nil, fmt.Errorf("failed to decode push notification params: %w", err)
+	}
+	if err := json.Unmarshal(data, &notification.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode push notification data: %w", err)
+	}
+	if sentAt.Valid {
+		notification.SentAt = &sentAt.Time
+	}
+
+	return notification, nil
+}
+
+// NotificationRepository はPostgreSQLを使用したNotificationRepositoryの実装です
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+// NewNotificationRepository は新しいNotificationRepositoryを作成します
+func NewNotificationRepository(db *sql.DB) repository.NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+const notificationColumns = `id, user_id, category, template, params, data, read_at, created_at`
+
+// Create はお知らせを保存します
+func (r *NotificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	query := `
+		INSERT INTO notifications (` + notificationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	params, err := json.Marshal(notification.Params)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification params: %w", err)
+	}
+	data, err := json.Marshal(notification.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification data: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		notification.ID,
+		notification.UserID,
+		notification.Category,
+		notification.Template,
+		params,
+		data,
+		notification.ReadAt,
+		notification.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUserID はユーザーのお知らせを新しい順に取得します
+func (r *NotificationRepository) ListByUserID(ctx context.Context, userID string, unreadOnly bool, cursor *repository.FeedCursor, limit int) ([]*entity.Notification, error) {
+	cursorTime, cursorID := cursorArgs(cursor)
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE user_id = $1
+		AND ($2::boolean = false OR read_at IS NULL)
+		AND ($3::timestamptz IS NULL OR (created_at, id) < ($3::timestamptz, $4::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5
+	`
+	return r.list(ctx, query, userID, unreadOnly, cursorTime, cursorID, limit)
+}
+
+// CountUnread はユーザーの未読のお知らせの件数を取得します
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkRead はユーザーのお知らせを既読にします
+// 既読のお知らせの既読日時は変更しません
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID string, id uuid.UUID, readAt time.Time) (bool, error) {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, $3) WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID, readAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// MarkAllRead はユーザーの全ての未読のお知らせを既読にします
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID string, readAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE notifications SET read_at = $2 WHERE user_id = $1 AND read_at IS NULL`, userID, readAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	return nil
+}
+
+// ListAllByUserID はユーザーの全てのお知らせを新しい順に取得します
+func (r *NotificationRepository) ListAllByUserID(ctx context.Context, userID string) ([]*entity.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+	return r.list(ctx, query, userID)
+}
+
+// DeleteAllByUserID はユーザーの全てのお知らせを削除します
+func (r *NotificationRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM notifications WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete notifications: %w", err)
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entity.Notification, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*entity.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func scanNotification(s rowScanner) (*entity.Notification, error) {
+	notification := &entity.Notification{}
+	var params, data []byte
+	var readAt sql.NullTime
+	err := s.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Category,
+		&notification.Template,
+		&params,
+		&data,
+		&readAt,
+		&notification.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(params, &notification.Params); err != nil {
+		return nil, fmt.Errorf("failed to decode notification params: %w", err)
+	}
+	if err := json.Unmarshal(data, &notification.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode notification data: %w", err)
+	}
+	if readAt.Valid {
+		notification.ReadAt = &readAt.Time
+	}
+
+	return notification, nil
+}
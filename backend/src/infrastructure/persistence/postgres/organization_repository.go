@@ -0,0 +1,448 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationRepository はPostgreSQLを使用したOrganizationRepositoryの実装です
+type OrganizationRepository struct {
+	db *sql.DB
+}
+
+// NewOrganizationRepository は新しいOrganizationRepositoryを作成します
+func NewOrganizationRepository(db *sql.DB) repository.OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+const organizationColumns = `o.id, o.name, o.type, o.description, o.created_by, o.created_at, o.updated_at`
+
+// Create は組織を作成し、作成者を所有者として登録します
+func (r *OrganizationRepository) Create(ctx context.Context, org *entity.Organization, owner *entity.OrganizationMember) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO organizations (id, name, type, description, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		org.ID,
+		org.Name,
+		org.Type,
+		org.Description,
+		org.CreatedBy,
+		org.CreatedAt,
+		org.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	memberQuery := `
+		INSERT INTO organization_members (organization_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := tx.ExecContext(ctx, memberQuery, owner.OrganizationID, owner.UserID, owner.Role, owner.JoinedAt); err != nil {
+		return fmt.Errorf("failed to create organization owner: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// FindByID は指定されたIDの組織を取得します
+func (r *OrganizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organizations o WHERE o.id = $1`
+
+	org := &entity.Organization{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&org.ID,
+		&org.Name,
+		&org.Type,
+		&org.Description,
+		&org.CreatedBy,
+		&org.CreatedAt,
+		&org.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization: %w", err)
+	}
+
+	return org, nil
+}
+
+// ListByUserID は指定されたユーザーが所属する組織の一覧を取得します
+func (r *OrganizationRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.OrganizationMembership, error) {
+	query := `
+		SELECT ` + organizationColumns + `, m.role
+		FROM organizations o
+		INNER JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var memberships []*entity.OrganizationMembership
+	for rows.Next() {
+		m := &entity.OrganizationMembership{Organization: &entity.Organization{}}
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			&m.Type,
+			&m.Description,
+			&m.CreatedBy,
+			&m.CreatedAt,
+			&m.UpdatedAt,
+			&m.Role,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		memberships = append(memberships, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating organizations: %w", err)
+	}
+
+	return memberships, nil
+}
+
+// Update は組織を更新します
+func (r *OrganizationRepository) Update(ctx context.Context, org *entity.Organization) error {
+	query := `
+		UPDATE organizations
+		SET name = $1, type = $2, description = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	result, err := r.db.ExecContext(ctx, query, org.Name, org.Type, org.Description, org.UpdatedAt, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("organization not found")
+	}
+
+	return nil
+}
+
+// Delete は組織とメンバー、招待を削除します
+func (r *OrganizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM organization_invitations WHERE organization_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete organization invitations: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM api_keys WHERE organization_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete organization api keys: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM organization_members WHERE organization_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete organization members: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM organizations WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// AddMember はメンバーを追加します。既に所属している場合は役割を更新します
+func (r *OrganizationRepository) AddMember(ctx context.Context, member *entity.OrganizationMember) error {
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, member.OrganizationID, member.UserID, member.Role, member.JoinedAt); err != nil {
+		return fmt.Errorf("failed to add organization member: %w", err)
+	}
+
+	return nil
+}
+
+// FindMember は組織のメンバーを取得します
+func (r *OrganizationRepository) FindMember(ctx context.Context, orgID uuid.UUID, userID string) (*entity.OrganizationMember, error) {
+	query := `
+		SELECT organization_id, user_id, role, joined_at
+		FROM organization_members
+		WHERE organization_id = $1 AND user_id = $2
+	`
+
+	member := &entity.OrganizationMember{}
+	err := r.db.QueryRowContext(ctx, query, orgID, userID).Scan(
+		&member.OrganizationID,
+		&member.UserID,
+		&member.Role,
+		&member.JoinedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization member: %w", err)
+	}
+
+	return member, nil
+}
+
+// ListMembers は組織のメンバー一覧を取得します
+func (r *OrganizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID) ([]*entity.OrganizationMember, error) {
+	query := `
+		SELECT organization_id, user_id, role, joined_at
+		FROM organization_members
+		WHERE organization_id = $1
+		ORDER BY joined_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*entity.OrganizationMember
+	for rows.Next() {
+		member := &entity.OrganizationMember{}
+		if err := rows.Scan(&member.OrganizationID, &member.UserID, &member.Role, &member.JoinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating organization members: %w", err)
+	}
+
+	return members, nil
+}
+
+// UpdateMemberRole はメンバーの役割を変更します
+func (r *OrganizationRepository) UpdateMemberRole(ctx context.Context, orgID uuid.UUID, userID string, role entity.OrganizationRole) error {
+	query := `UPDATE organization_members SET role = $1 WHERE organization_id = $2 AND user_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, role, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update organization member role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("organization member not found")
+	}
+
+	return nil
+}
+
+// RemoveMember はメンバーを組織から外します
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, orgID uuid.UUID, userID string) error {
+	query := `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, orgID, userID); err != nil {
+		return fmt.Errorf("failed to remove organization member: %w", err)
+	}
+
+	return nil
+}
+
+// IsManagerOf は managerID のユーザーが、memberID のユーザーが所属する組織の所有者またはマネージャーかどうかを確認します
+func (r *OrganizationRepository) IsManagerOf(ctx context.Context, managerID, memberID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM organization_members manager
+			INNER JOIN organization_members member ON member.organization_id = manager.organization_id
+			WHERE manager.user_id = $1
+			AND manager.role IN ('owner', 'manager')
+			AND member.user_id = $2
+		)
+	`
+
+	var ok bool
+	if err := r.db.QueryRowContext(ctx, query, managerID, memberID).Scan(&ok); err != nil {
+		return false, fmt.Errorf("failed to check organization manager: %w", err)
+	}
+
+	return ok, nil
+}
+
+// OrganizationInvitationRepository はPostgreSQLを使用したOrganizationInvitationRepositoryの実装です
+type OrganizationInvitationRepository struct {
+	db *sql.DB
+}
+
+// NewOrganizationInvitationRepository は新しいOrganizationInvitationRepositoryを作成します
+func NewOrganizationInvitationRepository(db *sql.DB) repository.OrganizationInvitationRepository {
+	return &OrganizationInvitationRepository{db: db}
+}
+
+const organizationInvitationColumns = `id, organization_id, user_id, role, status, invited_by, expires_at, responded_at, created_at`
+
+// Create は招待を保存します
+func (r *OrganizationInvitationRepository) Create(ctx context.Context, invitation *entity.OrganizationInvitation) error {
+	query := `
+		INSERT INTO organization_invitations (` + organizationInvitationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		invitation.ID,
+		invitation.OrganizationID,
+		invitation.UserID,
+		invitation.Role,
+		invitation.Status,
+		invitation.InvitedBy,
+		invitation.ExpiresAt,
+		invitation.RespondedAt,
+		invitation.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create organization invitation: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID は指定されたIDの招待を取得します
+func (r *OrganizationInvitationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.OrganizationInvitation, error) {
+	query := `SELECT ` + organizationInvitationColumns + ` FROM organization_invitations WHERE id = $1`
+
+	invitation, err := scanOrganizationInvitation(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// FindPending は組織からユーザーへの回答待ちの招待を取得します
+func (r *OrganizationInvitationRepository) FindPending(ctx context.Context, orgID uuid.UUID, userID string) (*entity.OrganizationInvitation, error) {
+	query := `
+		SELECT ` + organizationInvitationColumns + `
+		FROM organization_invitations
+		WHERE organization_id = $1 AND user_id = $2 AND status = 'pending' AND expires_at > $3
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	invitation, err := scanOrganizationInvitation(r.db.QueryRowContext(ctx, query, orgID, userID, time.Now()))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// ListPendingByUserID はユーザー宛ての回答待ちの招待一覧を取得します
+func (r *OrganizationInvitationRepository) ListPendingByUserID(ctx context.Context, userID string) ([]*entity.OrganizationInvitation, error) {
+	query := `
+		SELECT ` + organizationInvitationColumns + `
+		FROM organization_invitations
+		WHERE user_id = $1 AND status = 'pending' AND expires_at > $2
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []*entity.OrganizationInvitation
+	for rows.Next() {
+		invitation, err := scanOrganizationInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization invitation: %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating organization invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// Update は招待を更新します
+func (r *OrganizationInvitationRepository) Update(ctx context.Context, invitation *entity.OrganizationInvitation) error {
+	query := `UPDATE organization_invitations SET status = $1, responded_at = $2 WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, invitation.Status, invitation.RespondedAt, invitation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update organization invitation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("organization invitation not found")
+	}
+
+	return nil
+}
+
+// scanOrganizationInvitation は1行分の招待を読み取ります
+func scanOrganizationInvitation(row rowScanner) (*entity.OrganizationInvitation, error) {
+	invitation := &entity.OrganizationInvitation{}
+	var respondedAt sql.NullTime
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.OrganizationID,
+		&invitation.UserID,
+		&invitation.Role,
+		&invitation.Status,
+		&invitation.InvitedBy,
+		&invitation.ExpiresAt,
+		&respondedAt,
+		&invitation.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if respondedAt.Valid {
+		invitation.RespondedAt = &respondedAt.Time
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// OshiRepository はPostgreSQLを使用したOshiRepositoryの実装です
+type OshiRepository struct {
+	db *sql.DB
+}
+
+// NewOshiRepository は新しいOshiRepositoryを作成します
+func NewOshiRepository(db *sql.DB) repository.OshiRepository {
+	return &OshiRepository{db: db}
+}
+
+const oshiColumns = `id, name, group_name, agency, description, profile_images, tags,
+	creator_user_id, official_diagnosis_result, created_by, created_at, updated_at`
+
+// oshiSelectColumns はフォロワー数を含む取得用のカラムです
+// フォロワー数はフォローの登録・解除時にのみ更新します
+const oshiSelectColumns = oshiColumns + `, follower_count`
+
+// Create は新しい推しを保存します
+func (r *OshiRepository) Create(ctx context.Context, oshi *entity.Oshi) error {
+	query := `
+		INSERT INTO oshis (` + oshiColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		oshi.ID,
+		oshi.Name,
+		oshi.GroupName,
+		oshi.Agency,
+		oshi.Description,
+		pq.Array(oshi.ProfileImages),
+		pq.Array(oshi.Tags),
+		oshi.CreatorUserID,
+		oshi.OfficialDiagnosisResult,
+		oshi.CreatedBy,
+		oshi.CreatedAt,
+		oshi.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create oshi: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID は指定されたIDの推しを取得します
+func (r *OshiRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Oshi, error) {
+	query := `SELECT ` + oshiSelectColumns + ` FROM oshis WHERE id = $1`
+
+	oshi, err := scanOshi(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oshi: %w", err)
+	}
+
+	return oshi, nil
+}
+
+// FindByIDs は指定されたIDの推しをまとめて取得します
+func (r *OshiRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Oshi, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT ` + oshiSelectColumns + ` FROM oshis WHERE id = ANY($1::uuid[])`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(uuidStrings(ids)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oshis: %w", err)
+	}
+	defer rows.Close()
+
+	var oshis []*entity.Oshi
+	for rows.Next() {
+		oshi, err := scanOshi(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan oshi: %w", err)
+		}
+		oshis = append(oshis, oshi)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating oshis: %w", err)
+	}
+
+	return oshis, nil
+}
+
+// List は条件に一致する推しの一覧と総件数を取得します
+func (r *OshiRepository) List(ctx context.Context, filter repository.OshiFilter) ([]*entity.Oshi, int, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Query != "" {
+		args = append(args, "%"+escapeLike(filter.Query)+"%")
+		n := len(args)
+		conditions = append(conditions, fmt.Sprintf("(name ILIKE $%d OR group_name ILIKE $%d OR agency ILIKE $%d)", n, n, n))
+	}
+	if filter.GroupName != "" {
+		args = append(args, filter.GroupName)
+		conditions = append(conditions, fmt.Sprintf("group_name = $%d", len(args)))
+	}
+	if filter.Tag != "" {
+		args = append(args, filter.Tag)
+		conditions = append(conditions, fmt.Sprintf("$%d = ANY(tags)", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM oshis `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count oshis: %w", err)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM oshis
+		%s
+		ORDER BY name ASC, id ASC
+		LIMIT $%d OFFSET $%d
+	`, oshiSelectColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list oshis: %w", err)
+	}
+	defer rows.Close()
+
+	var oshis []*entity.Oshi
+	for rows.Next() {
+		oshi, err := scanOshi(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan oshi: %w", err)
+		}
+		oshis = append(oshis, oshi)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating oshis: %w", err)
+	}
+
+	return oshis, total, nil
+}
+
+// Update は推しを更新します
+func (r *OshiRepository) Update(ctx context.Context, oshi *entity.Oshi) error {
+	query := `
+		UPDATE oshis
+		SET name = $1, group_name = $2, agency = $3, description = $4, profile_images = $5, tags = $6,
+			creator_user_id = $7, official_diagnosis_result = $8, updated_at = $9
+		WHERE id = $10
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		oshi.Name,
+		oshi.GroupName,
+		oshi.Agency,
+		oshi.Description,
+		pq.Array(oshi.ProfileImages),
+		pq.Array(oshi.Tags),
+		oshi.CreatorUserID,
+		oshi.OfficialDiagnosisResult,
+		oshi.UpdatedAt,
+		oshi.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update oshi: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("oshi not found")
+	}
+
+	return nil
+}
+
+// Delete は推しを削除します
+func (r *OshiRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM oshis WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete oshi: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("oshi not found")
+	}
+
+	return nil
+}
+
+// scanOshi は1行分の推しを読み取ります
+func scanOshi(row rowScanner) (*entity.Oshi, error) {
+	oshi := &entity.Oshi{}
+	var creatorUserID sql.NullString
+	err := row.Scan(
+		&oshi.ID,
+		&oshi.Name,
+		&oshi.GroupName,
+		&oshi.Agency,
+		&oshi.Description,
+		pq.Array(&oshi.ProfileImages),
+		pq.Array(&oshi.Tags),
+		&creatorUserID,
+		&oshi.OfficialDiagnosisResult,
+		&oshi.CreatedBy,
+		&oshi.CreatedAt,
+		&oshi.UpdatedAt,
+		&oshi.FollowerCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if creatorUserID.Valid {
+		oshi.CreatorUserID = &creatorUserID.String
+	}
+	if oshi.ProfileImages == nil {
+		oshi.ProfileImages = []string{}
+	}
+	if oshi.Tags == nil {
+		oshi.Tags = []string{}
+	}
+
+	return oshi, nil
+}
+
+// uuidStrings はUUIDの配列を uuid[] のパラメータとして渡せる文字列の配列に変換します
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	return out
+}
+
+// escapeLike はLIKE検索のワイルドカード文字をエスケープします
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
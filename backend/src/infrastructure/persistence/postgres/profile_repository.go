@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/lib/pq"
+)
+
+// ProfileRepository はPostgreSQLを使用したProfileRepositoryの実装です
+type ProfileRepository struct {
+	db *sql.DB
+}
+
+// NewProfileRepository は新しいProfileRepositoryを作成します
+func NewProfileRepository(db *sql.DB) repository.ProfileRepository {
+	return &ProfileRepository{db: db}
+}
+
+// FindByUserID は指定されたユーザーのプロフィールを取得します
+func (r *ProfileRepository) FindByUserID(ctx context.Context, userID string) (*entity.UserProfile, error) {
+	query := `
+		SELECT user_id, display_name, avatar_url, bio, birthday, favorite_oshi,
+			locale, timezone, privacy, created_at, updated_at
+		FROM user_profiles
+		WHERE user_id = $1
+	`
+
+	profile := &entity.UserProfile{}
+	var birthday sql.NullTime
+	var privacy []byte
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&profile.UserID,
+		&profile.DisplayName,
+		&profile.AvatarURL,
+		&profile.Bio,
+		&birthday,
+		pq.Array(&profile.FavoriteOshi),
+		&profile.Locale,
+		&profile.Timezone,
+		&privacy,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find profile: %w", err)
+	}
+
+	if birthday.Valid {
+		profile.Birthday = &birthday.Time
+	}
+	if profile.FavoriteOshi == nil {
+		profile.FavoriteOshi = []string{}
+	}
+	if err := json.Unmarshal(privacy, &profile.Privacy); err != nil {
+		return nil, fmt.Errorf("failed to decode profile privacy: %w", err)
+	}
+
+	return profile, nil
+}
+
+// Save はプロフィールを作成または更新します
+func (r *ProfileRepository) Save(ctx context.Context, profile *entity.UserProfile) error {
+	query := `
+		INSERT INTO user_profiles (
+			user_id, display_name, avatar_url, bio, birthday, favorite_oshi,
+			locale, timezone, privacy, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (user_id) DO UPDATE SET
+			display_name = EXCLUDED.display_name,
+			avatar_url = EXCLUDED.avatar_url,
+			bio = EXCLUDED.bio,
+			birthday = EXCLUDED.birthday,
+			favorite_oshi = EXCLUDED.favorite_oshi,
+			locale = EXCLUDED.locale,
+			timezone = EXCLUDED.timezone,
+			privacy = EXCLUDED.privacy,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	privacy, err := json.Marshal(profile.Privacy)
+	if err != nil {
+		return fmt.Errorf("failed to encode profile privacy: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		profile.UserID,
+		profile.DisplayName,
+		profile.AvatarURL,
+		profile.Bio,
+		profile.Birthday,
+		pq.Array(profile.FavoriteOshi),
+		profile.Locale,
+		profile.Timezone,
+		privacy,
+		profile.CreatedAt,
+		profile.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,395 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+const deviceSessionColumns = `
+	id, user_id, refresh_token_hash, device_id, user_agent, ip_address, app_version,
+	fcm_token, last_seen_at, expires_at, revoked_at, created_at
+`
+
+// SessionRepository はPostgreSQLを使用したSessionRepositoryの実装です
+type SessionRepository struct {
+	db *sql.DB
+}
+
+// NewSessionRepository は新しいSessionRepositoryを作成します
+func NewSessionRepository(db *sql.DB) repository.SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// Create は新しいセッションを作成します
+func (r *SessionRepository) Create(ctx context.Context, session *entity.DeviceSession) error {
+	query := `
+		INSERT INTO device_sessions (` + deviceSessionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		session.ID,
+		session.UserID,
+		session.RefreshTokenHash,
+		session.DeviceID,
+		session.UserAgent,
+		session.IPAddress,
+		session.AppVersion,
+		session.FCMToken,
+		session.LastSeenAt,
+		session.ExpiresAt,
+		session.RevokedAt,
+		session.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID は指定されたIDのセッションを取得します
+func (r *SessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.DeviceSession, error) {
+	query := `SELECT ` + deviceSessionColumns + ` FROM device_sessions WHERE id = $1`
+
+	session, err := scanDeviceSession(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	return session, nil
+}
+
+// FindByRefreshTokenHash はリフレッシュトークンのハッシュでセッションを取得します
+func (r *SessionRepository) FindByRefreshTokenHash(ctx context.Context, hash string) (*entity.DeviceSession, error) {
+	query := `SELECT ` + deviceSessionColumns + ` FROM device_sessions WHERE refresh_token_hash = $1`
+
+	session, err := scanDeviceSession(r.db.QueryRowContext(ctx, query, hash))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	return session, nil
+}
+
+// FindByConsumedRefreshTokenHash はローテーションで使用済みになったリフレッシュトークンのハッシュでセッションを取得します
+func (r *SessionRepository) FindByConsumedRefreshTokenHash(ctx context.Context, hash string) (*entity.DeviceSession, error) {
+	query := `
+		SELECT ` + deviceSessionColumns + `
+		FROM device_sessions
+		WHERE id = (SELECT session_id FROM consumed_refresh_tokens WHERE token_hash = $1)
+	`
+
+	session, err := scanDeviceSession(r.db.QueryRowContext(ctx, query, hash))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	return session, nil
+}
+
+// FindActiveByUserID は指定されたユーザーの有効なセッション一覧を取得します
+func (r *SessionRepository) FindActiveByUserID(ctx context.Context, userID string) ([]*entity.DeviceSession, error) {
+	query := `
+		SELECT ` + deviceSessionColumns + `
+		FROM device_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_seen_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*entity.DeviceSession
+	for rows.Next() {
+		session, err := scanDeviceSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// HasKnownDevice は端末IDまたはユーザーエージェントが一致する過去のセッションがあるかどうかを確認します
+// 端末IDが指定された場合は端末IDのみで判定します
+func (r *SessionRepository) HasKnownDevice(ctx context.Context, userID, deviceID, userAgent string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM device_sessions
+			WHERE user_id = $1 AND device_id = $2 AND $2 <> ''
+		)
+	`
+	arg := deviceID
+	if deviceID == "" {
+		query = `
+			SELECT EXISTS (
+				SELECT 1 FROM device_sessions
+				WHERE user_id = $1 AND user_agent = $2
+			)
+		`
+		arg = userAgent
+	}
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, userID, arg).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check known device: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Update は既存のセッションを更新します
+func (r *SessionRepository) Update(ctx context.Context, session *entity.DeviceSession) error {
+	query := `
+		UPDATE device_sessions
+		SET refresh_token_hash = $2, user_agent = $3, ip_address = $4, app_version = $5,
+			fcm_token = $6, last_seen_at = $7, expires_at = $8, revoked_at = $9
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		session.ID,
+		session.RefreshTokenHash,
+		session.UserAgent,
+		session.IPAddress,
+		session.AppVersion,
+		session.FCMToken,
+		session.LastSeenAt,
+		session.ExpiresAt,
+		session.RevokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
+
+// Rotate はセッションのリフレッシュトークンを差し替え、差し替え前のトークンを使用済みとして記録します
+// 同じトークンでの同時のリクエストは、差し替え前のハッシュが一致した1件だけが更新できます
+func (r *SessionRepository) Rotate(ctx context.Context, session *entity.DeviceSession, previousHash string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE device_sessions
+		SET refresh_token_hash = $2, user_agent = $3, ip_address = $4, app_version = $5,
+			fcm_token = $6, last_seen_at = $7, expires_at = $8
+		WHERE id = $1 AND refresh_token_hash = $9 AND revoked_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, query,
+		session.ID,
+		session.RefreshTokenHash,
+		session.UserAgent,
+		session.IPAddress,
+		session.AppVersion,
+		session.FCMToken,
+		session.LastSeenAt,
+		session.ExpiresAt,
+		previousHash,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	query = `
+		INSERT INTO consumed_refresh_tokens (token_hash, session_id, consumed_at)
+		VALUES ($1, $2, $3)
+	`
+	if _, err := tx.ExecContext(ctx, query, previousHash, session.ID, session.LastSeenAt); err != nil {
+		return false, fmt.Errorf("failed to record consumed refresh token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// Revoke は指定されたユーザーのセッションを失効させます
+func (r *SessionRepository) Revoke(ctx context.Context, userID string, id uuid.UUID) error {
+	query := `
+		UPDATE device_sessions
+		SET revoked_at = $3
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
+
+// RevokeAllByUserID は指定されたユーザーのすべてのセッションを失効させます
+func (r *SessionRepository) RevokeAllByUserID(ctx context.Context, userID string) error {
+	query := `UPDATE device_sessions SET revoked_at = $2 WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+// scanDeviceSession は1行分のセッションを読み込みます
+func scanDeviceSession(row rowScanner) (*entity.DeviceSession, error) {
+	session := &entity.DeviceSession{}
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.RefreshTokenHash,
+		&session.DeviceID,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.AppVersion,
+		&session.FCMToken,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+		&revokedAt,
+		&session.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+
+	return session, nil
+}
+
+// SecurityEventRepository はPostgreSQLを使用したSecurityEventRepositoryの実装です
+type SecurityEventRepository struct {
+	db *sql.DB
+}
+
+// NewSecurityEventRepository は新しいSecurityEventRepositoryを作成します
+func NewSecurityEventRepository(db *sql.DB) repository.SecurityEventRepository {
+	return &SecurityEventRepository{db: db}
+}
+
+// Create は新しいセキュリティイベントを記録します
+func (r *SecurityEventRepository) Create(ctx context.Context, event *entity.SecurityEvent) error {
+	query := `
+		INSERT INTO security_events (
+			id, user_id, type, session_id, user_agent, ip_address, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID,
+		event.UserID,
+		event.Type,
+		event.SessionID,
+		event.UserAgent,
+		event.IPAddress,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create security event: %w", err)
+	}
+
+	return nil
+}
+
+// FindByUserID は指定されたユーザーのセキュリティイベントを新しい順に取得します
+func (r *SecurityEventRepository) FindByUserID(ctx context.Context, userID string, limit int) ([]*entity.SecurityEvent, error) {
+	query := `
+		SELECT id, user_id, type, session_id, user_agent, ip_address, created_at
+		FROM security_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find security events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entity.SecurityEvent
+	for rows.Next() {
+		event := &entity.SecurityEvent{}
+		var sessionID uuid.NullUUID
+		err := rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.Type,
+			&sessionID,
+			&event.UserAgent,
+			&event.IPAddress,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan security event: %w", err)
+		}
+		if sessionID.Valid {
+			event.SessionID = &sessionID.UUID
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating security events: %w", err)
+	}
+
+	return events, nil
+}
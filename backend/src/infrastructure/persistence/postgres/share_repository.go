@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// ShareLinkRepository はPostgreSQLを使用したShareLinkRepositoryの実装です
+type ShareLinkRepository struct {
+	db *sql.DB
+}
+
+// NewShareLinkRepository は新しいShareLinkRepositoryを作成します
+func NewShareLinkRepository(db *sql.DB) repository.ShareLinkRepository {
+	return &ShareLinkRepository{db: db}
+}
+
+const shareLinkColumns = `id, slug, user_id, kind, result_id, oshi_id, title, summary, score,
+	image_key, expires_at, revoked_at, created_at`
+
+// Create は新しい共有リンクを保存します
+func (r *ShareLinkRepository) Create(ctx context.Context, link *entity.ShareLink) error {
+	query := `
+		INSERT INTO share_links (` + shareLinkColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		link.ID,
+		link.Slug,
+		link.UserID,
+		link.Kind,
+		link.ResultID,
+		link.OshiID,
+		link.Title,
+		link.Summary,
+		link.Score,
+		link.ImageKey,
+		link.ExpiresAt,
+		link.RevokedAt,
+		link.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return nil
+}
+
+// FindBySlug は指定されたスラッグの共有リンクを取得します
+func (r *ShareLinkRepository) FindBySlug(ctx context.Context, slug string) (*entity.ShareLink, error) {
+	query := `SELECT ` + shareLinkColumns + ` FROM share_links WHERE slug = $1`
+
+	link, err := scanShareLink(r.db.QueryRowContext(ctx, query, slug))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find share link: %w", err)
+	}
+
+	return link, nil
+}
+
+// FindVisibleBySlug は閲覧者に表示できる共有リンクを取得します
+func (r *ShareLinkRepository) FindVisibleBySlug(ctx context.Context, slug, viewerID string) (*entity.ShareLink, error) {
+	query := `
+		SELECT ` + shareLinkColumns + `
+		FROM share_links
+		WHERE slug = $1
+		AND ` + hiddenFromViewer("user_id", "$2")
+
+	link, err := scanShareLink(r.db.QueryRowContext(ctx, query, slug, viewerID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find share link: %w", err)
+	}
+
+	return link, nil
+}
+
+// ListByUserID はユーザーの共有リンクを新しい順に取得します
+func (r *ShareLinkRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.ShareLink, error) {
+	query := `
+		SELECT ` + shareLinkColumns + `
+		FROM share_links
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*entity.ShareLink
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan share link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating share links: %w", err)
+	}
+
+	return links, nil
+}
+
+// Update は共有リンクのカード画像と無効化日時を更新します
+func (r *ShareLinkRepository) Update(ctx context.Context, link *entity.ShareLink) error {
+	query := `UPDATE share_links SET image_key = $1, revoked_at = $2 WHERE id = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, link.ImageKey, link.RevokedAt, link.ID); err != nil {
+		return fmt.Errorf("failed to update share link: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAllByUserID はユーザーの全ての共有リンクを削除します
+func (r *ShareLinkRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM share_links WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete share links: %w", err)
+	}
+
+	return nil
+}
+
+func scanShareLink(s rowScanner) (*entity.ShareLink, error) {
+	link := &entity.ShareLink{}
+	var oshiID uuid.NullUUID
+	var score sql.NullInt64
+	var expiresAt, revokedAt sql.NullTime
+	err := s.Scan(
+		&link.ID,
+		&link.Slug,
+		&link.UserID,
+		&link.Kind,
+		&link.ResultID,
+		&oshiID,
+		&link.Title,
+		&link.Summary,
+		&score,
+		&link.ImageKey,
+		&expiresAt,
+		&revokedAt,
+		&link.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if oshiID.Valid {
+		link.OshiID = &oshiID.UUID
+	}
+	if score.Valid {
+		v := int(score.Int64)
+		link.Score = &v
+	}
+	if expiresAt.Valid {
+		link.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		link.RevokedAt = &revokedAt.Time
+	}
+
+	return link, nil
+}
@@ -117,6 +117,53 @@ func (r *SubscriptionRepository) FindByUserID(ctx context.Context, userID uuid.U
 	return subscription, nil
 }
 
+// ListByUserID は指定されたユーザーIDのサブスクリプション履歴を新しい順に取得します
+func (r *SubscriptionRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Subscription, error) {
+	query := `
+		SELECT id, user_id, plan_type, status, start_date, end_date, created_at, updated_at
+		FROM subscriptions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*entity.Subscription
+	for rows.Next() {
+		subscription := &entity.Subscription{}
+		var endDate sql.NullTime
+		err := rows.Scan(
+			&subscription.ID,
+			&subscription.UserID,
+			&subscription.PlanType,
+			&subscription.Status,
+			&subscription.StartDate,
+			&endDate,
+			&subscription.CreatedAt,
+			&subscription.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subscription: %w", err)
+		}
+
+		if endDate.Valid {
+			subscription.EndDate = &endDate.Time
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
 // Update は既存のサブスクリプションを更新します
 func (r *SubscriptionRepository) Update(ctx context.Context, subscription *entity.Subscription) error {
 	query := `
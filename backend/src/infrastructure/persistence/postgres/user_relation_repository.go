@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+// hiddenFromViewer は閲覧者に表示しないユーザーを除外する条件を返します
+// 閲覧者がブロック・ミュートしたユーザーと、閲覧者をブロックしたユーザーを除外します
+// viewerParam には閲覧者のIDのパラメーターを指定し、未ログインの場合は空文字を渡すと何も除外しません
+func hiddenFromViewer(userColumn, viewerParam string) string {
+	viewer := `NULLIF(` + viewerParam + `, '')::uuid`
+	return `NOT EXISTS (
+			SELECT 1 FROM user_relations ur
+			WHERE (ur.user_id = ` + viewer + ` AND ur.target_id = ` + userColumn + `)
+			OR (ur.user_id = ` + userColumn + ` AND ur.target_id = ` + viewer + ` AND ur.relation_type = 'block')
+		)`
+}
+
+// UserRelationRepository はPostgreSQLを使用したUserRelationRepositoryの実装です
+type UserRelationRepository struct {
+	db *sql.DB
+}
+
+// NewUserRelationRepository は新しいUserRelationRepositoryを作成します
+func NewUserRelationRepository(db *sql.DB) repository.UserRelationRepository {
+	return &UserRelationRepository{db: db}
+}
+
+// Save はブロックまたはミュートを保存します
+func (r *UserRelationRepository) Save(ctx context.Context, relation *entity.UserRelation) error {
+	query := `
+		INSERT INTO user_relations (user_id, target_id, relation_type, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, target_id) DO UPDATE
+		SET relation_type = EXCLUDED.relation_type, created_at = EXCLUDED.created_at
+		WHERE user_relations.relation_type <> EXCLUDED.relation_type
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		relation.UserID,
+		relation.TargetID,
+		relation.Type,
+		relation.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save user relation: %w", err)
+	}
+
+	return nil
+}
+
+// Delete は指定した種類の関係を削除します
+func (r *UserRelationRepository) Delete(ctx context.Context, userID, targetID string, relationType entity.UserRelationType) (bool, error) {
+	query := `DELETE FROM user_relations WHERE user_id = $1 AND target_id = $2 AND relation_type = $3`
+
+	result, err := r.db.ExecContext(ctx, query, userID, targetID, relationType)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete user relation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ListByUserID はユーザーが設定した指定の種類の関係を新しい順に取得します
+func (r *UserRelationRepository) ListByUserID(ctx context.Context, userID string, relationType entity.UserRelationType) ([]*entity.UserRelation, error) {
+	query := `
+		SELECT user_id, target_id, relation_type, created_at
+		FROM user_relations
+		WHERE user_id = $1 AND relation_type = $2
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, relationType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user relations: %w", err)
+	}
+	defer rows.Close()
+
+	var relations []*entity.UserRelation
+	for rows.Next() {
+		relation := &entity.UserRelation{}
+		if err := rows.Scan(&relation.UserID, &relation.TargetID, &relation.Type, &relation.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan user relation: %w", err)
+		}
+		relations = append(relations, relation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user relations: %w", err)
+	}
+
+	return relations, nil
+}
+
+// IsHidden は閲覧者に対象のユーザーの投稿などを表示しないかどうかを確認します
+func (r *UserRelationRepository) IsHidden(ctx context.Context, viewerID, targetID string) (bool, error) {
+	query := `SELECT NOT ` + hiddenFromViewer(`$2::uuid`, `$1`)
+
+	var hidden bool
+	if err := r.db.QueryRowContext(ctx, query, viewerID, targetID).Scan(&hidden); err != nil {
+		return false, fmt.Errorf("failed to check user relation: %w", err)
+	}
+
+	return hidden, nil
+}
+
+// IsBlocked は blockerID のユーザーが blockedID のユーザーをブロックしているかどうかを確認します
+func (r *UserRelationRepository) IsBlocked(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM user_relations
+			WHERE user_id = $1 AND target_id = $2 AND relation_type = 'block'
+		)
+	`
+
+	var blocked bool
+	if err := r.db.QueryRowContext(ctx, query, blockerID, blockedID).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// HasAny はユーザーに一覧から除外するユーザーが1人でもいるかどうかを確認します
+func (r *UserRelationRepository) HasAny(ctx context.Context, viewerID string) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM user_relations WHERE user_id = $1)
+		OR EXISTS (SELECT 1 FROM user_relations WHERE target_id = $1 AND relation_type = 'block')
+	`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, viewerID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check user relations: %w", err)
+	}
+
+	return exists, nil
+}
+
+// DeleteAllByUserID はユーザーが設定した関係と、ユーザーに設定された関係を全て削除します
+func (r *UserRelationRepository) DeleteAllByUserID(ctx context.Context, userID string) error {
+	query := `DELETE FROM user_relations WHERE user_id = $1 OR target_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to delete user relations: %w", err)
+	}
+
+	return nil
+}
@@ -3,7 +3,6 @@ package persistence
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"time"
 
 	"kimiyomi/backend/src/domain/entity"
@@ -31,16 +30,21 @@ func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 	user.UpdatedAt = time.Now()
 
 	query := `
-		INSERT INTO users (id, email, password, name, role, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO users (id, email, password, name, role, role_version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
+	if user.RoleVersion == 0 {
+		user.RoleVersion = 1
+	}
+
 	_, err := r.db.ExecContext(ctx, query,
 		user.ID,
 		user.Email,
 		user.Password,
 		user.Name,
 		user.Role,
+		user.RoleVersion,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -51,7 +55,7 @@ func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 // FindByID はIDでユーザーを検索します
 func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
 	query := `
-		SELECT id, email, password, name, role, created_at, updated_at
+		SELECT id, email, password, name, role, role_version, deleted_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -63,12 +67,14 @@ func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User,
 		&user.Password,
 		&user.Name,
 		&user.Role,
+		&user.RoleVersion,
+		&user.DeletedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, errors.New("user not found")
+		return nil, entity.ErrUserNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -80,7 +86,7 @@ func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User,
 // FindByEmail はメールアドレスでユーザーを検索します
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	query := `
-		SELECT id, email, password, name, role, created_at, updated_at
+		SELECT id, email, password, name, role, role_version, deleted_at, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -92,12 +98,14 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 		&user.Password,
 		&user.Name,
 		&user.Role,
+		&user.RoleVersion,
+		&user.DeletedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, errors.New("user not found")
+		return nil, entity.ErrUserNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -112,8 +120,8 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 
 	query := `
 		UPDATE users
-		SET email = $1, password = $2, name = $3, role = $4, updated_at = $5
-		WHERE id = $6
+		SET email = $1, password = $2, name = $3, role = $4, role_version = $5, updated_at = $6
+		WHERE id = $7
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -121,6 +129,7 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 		user.Password,
 		user.Name,
 		user.Role,
+		user.RoleVersion,
 		user.UpdatedAt,
 		user.ID,
 	)
@@ -133,7 +142,7 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 		return err
 	}
 	if rowsAffected == 0 {
-		return errors.New("user not found")
+		return entity.ErrUserNotFound
 	}
 
 	return nil
@@ -156,7 +165,7 @@ func (r *userRepository) Delete(ctx context.Context, id string) error {
 		return err
 	}
 	if rowsAffected == 0 {
-		return errors.New("user not found")
+		return entity.ErrUserNotFound
 	}
 
 	return nil
@@ -165,7 +174,7 @@ func (r *userRepository) Delete(ctx context.Context, id string) error {
 // List は全てのユーザーを取得します
 func (r *userRepository) List(ctx context.Context) ([]*entity.User, error) {
 	query := `
-		SELECT id, email, password, name, role, created_at, updated_at
+		SELECT id, email, password, name, role, role_version, deleted_at, created_at, updated_at
 		FROM users
 		ORDER BY created_at DESC
 	`
@@ -185,6 +194,8 @@ func (r *userRepository) List(ctx context.Context) ([]*entity.User, error) {
 			&user.Password,
 			&user.Name,
 			&user.Role,
+			&user.RoleVersion,
+			&user.DeletedAt,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
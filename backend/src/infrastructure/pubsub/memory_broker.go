@@ -0,0 +1,89 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"kimiyomi/backend/src/domain/entity"
+)
+
+// subscriberBuffer は購読者ごとに溜めておけるイベントの件数です
+const subscriberBuffer = 32
+
+// MemoryBroker はプロセス内の購読者にイベントを配信するブローカーです
+// 受け取りが遅れてバッファが溢れた購読者へのイベントは破棄し、配信で待たされることはありません
+// 複数のサーバーで共有されないため、複数台で動かす場合は PostgresBroker を使います
+type MemoryBroker struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan *entity.RealtimeEvent]struct{}
+	closed      bool
+}
+
+// NewMemoryBroker は新しいMemoryBrokerを作成します
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		subscribers: make(map[string]map[chan *entity.RealtimeEvent]struct{}),
+	}
+}
+
+// Subscribe はトピックを購読し、イベントを受け取るチャネルと購読を解除する関数を返します
+// 停止後に購読した場合は閉じたチャネルを返します
+func (b *MemoryBroker) Subscribe(topic string) (<-chan *entity.RealtimeEvent, func()) {
+	ch := make(chan *entity.RealtimeEvent, subscriberBuffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[chan *entity.RealtimeEvent]struct{})
+	}
+	b.subscribers[topic][ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[topic][ch]; !ok {
+			return
+		}
+		delete(b.subscribers[topic], ch)
+		if len(b.subscribers[topic]) == 0 {
+			delete(b.subscribers, topic)
+		}
+		close(ch)
+	}
+}
+
+// Publish はこのプロセスのトピックの購読者にイベントを配信します
+func (b *MemoryBroker) Publish(_ context.Context, topic string, event *entity.RealtimeEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[topic] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Close は全ての購読者のチャネルを閉じ、以降の購読を受け付けません
+// サーバーの停止時に、配信中の接続を終えるために呼び出します
+func (b *MemoryBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for topic, subscribers := range b.subscribers {
+		for ch := range subscribers {
+			close(ch)
+		}
+		delete(b.subscribers, topic)
+	}
+}
@@ -0,0 +1,120 @@
+package pubsub
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// notifyChannel はイベントを配信するPostgreSQLの通知チャネルです
+	notifyChannel = "realtime_events"
+	// maxNotifyPayload は通知のペイロードの上限です（PostgreSQLの上限の8000バイトより少し小さくする）
+	maxNotifyPayload = 7900
+)
+
+// notifyEnvelope は通知で送るトピックとイベントです
+type notifyEnvelope struct {
+	Topic string                `json:"topic"`
+	Event *entity.RealtimeEvent `json:"event"`
+}
+
+// PostgresBroker はPostgreSQLの LISTEN/NOTIFY を使って全てのサーバーの購読者にイベントを配信するブローカーです
+// 配信したイベントは全てのサーバーが受け取り、それぞれのプロセスの購読者に渡します
+type PostgresBroker struct {
+	db       *sql.DB
+	listener *pq.Listener
+	local    *MemoryBroker
+	logger   *log.Logger
+}
+
+// NewPostgresBroker は新しいPostgresBrokerを作成し、通知の受信を開始します
+// LISTEN は接続を占有するため、dataSourceName で db とは別の接続を開きます
+func NewPostgresBroker(db *sql.DB, dataSourceName string, logger *log.Logger) (*PostgresBroker, error) {
+	b := &PostgresBroker{
+		db:     db,
+		local:  NewMemoryBroker(),
+		logger: logger,
+	}
+
+	b.listener = pq.NewListener(dataSourceName, 10*time.Second, time.Minute, b.onListenerEvent)
+	if err := b.listener.Listen(notifyChannel); err != nil {
+		b.listener.Close()
+		return nil, fmt.Errorf("failed to listen realtime channel: %w", err)
+	}
+
+	go b.run()
+	return b, nil
+}
+
+// Publish は全てのサーバーのトピックの購読者にイベントを配信します
+// 通知の上限を超えるイベントは Data を省いて配信します
+func (b *PostgresBroker) Publish(ctx context.Context, topic string, event *entity.RealtimeEvent) error {
+	payload, err := json.Marshal(notifyEnvelope{Topic: topic, Event: event})
+	if err != nil {
+		return fmt.Errorf("failed to encode realtime event: %w", err)
+	}
+	if len(payload) > maxNotifyPayload {
+		truncated := *event
+		truncated.Data = nil
+		truncated.Truncated = true
+		if payload, err = json.Marshal(notifyEnvelope{Topic: topic, Event: &truncated}); err != nil {
+			return fmt.Errorf("failed to encode realtime event: %w", err)
+		}
+	}
+
+	if _, err := b.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, notifyChannel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify realtime event: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe はこのサーバーでトピックを購読します
+func (b *PostgresBroker) Subscribe(topic string) (<-chan *entity.RealtimeEvent, func()) {
+	return b.local.Subscribe(topic)
+}
+
+// Close は通知の受信を止め、全ての購読者のチャネルを閉じます
+func (b *PostgresBroker) Close() {
+	if err := b.listener.Close(); err != nil {
+		b.logger.Printf("failed to close realtime listener: %v", err)
+	}
+	b.local.Close()
+}
+
+// run は受信した通知をこのサーバーの購読者に渡します
+func (b *PostgresBroker) run() {
+	for notification := range b.listener.Notify {
+		// 再接続した場合は nil が届く。切断中のイベントはクライアントの再取得で補う
+		if notification == nil {
+			continue
+		}
+
+		var envelope notifyEnvelope
+		if err := json.Unmarshal([]byte(notification.Extra), &envelope); err != nil || envelope.Event == nil {
+			b.logger.Printf("failed to decode realtime event: %v", err)
+			continue
+		}
+		_ = b.local.Publish(context.Background(), envelope.Topic, envelope.Event)
+	}
+}
+
+// onListenerEvent は通知の受信の接続状態の変化を記録します
+func (b *PostgresBroker) onListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected:
+		b.logger.Printf("realtime listener disconnected: %v", err)
+	case pq.ListenerEventReconnected:
+		b.logger.Printf("realtime listener reconnected")
+	case pq.ListenerEventConnectionAttemptFailed:
+		b.logger.Printf("realtime listener connection attempt failed: %v", err)
+	}
+}
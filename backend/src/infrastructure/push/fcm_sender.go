@@ -0,0 +1,75 @@
+package push
+
+import (
+	"context"
+	"fmt"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/messaging"
+	"google.golang.org/api/option"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+)
+
+// fcmMulticastLimit は1回のリクエストで送信できるトークンの最大数です
+const fcmMulticastLimit = 500
+
+// FCMSender はFirebase Cloud Messagingを使用したPushSenderの実装です
+type FCMSender struct {
+	client *messaging.Client
+}
+
+// NewFCMSender は新しいFCMSenderを作成します
+func NewFCMSender(ctx context.Context, credentialsFile string) (*FCMSender, error) {
+	app, err := firebase.NewApp(ctx, nil, option.WithCredentialsFile(credentialsFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize firebase app: %w", err)
+	}
+
+	client, err := app.Messaging(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize firebase messaging: %w", err)
+	}
+
+	return &FCMSender{client: client}, nil
+}
+
+// Send は端末のトークンにプッシュ通知を送信し、tokens と同じ順序で結果を返します
+func (s *FCMSender) Send(ctx context.Context, tokens []string, message *entity.PushMessage) ([]usecase.PushSendResult, error) {
+	results := make([]usecase.PushSendResult, 0, len(tokens))
+	for start := 0; start < len(tokens); start += fcmMulticastLimit {
+		end := start + fcmMulticastLimit
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+
+		response, err := s.client.SendEachForMulticast(ctx, &messaging.MulticastMessage{
+			Tokens: tokens[start:end],
+			Data:   message.Data,
+			Notification: &messaging.Notification{
+				Title: message.Title,
+				Body:  message.Body,
+			},
+			Android: &messaging.AndroidConfig{
+				CollapseKey: string(message.Category),
+				Notification: &messaging.AndroidNotification{
+					ChannelID: string(message.Category),
+				},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to send fcm message: %w", err)
+		}
+
+		for _, r := range response.Responses {
+			results = append(results, usecase.PushSendResult{
+				MessageID:    r.MessageID,
+				Err:          r.Error,
+				InvalidToken: r.Error != nil && (messaging.IsUnregistered(r.Error) || messaging.IsInvalidArgument(r.Error)),
+			})
+		}
+	}
+
+	return results, nil
+}
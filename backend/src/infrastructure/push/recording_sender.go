@@ -0,0 +1,58 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/usecase"
+)
+
+// SentPush は RecordingSender が受け付けたプッシュ通知です
+type SentPush struct {
+	Tokens  []string
+	Message *entity.PushMessage
+}
+
+// RecordingSender は送信したプッシュ通知を記録してログに出力するだけのPushSenderの実装です
+// FCMの認証情報が無いローカル環境で使います
+type RecordingSender struct {
+	mu     sync.Mutex
+	sent   []SentPush
+	logger *log.Logger
+}
+
+// NewRecordingSender は新しいRecordingSenderを作成します
+// logger が nil の場合はログに出力しません
+func NewRecordingSender(logger *log.Logger) *RecordingSender {
+	return &RecordingSender{logger: logger}
+}
+
+// Send はプッシュ通知を記録し、全ての端末に送信できたものとして結果を返します
+func (s *RecordingSender) Send(ctx context.Context, tokens []string, message *entity.PushMessage) ([]usecase.PushSendResult, error) {
+	s.mu.Lock()
+	s.sent = append(s.sent, SentPush{Tokens: append([]string(nil), tokens...), Message: message})
+	id := len(s.sent)
+	s.mu.Unlock()
+
+	if s.logger != nil {
+		s.logger.Printf("push notification to %d device(s): %s / %s", len(tokens), message.Title, message.Body)
+	}
+
+	results := make([]usecase.PushSendResult, len(tokens))
+	for i := range tokens {
+		results[i] = usecase.PushSendResult{MessageID: fmt.Sprintf("recorded-%d-%d", id, i)}
+	}
+
+	return results, nil
+}
+
+// Sent はこれまでに記録したプッシュ通知を返します
+func (s *RecordingSender) Sent() []SentPush {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SentPush(nil), s.sent...)
+}
@@ -0,0 +1,38 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+// Queue はPostgreSQLのjobsテーブルを使用したジョブキューです
+type Queue struct {
+	jobRepo repository.JobRepository
+}
+
+// NewQueue は新しいQueueを作成します
+func NewQueue(jobRepo repository.JobRepository) *Queue {
+	return &Queue{
+		jobRepo: jobRepo,
+	}
+}
+
+// Enqueue はジョブを登録します
+// runAt がゼロ値の場合は即時実行の対象になります
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}, runAt time.Time) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	if err := q.jobRepo.Enqueue(ctx, entity.NewJob(jobType, data, runAt)); err != nil {
+		return fmt.Errorf("failed to enqueue %s job: %w", jobType, err)
+	}
+
+	return nil
+}
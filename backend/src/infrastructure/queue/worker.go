@@ -0,0 +1,133 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+// HandlerFunc はジョブを処理する関数です
+// エラーを返した場合、試行回数の上限までバックオフしながら再実行します
+type HandlerFunc func(ctx context.Context, payload []byte) error
+
+const (
+	// pollInterval は実行可能なジョブが無い場合の待機時間です
+	pollInterval = 2 * time.Second
+	// jobTimeout は1件のジョブの実行時間の上限です
+	jobTimeout = 5 * time.Minute
+	// staleAfter はこの時間以上実行中のままのジョブをワーカー停止とみなして再実行します
+	staleAfter = 2 * jobTimeout
+)
+
+// Worker は登録されたハンドラーでジョブを処理します
+type Worker struct {
+	jobRepo  repository.JobRepository
+	handlers map[string]HandlerFunc
+	logger   *log.Logger
+}
+
+// NewWorker は新しいWorkerを作成します
+func NewWorker(jobRepo repository.JobRepository, logger *log.Logger) *Worker {
+	return &Worker{
+		jobRepo:  jobRepo,
+		handlers: make(map[string]HandlerFunc),
+		logger:   logger,
+	}
+}
+
+// Handle はジョブの種類に対応するハンドラーを登録します
+// Run の呼び出し前に登録してください
+func (w *Worker) Handle(jobType string, handler HandlerFunc) {
+	w.handlers[jobType] = handler
+}
+
+// Run は ctx がキャンセルされるまでジョブを処理します
+func (w *Worker) Run(ctx context.Context) {
+	types := make([]string, 0, len(w.handlers))
+	for jobType := range w.handlers {
+		types = append(types, jobType)
+	}
+
+	lastRequeue := time.Time{}
+	for {
+		if time.Since(lastRequeue) > staleAfter {
+			if err := w.jobRepo.RequeueStale(ctx, time.Now().Add(-staleAfter)); err != nil && ctx.Err() == nil {
+				w.logger.Printf("failed to requeue stale jobs: %v", err)
+			}
+			lastRequeue = time.Now()
+		}
+
+		processed, err := w.runNext(ctx, types)
+		if err != nil && ctx.Err() == nil {
+			w.logger.Printf("failed to process job: %v", err)
+		}
+		if processed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// runNext は実行可能なジョブを1件処理します
+func (w *Worker) runNext(ctx context.Context, types []string) (bool, error) {
+	job, err := w.jobRepo.ClaimNext(ctx, types, time.Now())
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	if err := w.execute(jobCtx, job); err != nil {
+		var retryAt *time.Time
+		if job.CanRetry() {
+			t := time.Now().Add(backoff(job.Attempts))
+			retryAt = &t
+		}
+		w.logger.Printf("job %s (%s) failed on attempt %d: %v", job.ID, job.Type, job.Attempts, err)
+		// シャットダウン中でも失敗を記録できるよう、親のコンテキストを使用しない
+		if err := w.jobRepo.MarkFailed(context.Background(), job.ID, err.Error(), retryAt); err != nil {
+			return true, err
+		}
+		return true, nil
+	}
+
+	if err := w.jobRepo.MarkDone(context.Background(), job.ID); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// execute はハンドラーを呼び出します。ハンドラー内のpanicはエラーとして扱います
+func (w *Worker) execute(ctx context.Context, job *entity.Job) (err error) {
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %s", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job.Payload)
+}
+
+// backoff は試行回数に応じた再実行までの待機時間を返します（30秒, 2分, 4.5分, ...）
+func backoff(attempts int) time.Duration {
+	return time.Duration(attempts*attempts) * 30 * time.Second
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// NewS3ClientFromEnv は環境変数の認証情報でS3クライアントを作成します
+// S3_ENDPOINT を指定した場合はMinIOなどのS3互換ストレージにパス形式で接続します
+func NewS3ClientFromEnv(region string) *s3.Client {
+	options := s3.Options{
+		Region: region,
+		Credentials: aws.NewCredentialsCache(aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{
+				AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+				SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+				SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+				Source:          "Environment",
+			}, nil
+		})),
+	}
+	if endpoint := os.Getenv("S3_ENDPOINT"); endpoint != "" {
+		options.BaseEndpoint = aws.String(endpoint)
+		options.UsePathStyle = true
+	}
+	return s3.New(options)
+}
@@ -5,14 +5,24 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/url"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/uuid"
 )
 
+// derivativesPrefix はアップロードしたファイルから作成したサムネイルや変換後の動画などの派生ファイルを保存する場所です
+// 派生ファイルは derived/{元のファイルのキー}/{名前} に保存し、元のファイルと一緒に削除します
+const derivativesPrefix = "derived/"
+
+// deleteObjectsBatchSize は一度にまとめて削除できるオブジェクトの最大件数です
+const deleteObjectsBatchSize = 1000
+
 // S3Storage はAWS S3を使用したファイルストレージの実装です
 type S3Storage struct {
 	client     *s3.Client
@@ -57,10 +67,29 @@ func (s *S3Storage) Upload(ctx context.Context, file *multipart.FileHeader) (str
 	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucketName, s.region, key), nil
 }
 
-// Delete はS3からファイルを削除します
+// Put は指定されたキーでデータをS3に保存します
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object to S3: %w", err)
+	}
+
+	return nil
+}
+
+// Delete はS3からファイルとその派生ファイルを削除します
+// filePath には Upload が返したURLと、Put で指定したキーのどちらも指定できます
+// 既に削除されている場合もエラーにしないため、ジョブから何度実行しても構いません
 func (s *S3Storage) Delete(ctx context.Context, filePath string) error {
-	// URLからキーを抽出
-	key := filepath.Base(filePath)
+	key := s.objectKey(filePath)
+	if key == "" {
+		return fmt.Errorf("invalid file path: %s", filePath)
+	}
 
 	// S3から削除
 	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
@@ -71,9 +100,66 @@ func (s *S3Storage) Delete(ctx context.Context, filePath string) error {
 		return fmt.Errorf("failed to delete file from S3: %w", err)
 	}
 
+	return s.deletePrefix(ctx, derivativesPrefix+key+"/")
+}
+
+// DerivativeKey はアップロードしたファイルの派生ファイルを保存するキーを返します
+// このキーに保存した派生ファイルは、元のファイルを Delete した時に一緒に削除されます
+func (s *S3Storage) DerivativeKey(filePath, name string) string {
+	return derivativesPrefix + s.objectKey(filePath) + "/" + name
+}
+
+// deletePrefix は指定した接頭辞で始まるキーのオブジェクトを全て削除します
+func (s *S3Storage) deletePrefix(ctx context.Context, prefix string) error {
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(s.bucketName),
+		Prefix:  aws.String(prefix),
+		MaxKeys: aws.Int32(deleteObjectsBatchSize),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list derived files in S3: %w", err)
+		}
+		if len(page.Contents) == 0 {
+			continue
+		}
+
+		objects := make([]types.ObjectIdentifier, len(page.Contents))
+		for i, object := range page.Contents {
+			objects[i] = types.ObjectIdentifier{Key: object.Key}
+		}
+		result, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.bucketName),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete derived files from S3: %w", err)
+		}
+		if len(result.Errors) > 0 {
+			return fmt.Errorf("failed to delete derived file %s from S3: %s", aws.ToString(result.Errors[0].Key), aws.ToString(result.Errors[0].Message))
+		}
+	}
+
 	return nil
 }
 
+// objectKey は Upload が返したURLまたはオブジェクトのキーから、S3のオブジェクトのキーを取り出します
+// URLの場合は仮想ホスト形式（https://{bucket}.s3.{region}.amazonaws.com/{key}）とパス形式
+// （https://s3.{region}.amazonaws.com/{bucket}/{key}）のどちらにも対応します
+func (s *S3Storage) objectKey(filePath string) string {
+	u, err := url.Parse(filePath)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return strings.TrimPrefix(filePath, "/")
+	}
+
+	key := strings.TrimPrefix(u.Path, "/")
+	if !strings.HasPrefix(u.Host, s.bucketName+".") {
+		key = strings.TrimPrefix(key, s.bucketName+"/")
+	}
+	return key
+}
+
 // GetSignedURL は署名付きURLを生成します
 func (s *S3Storage) GetSignedURL(ctx context.Context, key string, duration time.Duration) (string, error) {
 	presignClient := s3.NewPresignClient(s.client)
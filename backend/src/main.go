@@ -14,8 +14,23 @@ import (
 	"kimiyomi/backend/src/api/handler"
 	"kimiyomi/backend/src/api/middleware"
 	"kimiyomi/backend/src/api/router"
+	"kimiyomi/backend/src/domain/achievement"
+	"kimiyomi/backend/src/domain/email"
+	"kimiyomi/backend/src/domain/engagement"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/notification"
 	"kimiyomi/backend/src/infrastructure/auth"
+	"kimiyomi/backend/src/infrastructure/cache"
+	"kimiyomi/backend/src/infrastructure/mail"
+	"kimiyomi/backend/src/infrastructure/oauth"
+	"kimiyomi/backend/src/infrastructure/ogimage"
+	"kimiyomi/backend/src/infrastructure/payment"
 	"kimiyomi/backend/src/infrastructure/persistence"
+	"kimiyomi/backend/src/infrastructure/persistence/postgres"
+	"kimiyomi/backend/src/infrastructure/pubsub"
+	"kimiyomi/backend/src/infrastructure/push"
+	"kimiyomi/backend/src/infrastructure/queue"
+	"kimiyomi/backend/src/infrastructure/storage"
 	"kimiyomi/backend/src/usecase"
 
 	"github.com/gin-gonic/gin"
@@ -45,6 +60,91 @@ func main() {
 
 	// リポジトリの初期化
 	userRepo := persistence.NewUserRepository(db)
+	mfaRepo := postgres.NewMFARepository(db)
+	identityRepo := postgres.NewIdentityRepository(db)
+	oauthStateRepo := postgres.NewOAuthStateRepository(db)
+	apiKeyRepo := postgres.NewAPIKeyRepository(db)
+	sessionRepo := postgres.NewSessionRepository(db)
+	securityEventRepo := postgres.NewSecurityEventRepository(db)
+	profileRepo := postgres.NewProfileRepository(db)
+	subscriptionRepo := postgres.NewSubscriptionRepository(db)
+	contentRepo := postgres.NewContentRepository(db)
+	accountRepo := postgres.NewAccountRepository(db)
+	accountDeletionRepo := postgres.NewAccountDeletionRepository(db)
+	dataExportRepo := postgres.NewDataExportRepository(db)
+	jobRepo := postgres.NewJobRepository(db)
+	oshiRepo := postgres.NewOshiRepository(db)
+	orgRepo := postgres.NewOrganizationRepository(db)
+	orgInvitationRepo := postgres.NewOrganizationInvitationRepository(db)
+	followRepo := postgres.NewFollowRepository(db)
+	oshiNewsRepo := postgres.NewOshiNewsRepository(db)
+	feedRepo := postgres.NewFeedRepository(db)
+	activityRepo := postgres.NewActivityRepository(db)
+	leaderboardRepo := postgres.NewLeaderboardRepository(db)
+	accountFlagRepo := postgres.NewAccountFlagRepository(db)
+	badgeRepo := postgres.NewBadgeRepository(db)
+	pointLedgerRepo := postgres.NewPointLedgerRepository(db)
+	shareLinkRepo := postgres.NewShareLinkRepository(db)
+	communityPostRepo := postgres.NewCommunityPostRepository(db)
+	communityCommentRepo := postgres.NewCommunityCommentRepository(db)
+	reactionRepo := postgres.NewReactionRepository(db)
+	contentModerationRepo := postgres.NewContentModerationRepository(db)
+	userRelationRepo := postgres.NewUserRelationRepository(db)
+	conversationRepo := postgres.NewConversationRepository(db)
+	directMessageRepo := postgres.NewDirectMessageRepository(db)
+	messageTicketRepo := postgres.NewMessageTicketRepository(db)
+	notificationRepo := postgres.NewNotificationRepository(db)
+	deviceTokenRepo := postgres.NewDeviceTokenRepository(db)
+	notificationSettingsRepo := postgres.NewNotificationSettingsRepository(db)
+	pushNotificationRepo := postgres.NewPushNotificationRepository(db)
+	emailRepo := postgres.NewEmailRepository(db)
+	emailSuppressionRepo := postgres.NewEmailSuppressionRepository(db)
+
+	// ジョブキューの初期化
+	jobQueue := queue.NewQueue(jobRepo)
+
+	// リアルタイム配信の初期化（複数台のサーバーの購読者へ LISTEN/NOTIFY で配信する）
+	realtimeBroker, err := pubsub.NewPostgresBroker(db, os.Getenv("DATABASE_URL"), logger)
+	if err != nil {
+		logger.Fatalf("リアルタイム配信の初期化に失敗しました: %v", err)
+	}
+
+	// プッシュ通知の送信の初期化（FCMの認証情報が無い環境では送信内容をログに出力するだけにする）
+	var pushSender usecase.PushSender = push.NewRecordingSender(logger)
+	if credentialsFile := os.Getenv("FIREBASE_CREDENTIALS_FILE"); credentialsFile != "" {
+		fcmSender, err := push.NewFCMSender(context.Background(), credentialsFile)
+		if err != nil {
+			logger.Fatalf("プッシュ通知の初期化に失敗しました: %v", err)
+		}
+		pushSender = fcmSender
+	}
+
+	// メールの送信の初期化（SMTPサーバーが無い環境ではメールを MAIL_DIR に .eml ファイルとして書き出す）
+	var emailSender usecase.EmailSender
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		emailSender = mail.NewSMTPSender(smtpHost, os.Getenv("SMTP_PORT"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"))
+	} else {
+		mailDir := os.Getenv("MAIL_DIR")
+		if mailDir == "" {
+			mailDir = "tmp/mail"
+		}
+		fileSender, err := mail.NewFileSender(mailDir, logger)
+		if err != nil {
+			logger.Fatalf("メールの送信の初期化に失敗しました: %v", err)
+		}
+		emailSender = fileSender
+	}
+	emailFrom := os.Getenv("EMAIL_FROM")
+	if emailFrom == "" {
+		emailFrom = "キミヨミ <no-reply@localhost>"
+	}
+
+	// ファイルストレージの初期化
+	awsRegion := os.Getenv("AWS_REGION")
+	fileStorage := storage.NewS3Storage(storage.NewS3ClientFromEnv(awsRegion), os.Getenv("S3_BUCKET_NAME"), awsRegion)
+
+	// 決済サービスの初期化
+	paymentService := payment.NewStripeService(os.Getenv("STRIPE_SECRET_KEY"))
 
 	// トークンサービスの初期化
 	tokenService := auth.NewTokenService(
@@ -53,14 +153,189 @@ func main() {
 		168, // リフレッシュトークンの有効期限（時間）
 	)
 
+	// 二要素認証（TOTP）サービスの初期化
+	otpService := auth.NewTOTPService("Kimiyomi")
+
 	// ユースケースの初期化
 	authUseCase := usecase.NewAuthUseCase(userRepo)
+	mfaUseCase := usecase.NewMFAUseCase(mfaRepo, userRepo, otpService)
+	oauthUseCase := usecase.NewOAuthUseCase(oauth.ProvidersFromEnv(), identityRepo, oauthStateRepo, userRepo)
+	roleUseCase := usecase.NewRoleUseCase(userRepo)
+	apiKeyUseCase := usecase.NewAPIKeyUseCase(apiKeyRepo, userRepo, orgRepo)
+	profileUseCase := usecase.NewProfileUseCase(profileRepo, userRepo, userRelationRepo, fileStorage)
+	accountUseCase := usecase.NewAccountUseCase(
+		usecase.AccountRepositories{
+			Users:          userRepo,
+			Accounts:       accountRepo,
+			Deletions:      accountDeletionRepo,
+			Exports:        dataExportRepo,
+			Profiles:       profileRepo,
+			Identities:     identityRepo,
+			Sessions:       sessionRepo,
+			SecurityEvents: securityEventRepo,
+			APIKeys:        apiKeyRepo,
+			Subscriptions:  subscriptionRepo,
+			Contents:       contentRepo,
+		},
+		paymentService,
+		fileStorage,
+		fileStorage,
+		jobQueue,
+	)
+	notificationTemplates, err := notification.DefaultTemplates()
+	if err != nil {
+		logger.Fatalf("通知のテンプレートの読み込みに失敗しました: %v", err)
+	}
+	// 1分の間に届いた通知はまとめて送る
+	notificationUseCase := usecase.NewNotificationUseCase(
+		notificationRepo,
+		deviceTokenRepo,
+		notificationSettingsRepo,
+		pushNotificationRepo,
+		profileRepo,
+		followRepo,
+		notificationTemplates,
+		pushSender,
+		jobQueue,
+		realtimeBroker,
+		time.Minute,
+	)
+	accountUseCase.RegisterDataSource(notificationUseCase)
+	oshiUseCase := usecase.NewOshiUseCase(oshiRepo, oshiNewsRepo, userRepo, fileStorage)
+	followUseCase := usecase.NewFollowUseCase(followRepo, oshiRepo)
+	accountUseCase.RegisterDataSource(followUseCase)
+	feedUseCase := usecase.NewFeedUseCase(feedRepo, oshiRepo, contentRepo, oshiNewsRepo)
+	engagementScorer := engagement.NewScorer(engagement.DefaultConfig())
+	engagementUseCase := usecase.NewEngagementUseCase(activityRepo, oshiRepo, engagementScorer)
+	accountUseCase.RegisterDataSource(engagementUseCase)
+	leaderboardUseCase := usecase.NewLeaderboardUseCase(
+		leaderboardRepo,
+		accountFlagRepo,
+		oshiRepo,
+		profileRepo,
+		userRepo,
+		userRelationRepo,
+		cache.NewMemoryCache(),
+		engagementScorer.Location(),
+		realtimeBroker,
+	)
+	engagementUseCase.AddObserver(leaderboardUseCase)
+	badgeDefinitions, err := achievement.DefaultDefinitions()
+	if err != nil {
+		logger.Fatalf("バッジの定義の読み込みに失敗しました: %v", err)
+	}
+	achievementUseCase := usecase.NewAchievementUseCase(badgeDefinitions, badgeRepo, pointLedgerRepo, activityRepo, engagementScorer, realtimeBroker, notificationUseCase)
+	engagementUseCase.AddObserver(achievementUseCase)
+	accountUseCase.RegisterDataSource(achievementUseCase)
+	cardRenderer, err := ogimage.NewRenderer()
+	if err != nil {
+		logger.Fatalf("シェアカードのフォントの読み込みに失敗しました: %v", err)
+	}
+	publicBaseURL := os.Getenv("PUBLIC_BASE_URL")
+	if publicBaseURL == "" {
+		publicBaseURL = "http://localhost:8080"
+	}
+	shareUseCase := usecase.NewShareUseCase(shareLinkRepo, oshiRepo, cardRenderer, fileStorage, jobQueue, publicBaseURL)
+	accountUseCase.RegisterDataSource(shareUseCase)
+	emailTemplates, err := email.DefaultTemplates()
+	if err != nil {
+		logger.Fatalf("メールのテンプレートの読み込みに失敗しました: %v", err)
+	}
+	emailUseCase := usecase.NewEmailUseCase(
+		emailRepo,
+		emailSuppressionRepo,
+		userRepo,
+		profileRepo,
+		emailTemplates,
+		emailSender,
+		jobQueue,
+		emailFrom,
+		publicBaseURL,
+		os.Getenv("JWT_SECRET_KEY"),
+	)
+	accountUseCase.RegisterDataSource(emailUseCase)
+	// 新しい端末からのログインなどのセキュリティイベントはメールで知らせる
+	sessionUseCase := usecase.NewSessionUseCase(sessionRepo, securityEventRepo, userRepo, mfaRepo, tokenService, emailUseCase, 168*time.Hour)
+	communityUseCase := usecase.NewCommunityUseCase(communityPostRepo, communityCommentRepo, reactionRepo, oshiRepo, fileStorage, jobQueue, userRelationRepo)
+	accountUseCase.RegisterDataSource(communityUseCase)
+	contentUseCase := usecase.NewContentUseCase(contentRepo, subscriptionRepo, oshiRepo, orgRepo, fileStorage, jobQueue, notificationUseCase)
+	contentModerationUseCase := usecase.NewContentModerationUseCase(
+		contentRepo,
+		contentModerationRepo,
+		oshiRepo,
+		notificationUseCase,
+		3, // 審査が終わるまで自動で非表示にする未処理の通報の件数
+	)
+	accountUseCase.RegisterDataSource(contentModerationUseCase)
+	userRelationUseCase := usecase.NewUserRelationUseCase(userRelationRepo, userRepo)
+	accountUseCase.RegisterDataSource(userRelationUseCase)
+	// メッセージチケットの購入の導入までは管理者が付与したチケットのみを使う
+	messageUseCase := usecase.NewMessageUseCase(
+		conversationRepo,
+		directMessageRepo,
+		messageTicketRepo,
+		userRepo,
+		userRelationRepo,
+		subscriptionRepo,
+		fileStorage,
+		jobQueue,
+		realtimeBroker,
+		notificationUseCase,
+		map[entity.PlanType]int{ // ファンが月に送れるメッセージの件数
+			entity.PlanTypeBasic:   5,
+			entity.PlanTypePremium: 30,
+		},
+		engagementScorer.Location(),
+	)
+	accountUseCase.RegisterDataSource(messageUseCase)
+	realtimeUseCase := usecase.NewRealtimeUseCase(realtimeBroker)
+	// 購入履歴の導入までは売上を0件として集計する
+	organizationUseCase := usecase.NewOrganizationUseCase(orgRepo, orgInvitationRepo, userRepo)
+
+	// バックグラウンドジョブのワーカーの初期化
+	worker := queue.NewWorker(jobRepo, logger)
+	worker.Handle(usecase.JobTypeAccountDelete, accountUseCase.HandleDeletionJob)
+	worker.Handle(usecase.JobTypeAccountExport, accountUseCase.HandleExportJob)
+	worker.Handle(usecase.JobTypeStorageDelete, usecase.NewStorageDeleteJobHandler(fileStorage))
+	worker.Handle(usecase.JobTypePushDeliver, notificationUseCase.HandleDeliveryJob)
+	worker.Handle(usecase.JobTypeNotificationFanout, notificationUseCase.HandleFanoutJob)
+	worker.Handle(usecase.JobTypeEmailSend, emailUseCase.HandleSendJob)
+	worker.Handle(usecase.JobTypeContentRelease, contentUseCase.HandleReleaseJob)
+	worker.Handle(usecase.JobTypeContentSearchReindex, contentUseCase.HandleSearchReindexJob)
+
+	// 検索の導入前に作成したコンテンツなど、検索用の索引が無いコンテンツの索引を作成する
+	if err := contentUseCase.EnqueueSearchReindex(context.Background()); err != nil {
+		logger.Printf("コンテンツの検索用の索引の作成の登録に失敗しました: %v", err)
+	}
 
 	// ミドルウェアの初期化
-	authMiddleware := middleware.NewAuthMiddleware(tokenService)
+	authMiddleware := middleware.NewAuthMiddleware(tokenService, roleUseCase, sessionUseCase)
+	apiKeyMiddleware := middleware.NewAPIKeyMiddleware(apiKeyUseCase)
 
 	// ハンドラーの初期化
-	authHandler := handler.NewAuthHandler(authUseCase, tokenService)
+	authHandler := handler.NewAuthHandler(authUseCase, mfaUseCase, sessionUseCase, tokenService)
+	mfaHandler := handler.NewMFAHandler(mfaUseCase, sessionUseCase, tokenService)
+	oauthHandler := handler.NewOAuthHandler(oauthUseCase, mfaUseCase, sessionUseCase, tokenService)
+	roleHandler := handler.NewRoleHandler(roleUseCase)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUseCase)
+	sessionHandler := handler.NewSessionHandler(sessionUseCase)
+	profileHandler := handler.NewProfileHandler(profileUseCase)
+	accountHandler := handler.NewAccountHandler(accountUseCase)
+	oshiHandler := handler.NewOshiHandler(oshiUseCase)
+	organizationHandler := handler.NewOrganizationHandler(organizationUseCase)
+	feedHandler := handler.NewFeedHandler(followUseCase, feedUseCase)
+	engagementHandler := handler.NewEngagementHandler(engagementUseCase)
+	leaderboardHandler := handler.NewLeaderboardHandler(leaderboardUseCase)
+	achievementHandler := handler.NewAchievementHandler(achievementUseCase)
+	shareHandler := handler.NewShareHandler(shareUseCase)
+	communityHandler := handler.NewCommunityHandler(communityUseCase)
+	contentHandler := handler.NewContentHandler(contentUseCase)
+	contentModerationHandler := handler.NewContentModerationHandler(contentModerationUseCase)
+	userRelationHandler := handler.NewUserRelationHandler(userRelationUseCase)
+	messageHandler := handler.NewMessageHandler(messageUseCase)
+	realtimeHandler := handler.NewRealtimeHandler(realtimeUseCase)
+	notificationHandler := handler.NewNotificationHandler(notificationUseCase)
+	emailHandler := handler.NewEmailHandler(emailUseCase, os.Getenv("EMAIL_WEBHOOK_SECRET"))
 
 	// Ginエンジンの初期化
 	if os.Getenv("APP_ENV") == "production" {
@@ -71,7 +346,34 @@ func main() {
 	engine.Use(gin.Logger())
 
 	// ルーターの初期化と設定
-	r := router.NewRouter(engine, authHandler, authMiddleware)
+	r := router.NewRouter(
+		engine,
+		authHandler,
+		mfaHandler,
+		oauthHandler,
+		roleHandler,
+		apiKeyHandler,
+		sessionHandler,
+		profileHandler,
+		accountHandler,
+		oshiHandler,
+		organizationHandler,
+		feedHandler,
+		engagementHandler,
+		leaderboardHandler,
+		achievementHandler,
+		shareHandler,
+		communityHandler,
+		contentHandler,
+		contentModerationHandler,
+		userRelationHandler,
+		messageHandler,
+		realtimeHandler,
+		notificationHandler,
+		emailHandler,
+		authMiddleware,
+		apiKeyMiddleware,
+	)
 	r.Setup()
 
 	// HTTPサーバーの設定
@@ -86,6 +388,17 @@ func main() {
 		WriteTimeout: 15 * time.Second,
 	}
 
+	// 停止時に配信中の SSE・WebSocket の接続を終える
+	srv.RegisterOnShutdown(realtimeBroker.Close)
+
+	// ワーカーを非同期で起動
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		worker.Run(workerCtx)
+	}()
+
 	// サーバーを非同期で起動
 	go func() {
 		logger.Printf("サーバーを起動しました。ポート: %s\n", port)
@@ -106,6 +419,10 @@ func main() {
 		logger.Fatalf("サーバーのシャットダウンに失敗しました: %v", err)
 	}
 
+	// 実行中のジョブの終了を待つ
+	stopWorker()
+	<-workerDone
+
 	logger.Println("サーバーを正常にシャットダウンしました")
 }
 
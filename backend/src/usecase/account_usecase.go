@@ -0,0 +1,475 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrDeletionAlreadyRequested = errors.New("account deletion has already been requested")
+	ErrDeletionNotFound         = errors.New("account deletion request not found")
+	ErrExportInProgress         = errors.New("a data export is already in progress")
+	ErrExportNotFound           = errors.New("data export not found")
+)
+
+const (
+	// exportPendingTimeout を過ぎても完了しないエクスポートは失敗とみなし、新しい要求を受け付けます
+	exportPendingTimeout = time.Hour
+	// exportURLExpiry はエクスポートファイルの署名付きURLの有効期限です
+	exportURLExpiry = 24 * time.Hour
+	// exportSecurityEventLimit はエクスポートに含めるセキュリティイベントの件数です
+	exportSecurityEventLimit = 1000
+)
+
+// ExportStorage はエクスポートファイルを保存するストレージのインターフェースです
+type ExportStorage interface {
+	// Put は指定されたキーでデータを保存します
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	// GetSignedURL はダウンロード用の署名付きURLを生成します
+	GetSignedURL(ctx context.Context, key string, duration time.Duration) (string, error)
+}
+
+// PersonalDataSource はユーザーの個人データを保持する機能ごとの拡張ポイントです
+// 新しい機能でユーザーデータを扱う場合は RegisterDataSource で登録し、
+// エクスポートと退会時の削除・匿名化の対象に含めます
+type PersonalDataSource interface {
+	// Name はエクスポートファイル内のファイル名（拡張子なし）です
+	Name() string
+	// ExportPersonalData はユーザーのデータをJSONに変換できる形式で返します
+	ExportPersonalData(ctx context.Context, userID string) (interface{}, error)
+	// ErasePersonalData はユーザーのデータを削除または匿名化します
+	// 退会処理の再実行に備えて冪等に実装してください
+	ErasePersonalData(ctx context.Context, userID string) error
+}
+
+// AccountRepositories はアカウント管理で使用するリポジトリの一覧です
+type AccountRepositories struct {
+	Users          repository.UserRepository
+	Accounts       repository.AccountRepository
+	Deletions      repository.AccountDeletionRepository
+	Exports        repository.DataExportRepository
+	Profiles       repository.ProfileRepository
+	Identities     repository.IdentityRepository
+	Sessions       repository.SessionRepository
+	SecurityEvents repository.SecurityEventRepository
+	APIKeys        repository.APIKeyRepository
+	Subscriptions  repository.SubscriptionRepository
+	Contents       repository.ContentRepository
+}
+
+// AccountUseCase は退会とデータエクスポートのユースケースを実装します
+type AccountUseCase struct {
+	repos          AccountRepositories
+	paymentService PaymentService
+	fileStorage    FileStorage
+	exportStorage  ExportStorage
+	jobQueue       JobQueue
+	dataSources    []PersonalDataSource
+}
+
+// NewAccountUseCase は新しいAccountUseCaseを作成します
+func NewAccountUseCase(
+	repos AccountRepositories,
+	paymentService PaymentService,
+	fileStorage FileStorage,
+	exportStorage ExportStorage,
+	jobQueue JobQueue,
+) *AccountUseCase {
+	return &AccountUseCase{
+		repos:          repos,
+		paymentService: paymentService,
+		fileStorage:    fileStorage,
+		exportStorage:  exportStorage,
+		jobQueue:       jobQueue,
+	}
+}
+
+// RegisterDataSource はエクスポートと退会処理の対象となるデータを登録します
+func (uc *AccountUseCase) RegisterDataSource(source PersonalDataSource) {
+	uc.dataSources = append(uc.dataSources, source)
+}
+
+// RequestDeletionInput は退会申請の入力データです
+type RequestDeletionInput struct {
+	UserID   string
+	Password string // パスワードを設定しているユーザーは再入力が必要
+	Reason   string
+}
+
+// accountDeletionPayload は退会処理ジョブのペイロードです
+type accountDeletionPayload struct {
+	DeletionID uuid.UUID `json:"deletion_id"`
+}
+
+// RequestDeletion は退会を申請します
+// 猶予期間の経過後にジョブで個人データを削除します
+func (uc *AccountUseCase) RequestDeletion(ctx context.Context, input RequestDeletionInput) (*entity.AccountDeletion, error) {
+	user, err := uc.repos.Users.FindByID(ctx, input.UserID)
+	if err != nil || user.IsDeleted() {
+		return nil, ErrUserNotFound
+	}
+
+	if user.Password != "" {
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password)); err != nil {
+			return nil, ErrInvalidCredentials
+		}
+	}
+
+	existing, err := uc.repos.Deletions.FindScheduledByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrDeletionAlreadyRequested
+	}
+
+	deletion := entity.NewAccountDeletion(user.ID, input.Reason)
+	if err := uc.repos.Deletions.Create(ctx, deletion); err != nil {
+		return nil, err
+	}
+
+	payload := accountDeletionPayload{DeletionID: deletion.ID}
+	if err := uc.jobQueue.Enqueue(ctx, JobTypeAccountDelete, payload, deletion.ScheduledAt); err != nil {
+		return nil, err
+	}
+
+	return deletion, nil
+}
+
+// GetDeletion は削除予定の退会申請を取得します
+func (uc *AccountUseCase) GetDeletion(ctx context.Context, userID string) (*entity.AccountDeletion, error) {
+	deletion, err := uc.repos.Deletions.FindScheduledByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if deletion == nil {
+		return nil, ErrDeletionNotFound
+	}
+
+	return deletion, nil
+}
+
+// CancelDeletion は猶予期間中の退会申請を取り消します
+// 登録済みのジョブは実行時に申請の状態を確認して何もせずに終了します
+func (uc *AccountUseCase) CancelDeletion(ctx context.Context, userID string) (*entity.AccountDeletion, error) {
+	deletion, err := uc.GetDeletion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	deletion.Cancel()
+	if err := uc.repos.Deletions.Update(ctx, deletion); err != nil {
+		return nil, err
+	}
+
+	return deletion, nil
+}
+
+// HandleDeletionJob は退会処理ジョブを処理します
+func (uc *AccountUseCase) HandleDeletionJob(ctx context.Context, payload []byte) error {
+	var p accountDeletionPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("failed to decode account deletion payload: %w", err)
+	}
+
+	return uc.ExecuteDeletion(ctx, p.DeletionID)
+}
+
+// ExecuteDeletion は退会申請に従ってユーザーの個人データを削除します
+// 途中で失敗した場合に再実行できるよう、各処理は冪等になっています
+func (uc *AccountUseCase) ExecuteDeletion(ctx context.Context, deletionID uuid.UUID) error {
+	deletion, err := uc.repos.Deletions.FindByID(ctx, deletionID)
+	if err != nil {
+		return err
+	}
+	if deletion == nil || !deletion.IsScheduled() || time.Now().Before(deletion.ScheduledAt) {
+		return nil
+	}
+
+	user, err := uc.repos.Users.FindByID(ctx, deletion.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	// 課金を止めてからデータを削除する
+	if err := uc.cancelSubscriptions(ctx, user.ID); err != nil {
+		return err
+	}
+
+	files, err := uc.collectStoredFiles(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range uc.dataSources {
+		if err := source.ErasePersonalData(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to erase %s: %w", source.Name(), err)
+		}
+	}
+
+	if !user.IsDeleted() {
+		user.Tombstone()
+	}
+	if err := uc.repos.Accounts.Purge(ctx, user); err != nil {
+		return err
+	}
+
+	// ファイルの削除はDBの削除後に個別のジョブで行い、ストレージ障害で退会処理が止まらないようにする
+	for _, path := range files {
+		if err := enqueueStorageDelete(ctx, uc.jobQueue, path, time.Time{}); err != nil {
+			fmt.Printf("failed to enqueue storage cleanup: %v\n", err)
+		}
+	}
+
+	deletion.Complete()
+	return uc.repos.Deletions.Update(ctx, deletion)
+}
+
+// cancelSubscriptions は有効なサブスクリプションを解約します
+func (uc *AccountUseCase) cancelSubscriptions(ctx context.Context, userID string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil
+	}
+
+	subscriptions, err := uc.repos.Subscriptions.ListByUserID(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subscriptions {
+		if sub.Status != entity.SubscriptionStatusActive {
+			continue
+		}
+		if err := uc.paymentService.CancelSubscription(ctx, sub.ID); err != nil {
+			return fmt.Errorf("failed to cancel subscription: %w", err)
+		}
+		if err := uc.repos.Subscriptions.UpdateStatus(ctx, sub.ID, entity.SubscriptionStatusInactive); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectStoredFiles はユーザーがストレージにアップロードしたファイルの一覧を取得します
+func (uc *AccountUseCase) collectStoredFiles(ctx context.Context, userID string) ([]string, error) {
+	var files []string
+
+	profile, err := uc.repos.Profiles.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile != nil && profile.AvatarURL != "" {
+		files = append(files, profile.AvatarURL)
+	}
+
+	if uid, err := uuid.Parse(userID); err == nil {
+		contents, err := uc.repos.Contents.FindByUserID(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		for _, content := range contents {
+			files = append(files, content.FilePath)
+		}
+	}
+
+	return files, nil
+}
+
+// accountExportPayload はデータエクスポートジョブのペイロードです
+type accountExportPayload struct {
+	ExportID uuid.UUID `json:"export_id"`
+}
+
+// RequestExport はユーザーデータのエクスポートを要求します
+// ZIPファイルはジョブで非同期に作成します
+func (uc *AccountUseCase) RequestExport(ctx context.Context, userID string) (*entity.DataExport, error) {
+	latest, err := uc.repos.Exports.FindLatestByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Status == entity.DataExportStatusPending && time.Since(latest.CreatedAt) < exportPendingTimeout {
+		return nil, ErrExportInProgress
+	}
+
+	export := entity.NewDataExport(userID)
+	if err := uc.repos.Exports.Create(ctx, export); err != nil {
+		return nil, err
+	}
+
+	if err := uc.jobQueue.Enqueue(ctx, JobTypeAccountExport, accountExportPayload{ExportID: export.ID}, time.Time{}); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// GetExport はエクスポートの状態を取得します
+// ダウンロード可能な場合は署名付きURLも返します
+func (uc *AccountUseCase) GetExport(ctx context.Context, userID string, exportID uuid.UUID) (*entity.DataExport, string, error) {
+	export, err := uc.repos.Exports.FindByID(ctx, exportID)
+	if err != nil {
+		return nil, "", err
+	}
+	if export == nil || export.UserID != userID {
+		return nil, "", ErrExportNotFound
+	}
+
+	if !export.IsDownloadable(time.Now()) {
+		return export, "", nil
+	}
+
+	url, err := uc.exportStorage.GetSignedURL(ctx, export.FileKey, exportURLExpiry)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return export, url, nil
+}
+
+// HandleExportJob はデータエクスポートジョブを処理します
+func (uc *AccountUseCase) HandleExportJob(ctx context.Context, payload []byte) error {
+	var p accountExportPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("failed to decode account export payload: %w", err)
+	}
+
+	return uc.BuildExport(ctx, p.ExportID)
+}
+
+// BuildExport はユーザーデータのZIPファイルを作成してストレージに保存します
+func (uc *AccountUseCase) BuildExport(ctx context.Context, exportID uuid.UUID) error {
+	export, err := uc.repos.Exports.FindByID(ctx, exportID)
+	if err != nil {
+		return err
+	}
+	if export == nil || export.Status != entity.DataExportStatusPending {
+		return nil
+	}
+
+	archive, err := uc.buildArchive(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("exports/%s/%s.zip", export.UserID, export.ID)
+	if err := uc.exportStorage.Put(ctx, key, bytes.NewReader(archive), "application/zip"); err != nil {
+		return err
+	}
+
+	export.MarkReady(key)
+	if err := uc.repos.Exports.Update(ctx, export); err != nil {
+		return err
+	}
+
+	// ダウンロード期限を過ぎたファイルは削除する
+	if err := enqueueStorageDelete(ctx, uc.jobQueue, key, *export.ExpiresAt); err != nil {
+		fmt.Printf("failed to enqueue export cleanup: %v\n", err)
+	}
+
+	return nil
+}
+
+// archiveEntry はエクスポートファイル内の1つのJSONファイルです
+type archiveEntry struct {
+	name string
+	data interface{}
+}
+
+// buildArchive はユーザーデータをJSONファイルにまとめたZIPを作成します
+func (uc *AccountUseCase) buildArchive(ctx context.Context, userID string) ([]byte, error) {
+	user, err := uc.repos.Users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	entries := []archiveEntry{{name: "account", data: user}}
+	add := func(name string, data interface{}) {
+		entries = append(entries, archiveEntry{name: name, data: data})
+	}
+
+	profile, err := uc.repos.Profiles.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	add("profile", profile)
+
+	identities, err := uc.repos.Identities.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	add("identities", identities)
+
+	sessions, err := uc.repos.Sessions.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	add("sessions", sessions)
+
+	events, err := uc.repos.SecurityEvents.FindByUserID(ctx, userID, exportSecurityEventLimit)
+	if err != nil {
+		return nil, err
+	}
+	add("security_events", events)
+
+	apiKeys, err := uc.repos.APIKeys.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	add("api_keys", apiKeys)
+
+	if uid, err := uuid.Parse(userID); err == nil {
+		purchases, err := uc.repos.Subscriptions.ListByUserID(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		add("purchases", purchases)
+
+		contents, err := uc.repos.Contents.FindByUserID(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		add("contents", contents)
+	}
+
+	// 診断結果や相性診断の履歴など、機能ごとのデータは登録されたデータソースから取得する
+	for _, source := range uc.dataSources {
+		data, err := source.ExportPersonalData(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", source.Name(), err)
+		}
+		add(source.Name(), data)
+	}
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, entry := range entries {
+		w, err := zw.Create(entry.name + ".json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create archive entry: %w", err)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(entry.data); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", entry.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// memoryAccountDeletionRepository はテスト用のメモリ上のAccountDeletionRepositoryです
+type memoryAccountDeletionRepository struct {
+	mu        sync.Mutex
+	deletions map[uuid.UUID]*entity.AccountDeletion
+}
+
+func (r *memoryAccountDeletionRepository) Create(ctx context.Context, deletion *entity.AccountDeletion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deletions[deletion.ID] = deletion
+	return nil
+}
+
+func (r *memoryAccountDeletionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.AccountDeletion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.deletions[id], nil
+}
+
+func (r *memoryAccountDeletionRepository) FindScheduledByUserID(ctx context.Context, userID string) (*entity.AccountDeletion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, deletion := range r.deletions {
+		if deletion.UserID == userID && deletion.IsScheduled() {
+			return deletion, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAccountDeletionRepository) Update(ctx context.Context, deletion *entity.AccountDeletion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deletions[deletion.ID] = deletion
+	return nil
+}
+
+// memoryDataExportRepository はテスト用のメモリ上のDataExportRepositoryです
+type memoryDataExportRepository struct {
+	mu      sync.Mutex
+	exports map[uuid.UUID]*entity.DataExport
+}
+
+func (r *memoryDataExportRepository) Create(ctx context.Context, export *entity.DataExport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exports[export.ID] = export
+	return nil
+}
+
+func (r *memoryDataExportRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.DataExport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.exports[id], nil
+}
+
+func (r *memoryDataExportRepository) FindLatestByUserID(ctx context.Context, userID string) (*entity.DataExport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var latest *entity.DataExport
+	for _, export := range r.exports {
+		if export.UserID == userID && (latest == nil || export.CreatedAt.After(latest.CreatedAt)) {
+			latest = export
+		}
+	}
+	return latest, nil
+}
+
+func (r *memoryDataExportRepository) Update(ctx context.Context, export *entity.DataExport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exports[export.ID] = export
+	return nil
+}
+
+// memoryAccountRepository はテスト用の削除したユーザーを記録するAccountRepositoryです
+type memoryAccountRepository struct {
+	purged []*entity.User
+}
+
+func (r *memoryAccountRepository) Purge(ctx context.Context, user *entity.User) error {
+	r.purged = append(r.purged, user)
+	return nil
+}
+
+// memoryProfileRepository はテスト用のプロフィールを持たないProfileRepositoryです
+type memoryProfileRepository struct {
+	repository.ProfileRepository
+}
+
+func (r *memoryProfileRepository) FindByUserID(ctx context.Context, userID string) (*entity.UserProfile, error) {
+	return nil, nil
+}
+
+// memorySubscriptionRepository はテスト用のメモリ上のSubscriptionRepositoryです
+// テストで使わないメソッドは埋め込んだインターフェースに委ねます（呼び出すとpanicします）
+type memorySubscriptionRepository struct {
+	repository.SubscriptionRepository
+
+	mu            sync.Mutex
+	subscriptions []*entity.Subscription
+}
+
+func (r *memorySubscriptionRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var subscriptions []*entity.Subscription
+	for _, sub := range r.subscriptions {
+		if sub.UserID == userID {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r *memorySubscriptionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.SubscriptionStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, sub := range r.subscriptions {
+		if sub.ID == id {
+			sub.Status = status
+		}
+	}
+	return nil
+}
+
+// recordingPaymentService はテスト用の解約したサブスクリプションを記録するPaymentServiceです
+type recordingPaymentService struct {
+	PaymentService
+	cancelled []uuid.UUID
+}
+
+func (s *recordingPaymentService) CancelSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	s.cancelled = append(s.cancelled, subscriptionID)
+	return nil
+}
+
+// memoryExportStorage はテスト用のメモリ上のExportStorageです
+type memoryExportStorage struct {
+	files map[string][]byte
+}
+
+func (s *memoryExportStorage) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.files[key] = data
+	return nil
+}
+
+func (s *memoryExportStorage) GetSignedURL(ctx context.Context, key string, duration time.Duration) (string, error) {
+	return "https://storage.example.com/" + key, nil
+}
+
+// recordingDataSource はテスト用の削除とエクスポートの呼び出しを記録するPersonalDataSourceです
+type recordingDataSource struct {
+	erased []string
+}
+
+func (s *recordingDataSource) Name() string {
+	return "diagnoses"
+}
+
+func (s *recordingDataSource) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	return []string{"diagnosis of " + userID}, nil
+}
+
+func (s *recordingDataSource) ErasePersonalData(ctx context.Context, userID string) error {
+	s.erased = append(s.erased, userID)
+	return nil
+}
+
+type accountFixture struct {
+	user          *entity.User
+	deletions     *memoryAccountDeletionRepository
+	exports       *memoryDataExportRepository
+	accounts      *memoryAccountRepository
+	subscriptions *memorySubscriptionRepository
+	contents      *memoryContentRepository
+	payments      *recordingPaymentService
+	storage       *memoryExportStorage
+	jobs          *memoryJobQueue
+	source        *recordingDataSource
+	uc            *AccountUseCase
+}
+
+func newAccountFixture(t *testing.T, password string) *accountFixture {
+	t.Helper()
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	user := entity.NewUser("fan@example.com", string(hashed), "Fan")
+	user.ID = uuid.New().String()
+
+	f := &accountFixture{
+		user:          user,
+		deletions:     &memoryAccountDeletionRepository{deletions: map[uuid.UUID]*entity.AccountDeletion{}},
+		exports:       &memoryDataExportRepository{exports: map[uuid.UUID]*entity.DataExport{}},
+		accounts:      &memoryAccountRepository{},
+		subscriptions: &memorySubscriptionRepository{},
+		contents:      newMemoryContentRepository(),
+		payments:      &recordingPaymentService{},
+		storage:       &memoryExportStorage{files: map[string][]byte{}},
+		jobs:          &memoryJobQueue{},
+		source:        &recordingDataSource{},
+	}
+	f.uc = NewAccountUseCase(AccountRepositories{
+		Users:          newMemoryUserRepository(user),
+		Accounts:       f.accounts,
+		Deletions:      f.deletions,
+		Exports:        f.exports,
+		Profiles:       &memoryProfileRepository{},
+		Identities:     &memoryIdentityRepository{},
+		Sessions:       newMemorySessionRepository(),
+		SecurityEvents: &memorySecurityEventRepository{},
+		APIKeys:        newMemoryAPIKeyRepository(),
+		Subscriptions:  f.subscriptions,
+		Contents:       f.contents,
+	}, f.payments, nil, f.storage, f.jobs)
+	f.uc.RegisterDataSource(f.source)
+	return f
+}
+
+func TestAccountRequestDeletionRequiresPassword(t *testing.T) {
+	f := newAccountFixture(t, "correct-password")
+	ctx := context.Background()
+
+	_, err := f.uc.RequestDeletion(ctx, RequestDeletionInput{UserID: f.user.ID, Password: "wrong-password"})
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("request with a wrong password error = %v, want ErrInvalidCredentials", err)
+	}
+	if _, err := f.uc.RequestDeletion(ctx, RequestDeletionInput{UserID: f.user.ID, Password: "correct-password"}); err != nil {
+		t.Fatalf("failed to request deletion: %v", err)
+	}
+	_, err = f.uc.RequestDeletion(ctx, RequestDeletionInput{UserID: f.user.ID, Password: "correct-password"})
+	if !errors.Is(err, ErrDeletionAlreadyRequested) {
+		t.Fatalf("second request error = %v, want ErrDeletionAlreadyRequested", err)
+	}
+	if len(f.jobs.jobs) != 1 || f.jobs.jobs[0] != JobTypeAccountDelete {
+		t.Errorf("enqueued jobs = %v, want one %s", f.jobs.jobs, JobTypeAccountDelete)
+	}
+}
+
+func TestAccountExecuteDeletionWaitsForGracePeriod(t *testing.T) {
+	f := newAccountFixture(t, "password")
+	ctx := context.Background()
+
+	deletion, err := f.uc.RequestDeletion(ctx, RequestDeletionInput{UserID: f.user.ID, Password: "password"})
+	if err != nil {
+		t.Fatalf("failed to request deletion: %v", err)
+	}
+	if err := f.uc.ExecuteDeletion(ctx, deletion.ID); err != nil {
+		t.Fatalf("failed to execute deletion: %v", err)
+	}
+
+	// 猶予期間中や取り消し後は何も削除しない
+	deletion.ScheduledAt = time.Now().Add(-time.Minute)
+	deletion.Cancel()
+	if err := f.uc.ExecuteDeletion(ctx, deletion.ID); err != nil {
+		t.Fatalf("failed to execute cancelled deletion: %v", err)
+	}
+	if len(f.source.erased) != 0 || len(f.accounts.purged) != 0 {
+		t.Fatalf("data erased before the grace period or after cancellation")
+	}
+}
+
+func TestAccountExecuteDeletionErasesPersonalData(t *testing.T) {
+	f := newAccountFixture(t, "password")
+	ctx := context.Background()
+	uid := uuid.MustParse(f.user.ID)
+
+	sub, err := entity.NewSubscription(uid, entity.PlanTypeBasic, time.Now(), nil)
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+	sub.Status = entity.SubscriptionStatusActive
+	f.subscriptions.subscriptions = append(f.subscriptions.subscriptions, sub)
+	content, err := entity.NewContent(uid, "title", "", entity.ContentTypeImage, "contents/a.jpg", decimal.Zero)
+	if err != nil {
+		t.Fatalf("failed to create content: %v", err)
+	}
+	f.contents.contents[content.ID] = content
+
+	deletion := entity.NewAccountDeletion(f.user.ID, "")
+	deletion.ScheduledAt = time.Now().Add(-time.Minute)
+	f.deletions.deletions[deletion.ID] = deletion
+
+	if err := f.uc.ExecuteDeletion(ctx, deletion.ID); err != nil {
+		t.Fatalf("failed to execute deletion: %v", err)
+	}
+
+	if len(f.payments.cancelled) != 1 || sub.Status != entity.SubscriptionStatusInactive {
+		t.Errorf("active subscription must be cancelled before erasure")
+	}
+	if len(f.source.erased) != 1 || f.source.erased[0] != f.user.ID {
+		t.Errorf("erased data sources = %v, want the deleted user", f.source.erased)
+	}
+	if len(f.accounts.purged) != 1 || !f.accounts.purged[0].IsDeleted() || f.accounts.purged[0].Email == "fan@example.com" {
+		t.Errorf("user must be purged as a tombstone")
+	}
+	if len(f.jobs.jobs) != 1 || f.jobs.jobs[0] != JobTypeStorageDelete {
+		t.Errorf("enqueued jobs = %v, want a storage cleanup for the uploaded content", f.jobs.jobs)
+	}
+	if deletion.Status != entity.AccountDeletionStatusCompleted {
+		t.Errorf("deletion status = %s, want completed", deletion.Status)
+	}
+}
+
+func TestAccountExportIsOnlyVisibleToOwner(t *testing.T) {
+	f := newAccountFixture(t, "password")
+	ctx := context.Background()
+
+	export, err := f.uc.RequestExport(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("failed to request export: %v", err)
+	}
+	if _, err := f.uc.RequestExport(ctx, f.user.ID); !errors.Is(err, ErrExportInProgress) {
+		t.Fatalf("second export error = %v, want ErrExportInProgress", err)
+	}
+	if err := f.uc.BuildExport(ctx, export.ID); err != nil {
+		t.Fatalf("failed to build export: %v", err)
+	}
+
+	if _, _, err := f.uc.GetExport(ctx, uuid.New().String(), export.ID); !errors.Is(err, ErrExportNotFound) {
+		t.Fatalf("export of another user error = %v, want ErrExportNotFound", err)
+	}
+	_, url, err := f.uc.GetExport(ctx, f.user.ID, export.ID)
+	if err != nil || url == "" {
+		t.Fatalf("owner must get a download url (url %q, err %v)", url, err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(f.storage.files[export.FileKey]), int64(len(f.storage.files[export.FileKey])))
+	if err != nil {
+		t.Fatalf("failed to read archive: %v", err)
+	}
+	names := map[string]bool{}
+	for _, file := range archive.File {
+		names[file.Name] = true
+		if file.Name != "account.json" {
+			continue
+		}
+		r, _ := file.Open()
+		data, _ := io.ReadAll(r)
+		r.Close()
+		if strings.Contains(string(data), f.user.Password) {
+			t.Error("password hash must not be exported")
+		}
+	}
+	for _, name := range []string{"account.json", "diagnoses.json"} {
+		if !names[name] {
+			t.Errorf("archive is missing %s", name)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"kimiyomi/backend/src/domain/engagement"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+// PointReasonBadgeReward はバッジ獲得の報酬として付与したポイントの理由です
+const PointReasonBadgeReward = "badge_reward"
+
+const (
+	defaultPointListLimit = 20
+	maxPointListLimit     = 100
+)
+
+// AchievementUseCase はバッジの獲得判定と推し活ポイントのユースケースを実装します
+type AchievementUseCase struct {
+	definitions  []*entity.BadgeDefinition
+	badgeRepo    repository.BadgeRepository
+	ledgerRepo   repository.PointLedgerRepository
+	activityRepo repository.ActivityRepository
+	scorer       *engagement.Scorer
+	publisher    RealtimePublisher
+	notifier     Notifier
+}
+
+// NewAchievementUseCase は新しいAchievementUseCaseを作成します
+func NewAchievementUseCase(
+	definitions []*entity.BadgeDefinition,
+	badgeRepo repository.BadgeRepository,
+	ledgerRepo repository.PointLedgerRepository,
+	activityRepo repository.ActivityRepository,
+	scorer *engagement.Scorer,
+	publisher RealtimePublisher,
+	notifier Notifier,
+) *AchievementUseCase {
+	return &AchievementUseCase{
+		definitions:  definitions,
+		badgeRepo:    badgeRepo,
+		ledgerRepo:   ledgerRepo,
+		activityRepo: activityRepo,
+		scorer:       scorer,
+		publisher:    publisher,
+		notifier:     notifier,
+	}
+}
+
+// BadgeStatus はバッジの定義とユーザーの獲得状況です
+type BadgeStatus struct {
+	*entity.BadgeDefinition
+	Earned    bool       `json:"earned"`
+	AwardedAt *time.Time `json:"awarded_at,omitempty"`
+}
+
+// BadgeList はユーザーのバッジの一覧とポイント残高です
+type BadgeList struct {
+	Badges        []*BadgeStatus `json:"badges"`
+	PointsBalance int64          `json:"points_balance"`
+}
+
+// PointsBalanceEvent はポイント残高が変わったことを知らせるイベントの内容です
+type PointsBalanceEvent struct {
+	Balance int64 `json:"balance"`
+}
+
+// PointHistory はユーザーのポイント残高と増減の履歴です
+type PointHistory struct {
+	Balance int64                      `json:"balance"`
+	Entries []*entity.PointLedgerEntry `json:"entries"`
+}
+
+// OnActivity は行動が記録されたときに、その行動に関係するバッジの獲得条件を判定します
+func (uc *AchievementUseCase) OnActivity(ctx context.Context, event *entity.ActivityEvent, _ float64) error {
+	return uc.evaluate(ctx, event.UserID, func(def *entity.BadgeDefinition) bool {
+		return def.Metric == entity.BadgeMetricStreakDays || def.ActivityType == event.Type
+	})
+}
+
+// Evaluate はユーザーの全てのバッジの獲得条件を判定します
+// バッジの定義を追加したときに、既存の行動から獲得済みとなるユーザーへ付与するために使います
+func (uc *AchievementUseCase) Evaluate(ctx context.Context, userID string) error {
+	return uc.evaluate(ctx, userID, func(*entity.BadgeDefinition) bool { return true })
+}
+
+// ListBadges は全てのバッジとユーザーの獲得状況を取得します
+func (uc *AchievementUseCase) ListBadges(ctx context.Context, userID string) (*BadgeList, error) {
+	earned, err := uc.badgeRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	awardedAt := make(map[string]time.Time, len(earned))
+	for _, badge := range earned {
+		awardedAt[badge.BadgeID] = badge.AwardedAt
+	}
+
+	balance, err := uc.ledgerRepo.Balance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &BadgeList{
+		Badges:        make([]*BadgeStatus, 0, len(uc.definitions)),
+		PointsBalance: balance,
+	}
+	for _, def := range uc.definitions {
+		status := &BadgeStatus{BadgeDefinition: def}
+		if at, ok := awardedAt[def.ID]; ok {
+			status.Earned = true
+			status.AwardedAt = &at
+		}
+		list.Badges = append(list.Badges, status)
+	}
+
+	return list, nil
+}
+
+// GetPoints はユーザーのポイント残高と増減の履歴を取得します
+func (uc *AchievementUseCase) GetPoints(ctx context.Context, userID string, limit, offset int) (*PointHistory, error) {
+	if limit <= 0 {
+		limit = defaultPointListLimit
+	}
+	if limit > maxPointListLimit {
+		limit = maxPointListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	balance, err := uc.ledgerRepo.Balance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.ledgerRepo.ListByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []*entity.PointLedgerEntry{}
+	}
+
+	return &PointHistory{Balance: balance, Entries: entries}, nil
+}
+
+// Name はエクスポートファイル内のファイル名です
+func (uc *AchievementUseCase) Name() string {
+	return "achievements"
+}
+
+// ExportPersonalData はユーザーが獲得したバッジとポイントの履歴を返します
+func (uc *AchievementUseCase) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	badges, err := uc.badgeRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*entity.PointLedgerEntry
+	for offset := 0; ; offset += maxPointListLimit {
+		page, err := uc.ledgerRepo.ListByUserID(ctx, userID, maxPointListLimit, offset)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, page...)
+		if len(page) < maxPointListLimit {
+			break
+		}
+	}
+
+	return map[string]interface{}{
+		"badges":       badges,
+		"point_ledger": entries,
+	}, nil
+}
+
+// ErasePersonalData はユーザーが獲得したバッジとポイントの履歴を削除します
+func (uc *AchievementUseCase) ErasePersonalData(ctx context.Context, userID string) error {
+	if err := uc.badgeRepo.DeleteAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+	return uc.ledgerRepo.DeleteAllByUserID(ctx, userID)
+}
+
+// evaluate は target が true を返すバッジのうち未獲得のものの獲得条件を判定し、条件を満たしたバッジを付与します
+func (uc *AchievementUseCase) evaluate(ctx context.Context, userID string, target func(*entity.BadgeDefinition) bool) error {
+	earned, err := uc.badgeRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	earnedIDs := make(map[string]bool, len(earned))
+	for _, badge := range earned {
+		earnedIDs[badge.BadgeID] = true
+	}
+
+	counts := make(map[entity.ActivityType]int)
+	streak := -1
+	for _, def := range uc.definitions {
+		if earnedIDs[def.ID] || !target(def) {
+			continue
+		}
+
+		var value int
+		switch def.Metric {
+		case entity.BadgeMetricActivityCount:
+			count, ok := counts[def.ActivityType]
+			if !ok {
+				count, err = uc.activityRepo.CountByUser(ctx, userID, def.ActivityType)
+				if err != nil {
+					return err
+				}
+				counts[def.ActivityType] = count
+			}
+			value = count
+		case entity.BadgeMetricStreakDays:
+			if streak < 0 {
+				streak, err = uc.streakDays(ctx, userID)
+				if err != nil {
+					return err
+				}
+			}
+			value = streak
+		}
+
+		if value >= def.Threshold {
+			if err := uc.award(ctx, userID, def); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// award はバッジを付与し、報酬のポイントを台帳に記録して、初めて獲得した場合はユーザーに知らせます
+// ポイントは参照IDで重複を防ぐため、先に記録してからバッジを付与します
+// 途中で失敗しても次回の判定で付与され、ポイントが二重に記録されることはありません
+func (uc *AchievementUseCase) award(ctx context.Context, userID string, def *entity.BadgeDefinition) error {
+	if def.RewardPoints > 0 {
+		entry := entity.NewPointLedgerEntry(userID, def.RewardPoints, PointReasonBadgeReward, "badge:"+def.ID)
+		booked, err := uc.ledgerRepo.Book(ctx, entry)
+		if err != nil {
+			return err
+		}
+		if booked {
+			uc.publishBalance(ctx, userID)
+		}
+	}
+
+	awarded, err := uc.badgeRepo.Award(ctx, entity.NewUserBadge(userID, def.ID))
+	if err != nil {
+		return err
+	}
+	if awarded {
+		notify(ctx, uc.notifier, NotifyInput{
+			UserID:   userID,
+			Category: entity.NotificationCategoryAchievement,
+			Template: "achievement.badge_awarded",
+			Params: map[string]string{
+				"badge_name": def.Name,
+				"points":     strconv.FormatInt(def.RewardPoints, 10),
+			},
+			Data: map[string]string{"badge_id": def.ID},
+		})
+	}
+	return nil
+}
+
+// publishBalance はユーザーに最新のポイント残高を知らせます
+func (uc *AchievementUseCase) publishBalance(ctx context.Context, userID string) {
+	balance, err := uc.ledgerRepo.Balance(ctx, userID)
+	if err != nil {
+		fmt.Printf("failed to get points balance: %v\n", err)
+		return
+	}
+	publishRealtime(ctx, uc.publisher, userTopic(userID), entity.RealtimeEventPointsBalance, &PointsBalanceEvent{Balance: balance})
+}
+
+// streakDays はユーザーがいずれかの推しに対して連続して行動した日数を取得します
+// 判定に必要な期間の行動のみを読み込みます
+func (uc *AchievementUseCase) streakDays(ctx context.Context, userID string) (int, error) {
+	maxDays := 0
+	for _, def := range uc.definitions {
+		if def.Metric == entity.BadgeMetricStreakDays && def.Threshold > maxDays {
+			maxDays = def.Threshold
+		}
+	}
+
+	now := time.Now()
+	events, err := uc.activityRepo.ListByUser(ctx, userID, uc.scorer.StartOfDay(now).AddDate(0, 0, -maxDays))
+	if err != nil {
+		return 0, err
+	}
+
+	return uc.scorer.StreakDays(events, now), nil
+}
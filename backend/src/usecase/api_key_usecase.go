@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidAPIKeyScope    = errors.New("invalid api key scope")
+	ErrAPIKeyScopeNotAllowed = errors.New("api key scope exceeds your permissions")
+	ErrAPIKeyLimitReached    = errors.New("api key limit reached")
+	ErrInvalidRateLimit      = errors.New("invalid rate limit")
+	ErrAPIKeyNotFound        = errors.New("api key not found")
+)
+
+const (
+	maxAPIKeysPerUser         = 20
+	maxAPIKeysPerOrganization = 20
+	defaultAPIKeyRateLimit    = 60
+	maxAPIKeyRateLimit        = 1000
+	apiKeyPrefixByteSize      = 6
+	apiKeyLastUsedGranularity = time.Minute
+)
+
+// APIKeyUseCase はAPIキー関連のユースケースを実装します
+type APIKeyUseCase struct {
+	apiKeyRepo repository.APIKeyRepository
+	userRepo   repository.UserRepository
+	orgRepo    repository.OrganizationRepository
+}
+
+// NewAPIKeyUseCase は新しいAPIKeyUseCaseを作成します
+func NewAPIKeyUseCase(apiKeyRepo repository.APIKeyRepository, userRepo repository.UserRepository, orgRepo repository.OrganizationRepository) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		orgRepo:    orgRepo,
+	}
+}
+
+// CreateAPIKeyInput はAPIキー作成の入力データです
+type CreateAPIKeyInput struct {
+	UserID string
+	// OrganizationID を指定した場合は組織が所有するキーを発行します
+	OrganizationID     *uuid.UUID
+	Name               string
+	Scopes             []string
+	RateLimitPerMinute int
+	ExpiresAt          *time.Time
+}
+
+// CreatedAPIKey は作成したAPIキーと平文のキーです
+// 平文のキーはこのときにしか取得できません
+type CreatedAPIKey struct {
+	*entity.APIKey
+	Key string `json:"key"`
+}
+
+// Create は新しいAPIキーを発行します
+// スコープは発行者のロールが持つ権限の範囲内でのみ指定できます
+// 組織が所有するキーは、その組織のオーナーまたはマネージャーのみが発行できます
+func (uc *APIKeyUseCase) Create(ctx context.Context, input CreateAPIKeyInput) (*CreatedAPIKey, error) {
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	allowed := auth.PermissionStrings(user.Role)
+	for _, scope := range input.Scopes {
+		if !auth.IsValidAPIKeyScope(scope) {
+			return nil, ErrInvalidAPIKeyScope
+		}
+		if !contains(allowed, scope) {
+			return nil, ErrAPIKeyScopeNotAllowed
+		}
+	}
+
+	rateLimit := input.RateLimitPerMinute
+	if rateLimit == 0 {
+		rateLimit = defaultAPIKeyRateLimit
+	}
+	if rateLimit < 0 || rateLimit > maxAPIKeyRateLimit {
+		return nil, ErrInvalidRateLimit
+	}
+
+	var keys []*entity.APIKey
+	limit := maxAPIKeysPerUser
+	if input.OrganizationID != nil {
+		if err := uc.authorizeOrganizationManager(ctx, input.UserID, *input.OrganizationID); err != nil {
+			return nil, err
+		}
+		keys, err = uc.apiKeyRepo.FindByOrganizationID(ctx, *input.OrganizationID)
+		limit = maxAPIKeysPerOrganization
+	} else {
+		keys, err = uc.apiKeyRepo.FindByUserID(ctx, input.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	active := 0
+	for _, k := range keys {
+		if !k.IsRevoked() && !k.IsExpired(time.Now()) {
+			active++
+		}
+	}
+	if active >= limit {
+		return nil, ErrAPIKeyLimitReached
+	}
+
+	prefixBytes := make([]byte, apiKeyPrefixByteSize)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate api key prefix: %w", err)
+	}
+	prefix := hex.EncodeToString(prefixBytes)
+	secret, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	key := entity.NewAPIKey(input.UserID, input.Name, prefix, hashAPIKeySecret(secret), input.Scopes, rateLimit, input.ExpiresAt)
+	key.OrganizationID = input.OrganizationID
+	if err := uc.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &CreatedAPIKey{
+		APIKey: key,
+		Key:    auth.APIKeyPrefix + prefix + "_" + secret,
+	}, nil
+}
+
+// List はユーザーが個人で所有するAPIキー一覧を取得します
+// orgID を指定した場合は組織が所有するAPIキー一覧を取得します
+func (uc *APIKeyUseCase) List(ctx context.Context, userID string, orgID *uuid.UUID) ([]*entity.APIKey, error) {
+	if orgID != nil {
+		if err := uc.authorizeOrganizationManager(ctx, userID, *orgID); err != nil {
+			return nil, err
+		}
+		return uc.apiKeyRepo.FindByOrganizationID(ctx, *orgID)
+	}
+	return uc.apiKeyRepo.FindByUserID(ctx, userID)
+}
+
+// Revoke はAPIキーを失効させます
+// 組織が所有するキーは、発行者に限らずその組織のオーナーまたはマネージャーが失効できます
+func (uc *APIKeyUseCase) Revoke(ctx context.Context, userID string, id uuid.UUID) error {
+	key, err := uc.apiKeyRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key == nil || key.IsRevoked() {
+		return ErrAPIKeyNotFound
+	}
+
+	if key.IsOrganizationOwned() {
+		if err := uc.authorizeOrganizationManager(ctx, userID, *key.OrganizationID); err != nil {
+			// 所属していない組織のキーの存在は明かさない
+			if errors.Is(err, ErrOrganizationNotFound) {
+				return ErrAPIKeyNotFound
+			}
+			return err
+		}
+	} else if key.UserID != userID {
+		return ErrAPIKeyNotFound
+	}
+
+	if err := uc.apiKeyRepo.Revoke(ctx, id); err != nil {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// authorizeOrganizationManager はユーザーが組織のオーナーまたはマネージャーであることを確認します
+func (uc *APIKeyUseCase) authorizeOrganizationManager(ctx context.Context, userID string, orgID uuid.UUID) error {
+	member, err := uc.orgRepo.FindMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrOrganizationNotFound
+	}
+	if !member.Role.CanManageMembers() {
+		return ErrOrganizationForbidden
+	}
+	return nil
+}
+
+// AuthenticateAPIKey はAPIキーを検証し、認証された主体を返します
+// 実効権限はキーのスコープと所有者の現在のロールの権限の積集合です
+func (uc *APIKeyUseCase) AuthenticateAPIKey(ctx context.Context, rawKey string) (*auth.APIKeyPrincipal, error) {
+	body := strings.TrimPrefix(rawKey, auth.APIKeyPrefix)
+	if body == rawKey {
+		return nil, auth.ErrInvalidAPIKey
+	}
+	parts := strings.SplitN(body, "_", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, auth.ErrInvalidAPIKey
+	}
+
+	key, err := uc.apiKeyRepo.FindByPrefix(ctx, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || key.IsRevoked() {
+		return nil, auth.ErrInvalidAPIKey
+	}
+	if subtle.ConstantTimeCompare([]byte(key.SecretHash), []byte(hashAPIKeySecret(parts[1]))) != 1 {
+		return nil, auth.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.IsExpired(now) {
+		return nil, auth.ErrAPIKeyExpired
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, key.UserID)
+	if err != nil {
+		return nil, auth.ErrInvalidAPIKey
+	}
+
+	// 組織が所有するキーは、発行者が組織のオーナーまたはマネージャーである間のみ有効とする
+	var orgID string
+	if key.IsOrganizationOwned() {
+		if err := uc.authorizeOrganizationManager(ctx, key.UserID, *key.OrganizationID); err != nil {
+			if errors.Is(err, ErrOrganizationNotFound) || errors.Is(err, ErrOrganizationForbidden) {
+				return nil, auth.ErrInvalidAPIKey
+			}
+			return nil, err
+		}
+		orgID = key.OrganizationID.String()
+	}
+
+	allowed := auth.PermissionStrings(user.Role)
+	perms := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		if contains(allowed, scope) {
+			perms = append(perms, scope)
+		}
+	}
+
+	// 書き込みを抑えるため、最終使用日時は一定間隔でのみ更新する
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyLastUsedGranularity {
+		_ = uc.apiKeyRepo.TouchLastUsed(ctx, key.ID, now)
+	}
+
+	return &auth.APIKeyPrincipal{
+		KeyID:              key.ID.String(),
+		UserID:             user.ID,
+		OrganizationID:     orgID,
+		Role:               user.Role,
+		Permissions:        perms,
+		RateLimitPerMinute: key.RateLimitPerMinute,
+	}, nil
+}
+
+// hashAPIKeySecret はAPIキーのシークレットのハッシュを返します
+// シークレットは十分なエントロピーを持つため、ソルトなしのSHA-256で保存します
+func hashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// contains はスライスに値が含まれるかどうかを確認します
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
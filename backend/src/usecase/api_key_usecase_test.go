@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// memoryAPIKeyRepository はテスト用のメモリ上のAPIKeyRepositoryです
+type memoryAPIKeyRepository struct {
+	mu   sync.Mutex
+	keys map[uuid.UUID]*entity.APIKey
+}
+
+func newMemoryAPIKeyRepository() *memoryAPIKeyRepository {
+	return &memoryAPIKeyRepository{keys: map[uuid.UUID]*entity.APIKey{}}
+}
+
+func (r *memoryAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys[key.ID] = key
+	return nil
+}
+
+func (r *memoryAPIKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range r.keys {
+		if key.Prefix == prefix {
+			return key, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAPIKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.keys[id], nil
+}
+
+func (r *memoryAPIKeyRepository) FindByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var keys []*entity.APIKey
+	for _, key := range r.keys {
+		if key.UserID == userID && !key.IsOrganizationOwned() {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (r *memoryAPIKeyRepository) FindByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entity.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var keys []*entity.APIKey
+	for _, key := range r.keys {
+		if key.IsOrganizationOwned() && *key.OrganizationID == orgID {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (r *memoryAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key, ok := r.keys[id]
+	if !ok {
+		return ErrAPIKeyNotFound
+	}
+	key.Revoke()
+	return nil
+}
+
+func (r *memoryAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if key, ok := r.keys[id]; ok {
+		key.LastUsedAt = &usedAt
+	}
+	return nil
+}
+
+// newTestUser はIDとロールを設定したテスト用のユーザーを作成します
+func newTestUser(role string) *entity.User {
+	user := entity.NewUser(uuid.NewString()+"@example.com", "hashed-password", "User")
+	user.ID = uuid.New().String()
+	user.Role = role
+	return user
+}
+
+func TestAPIKeyCreateRejectsScopeBeyondRole(t *testing.T) {
+	fan := newTestUser(entity.RoleFan)
+	uc := NewAPIKeyUseCase(newMemoryAPIKeyRepository(), newMemoryUserRepository(fan), &memoryOrganizationRepository{})
+
+	_, err := uc.Create(context.Background(), CreateAPIKeyInput{
+		UserID: fan.ID,
+		Name:   "key",
+		Scopes: []string{string(auth.PermContentRead), string(auth.PermContentCreate)},
+	})
+	if !errors.Is(err, ErrAPIKeyScopeNotAllowed) {
+		t.Fatalf("create error = %v, want ErrAPIKeyScopeNotAllowed", err)
+	}
+}
+
+func TestAPIKeyPermissionsFollowOwnerRole(t *testing.T) {
+	creator := newTestUser(entity.RoleCreator)
+	uc := NewAPIKeyUseCase(newMemoryAPIKeyRepository(), newMemoryUserRepository(creator), &memoryOrganizationRepository{})
+	ctx := context.Background()
+
+	created, err := uc.Create(ctx, CreateAPIKeyInput{
+		UserID: creator.ID,
+		Name:   "key",
+		Scopes: []string{string(auth.PermContentRead), string(auth.PermContentCreate)},
+	})
+	if err != nil {
+		t.Fatalf("failed to create api key: %v", err)
+	}
+
+	// 発行後に降格した場合、キーのスコープは現在のロールの権限に絞られる
+	creator.UpdateRole(entity.RoleFan)
+	principal, err := uc.AuthenticateAPIKey(ctx, created.Key)
+	if err != nil {
+		t.Fatalf("failed to authenticate api key: %v", err)
+	}
+	if len(principal.Permissions) != 1 || principal.Permissions[0] != string(auth.PermContentRead) {
+		t.Errorf("permissions = %v, want only %s", principal.Permissions, auth.PermContentRead)
+	}
+}
+
+func TestAPIKeyOrganizationKeyRequiresManager(t *testing.T) {
+	manager := newTestUser(entity.RoleCreator)
+	cast := newTestUser(entity.RoleCreator)
+	outsider := newTestUser(entity.RoleCreator)
+	orgID := uuid.New()
+	managerMember := entity.NewOrganizationMember(orgID, manager.ID, entity.OrganizationRoleManager)
+	orgRepo := &memoryOrganizationRepository{members: []*entity.OrganizationMember{
+		entity.NewOrganizationMember(orgID, uuid.New().String(), entity.OrganizationRoleOwner),
+		managerMember,
+		entity.NewOrganizationMember(orgID, cast.ID, entity.OrganizationRoleMember),
+	}}
+	uc := NewAPIKeyUseCase(newMemoryAPIKeyRepository(), newMemoryUserRepository(manager, cast, outsider), orgRepo)
+	ctx := context.Background()
+
+	input := CreateAPIKeyInput{
+		OrganizationID: &orgID,
+		Name:           "org key",
+		Scopes:         []string{string(auth.PermContentCreate)},
+	}
+	input.UserID = cast.ID
+	if _, err := uc.Create(ctx, input); !errors.Is(err, ErrOrganizationForbidden) {
+		t.Fatalf("create by a cast error = %v, want ErrOrganizationForbidden", err)
+	}
+	input.UserID = manager.ID
+	created, err := uc.Create(ctx, input)
+	if err != nil {
+		t.Fatalf("failed to create organization api key: %v", err)
+	}
+
+	// 組織外のユーザーにはキーの存在を明かさない
+	if err := uc.Revoke(ctx, outsider.ID, created.ID); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("revoke by an outsider error = %v, want ErrAPIKeyNotFound", err)
+	}
+
+	// 発行者がマネージャーでなくなるとキーは使えなくなる
+	managerMember.Role = entity.OrganizationRoleMember
+	if _, err := uc.AuthenticateAPIKey(ctx, created.Key); !errors.Is(err, auth.ErrInvalidAPIKey) {
+		t.Fatalf("authenticate after demotion error = %v, want ErrInvalidAPIKey", err)
+	}
+}
@@ -66,10 +66,11 @@ func (uc *authUseCase) Register(ctx context.Context, email, password, name strin
 
 	// 新規ユーザーの作成
 	user := &entity.User{
-		Email:    email,
-		Password: string(hashedPassword),
-		Name:     name,
-		Role:     "user", // デフォルトロール
+		Email:       email,
+		Password:    string(hashedPassword),
+		Name:        name,
+		Role:        entity.RoleFan, // デフォルトロール
+		RoleVersion: 1,
 	}
 
 	// ユーザーの保存
@@ -0,0 +1,537 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPostNotFound       = errors.New("post not found")
+	ErrCommentNotFound    = errors.New("comment not found")
+	ErrCommunityForbidden = errors.New("not allowed to delete this post or comment")
+	ErrInvalidPostImage   = errors.New("post images must be jpg, png, gif or webp images up to 5MB")
+)
+
+const (
+	defaultCommunityLimit = 20
+	maxCommunityLimit     = 50
+	maxPostImageUploads   = 4
+)
+
+// CommunityUseCase は推しのページのコミュニティのユースケースを実装します
+type CommunityUseCase struct {
+	postRepo     repository.CommunityPostRepository
+	commentRepo  repository.CommunityCommentRepository
+	reactionRepo repository.ReactionRepository
+	oshiRepo     repository.OshiRepository
+	fileStorage  FileStorage
+	jobQueue     JobQueue
+	relationRepo repository.UserRelationRepository
+}
+
+// NewCommunityUseCase は新しいCommunityUseCaseを作成します
+func NewCommunityUseCase(
+	postRepo repository.CommunityPostRepository,
+	commentRepo repository.CommunityCommentRepository,
+	reactionRepo repository.ReactionRepository,
+	oshiRepo repository.OshiRepository,
+	fileStorage FileStorage,
+	jobQueue JobQueue,
+	relationRepo repository.UserRelationRepository,
+) *CommunityUseCase {
+	return &CommunityUseCase{
+		postRepo:     postRepo,
+		commentRepo:  commentRepo,
+		reactionRepo: reactionRepo,
+		oshiRepo:     oshiRepo,
+		fileStorage:  fileStorage,
+		jobQueue:     jobQueue,
+		relationRepo: relationRepo,
+	}
+}
+
+// CommunityActor はコミュニティを操作するユーザーです
+type CommunityActor struct {
+	UserID      string
+	ModerateAny bool // 他のユーザーの投稿やコメントを削除できる権限を持つかどうか
+}
+
+// PostView はリアクションの集計を含む投稿です
+type PostView struct {
+	*entity.CommunityPost
+	Reactions *entity.ReactionSummary `json:"reactions"`
+}
+
+// CommentView はリアクションの集計を含むコメントです
+type CommentView struct {
+	*entity.CommunityComment
+	Reactions *entity.ReactionSummary `json:"reactions"`
+}
+
+// PostPage は投稿の一覧の1ページ分の結果です
+// NextCursor が空の場合は続きがありません
+type PostPage struct {
+	Items      []*PostView `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// CommentPage はコメントの一覧の1ページ分の結果です
+// NextCursor が空の場合は続きがありません
+type CommentPage struct {
+	Items      []*CommentView `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// CreatePost は推しのページに投稿します
+// 画像はストレージにアップロードし、投稿の保存に失敗した場合は削除します
+func (uc *CommunityUseCase) CreatePost(ctx context.Context, userID string, oshiID uuid.UUID, body string, images []*multipart.FileHeader) (*PostView, error) {
+	if len(images) > maxPostImageUploads {
+		return nil, entity.ErrTooManyPostImages
+	}
+	for _, image := range images {
+		if !isValidImageUpload(image) {
+			return nil, ErrInvalidPostImage
+		}
+	}
+
+	oshi, err := uc.oshiRepo.FindByID(ctx, oshiID)
+	if err != nil {
+		return nil, err
+	}
+	if oshi == nil {
+		return nil, ErrOshiNotFound
+	}
+
+	// 本文の検証を画像のアップロードより先に行う
+	if _, err := entity.NewCommunityPost(oshiID, userID, body, make([]string, len(images))); err != nil {
+		return nil, err
+	}
+
+	imageURLs := make([]string, 0, len(images))
+	for _, image := range images {
+		imageURL, err := uc.fileStorage.Upload(ctx, image)
+		if err != nil {
+			uc.deleteUploaded(ctx, imageURLs)
+			return nil, fmt.Errorf("failed to upload post image: %w", err)
+		}
+		imageURLs = append(imageURLs, imageURL)
+	}
+
+	post, err := entity.NewCommunityPost(oshiID, userID, body, imageURLs)
+	if err != nil {
+		uc.deleteUploaded(ctx, imageURLs)
+		return nil, err
+	}
+
+	if err := uc.postRepo.Create(ctx, post); err != nil {
+		// アップロードしたファイルを削除
+		uc.deleteUploaded(ctx, imageURLs)
+		return nil, err
+	}
+
+	return &PostView{CommunityPost: post, Reactions: emptyReactionSummary()}, nil
+}
+
+// ListPosts は推しのページの投稿を新しい順に取得します
+// cursor には前のページの NextCursor を指定します
+func (uc *CommunityUseCase) ListPosts(ctx context.Context, viewerID string, oshiID uuid.UUID, cursor string, limit int) (*PostPage, error) {
+	limit = communityLimit(limit)
+	position, err := parseCommunityCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	oshi, err := uc.oshiRepo.FindByID(ctx, oshiID)
+	if err != nil {
+		return nil, err
+	}
+	if oshi == nil {
+		return nil, ErrOshiNotFound
+	}
+
+	// 続きの有無を判定するため1件多く取得する
+	filter := repository.CommunityFilter{ViewerID: viewerID}
+	posts, err := uc.postRepo.ListByOshiID(ctx, oshiID, filter, position, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &PostPage{Items: []*PostView{}}
+	if len(posts) > limit {
+		posts = posts[:limit]
+		last := posts[len(posts)-1]
+		page.NextCursor = encodeFeedCursor(repository.FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	items, err := uc.postViews(ctx, viewerID, posts)
+	if err != nil {
+		return nil, err
+	}
+	page.Items = items
+
+	return page, nil
+}
+
+// GetPost は投稿を取得します
+func (uc *CommunityUseCase) GetPost(ctx context.Context, viewerID string, postID uuid.UUID) (*PostView, error) {
+	post, err := uc.findVisiblePost(ctx, viewerID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := uc.postViews(ctx, viewerID, []*entity.CommunityPost{post})
+	if err != nil {
+		return nil, err
+	}
+	return views[0], nil
+}
+
+// DeletePost は投稿を削除します
+// 投稿者本人またはモデレーターのみ削除できます
+func (uc *CommunityUseCase) DeletePost(ctx context.Context, actor CommunityActor, postID uuid.UUID) error {
+	post, err := uc.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post == nil || post.IsDeleted() {
+		return ErrPostNotFound
+	}
+	if post.UserID != actor.UserID && !actor.ModerateAny {
+		return ErrCommunityForbidden
+	}
+
+	images := post.SoftDelete()
+	if err := uc.postRepo.Update(ctx, post); err != nil {
+		return err
+	}
+
+	for _, imageURL := range images {
+		if err := enqueueStorageDelete(ctx, uc.jobQueue, imageURL, time.Time{}); err != nil {
+			fmt.Printf("failed to enqueue post image deletion: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// CreateComment は投稿にコメントします
+// 返信への返信はスレッドの先頭のコメントへの返信として扱います
+func (uc *CommunityUseCase) CreateComment(ctx context.Context, userID string, postID uuid.UUID, parentID *uuid.UUID, body string) (*CommentView, error) {
+	post, err := uc.findVisiblePost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		parent, err := uc.commentRepo.FindByID(ctx, *parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || parent.PostID != post.ID || parent.IsDeleted() {
+			return nil, ErrCommentNotFound
+		}
+		hidden, err := uc.isHidden(ctx, userID, parent.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if hidden {
+			return nil, ErrCommentNotFound
+		}
+		if parent.ParentID != nil {
+			parentID = parent.ParentID
+		}
+	}
+
+	comment, err := entity.NewCommunityComment(post.ID, parentID, userID, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.commentRepo.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return &CommentView{CommunityComment: comment, Reactions: emptyReactionSummary()}, nil
+}
+
+// ListComments は投稿のコメントを古い順に取得します
+// parentID を指定した場合はそのコメントへの返信を取得します
+func (uc *CommunityUseCase) ListComments(ctx context.Context, viewerID string, postID uuid.UUID, parentID *uuid.UUID, cursor string, limit int) (*CommentPage, error) {
+	limit = communityLimit(limit)
+	position, err := parseCommunityCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.findVisiblePost(ctx, viewerID, postID); err != nil {
+		return nil, err
+	}
+
+	// 続きの有無を判定するため1件多く取得する
+	filter := repository.CommunityFilter{ViewerID: viewerID}
+	comments, err := uc.commentRepo.ListByPostID(ctx, postID, parentID, filter, position, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &CommentPage{Items: []*CommentView{}}
+	if len(comments) > limit {
+		comments = comments[:limit]
+		last := comments[len(comments)-1]
+		page.NextCursor = encodeFeedCursor(repository.FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	ids := make([]uuid.UUID, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	summaries, err := uc.reactionRepo.Summaries(ctx, entity.ReactionTargetComment, ids, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, comment := range comments {
+		page.Items = append(page.Items, &CommentView{CommunityComment: comment, Reactions: summaries[comment.ID]})
+	}
+
+	return page, nil
+}
+
+// ListReplies はコメントへの返信を古い順に取得します
+func (uc *CommunityUseCase) ListReplies(ctx context.Context, viewerID string, commentID uuid.UUID, cursor string, limit int) (*CommentPage, error) {
+	comment, err := uc.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, ErrCommentNotFound
+	}
+
+	return uc.ListComments(ctx, viewerID, comment.PostID, &comment.ID, cursor, limit)
+}
+
+// DeleteComment はコメントを削除します
+// コメントした本人またはモデレーターのみ削除できます
+func (uc *CommunityUseCase) DeleteComment(ctx context.Context, actor CommunityActor, commentID uuid.UUID) error {
+	comment, err := uc.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if comment == nil || comment.IsDeleted() {
+		return ErrCommentNotFound
+	}
+	if comment.UserID != actor.UserID && !actor.ModerateAny {
+		return ErrCommunityForbidden
+	}
+
+	comment.SoftDelete()
+	return uc.commentRepo.SoftDelete(ctx, comment)
+}
+
+// AddPostReaction は投稿にリアクションします
+func (uc *CommunityUseCase) AddPostReaction(ctx context.Context, userID string, postID uuid.UUID, reaction entity.ReactionType) (*entity.ReactionSummary, error) {
+	if _, err := uc.findVisiblePost(ctx, userID, postID); err != nil {
+		return nil, err
+	}
+	return uc.react(ctx, entity.ReactionTargetPost, postID, userID, reaction, true)
+}
+
+// RemovePostReaction は投稿へのリアクションを取り消します
+func (uc *CommunityUseCase) RemovePostReaction(ctx context.Context, userID string, postID uuid.UUID, reaction entity.ReactionType) (*entity.ReactionSummary, error) {
+	return uc.react(ctx, entity.ReactionTargetPost, postID, userID, reaction, false)
+}
+
+// AddCommentReaction はコメントにリアクションします
+func (uc *CommunityUseCase) AddCommentReaction(ctx context.Context, userID string, commentID uuid.UUID, reaction entity.ReactionType) (*entity.ReactionSummary, error) {
+	comment, err := uc.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil || comment.IsDeleted() || (comment.HiddenAt != nil && comment.UserID != userID) {
+		return nil, ErrCommentNotFound
+	}
+	hidden, err := uc.isHidden(ctx, userID, comment.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if hidden {
+		return nil, ErrCommentNotFound
+	}
+	if _, err := uc.findVisiblePost(ctx, userID, comment.PostID); err != nil {
+		return nil, err
+	}
+	return uc.react(ctx, entity.ReactionTargetComment, commentID, userID, reaction, true)
+}
+
+// RemoveCommentReaction はコメントへのリアクションを取り消します
+func (uc *CommunityUseCase) RemoveCommentReaction(ctx context.Context, userID string, commentID uuid.UUID, reaction entity.ReactionType) (*entity.ReactionSummary, error) {
+	return uc.react(ctx, entity.ReactionTargetComment, commentID, userID, reaction, false)
+}
+
+// Name はエクスポートファイル内のファイル名です
+func (uc *CommunityUseCase) Name() string {
+	return "community"
+}
+
+// ExportPersonalData はユーザーの投稿・コメント・リアクションを返します
+func (uc *CommunityUseCase) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	posts, err := uc.postRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := uc.commentRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	reactions, err := uc.reactionRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"posts":     posts,
+		"comments":  comments,
+		"reactions": reactions,
+	}, nil
+}
+
+// ErasePersonalData はユーザーの投稿とコメントの本文と画像を消し、リアクションを削除します
+// 他のユーザーの返信のスレッドを保つため、投稿とコメントは削除済みとして残します
+func (uc *CommunityUseCase) ErasePersonalData(ctx context.Context, userID string) error {
+	posts, err := uc.postRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		for _, imageURL := range post.ImageURLs {
+			if err := enqueueStorageDelete(ctx, uc.jobQueue, imageURL, time.Time{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := uc.postRepo.SoftDeleteAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := uc.commentRepo.SoftDeleteAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+	return uc.reactionRepo.DeleteAllByUserID(ctx, userID)
+}
+
+// react はリアクションを追加または取り消し、対象の最新の集計を返します
+func (uc *CommunityUseCase) react(ctx context.Context, targetType entity.ReactionTargetType, targetID uuid.UUID, userID string, reactionType entity.ReactionType, add bool) (*entity.ReactionSummary, error) {
+	reaction, err := entity.NewReaction(targetType, targetID, userID, reactionType)
+	if err != nil {
+		return nil, err
+	}
+
+	if add {
+		_, err = uc.reactionRepo.Add(ctx, reaction)
+	} else {
+		_, err = uc.reactionRepo.Remove(ctx, targetType, targetID, userID, reactionType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := uc.reactionRepo.Summaries(ctx, targetType, []uuid.UUID{targetID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	return summaries[targetID], nil
+}
+
+// findVisiblePost は閲覧者に表示できる投稿を取得します
+// 削除された投稿、閲覧者以外の非表示にされた投稿、閲覧者がブロック・ミュートした投稿者と閲覧者をブロックした投稿者の投稿は見つからないものとして扱います
+func (uc *CommunityUseCase) findVisiblePost(ctx context.Context, viewerID string, postID uuid.UUID) (*entity.CommunityPost, error) {
+	post, err := uc.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post == nil || post.IsDeleted() {
+		return nil, ErrPostNotFound
+	}
+	if post.HiddenAt != nil && post.UserID != viewerID {
+		return nil, ErrPostNotFound
+	}
+
+	hidden, err := uc.isHidden(ctx, viewerID, post.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if hidden {
+		return nil, ErrPostNotFound
+	}
+
+	return post, nil
+}
+
+// isHidden は閲覧者に投稿者の投稿やコメントを表示しないかどうかを確認します
+func (uc *CommunityUseCase) isHidden(ctx context.Context, viewerID, authorID string) (bool, error) {
+	if viewerID == "" || viewerID == authorID {
+		return false, nil
+	}
+	return uc.relationRepo.IsHidden(ctx, viewerID, authorID)
+}
+
+// postViews は投稿にリアクションの集計を付けて返します
+func (uc *CommunityUseCase) postViews(ctx context.Context, viewerID string, posts []*entity.CommunityPost) ([]*PostView, error) {
+	ids := make([]uuid.UUID, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	summaries, err := uc.reactionRepo.Summaries(ctx, entity.ReactionTargetPost, ids, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*PostView, 0, len(posts))
+	for _, post := range posts {
+		views = append(views, &PostView{CommunityPost: post, Reactions: summaries[post.ID]})
+	}
+	return views, nil
+}
+
+// deleteUploaded はアップロード済みのファイルを削除します
+func (uc *CommunityUseCase) deleteUploaded(ctx context.Context, fileURLs []string) {
+	for _, fileURL := range fileURLs {
+		_ = uc.fileStorage.Delete(ctx, fileURL)
+	}
+}
+
+// communityLimit は一覧の取得件数を既定値と上限の範囲に収めます
+func communityLimit(limit int) int {
+	if limit <= 0 {
+		return defaultCommunityLimit
+	}
+	if limit > maxCommunityLimit {
+		return maxCommunityLimit
+	}
+	return limit
+}
+
+// parseCommunityCursor は一覧のページ位置を復元します
+// 空の場合は nil を返します
+func parseCommunityCursor(cursor string) (*repository.FeedCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	return decodeFeedCursor(cursor)
+}
+
+// emptyReactionSummary はリアクションが無い場合の集計を返します
+func emptyReactionSummary() *entity.ReactionSummary {
+	return &entity.ReactionSummary{
+		Counts: map[entity.ReactionType]int{},
+		Mine:   []entity.ReactionType{},
+	}
+}
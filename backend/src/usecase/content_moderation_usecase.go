@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrContentAlreadyReported   = errors.New("content already reported by this user")
+	ErrCannotReportOwnContent   = errors.New("cannot report your own content")
+	ErrInvalidModerationAction  = errors.New("invalid moderation action")
+	ErrModerationReasonRequired = errors.New("reason is required for this moderation action")
+	ErrInvalidModerationQueue   = errors.New("invalid moderation queue")
+	ErrNoOpenReports            = errors.New("content has no open reports")
+)
+
+const (
+	defaultModerationQueueLimit = 20
+	maxModerationQueueLimit     = 100
+)
+
+// ContentModerationUseCase はコンテンツの通報と審査のユースケースを実装します
+type ContentModerationUseCase struct {
+	contentRepo         repository.ContentRepository
+	moderationRepo      repository.ContentModerationRepository
+	oshiRepo            repository.OshiRepository
+	notifier            Notifier
+	reportHideThreshold int
+}
+
+// NewContentModerationUseCase は新しいContentModerationUseCaseを作成します
+// 未処理の通報が reportHideThreshold 件に達したコンテンツは審査が終わるまで自動で非表示にします
+func NewContentModerationUseCase(
+	contentRepo repository.ContentRepository,
+	moderationRepo repository.ContentModerationRepository,
+	oshiRepo repository.OshiRepository,
+	notifier Notifier,
+	reportHideThreshold int,
+) *ContentModerationUseCase {
+	return &ContentModerationUseCase{
+		contentRepo:         contentRepo,
+		moderationRepo:      moderationRepo,
+		oshiRepo:            oshiRepo,
+		notifier:            notifier,
+		reportHideThreshold: reportHideThreshold,
+	}
+}
+
+// ReportContentInput はコンテンツの通報の入力データです
+type ReportContentInput struct {
+	ContentID  uuid.UUID
+	ReporterID string
+	Reason     entity.ReportReason
+	Details    string
+}
+
+// ReportContent はコンテンツを通報します
+// 未処理の通報が一定数に達した場合はコンテンツを非表示にして、審査の履歴に記録します
+func (uc *ContentModerationUseCase) ReportContent(ctx context.Context, input ReportContentInput) (*entity.ContentReport, error) {
+	content, err := uc.contentRepo.FindByID(ctx, input.ContentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content: %w", err)
+	}
+	// 公開されていないコンテンツは通報者からは見えないため存在しないものとして扱う
+	if content == nil || !content.IsVisibleAt(time.Now()) {
+		return nil, ErrContentNotFound
+	}
+	if content.UserID.String() == input.ReporterID {
+		return nil, ErrCannotReportOwnContent
+	}
+
+	report, err := entity.NewContentReport(input.ContentID, input.ReporterID, input.Reason, input.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	created, reportCount, err := uc.moderationRepo.CreateReport(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrContentAlreadyReported
+	}
+
+	if uc.reportHideThreshold > 0 && reportCount >= uc.reportHideThreshold {
+		content.ReportCount = reportCount
+		content.Hide()
+		event, err := entity.NewModerationEvent(content.ID, nil, entity.ModerationActionAutoHide,
+			content.ModerationStatus, content.ModerationStatus, fmt.Sprintf("未処理の通報が%d件に達したため自動で非表示にしました", reportCount))
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.moderationRepo.SaveDecision(ctx, content, event, ""); err != nil {
+			return nil, fmt.Errorf("failed to hide reported content: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// ListQueue は審査キューのコンテンツを取得します
+func (uc *ContentModerationUseCase) ListQueue(ctx context.Context, queue repository.ModerationQueue, limit, offset int) ([]*entity.Content, error) {
+	if queue == "" {
+		queue = repository.ModerationQueuePending
+	}
+	if queue != repository.ModerationQueuePending && queue != repository.ModerationQueueReported {
+		return nil, ErrInvalidModerationQueue
+	}
+	if limit <= 0 {
+		limit = defaultModerationQueueLimit
+	}
+	if limit > maxModerationQueueLimit {
+		limit = maxModerationQueueLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	contents, err := uc.moderationRepo.ListQueue(ctx, queue, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if contents == nil {
+		contents = []*entity.Content{}
+	}
+
+	return contents, nil
+}
+
+// ContentReview は審査画面に表示するコンテンツと通報、審査の履歴です
+type ContentReview struct {
+	Content *entity.Content           `json:"content"`
+	Reports []*entity.ContentReport   `json:"reports"`
+	History []*entity.ModerationEvent `json:"history"`
+}
+
+// GetReview はコンテンツの審査に必要な情報を取得します
+func (uc *ContentModerationUseCase) GetReview(ctx context.Context, contentID uuid.UUID) (*ContentReview, error) {
+	content, err := uc.contentRepo.FindByID(ctx, contentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content: %w", err)
+	}
+	if content == nil {
+		return nil, ErrContentNotFound
+	}
+
+	reports, err := uc.moderationRepo.ListReports(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if reports == nil {
+		reports = []*entity.ContentReport{}
+	}
+
+	history, err := uc.moderationRepo.ListEvents(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		history = []*entity.ModerationEvent{}
+	}
+
+	return &ContentReview{Content: content, Reports: reports, History: history}, nil
+}
+
+// DecideInput は審査の操作の入力データです
+type DecideInput struct {
+	ModeratorID string
+	ContentID   uuid.UUID
+	Action      entity.ModerationAction
+	Reason      string
+}
+
+// Decide はコンテンツの審査の操作を行い、審査の履歴に記録します
+// 却下と取り下げは利用者へ説明できるよう理由を必須とします
+func (uc *ContentModerationUseCase) Decide(ctx context.Context, input DecideInput) (*entity.Content, error) {
+	content, err := uc.contentRepo.FindByID(ctx, input.ContentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content: %w", err)
+	}
+	if content == nil {
+		return nil, ErrContentNotFound
+	}
+
+	from := content.ModerationStatus
+	var reportStatus entity.ReportStatus
+	switch input.Action {
+	case entity.ModerationActionApprove:
+		if from == entity.ModerationStatusTakenDown {
+			return nil, entity.ErrInvalidModerationTransition
+		}
+		err = content.TransitionModeration(entity.ModerationStatusApproved)
+	case entity.ModerationActionReject:
+		if input.Reason == "" {
+			return nil, ErrModerationReasonRequired
+		}
+		err = content.TransitionModeration(entity.ModerationStatusRejected)
+	case entity.ModerationActionTakeDown:
+		if input.Reason == "" {
+			return nil, ErrModerationReasonRequired
+		}
+		err = content.TransitionModeration(entity.ModerationStatusTakenDown)
+		reportStatus = entity.ReportStatusResolved
+	case entity.ModerationActionRestore:
+		if from != entity.ModerationStatusTakenDown {
+			return nil, entity.ErrInvalidModerationTransition
+		}
+		err = content.TransitionModeration(entity.ModerationStatusApproved)
+	case entity.ModerationActionDismissReports:
+		if content.ReportCount == 0 && content.HiddenAt == nil {
+			return nil, ErrNoOpenReports
+		}
+		content.Unhide()
+		reportStatus = entity.ReportStatusDismissed
+	default:
+		return nil, ErrInvalidModerationAction
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := entity.NewModerationEvent(content.ID, &input.ModeratorID, input.Action, from, content.ModerationStatus, input.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.moderationRepo.SaveDecision(ctx, content, event, reportStatus); err != nil {
+		return nil, err
+	}
+
+	uc.notifyDecision(ctx, content, input)
+
+	return content, nil
+}
+
+// moderationTemplates は投稿者に知らせる審査の操作とお知らせのテンプレートです
+var moderationTemplates = map[entity.ModerationAction]string{
+	entity.ModerationActionApprove:  "moderation.approved",
+	entity.ModerationActionReject:   "moderation.rejected",
+	entity.ModerationActionTakeDown: "moderation.taken_down",
+	entity.ModerationActionRestore:  "moderation.restored",
+}
+
+// notifyDecision は審査の結果を投稿者に知らせ、初めて公開されたコンテンツを推しのフォロワーに知らせます
+// 公開日時が未来のコンテンツは、公開日時の到来を処理するジョブがフォロワーに知らせます
+func (uc *ContentModerationUseCase) notifyDecision(ctx context.Context, content *entity.Content, input DecideInput) {
+	template, ok := moderationTemplates[input.Action]
+	if !ok {
+		return
+	}
+	notify(ctx, uc.notifier, NotifyInput{
+		UserID:   content.UserID.String(),
+		Category: entity.NotificationCategoryModeration,
+		Template: template,
+		Params:   map[string]string{"title": content.Title, "reason": input.Reason},
+		Data:     map[string]string{"content_id": content.ID.String()},
+	})
+
+	// 取り下げからの再公開など、既に知らせたコンテンツはフォロワーには知らせない
+	releaseContent(ctx, uc.contentRepo, uc.oshiRepo, uc.notifier, content)
+}
+
+// Name はデータの種類の名前を返します
+func (uc *ContentModerationUseCase) Name() string {
+	return "content_reports"
+}
+
+// ExportPersonalData はユーザーが行った通報を返します
+func (uc *ContentModerationUseCase) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	return uc.moderationRepo.ListReportsByReporterID(ctx, userID)
+}
+
+// ErasePersonalData はユーザーが行った通報の詳細を消去します
+// 通報の件数と審査の記録を保つため、通報そのものは残します
+func (uc *ContentModerationUseCase) ErasePersonalData(ctx context.Context, userID string) error {
+	return uc.moderationRepo.ClearReportDetailsByReporterID(ctx, userID)
+}
@@ -2,9 +2,15 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"kimiyomi/backend/src/domain/entity"
 	"kimiyomi/backend/src/domain/repository"
@@ -13,11 +19,35 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+var (
+	ErrContentForbidden = errors.New("not allowed to manage this content")
+	ErrContentNotFound  = errors.New("content not found")
+	ErrContentNoAccess  = errors.New("user does not have access to this content")
+
+	ErrInvalidContentFilter = errors.New("invalid content filter")
+	ErrInvalidSearchQuery   = errors.New("search query is too long")
+)
+
+const (
+	defaultContentLimit = 20
+	maxContentLimit     = 50
+
+	// maxContentQueryLength は検索語の最大文字数です
+	maxContentQueryLength = 100
+
+	// contentReindexPageSize は検索用の索引の作成のジョブ1回で処理するコンテンツの件数です
+	contentReindexPageSize = 500
+)
+
 // ContentUseCase はコンテンツ関連のユースケースを実装します
 type ContentUseCase struct {
 	contentRepo      repository.ContentRepository
 	subscriptionRepo repository.SubscriptionRepository
+	oshiRepo         repository.OshiRepository
+	orgRepo          repository.OrganizationRepository
 	fileStorage      FileStorage
+	jobQueue         JobQueue
+	notifier         Notifier
 }
 
 // FileStorage はファイルストレージの操作を定義するインターフェースです
@@ -32,23 +62,43 @@ type FileStorage interface {
 func NewContentUseCase(
 	contentRepo repository.ContentRepository,
 	subscriptionRepo repository.SubscriptionRepository,
+	oshiRepo repository.OshiRepository,
+	orgRepo repository.OrganizationRepository,
 	fileStorage FileStorage,
+	jobQueue JobQueue,
+	notifier Notifier,
 ) *ContentUseCase {
 	return &ContentUseCase{
 		contentRepo:      contentRepo,
 		subscriptionRepo: subscriptionRepo,
+		oshiRepo:         oshiRepo,
+		orgRepo:          orgRepo,
 		fileStorage:      fileStorage,
+		jobQueue:         jobQueue,
+		notifier:         notifier,
 	}
 }
 
+// ContentReleasePayload はコンテンツの公開日時の到来を処理するジョブのペイロードです
+// 登録後に公開日時が変更された場合は、変更後の公開日時で登録したジョブだけが処理します
+type ContentReleasePayload struct {
+	ContentID uuid.UUID `json:"content_id"`
+	PublishAt time.Time `json:"publish_at"`
+}
+
 // CreateContentInput はコンテンツ作成の入力データです
 type CreateContentInput struct {
 	UserID      uuid.UUID
+	OnBehalfOf  uuid.UUID  // 組織のマネージャーが所属キャストとして投稿する場合の投稿者（任意）
+	OshiID      *uuid.UUID // コンテンツに登場する推し（任意）
 	Title       string
 	Description string
 	ContentType entity.ContentType
 	File        *multipart.FileHeader
 	Price       decimal.Decimal
+	PublishAt   *time.Time // 公開日時（任意）
+	UnpublishAt *time.Time // 公開終了日時（任意）
+	OrgScope    *uuid.UUID // 組織が所有するAPIキーで操作する場合の組織（任意、指定時はその組織に所属するユーザーのコンテンツに限定する）
 }
 
 // CreateContent は新しいコンテンツを作成します
@@ -59,6 +109,41 @@ func (uc *ContentUseCase) CreateContent(ctx context.Context, input CreateContent
 		return nil, fmt.Errorf("invalid file extension for content type: %s", ext)
 	}
 
+	// 代理投稿の場合は投稿者の組織のマネージャーであることを確認
+	// 組織が所有するAPIキーでは、その組織に所属するキャストの代理投稿のみ可能
+	ownerID := input.UserID
+	if input.OnBehalfOf != uuid.Nil {
+		ownerID = input.OnBehalfOf
+	}
+	if input.OrgScope != nil {
+		ok, err := uc.canManageInOrganization(ctx, input.UserID, *input.OrgScope, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrContentForbidden
+		}
+	} else if ownerID != input.UserID {
+		ok, err := uc.orgRepo.IsManagerOf(ctx, input.UserID.String(), ownerID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to check organization membership: %w", err)
+		}
+		if !ok {
+			return nil, ErrContentForbidden
+		}
+	}
+
+	// 推しの存在を確認
+	if input.OshiID != nil {
+		oshi, err := uc.oshiRepo.FindByID(ctx, *input.OshiID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find oshi: %w", err)
+		}
+		if oshi == nil {
+			return nil, ErrOshiNotFound
+		}
+	}
+
 	// ファイルをストレージにアップロード
 	filePath, err := uc.fileStorage.Upload(ctx, input.File)
 	if err != nil {
@@ -67,7 +152,7 @@ func (uc *ContentUseCase) CreateContent(ctx context.Context, input CreateContent
 
 	// コンテンツエンティティを作成
 	content, err := entity.NewContent(
-		input.UserID,
+		ownerID,
 		input.Title,
 		input.Description,
 		input.ContentType,
@@ -79,6 +164,11 @@ func (uc *ContentUseCase) CreateContent(ctx context.Context, input CreateContent
 		_ = uc.fileStorage.Delete(ctx, filePath)
 		return nil, err
 	}
+	content.OshiID = input.OshiID
+	if err := content.SetAvailability(input.PublishAt, input.UnpublishAt); err != nil {
+		_ = uc.fileStorage.Delete(ctx, filePath)
+		return nil, err
+	}
 
 	// コンテンツを保存
 	if err := uc.contentRepo.Create(ctx, content); err != nil {
@@ -87,25 +177,296 @@ func (uc *ContentUseCase) CreateContent(ctx context.Context, input CreateContent
 		return nil, fmt.Errorf("failed to create content: %w", err)
 	}
 
+	if err := uc.scheduleRelease(ctx, content); err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// UpdateContentInput はコンテンツ更新の入力データです
+type UpdateContentInput struct {
+	ContentID   uuid.UUID
+	UserID      uuid.UUID
+	OshiID      *uuid.UUID // コンテンツに登場する推し（任意）
+	Title       string
+	Description string
+	Price       decimal.Decimal
+	ContentType entity.ContentType    // 差し替えるファイルの種類（任意、省略時は現在の種類）
+	File        *multipart.FileHeader // 差し替えるファイル（任意）
+	ManageAny   bool                  // 他のユーザーのコンテンツも管理できる権限を持つかどうか
+	OrgScope    *uuid.UUID            // 組織が所有するAPIキーで操作する場合の組織（任意、指定時はその組織に所属するユーザーのコンテンツに限定する）
+}
+
+// UpdateContent はコンテンツの情報を更新します
+// ファイルを差し替えた場合は審査待ちに戻して審査の履歴に残し、古いファイルはジョブでストレージから削除します
+// 取り下げられたコンテンツのファイルは差し替えられません
+func (uc *ContentUseCase) UpdateContent(ctx context.Context, input UpdateContentInput) (*entity.Content, error) {
+	content, err := uc.contentRepo.FindByID(ctx, input.ContentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content: %w", err)
+	}
+	if content == nil {
+		return nil, ErrContentNotFound
+	}
+	canManage, err := uc.canManageContent(ctx, input.UserID, input.ManageAny, input.OrgScope, content)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrContentForbidden
+	}
+
+	// 推しの存在を確認
+	if input.OshiID != nil {
+		oshi, err := uc.oshiRepo.FindByID(ctx, *input.OshiID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find oshi: %w", err)
+		}
+		if oshi == nil {
+			return nil, ErrOshiNotFound
+		}
+	}
+
+	if err := content.UpdateContent(input.Title, input.Description, input.Price); err != nil {
+		return nil, err
+	}
+	content.OshiID = input.OshiID
+
+	oldFilePath := content.FilePath
+	newFilePath := ""
+	var event *entity.ModerationEvent
+	if input.File != nil {
+		contentType := input.ContentType
+		if contentType == "" {
+			contentType = content.ContentType
+		}
+
+		// ファイルの拡張子を確認
+		ext := filepath.Ext(input.File.Filename)
+		if !isValidFileExtension(ext, contentType) {
+			return nil, fmt.Errorf("invalid file extension for content type: %s", ext)
+		}
+
+		// 取り下げられたコンテンツは差し替えて再公開できないため、アップロード前に確認する
+		if content.ModerationStatus == entity.ModerationStatusTakenDown {
+			return nil, entity.ErrContentTakenDown
+		}
+
+		newFilePath, err = uc.fileStorage.Upload(ctx, input.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload file: %w", err)
+		}
+		from := content.ModerationStatus
+		if err := content.ReplaceFile(contentType, newFilePath); err != nil {
+			_ = uc.fileStorage.Delete(ctx, newFilePath)
+			return nil, err
+		}
+
+		// 差し替えによる審査待ちへの遷移を審査の履歴に残す
+		actorID := input.UserID.String()
+		event, err = entity.NewModerationEvent(content.ID, &actorID, entity.ModerationActionResubmit, from, content.ModerationStatus, "")
+		if err != nil {
+			_ = uc.fileStorage.Delete(ctx, newFilePath)
+			return nil, err
+		}
+	}
+
+	if err := uc.contentRepo.Update(ctx, content, event); err != nil {
+		// アップロードしたファイルを削除
+		if newFilePath != "" {
+			_ = uc.fileStorage.Delete(ctx, newFilePath)
+		}
+		return nil, fmt.Errorf("failed to update content: %w", err)
+	}
+
+	// 古いファイルの削除は個別のジョブで行い、ストレージ障害で更新が失敗しないようにする
+	if newFilePath != "" {
+		if err := enqueueStorageDelete(ctx, uc.jobQueue, oldFilePath, time.Time{}); err != nil {
+			fmt.Printf("failed to enqueue storage cleanup: %v\n", err)
+		}
+	}
+
 	return content, nil
 }
 
+// DeleteContentInput はコンテンツ削除の入力データです
+type DeleteContentInput struct {
+	ContentID uuid.UUID
+	UserID    uuid.UUID
+	ManageAny bool       // 他のユーザーのコンテンツも管理できる権限を持つかどうか
+	OrgScope  *uuid.UUID // 組織が所有するAPIキーで操作する場合の組織（任意、指定時はその組織に所属するユーザーのコンテンツに限定する）
+}
+
+// DeleteContent はコンテンツを削除します
+// ファイルはDBの削除後にジョブでストレージから削除します
+func (uc *ContentUseCase) DeleteContent(ctx context.Context, input DeleteContentInput) error {
+	content, err := uc.contentRepo.FindByID(ctx, input.ContentID)
+	if err != nil {
+		return fmt.Errorf("failed to find content: %w", err)
+	}
+	if content == nil {
+		return ErrContentNotFound
+	}
+	canManage, err := uc.canManageContent(ctx, input.UserID, input.ManageAny, input.OrgScope, content)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return ErrContentForbidden
+	}
+
+	if err := uc.contentRepo.Delete(ctx, content.ID); err != nil {
+		return fmt.Errorf("failed to delete content: %w", err)
+	}
+
+	if err := enqueueStorageDelete(ctx, uc.jobQueue, content.FilePath, time.Time{}); err != nil {
+		fmt.Printf("failed to enqueue storage cleanup: %v\n", err)
+	}
+
+	return nil
+}
+
+// UpdateAvailabilityInput はコンテンツの公開期間の変更の入力データです
+type UpdateAvailabilityInput struct {
+	ContentID   uuid.UUID
+	UserID      uuid.UUID
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+	ManageAny   bool       // 他のユーザーのコンテンツも管理できる権限を持つかどうか
+	OrgScope    *uuid.UUID // 組織が所有するAPIキーで操作する場合の組織（任意、指定時はその組織に所属するユーザーのコンテンツに限定する）
+}
+
+// UpdateAvailability はコンテンツの公開期間を変更します
+// 公開日時を未来に変更した場合は、その日時に公開を知らせるジョブを登録します
+func (uc *ContentUseCase) UpdateAvailability(ctx context.Context, input UpdateAvailabilityInput) (*entity.Content, error) {
+	content, err := uc.contentRepo.FindByID(ctx, input.ContentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content: %w", err)
+	}
+	if content == nil {
+		return nil, ErrContentNotFound
+	}
+	canManage, err := uc.canManageContent(ctx, input.UserID, input.ManageAny, input.OrgScope, content)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrContentForbidden
+	}
+
+	if err := content.SetAvailability(input.PublishAt, input.UnpublishAt); err != nil {
+		return nil, err
+	}
+	if err := uc.contentRepo.Update(ctx, content, nil); err != nil {
+		return nil, fmt.Errorf("failed to update content: %w", err)
+	}
+
+	if err := uc.scheduleRelease(ctx, content); err != nil {
+		return nil, err
+	}
+	// 公開日時を過去に変更して公開期間に入った場合はすぐに知らせる
+	releaseContent(ctx, uc.contentRepo, uc.oshiRepo, uc.notifier, content)
+
+	return content, nil
+}
+
+// HandleReleaseJob はコンテンツの公開日時の到来を処理するジョブを処理します
+// 公開日時の時点で審査を通過していないコンテンツは、承認された時に公開を知らせます
+func (uc *ContentUseCase) HandleReleaseJob(ctx context.Context, payload []byte) error {
+	var p ContentReleasePayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("failed to decode content release payload: %w", err)
+	}
+
+	content, err := uc.contentRepo.FindByID(ctx, p.ContentID)
+	if err != nil {
+		return fmt.Errorf("failed to find content: %w", err)
+	}
+	// データベースに保存した日時は精度が落ちるため、秒単位で比較する
+	if content == nil || content.PublishAt == nil || !content.PublishAt.Truncate(time.Second).Equal(p.PublishAt.Truncate(time.Second)) {
+		return nil
+	}
+	// サーバー間の時刻のずれで公開日時より前に実行された場合は登録し直す
+	if content.PublishAt.After(time.Now()) {
+		return uc.jobQueue.Enqueue(ctx, JobTypeContentRelease, p, *content.PublishAt)
+	}
+
+	releaseContent(ctx, uc.contentRepo, uc.oshiRepo, uc.notifier, content)
+	return nil
+}
+
+// scheduleRelease は公開日時が未来のコンテンツについて、公開日時に公開を知らせるジョブを登録します
+func (uc *ContentUseCase) scheduleRelease(ctx context.Context, content *entity.Content) error {
+	if content.PublishAt == nil || content.ReleasedAt != nil || !content.PublishAt.After(time.Now()) {
+		return nil
+	}
+
+	payload := ContentReleasePayload{ContentID: content.ID, PublishAt: *content.PublishAt}
+	if err := uc.jobQueue.Enqueue(ctx, JobTypeContentRelease, payload, *content.PublishAt); err != nil {
+		return fmt.Errorf("failed to schedule content release: %w", err)
+	}
+
+	return nil
+}
+
+// releaseContent は公開されたコンテンツを公開済みとして記録し、推しのフォロワーに知らせます
+// 審査の承認と公開日時の到来のどちらが後になっても一度だけ知らせるよう、公開済みの記録で重複を防ぎます
+// お知らせは本来の処理の付随的なものなので、失敗してもログに記録するだけにします
+func releaseContent(ctx context.Context, contentRepo repository.ContentRepository, oshiRepo repository.OshiRepository, notifier Notifier, content *entity.Content) {
+	if !content.IsVisibleAt(time.Now()) {
+		return
+	}
+
+	released, err := contentRepo.MarkReleased(ctx, content.ID)
+	if err != nil {
+		fmt.Printf("failed to mark content released: %v\n", err)
+		return
+	}
+	if !released || content.OshiID == nil {
+		return
+	}
+
+	oshi, err := oshiRepo.FindByID(ctx, *content.OshiID)
+	if err != nil || oshi == nil {
+		fmt.Printf("failed to find oshi of published content: %v\n", err)
+		return
+	}
+	notifyOshiFollowers(ctx, notifier, oshi.ID, NotifyInput{
+		UserID:      content.UserID.String(),
+		Category:    entity.NotificationCategoryContent,
+		Template:    "content.published",
+		CollapseKey: oshi.ID.String(),
+		Params:      map[string]string{"oshi_name": oshi.Name, "title": content.Title},
+		Data:        map[string]string{"content_id": content.ID.String()},
+	})
+}
+
 // AttachContentToDiagnosisInput は診断へのコンテンツ紐付けの入力データです
 type AttachContentToDiagnosisInput struct {
 	ContentID   uuid.UUID
 	DiagnosisID uuid.UUID
 	UserID      uuid.UUID
+	ManageAny   bool       // 他のユーザーのコンテンツも管理できる権限を持つかどうか
+	OrgScope    *uuid.UUID // 組織が所有するAPIキーで操作する場合の組織（任意、指定時はその組織に所属するユーザーのコンテンツに限定する）
 }
 
 // AttachContentToDiagnosis はコンテンツを診断に紐付けます
 func (uc *ContentUseCase) AttachContentToDiagnosis(ctx context.Context, input AttachContentToDiagnosisInput) error {
-	// コンテンツの所有者確認
+	// コンテンツの管理権限を確認
 	content, err := uc.contentRepo.FindByID(ctx, input.ContentID)
 	if err != nil {
 		return fmt.Errorf("failed to find content: %w", err)
 	}
-	if content.UserID != input.UserID {
-		return fmt.Errorf("user is not the owner of the content")
+	if content == nil {
+		return ErrContentNotFound
+	}
+	canManage, err := uc.canManageContent(ctx, input.UserID, input.ManageAny, input.OrgScope, content)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return ErrContentForbidden
 	}
 
 	// コンテンツを診断に紐付け
@@ -120,6 +481,8 @@ func (uc *ContentUseCase) AttachContentToDiagnosis(ctx context.Context, input At
 type GetContentInput struct {
 	ContentID uuid.UUID
 	UserID    uuid.UUID
+	ManageAny bool       // 他のユーザーのコンテンツも管理できる権限を持つかどうか
+	OrgScope  *uuid.UUID // 組織が所有するAPIキーで操作する場合の組織（任意、指定時はその組織に所属するユーザーのコンテンツに限定する）
 }
 
 // GetContent は指定されたコンテンツを取得します
@@ -129,18 +492,278 @@ func (uc *ContentUseCase) GetContent(ctx context.Context, input GetContentInput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find content: %w", err)
 	}
+	if content == nil {
+		return nil, ErrContentNotFound
+	}
 
-	// コンテンツの所有者またはアクティブなサブスクリプションを持つユーザーのみアクセス可能
-	if content.UserID != input.UserID {
+	// コンテンツを管理できるユーザーまたはアクティブなサブスクリプションを持つユーザーのみアクセス可能
+	canManage, err := uc.canManageContent(ctx, input.UserID, input.ManageAny, input.OrgScope, content)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		// 審査を通過していない、通報により非表示の、または公開期間外のコンテンツは管理者以外には存在しないものとして扱う
+		if !content.IsVisibleAt(time.Now()) {
+			return nil, ErrContentNotFound
+		}
 		subscription, err := uc.subscriptionRepo.FindActiveByUserID(ctx, input.UserID)
-		if err != nil || !subscription.IsActive() {
-			return nil, fmt.Errorf("user does not have access to this content")
+		if err != nil || subscription == nil || !subscription.IsActive() {
+			return nil, ErrContentNoAccess
 		}
 	}
 
 	return content, nil
 }
 
+// ListContentsInput はコンテンツの一覧取得の入力データです
+// Owned を指定した場合は閲覧者が投稿したコンテンツを審査中・公開期間外のものも含めて返します
+// Pricing には free（無料）または paid（有料）を指定できます
+type ListContentsInput struct {
+	ViewerID    uuid.UUID
+	Owned       bool
+	OshiID      *uuid.UUID
+	ContentType entity.ContentType
+	MinPrice    *decimal.Decimal
+	MaxPrice    *decimal.Decimal
+	Pricing     string
+	Query       string
+	Sort        repository.ContentSort
+	Cursor      string
+	Limit       int
+}
+
+// ContentListItem はコンテンツの一覧の項目です
+// 閲覧できるかどうかはサブスクリプションによるため、ファイルの場所は含めません
+// 審査状態は閲覧者が投稿したコンテンツの一覧でのみ返します
+type ContentListItem struct {
+	ID               uuid.UUID               `json:"id"`
+	UserID           uuid.UUID               `json:"user_id"`
+	OshiID           *uuid.UUID              `json:"oshi_id,omitempty"`
+	Title            string                  `json:"title"`
+	Description      string                  `json:"description"`
+	ContentType      entity.ContentType      `json:"content_type"`
+	Price            decimal.Decimal         `json:"price"`
+	ModerationStatus entity.ModerationStatus `json:"moderation_status,omitempty"`
+	PublishAt        *time.Time              `json:"publish_at,omitempty"`
+	UnpublishAt      *time.Time              `json:"unpublish_at,omitempty"`
+	CreatedAt        time.Time               `json:"created_at"`
+}
+
+// ContentPage はコンテンツの一覧の1ページです
+// NextCursor が空の場合は続きがありません
+type ContentPage struct {
+	Items      []*ContentListItem `json:"items"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// ListContents は条件に一致するコンテンツを指定した順に取得します
+// cursor には前のページの NextCursor を指定します。並び順を変えた場合は最初のページから取得し直します
+func (uc *ContentUseCase) ListContents(ctx context.Context, input ListContentsInput) (*ContentPage, error) {
+	if input.Limit <= 0 {
+		input.Limit = defaultContentLimit
+	}
+	if input.Limit > maxContentLimit {
+		input.Limit = maxContentLimit
+	}
+
+	filter := repository.ContentFilter{
+		ViewerID:    input.ViewerID.String(),
+		OshiID:      input.OshiID,
+		ContentType: input.ContentType,
+		MinPrice:    input.MinPrice,
+		MaxPrice:    input.MaxPrice,
+		Query:       strings.TrimSpace(input.Query),
+		Sort:        input.Sort,
+	}
+	if input.Owned {
+		filter.OwnerID = input.ViewerID.String()
+	}
+	if filter.Sort == "" {
+		filter.Sort = repository.ContentSortNewest
+	}
+	switch filter.Sort {
+	case repository.ContentSortNewest, repository.ContentSortOldest, repository.ContentSortPriceAsc, repository.ContentSortPriceDesc:
+	default:
+		return nil, ErrInvalidContentFilter
+	}
+	switch input.Pricing {
+	case "":
+	case "free", "paid":
+		free := input.Pricing == "free"
+		filter.Free = &free
+	default:
+		return nil, ErrInvalidContentFilter
+	}
+	if filter.ContentType != "" && filter.ContentType != entity.ContentTypeImage && filter.ContentType != entity.ContentTypeVideo {
+		return nil, ErrInvalidContentFilter
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && filter.MinPrice.GreaterThan(*filter.MaxPrice) {
+		return nil, ErrInvalidContentFilter
+	}
+	if utf8.RuneCountInString(filter.Query) > maxContentQueryLength {
+		return nil, ErrInvalidSearchQuery
+	}
+
+	var position *repository.ContentCursor
+	if input.Cursor != "" {
+		decoded, err := decodeContentCursor(input.Cursor, filter.Sort)
+		if err != nil {
+			return nil, err
+		}
+		position = decoded
+	}
+
+	// 続きの有無を判定するため1件多く取得する
+	contents, err := uc.contentRepo.List(ctx, filter, position, input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ContentPage{Items: make([]*ContentListItem, 0, len(contents))}
+	if len(contents) > input.Limit {
+		contents = contents[:input.Limit]
+		page.NextCursor = encodeContentCursor(filter.Sort, contents[len(contents)-1])
+	}
+	for _, content := range contents {
+		item := &ContentListItem{
+			ID:          content.ID,
+			UserID:      content.UserID,
+			OshiID:      content.OshiID,
+			Title:       content.Title,
+			Description: content.Description,
+			ContentType: content.ContentType,
+			Price:       content.Price,
+			PublishAt:   content.PublishAt,
+			UnpublishAt: content.UnpublishAt,
+			CreatedAt:   content.CreatedAt,
+		}
+		if input.Owned {
+			item.ModerationStatus = content.ModerationStatus
+		}
+		page.Items = append(page.Items, item)
+	}
+
+	return page, nil
+}
+
+// HandleSearchReindexJob は検索用の索引が作成されていないコンテンツの索引を作成するジョブを処理します
+// 1回のジョブでは一定の件数だけを処理し、続きは次のジョブとして登録します
+func (uc *ContentUseCase) HandleSearchReindexJob(ctx context.Context, payload []byte) error {
+	contents, err := uc.contentRepo.ListUnindexed(ctx, contentReindexPageSize)
+	if err != nil {
+		return err
+	}
+
+	for _, content := range contents {
+		if err := uc.contentRepo.UpdateSearchIndex(ctx, content); err != nil {
+			return err
+		}
+	}
+
+	if len(contents) < contentReindexPageSize {
+		return nil
+	}
+	return uc.EnqueueSearchReindex(ctx)
+}
+
+// EnqueueSearchReindex は検索用の索引が作成されていないコンテンツの索引の作成を登録します
+func (uc *ContentUseCase) EnqueueSearchReindex(ctx context.Context) error {
+	return uc.jobQueue.Enqueue(ctx, JobTypeContentSearchReindex, struct{}{}, time.Time{})
+}
+
+// encodeContentCursor は並び順と最後のコンテンツの位置を不透明な文字列に変換します
+func encodeContentCursor(sort repository.ContentSort, content *entity.Content) string {
+	var key string
+	switch sort {
+	case repository.ContentSortPriceAsc, repository.ContentSortPriceDesc:
+		key = content.Price.String()
+	default:
+		availableAt := content.CreatedAt
+		if content.PublishAt != nil {
+			availableAt = *content.PublishAt
+		}
+		key = availableAt.UTC().Format(time.RFC3339Nano)
+	}
+	raw := string(sort) + "|" + key + "|" + content.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeContentCursor は不透明な文字列からコンテンツの一覧のページ位置を復元します
+// 並び順が異なるページ位置はエラーにします
+func decodeContentCursor(cursor string, sort repository.ContentSort) (*repository.ContentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 || parts[0] != string(sort) {
+		return nil, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	position := &repository.ContentCursor{ID: id}
+	switch sort {
+	case repository.ContentSortPriceAsc, repository.ContentSortPriceDesc:
+		if position.Price, err = decimal.NewFromString(parts[1]); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	default:
+		if position.AvailableAt, err = time.Parse(time.RFC3339Nano, parts[1]); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return position, nil
+}
+
+// canManageContent はユーザーがコンテンツを管理できるかどうかを確認します
+// コンテンツの投稿者本人と、全てのコンテンツを管理する権限を持つユーザーに加え、
+// 投稿者が所属する組織の所有者・マネージャーも代理で管理できます
+// orgScope を指定した場合は、その組織に所属するユーザーのコンテンツのみ管理できます
+func (uc *ContentUseCase) canManageContent(ctx context.Context, userID uuid.UUID, manageAny bool, orgScope *uuid.UUID, content *entity.Content) (bool, error) {
+	if orgScope != nil {
+		return uc.canManageInOrganization(ctx, userID, *orgScope, content.UserID)
+	}
+	if content.UserID == userID || manageAny {
+		return true, nil
+	}
+
+	ok, err := uc.orgRepo.IsManagerOf(ctx, userID.String(), content.UserID.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to check organization membership: %w", err)
+	}
+
+	return ok, nil
+}
+
+// canManageInOrganization は組織が所有するAPIキーで、ユーザーが投稿者のコンテンツを管理できるかどうかを確認します
+// 組織のキーは所属キャストの代理でのみ使えるため、投稿者がその組織に所属し、ユーザーがその組織の所有者・マネージャーである場合のみ管理できます
+// キーを発行したユーザー本人のコンテンツは組織のものではないため管理できません
+func (uc *ContentUseCase) canManageInOrganization(ctx context.Context, userID, orgID, ownerID uuid.UUID) (bool, error) {
+	if ownerID == userID {
+		return false, nil
+	}
+
+	owner, err := uc.orgRepo.FindMember(ctx, orgID, ownerID.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to check organization membership: %w", err)
+	}
+	if owner == nil {
+		return false, nil
+	}
+
+	manager, err := uc.orgRepo.FindMember(ctx, orgID, userID.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to check organization membership: %w", err)
+	}
+
+	return manager != nil && manager.Role.CanManageMembers(), nil
+}
+
 // isValidFileExtension はファイルの拡張子が有効かどうかを確認します
 func isValidFileExtension(ext string, contentType entity.ContentType) bool {
 	switch contentType {
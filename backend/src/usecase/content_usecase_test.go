@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"sync"
+	"testing"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// memoryContentRepository はテスト用のメモリ上のContentRepositoryです
+// テストで使わないメソッドは埋め込んだインターフェースに委ねます（呼び出すとpanicします）
+type memoryContentRepository struct {
+	repository.ContentRepository
+
+	mu       sync.Mutex
+	contents map[uuid.UUID]*entity.Content
+	events   []*entity.ModerationEvent
+}
+
+func newMemoryContentRepository(contents ...*entity.Content) *memoryContentRepository {
+	r := &memoryContentRepository{contents: map[uuid.UUID]*entity.Content{}}
+	for _, content := range contents {
+		r.contents[content.ID] = content
+	}
+	return r
+}
+
+func (r *memoryContentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Content, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.contents[id], nil
+}
+
+func (r *memoryContentRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Content, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var contents []*entity.Content
+	for _, content := range r.contents {
+		if content.UserID == userID {
+			contents = append(contents, content)
+		}
+	}
+	return contents, nil
+}
+
+func (r *memoryContentRepository) Update(ctx context.Context, content *entity.Content, event *entity.ModerationEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contents[content.ID] = content
+	if event != nil {
+		r.events = append(r.events, event)
+	}
+	return nil
+}
+
+func (r *memoryContentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.contents, id)
+	return nil
+}
+
+// memoryJobQueue はテスト用の登録されたジョブを記録するJobQueueです
+type memoryJobQueue struct {
+	mu   sync.Mutex
+	jobs []string
+}
+
+func (q *memoryJobQueue) Enqueue(ctx context.Context, jobType string, payload interface{}, runAt time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs = append(q.jobs, jobType)
+	return nil
+}
+
+// memoryFileStorage はテスト用のアップロードと削除を記録するFileStorageです
+type memoryFileStorage struct {
+	uploaded []string
+	deleted  []string
+}
+
+func (s *memoryFileStorage) Upload(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	path := "contents/" + uuid.NewString() + "-" + file.Filename
+	s.uploaded = append(s.uploaded, path)
+	return path, nil
+}
+
+func (s *memoryFileStorage) Delete(ctx context.Context, filePath string) error {
+	s.deleted = append(s.deleted, filePath)
+	return nil
+}
+
+// contentScopeFixture は2つの組織を管理するマネージャーと、それぞれの組織のキャストです
+type contentScopeFixture struct {
+	manager  uuid.UUID
+	orgA     uuid.UUID
+	orgB     uuid.UUID
+	castA    uuid.UUID
+	castB    uuid.UUID
+	contents *memoryContentRepository
+	uc       *ContentUseCase
+}
+
+func newContentScopeFixture(contents ...*entity.Content) *contentScopeFixture {
+	f := &contentScopeFixture{
+		manager: uuid.New(),
+		orgA:    uuid.New(),
+		orgB:    uuid.New(),
+		castA:   uuid.New(),
+		castB:   uuid.New(),
+	}
+	orgRepo := &memoryOrganizationRepository{members: []*entity.OrganizationMember{
+		entity.NewOrganizationMember(f.orgA, f.manager.String(), entity.OrganizationRoleOwner),
+		entity.NewOrganizationMember(f.orgA, f.castA.String(), entity.OrganizationRoleMember),
+		entity.NewOrganizationMember(f.orgB, f.manager.String(), entity.OrganizationRoleManager),
+		entity.NewOrganizationMember(f.orgB, f.castB.String(), entity.OrganizationRoleMember),
+	}}
+	f.contents = newMemoryContentRepository(contents...)
+	f.uc = NewContentUseCase(f.contents, nil, nil, orgRepo, nil, &memoryJobQueue{}, nil)
+	return f
+}
+
+func (f *contentScopeFixture) addContent(t *testing.T, ownerID uuid.UUID) *entity.Content {
+	t.Helper()
+	content, err := entity.NewContent(ownerID, "title", "", entity.ContentTypeImage, "contents/a.jpg", decimal.Zero)
+	if err != nil {
+		t.Fatalf("failed to create content: %v", err)
+	}
+	f.contents.contents[content.ID] = content
+	return content
+}
+
+func TestContentOrgScopeLimitsDeleteToOrganizationCasts(t *testing.T) {
+	f := newContentScopeFixture()
+	ctx := context.Background()
+
+	personal := f.addContent(t, f.manager)
+	otherOrg := f.addContent(t, f.castB)
+	ownOrg := f.addContent(t, f.castA)
+
+	for name, content := range map[string]*entity.Content{"personal": personal, "other organization": otherOrg} {
+		err := f.uc.DeleteContent(ctx, DeleteContentInput{ContentID: content.ID, UserID: f.manager, OrgScope: &f.orgA})
+		if !errors.Is(err, ErrContentForbidden) {
+			t.Errorf("%s: delete error = %v, want ErrContentForbidden", name, err)
+		}
+	}
+	if err := f.uc.DeleteContent(ctx, DeleteContentInput{ContentID: ownOrg.ID, UserID: f.manager, OrgScope: &f.orgA}); err != nil {
+		t.Fatalf("delete of an organization cast's content failed: %v", err)
+	}
+
+	// キーを使わない場合はマネージャーとして全ての所属キャストと自分のコンテンツを管理できる
+	for _, content := range []*entity.Content{personal, otherOrg} {
+		if err := f.uc.DeleteContent(ctx, DeleteContentInput{ContentID: content.ID, UserID: f.manager}); err != nil {
+			t.Errorf("unscoped delete failed: %v", err)
+		}
+	}
+}
+
+func TestContentOrgScopeIgnoresManageAny(t *testing.T) {
+	f := newContentScopeFixture()
+	content := f.addContent(t, uuid.New())
+
+	err := f.uc.DeleteContent(context.Background(), DeleteContentInput{
+		ContentID: content.ID,
+		UserID:    f.manager,
+		ManageAny: true,
+		OrgScope:  &f.orgA,
+	})
+	if !errors.Is(err, ErrContentForbidden) {
+		t.Fatalf("delete error = %v, want ErrContentForbidden", err)
+	}
+}
+
+func TestContentOrgScopeLimitsAvailabilityToOrganizationCasts(t *testing.T) {
+	f := newContentScopeFixture()
+	content := f.addContent(t, f.castB)
+
+	_, err := f.uc.UpdateAvailability(context.Background(), UpdateAvailabilityInput{
+		ContentID: content.ID,
+		UserID:    f.manager,
+		OrgScope:  &f.orgA,
+	})
+	if !errors.Is(err, ErrContentForbidden) {
+		t.Fatalf("update availability error = %v, want ErrContentForbidden", err)
+	}
+}
+
+func TestContentOrgScopeLimitsCreateToOrganizationCasts(t *testing.T) {
+	f := newContentScopeFixture()
+
+	for name, onBehalfOf := range map[string]uuid.UUID{"personal": uuid.Nil, "other organization": f.castB} {
+		_, err := f.uc.CreateContent(context.Background(), CreateContentInput{
+			UserID:      f.manager,
+			OnBehalfOf:  onBehalfOf,
+			Title:       "title",
+			ContentType: entity.ContentTypeImage,
+			File:        &multipart.FileHeader{Filename: "a.jpg"},
+			Price:       decimal.Zero,
+			OrgScope:    &f.orgA,
+		})
+		if !errors.Is(err, ErrContentForbidden) {
+			t.Errorf("%s: create error = %v, want ErrContentForbidden", name, err)
+		}
+	}
+}
+
+func TestContentUpdateFileResubmitsForModeration(t *testing.T) {
+	owner := uuid.New()
+	content, err := entity.NewContent(owner, "title", "", entity.ContentTypeImage, "contents/a.jpg", decimal.Zero)
+	if err != nil {
+		t.Fatalf("failed to create content: %v", err)
+	}
+	content.ModerationStatus = entity.ModerationStatusRejected
+	contents := newMemoryContentRepository(content)
+	storage := &memoryFileStorage{}
+	jobs := &memoryJobQueue{}
+	uc := NewContentUseCase(contents, nil, nil, nil, storage, jobs, nil)
+
+	updated, err := uc.UpdateContent(context.Background(), UpdateContentInput{
+		ContentID: content.ID,
+		UserID:    owner,
+		Title:     "title",
+		Price:     decimal.Zero,
+		File:      &multipart.FileHeader{Filename: "b.jpg"},
+	})
+	if err != nil {
+		t.Fatalf("failed to update content: %v", err)
+	}
+	if updated.ModerationStatus != entity.ModerationStatusPending {
+		t.Errorf("moderation status = %s, want pending", updated.ModerationStatus)
+	}
+	if len(contents.events) != 1 {
+		t.Fatalf("moderation events = %d, want 1", len(contents.events))
+	}
+	event := contents.events[0]
+	if event.Action != entity.ModerationActionResubmit || event.FromStatus != entity.ModerationStatusRejected ||
+		event.ToStatus != entity.ModerationStatusPending || event.ActorID == nil || *event.ActorID != owner.String() {
+		t.Errorf("moderation event = %+v, want a resubmit by the owner from rejected to pending", event)
+	}
+	if len(jobs.jobs) != 1 || jobs.jobs[0] != JobTypeStorageDelete {
+		t.Errorf("enqueued jobs = %v, want a cleanup of the replaced file", jobs.jobs)
+	}
+}
+
+func TestContentUpdateFileRefusesTakenDownContent(t *testing.T) {
+	owner := uuid.New()
+	content, err := entity.NewContent(owner, "title", "", entity.ContentTypeImage, "contents/a.jpg", decimal.Zero)
+	if err != nil {
+		t.Fatalf("failed to create content: %v", err)
+	}
+	content.ModerationStatus = entity.ModerationStatusTakenDown
+	contents := newMemoryContentRepository(content)
+	storage := &memoryFileStorage{}
+	uc := NewContentUseCase(contents, nil, nil, nil, storage, &memoryJobQueue{}, nil)
+
+	_, err = uc.UpdateContent(context.Background(), UpdateContentInput{
+		ContentID: content.ID,
+		UserID:    owner,
+		Title:     "title",
+		Price:     decimal.Zero,
+		File:      &multipart.FileHeader{Filename: "b.jpg"},
+	})
+	if !errors.Is(err, entity.ErrContentTakenDown) {
+		t.Fatalf("update error = %v, want ErrContentTakenDown", err)
+	}
+	if len(storage.uploaded) != 0 || len(contents.events) != 0 {
+		t.Errorf("taken down content must not upload a file or record a moderation event")
+	}
+	if content.ModerationStatus != entity.ModerationStatusTakenDown {
+		t.Errorf("moderation status = %s, want taken_down", content.ModerationStatus)
+	}
+}
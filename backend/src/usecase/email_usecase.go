@@ -0,0 +1,591 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"kimiyomi/backend/src/domain/email"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrEmailRejected は送信サーバーが宛先などを理由にメールを恒久的に拒否したことを表します
+	// EmailSender はこのエラーを返すことで、再試行しても届かないことを伝えます
+	ErrEmailRejected             = errors.New("email rejected")
+	ErrEmailTemplateNotFound     = errors.New("email template not found")
+	ErrInvalidUnsubscribeToken   = errors.New("invalid unsubscribe token")
+	ErrInvalidBounceType         = errors.New("invalid bounce type")
+	ErrEmailCategoryNotOptional  = errors.New("email category cannot be unsubscribed")
+	ErrEmailRecipientUnavailable = errors.New("email recipient unavailable")
+)
+
+// securityEventEmailTemplates はセキュリティイベントの種類ごとの知らせるメールのテンプレートです
+// ユーザー自身による手動ログアウトなど、ここに無い種類のイベントは知らせません
+var securityEventEmailTemplates = map[entity.SecurityEventType]string{
+	entity.SecurityEventNewDeviceLogin:     "new_device_login",
+	entity.SecurityEventRefreshTokenReused: "session_compromised",
+}
+
+// securityEventTimeFormat はメールに記載するイベントの日時の形式です
+const securityEventTimeFormat = "2006-01-02 15:04 MST"
+
+// unsubscribeTokenPurpose は配信停止のトークンの署名鍵を他の用途の鍵と区別するための接頭辞です
+const unsubscribeTokenPurpose = "email-unsubscribe:"
+
+// OutgoingEmail は送信サーバーに渡すメールです
+type OutgoingEmail struct {
+	From    string
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+	Headers map[string]string
+}
+
+// EmailSender はメールを送信するインターフェースです
+type EmailSender interface {
+	// Send はメールを送信し、送信サーバーが発行したメッセージIDを返します
+	// 宛先が存在しないなど再試行しても届かない場合は ErrEmailRejected をラップしたエラーを返します
+	Send(ctx context.Context, message *OutgoingEmail) (string, error)
+}
+
+// EmailSendPayload はメールの送信ジョブのペイロードです
+type EmailSendPayload struct {
+	EmailID uuid.UUID `json:"email_id"`
+}
+
+// BounceType はバウンスの種類を表す型です
+type BounceType string
+
+const (
+	// BounceTypeHard は宛先が存在しないなど恒久的なバウンスです
+	BounceTypeHard BounceType = "hard"
+	// BounceTypeSoft はメールボックスの容量超過など一時的なバウンスです
+	BounceTypeSoft BounceType = "soft"
+	// BounceTypeComplaint は受信者による迷惑メールの報告です
+	BounceTypeComplaint BounceType = "complaint"
+)
+
+// SendEmailInput はメールの送信依頼の入力です
+// To を省略した場合はユーザーのメールアドレスに、Locale を省略した場合はユーザーのプロフィールの言語で送ります
+type SendEmailInput struct {
+	UserID   string
+	To       string
+	Category entity.EmailCategory
+	Template string
+	Locale   string
+	Params   map[string]string
+}
+
+// BounceInput は送信サーバーから通知されたバウンスの入力です
+type BounceInput struct {
+	Email             string     `json:"email"`
+	Type              BounceType `json:"type"`
+	ProviderMessageID string     `json:"provider_message_id"`
+	Reason            string     `json:"reason"`
+}
+
+// EmailSubscription はメールの種類ごとの配信の状態です
+type EmailSubscription struct {
+	Category   entity.EmailCategory `json:"category"`
+	Subscribed bool                 `json:"subscribed"`
+	Optional   bool                 `json:"optional"`
+}
+
+// EmailUseCase はメールの送信と配信停止に関するユースケースを実装します
+type EmailUseCase struct {
+	emailRepo       repository.EmailRepository
+	suppressionRepo repository.EmailSuppressionRepository
+	userRepo        repository.UserRepository
+	profileRepo     repository.ProfileRepository
+	templates       *email.Templates
+	sender          EmailSender
+	jobQueue        JobQueue
+	from            string
+	baseURL         string
+	signingKey      []byte
+}
+
+// NewEmailUseCase は新しいEmailUseCaseを作成します
+// baseURL は配信停止のURLの組み立てに使う公開URL、secret は配信停止のトークンの署名に使う秘密鍵です
+func NewEmailUseCase(
+	emailRepo repository.EmailRepository,
+	suppressionRepo repository.EmailSuppressionRepository,
+	userRepo repository.UserRepository,
+	profileRepo repository.ProfileRepository,
+	templates *email.Templates,
+	sender EmailSender,
+	jobQueue JobQueue,
+	from string,
+	baseURL string,
+	secret string,
+) *EmailUseCase {
+	return &EmailUseCase{
+		emailRepo:       emailRepo,
+		suppressionRepo: suppressionRepo,
+		userRepo:        userRepo,
+		profileRepo:     profileRepo,
+		templates:       templates,
+		sender:          sender,
+		jobQueue:        jobQueue,
+		from:            from,
+		baseURL:         strings.TrimRight(baseURL, "/"),
+		signingKey:      []byte(unsubscribeTokenPurpose + secret),
+	}
+}
+
+// Send はメールを送信待ちとして記録し、送信ジョブを登録します
+// 宛先が配信停止またはバウンスしている場合は送信せずに記録だけを残します
+func (uc *EmailUseCase) Send(ctx context.Context, input SendEmailInput) (*entity.Email, error) {
+	if !uc.templates.Has(input.Template) {
+		return nil, ErrEmailTemplateNotFound
+	}
+
+	to, locale := input.To, input.Locale
+	if input.UserID != "" && to == "" {
+		user, err := uc.userRepo.FindByID(ctx, input.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+		if user == nil {
+			return nil, ErrUserNotFound
+		}
+		if user.IsDeleted() || !user.HasEmail() {
+			return nil, ErrEmailRecipientUnavailable
+		}
+		to = user.Email
+	}
+	if input.UserID != "" && locale == "" {
+		profile, err := uc.profileRepo.FindByUserID(ctx, input.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find profile: %w", err)
+		}
+		if profile != nil {
+			locale = profile.Locale
+		}
+	}
+	if locale == "" {
+		locale = entity.LocaleJa
+	}
+
+	message, err := entity.NewEmail(input.UserID, to, input.Category, input.Template, locale, input.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	suppressed, err := uc.suppressionRepo.IsSuppressed(ctx, message.To, message.Category)
+	if err != nil {
+		return nil, err
+	}
+	if suppressed {
+		message.Status = entity.EmailStatusSuppressed
+	}
+
+	if err := uc.emailRepo.Create(ctx, message); err != nil {
+		return nil, err
+	}
+	if suppressed {
+		return message, nil
+	}
+
+	if err := uc.jobQueue.Enqueue(ctx, JobTypeEmailSend, EmailSendPayload{EmailID: message.ID}, time.Time{}); err != nil {
+		return nil, fmt.Errorf("failed to enqueue email: %w", err)
+	}
+
+	return message, nil
+}
+
+// NotifySecurityEvent は新しい端末からのログインなどのセキュリティイベントをユーザーにメールで知らせます
+// 日時はプロフィールのタイムゾーンで記載し、メールアドレスを持たないユーザーや退会済みのユーザーには送りません
+func (uc *EmailUseCase) NotifySecurityEvent(ctx context.Context, event *entity.SecurityEvent) error {
+	template, ok := securityEventEmailTemplates[event.Type]
+	if !ok {
+		return nil
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, event.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil || user.IsDeleted() || !user.HasEmail() {
+		return nil
+	}
+
+	timezone := entity.DefaultTimezone
+	profile, err := uc.profileRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find profile: %w", err)
+	}
+	if profile != nil {
+		timezone = profile.Timezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	_, err = uc.Send(ctx, SendEmailInput{
+		UserID:   user.ID,
+		Category: entity.EmailCategorySecurity,
+		Template: template,
+		Params: map[string]string{
+			"name":        user.Name,
+			"device":      event.UserAgent,
+			"ip_address":  event.IPAddress,
+			"occurred_at": event.CreatedAt.In(loc).Format(securityEventTimeFormat),
+		},
+	})
+	return err
+}
+
+// HandleSendJob はメールの送信ジョブを処理します
+// 一時的な失敗ではエラーを返してジョブを再試行させ、試行回数の上限に達するか送信サーバーに拒否された場合は失敗として記録します
+func (uc *EmailUseCase) HandleSendJob(ctx context.Context, payload []byte) error {
+	var p EmailSendPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("failed to decode email send payload: %w", err)
+	}
+
+	message, err := uc.emailRepo.FindByID(ctx, p.EmailID)
+	if err != nil {
+		return err
+	}
+	if message == nil || message.Status != entity.EmailStatusQueued {
+		return nil
+	}
+
+	// 登録後に配信停止された場合は送信しない
+	suppressed, err := uc.suppressionRepo.IsSuppressed(ctx, message.To, message.Category)
+	if err != nil {
+		return err
+	}
+	if suppressed {
+		message.Status = entity.EmailStatusSuppressed
+		return uc.emailRepo.UpdateDelivery(ctx, message)
+	}
+
+	outgoing, err := uc.compose(message)
+	if err != nil {
+		// テンプレートの誤りは再試行しても直らない
+		message.Status = entity.EmailStatusFailed
+		message.LastError = err.Error()
+		return uc.emailRepo.UpdateDelivery(ctx, message)
+	}
+
+	message.Attempts++
+	messageID, sendErr := uc.sender.Send(ctx, outgoing)
+	switch {
+	case sendErr == nil:
+		now := time.Now()
+		message.Status = entity.EmailStatusSent
+		message.ProviderMessageID = messageID
+		message.LastError = ""
+		message.SentAt = &now
+	case errors.Is(sendErr, ErrEmailRejected):
+		message.Status = entity.EmailStatusFailed
+		message.LastError = sendErr.Error()
+		uc.suppress(ctx, message.To, "", entity.SuppressionReasonBounce)
+	default:
+		message.LastError = sendErr.Error()
+		if message.Attempts >= entity.DefaultJobMaxAttempts {
+			message.Status = entity.EmailStatusFailed
+		}
+	}
+
+	if err := uc.emailRepo.UpdateDelivery(ctx, message); err != nil {
+		return err
+	}
+	if message.Status == entity.EmailStatusQueued {
+		return fmt.Errorf("failed to send email: %w", sendErr)
+	}
+
+	return nil
+}
+
+// HandleBounce は送信サーバーから通知されたバウンスや迷惑メールの報告を記録します
+// 恒久的なバウンスと迷惑メールの報告では、以後そのメールアドレスに全ての種類のメールを送りません
+func (uc *EmailUseCase) HandleBounce(ctx context.Context, input BounceInput) error {
+	var message *entity.Email
+	if input.ProviderMessageID != "" {
+		found, err := uc.emailRepo.FindByProviderMessageID(ctx, input.ProviderMessageID)
+		if err != nil {
+			return err
+		}
+		message = found
+	}
+
+	address := input.Email
+	if address == "" && message != nil {
+		address = message.To
+	}
+	address, err := entity.NormalizeEmailAddress(address)
+	if err != nil {
+		return err
+	}
+
+	var reason entity.SuppressionReason
+	switch input.Type {
+	case BounceTypeHard:
+		reason = entity.SuppressionReasonBounce
+	case BounceTypeComplaint:
+		reason = entity.SuppressionReasonComplaint
+	case BounceTypeSoft:
+	default:
+		return ErrInvalidBounceType
+	}
+
+	if reason != "" {
+		suppression, err := entity.NewEmailSuppression(address, "", reason)
+		if err != nil {
+			return err
+		}
+		if err := uc.suppressionRepo.Add(ctx, suppression); err != nil {
+			return err
+		}
+	}
+
+	if message != nil && message.To == address && input.Type != BounceTypeComplaint {
+		message.Status = entity.EmailStatusBounced
+		message.LastError = input.Reason
+		if err := uc.emailRepo.UpdateDelivery(ctx, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnsubscribeURL はメールアドレスへの指定した種類のメールの配信停止のURLを返します
+func (uc *EmailUseCase) UnsubscribeURL(address string, category entity.EmailCategory) string {
+	return uc.baseURL + "/api/v1/email/unsubscribe?token=" + url.QueryEscape(uc.unsubscribeToken(address, category))
+}
+
+// ResolveUnsubscribeToken は配信停止のトークンを検証し、対象のメールアドレスと種類を返します
+func (uc *EmailUseCase) ResolveUnsubscribeToken(token string) (string, entity.EmailCategory, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+	expected := uc.sign(raw)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	address, category, ok := strings.Cut(string(raw), "|")
+	if !ok || !entity.IsValidEmailCategory(entity.EmailCategory(category)) {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	return address, entity.EmailCategory(category), nil
+}
+
+// Unsubscribe は配信停止のトークンが示すメールアドレスへの指定した種類のメールの配信を停止します
+func (uc *EmailUseCase) Unsubscribe(ctx context.Context, token string) (entity.EmailCategory, error) {
+	address, category, err := uc.ResolveUnsubscribeToken(token)
+	if err != nil {
+		return "", err
+	}
+	if !category.Unsubscribable() {
+		return "", ErrEmailCategoryNotOptional
+	}
+
+	suppression, err := entity.NewEmailSuppression(address, category, entity.SuppressionReasonUnsubscribe)
+	if err != nil {
+		return "", err
+	}
+	if err := uc.suppressionRepo.Add(ctx, suppression); err != nil {
+		return "", err
+	}
+
+	return category, nil
+}
+
+// GetSubscriptions はユーザーのメールアドレスへの種類ごとの配信の状態を返します
+func (uc *EmailUseCase) GetSubscriptions(ctx context.Context, userID string) ([]*EmailSubscription, error) {
+	address, err := uc.userAddress(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*EmailSubscription, 0, len(entity.EmailCategories))
+	for _, category := range entity.EmailCategories {
+		suppressed, err := uc.suppressionRepo.IsSuppressed(ctx, address, category)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, &EmailSubscription{
+			Category:   category,
+			Subscribed: !suppressed,
+			Optional:   category.Unsubscribable(),
+		})
+	}
+
+	return subscriptions, nil
+}
+
+// UpdateSubscriptions はユーザーのメールアドレスへの種類ごとの配信を変更します
+// 配信を再開しても、バウンスや迷惑メールの報告による停止は解除されません
+func (uc *EmailUseCase) UpdateSubscriptions(ctx context.Context, userID string, subscribed map[entity.EmailCategory]bool) ([]*EmailSubscription, error) {
+	for category := range subscribed {
+		if !entity.IsValidEmailCategory(category) {
+			return nil, entity.ErrInvalidEmailCategory
+		}
+		if !category.Unsubscribable() {
+			return nil, ErrEmailCategoryNotOptional
+		}
+	}
+
+	address, err := uc.userAddress(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for category, on := range subscribed {
+		if on {
+			if err := uc.suppressionRepo.RemoveUnsubscribe(ctx, address, category); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		suppression, err := entity.NewEmailSuppression(address, category, entity.SuppressionReasonUnsubscribe)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.suppressionRepo.Add(ctx, suppression); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.GetSubscriptions(ctx, userID)
+}
+
+// compose はメールをテンプレートから作成します
+// 配信停止できる種類のメールには配信停止のURLとワンクリックの配信停止のヘッダーを付けます
+func (uc *EmailUseCase) compose(message *entity.Email) (*OutgoingEmail, error) {
+	params := make(map[string]string, len(message.Params)+1)
+	for k, v := range message.Params {
+		params[k] = v
+	}
+
+	headers := map[string]string{}
+	if message.Category.Unsubscribable() {
+		unsubscribeURL := uc.UnsubscribeURL(message.To, message.Category)
+		params["unsubscribe_url"] = unsubscribeURL
+		headers["List-Unsubscribe"] = "<" + unsubscribeURL + ">"
+		headers["List-Unsubscribe-Post"] = "List-Unsubscribe=One-Click"
+	}
+
+	rendered, err := uc.templates.Render(message.Template, message.Locale, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutgoingEmail{
+		From:    uc.from,
+		To:      message.To,
+		Subject: rendered.Subject,
+		HTML:    rendered.HTML,
+		Text:    rendered.Text,
+		Headers: headers,
+	}, nil
+}
+
+// suppress はメールアドレスへの送信を停止します
+// 送信の記録の更新を優先するため、失敗してもログに記録するだけにします
+func (uc *EmailUseCase) suppress(ctx context.Context, address string, category entity.EmailCategory, reason entity.SuppressionReason) {
+	suppression, err := entity.NewEmailSuppression(address, category, reason)
+	if err == nil {
+		err = uc.suppressionRepo.Add(ctx, suppression)
+	}
+	if err != nil {
+		fmt.Printf("failed to suppress email address: %v\n", err)
+	}
+}
+
+// userAddress はユーザーのメールアドレスを返します
+// メールアドレスを持たないユーザーは無効なメールアドレスとして扱います
+func (uc *EmailUseCase) userAddress(ctx context.Context, userID string) (string, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return "", ErrUserNotFound
+	}
+	if !user.HasEmail() {
+		return "", entity.ErrInvalidEmailAddress
+	}
+	return entity.NormalizeEmailAddress(user.Email)
+}
+
+// unsubscribeToken はメールアドレスと種類に署名した配信停止のトークンを返します
+func (uc *EmailUseCase) unsubscribeToken(address string, category entity.EmailCategory) string {
+	raw := []byte(address + "|" + string(category))
+	return base64.RawURLEncoding.EncodeToString(raw) + "." + uc.sign(raw)
+}
+
+// sign はデータのHMAC-SHA256の署名を返します
+func (uc *EmailUseCase) sign(data []byte) string {
+	mac := hmac.New(sha256.New, uc.signingKey)
+	mac.Write(data)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Name はデータの種類の名前を返します
+func (uc *EmailUseCase) Name() string {
+	return "emails"
+}
+
+// ExportPersonalData はユーザーに送ったメールの記録と配信停止の状態を返します
+func (uc *EmailUseCase) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	emails, err := uc.emailRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var suppressions []*entity.EmailSuppression
+	address, err := uc.userAddress(ctx, userID)
+	if err == nil {
+		if suppressions, err = uc.suppressionRepo.ListByEmail(ctx, address); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, ErrUserNotFound) && !errors.Is(err, entity.ErrInvalidEmailAddress) {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"emails":       emails,
+		"suppressions": suppressions,
+	}, nil
+}
+
+// ErasePersonalData はユーザーに送ったメールの記録とメールアドレスへの送信の停止を削除します
+func (uc *EmailUseCase) ErasePersonalData(ctx context.Context, userID string) error {
+	if err := uc.emailRepo.DeleteAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	address, err := uc.userAddress(ctx, userID)
+	if errors.Is(err, ErrUserNotFound) || errors.Is(err, entity.ErrInvalidEmailAddress) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return uc.suppressionRepo.DeleteByEmail(ctx, address)
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"kimiyomi/backend/src/domain/engagement"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrActivityNotReportable = errors.New("activity type cannot be reported by clients")
+)
+
+// ActivityObserver は行動が記録されたときに通知を受け取るインターフェースです
+type ActivityObserver interface {
+	// OnActivity は記録された行動と、1日あたりの上限を考慮した減衰前のポイントを受け取ります
+	// 上限を超えた行動の場合 points は 0 です
+	OnActivity(ctx context.Context, event *entity.ActivityEvent, points float64) error
+}
+
+// EngagementUseCase は推し活の行動の記録とエンゲージメント（推し活レベル）のユースケースを実装します
+type EngagementUseCase struct {
+	activityRepo repository.ActivityRepository
+	oshiRepo     repository.OshiRepository
+	scorer       *engagement.Scorer
+	observers    []ActivityObserver
+}
+
+// NewEngagementUseCase は新しいEngagementUseCaseを作成します
+func NewEngagementUseCase(
+	activityRepo repository.ActivityRepository,
+	oshiRepo repository.OshiRepository,
+	scorer *engagement.Scorer,
+) *EngagementUseCase {
+	return &EngagementUseCase{
+		activityRepo: activityRepo,
+		oshiRepo:     oshiRepo,
+		scorer:       scorer,
+	}
+}
+
+// AddObserver は行動が記録されたときに通知を受け取る機能を登録します
+func (uc *EngagementUseCase) AddObserver(observer ActivityObserver) {
+	uc.observers = append(uc.observers, observer)
+}
+
+// RecordActivity はユーザーの推しに対する行動を記録します
+// 購入など、サーバー側で発生する行動の記録に使います
+func (uc *EngagementUseCase) RecordActivity(ctx context.Context, userID string, oshiID uuid.UUID, activityType entity.ActivityType) error {
+	event, err := entity.NewActivityEvent(userID, oshiID, activityType)
+	if err != nil {
+		return err
+	}
+
+	oshi, err := uc.oshiRepo.FindByID(ctx, oshiID)
+	if err != nil {
+		return err
+	}
+	if oshi == nil {
+		return ErrOshiNotFound
+	}
+
+	points := uc.scorer.Points(activityType)
+	if limit := uc.scorer.DailyCap(); limit > 0 && len(uc.observers) > 0 {
+		count, err := uc.activityRepo.CountSince(ctx, userID, oshiID, activityType, uc.scorer.StartOfDay(event.OccurredAt))
+		if err != nil {
+			return err
+		}
+		if count >= limit {
+			points = 0
+		}
+	}
+
+	if err := uc.activityRepo.Create(ctx, event); err != nil {
+		return err
+	}
+
+	for _, observer := range uc.observers {
+		if err := observer.OnActivity(ctx, event, points); err != nil {
+			fmt.Printf("failed to notify activity: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// ReportActivity はクライアントから報告された行動を記録します
+// 購入はクライアントから報告できません
+func (uc *EngagementUseCase) ReportActivity(ctx context.Context, userID string, oshiID uuid.UUID, activityType entity.ActivityType) error {
+	if activityType == entity.ActivityTypePurchase {
+		return ErrActivityNotReportable
+	}
+	return uc.RecordActivity(ctx, userID, oshiID, activityType)
+}
+
+// GetEngagement はユーザーの推しに対するエンゲージメントを算出します
+func (uc *EngagementUseCase) GetEngagement(ctx context.Context, userID string, oshiID uuid.UUID) (*entity.EngagementScore, error) {
+	oshi, err := uc.oshiRepo.FindByID(ctx, oshiID)
+	if err != nil {
+		return nil, err
+	}
+	if oshi == nil {
+		return nil, ErrOshiNotFound
+	}
+
+	now := time.Now()
+	events, err := uc.activityRepo.ListByUserAndOshi(ctx, userID, oshiID, uc.scorer.Since(now))
+	if err != nil {
+		return nil, err
+	}
+
+	score := uc.scorer.Score(events, now)
+	score.OshiID = oshiID
+	return score, nil
+}
+
+// ListEngagements はユーザーが行動した全ての推しのエンゲージメントをスコアの高い順に算出します
+func (uc *EngagementUseCase) ListEngagements(ctx context.Context, userID string) ([]*entity.EngagementScore, error) {
+	now := time.Now()
+	events, err := uc.activityRepo.ListByUser(ctx, userID, uc.scorer.Since(now))
+	if err != nil {
+		return nil, err
+	}
+
+	eventsByOshi := make(map[uuid.UUID][]*entity.ActivityEvent)
+	for _, event := range events {
+		eventsByOshi[event.OshiID] = append(eventsByOshi[event.OshiID], event)
+	}
+
+	scores := make([]*entity.EngagementScore, 0, len(eventsByOshi))
+	for oshiID, oshiEvents := range eventsByOshi {
+		score := uc.scorer.Score(oshiEvents, now)
+		score.OshiID = oshiID
+		scores = append(scores, score)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].OshiID.String() < scores[j].OshiID.String()
+	})
+
+	return scores, nil
+}
+
+// EngagementFactor はユーザーの推しに対するエンゲージメントを 0 以上 1 未満の係数で返します
+// 相性スコアの算出時に、推し活の熱量を加味するための補正に使います
+func (uc *EngagementUseCase) EngagementFactor(ctx context.Context, userID string, oshiID uuid.UUID) (float64, error) {
+	score, err := uc.GetEngagement(ctx, userID, oshiID)
+	if err != nil {
+		return 0, err
+	}
+	return uc.scorer.Factor(score.Score), nil
+}
+
+// Name はエクスポートファイル内のファイル名です
+func (uc *EngagementUseCase) Name() string {
+	return "activity_events"
+}
+
+// ExportPersonalData はユーザーの全ての行動の記録を返します
+func (uc *EngagementUseCase) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	return uc.activityRepo.ListByUser(ctx, userID, time.Time{})
+}
+
+// ErasePersonalData はユーザーの全ての行動の記録を削除します
+func (uc *EngagementUseCase) ErasePersonalData(ctx context.Context, userID string) error {
+	return uc.activityRepo.DeleteAllByUserID(ctx, userID)
+}
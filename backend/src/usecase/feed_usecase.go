@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+const (
+	defaultFeedLimit = 20
+	maxFeedLimit     = 50
+)
+
+// FeedUseCase はフォロー中の推しのホームフィードのユースケースを実装します
+type FeedUseCase struct {
+	feedRepo    repository.FeedRepository
+	oshiRepo    repository.OshiRepository
+	contentRepo repository.ContentRepository
+	newsRepo    repository.OshiNewsRepository
+}
+
+// NewFeedUseCase は新しいFeedUseCaseを作成します
+func NewFeedUseCase(
+	feedRepo repository.FeedRepository,
+	oshiRepo repository.OshiRepository,
+	contentRepo repository.ContentRepository,
+	newsRepo repository.OshiNewsRepository,
+) *FeedUseCase {
+	return &FeedUseCase{
+		feedRepo:    feedRepo,
+		oshiRepo:    oshiRepo,
+		contentRepo: contentRepo,
+		newsRepo:    newsRepo,
+	}
+}
+
+// FeedOshi はフィード項目に表示する推しの概要です
+type FeedOshi struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	GroupName    string    `json:"group_name"`
+	ProfileImage string    `json:"profile_image,omitempty"`
+}
+
+// FeedContent はフィード項目に表示するコンテンツの概要です
+// ファイルの保存先は購入前に見せないため含めません
+type FeedContent struct {
+	ID          uuid.UUID          `json:"id"`
+	UserID      uuid.UUID          `json:"user_id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	ContentType entity.ContentType `json:"content_type"`
+	Price       decimal.Decimal    `json:"price"`
+}
+
+// FeedCompatibilityPrompt は推しとの相性診断のおすすめです
+type FeedCompatibilityPrompt struct {
+	Message string `json:"message"`
+}
+
+// FeedItem はホームフィードの項目です
+type FeedItem struct {
+	Type      entity.FeedItemType      `json:"type"`
+	ID        uuid.UUID                `json:"id"`
+	Oshi      *FeedOshi                `json:"oshi"`
+	CreatedAt time.Time                `json:"created_at"`
+	Content   *FeedContent             `json:"content,omitempty"`
+	News      *entity.OshiNews         `json:"news,omitempty"`
+	Prompt    *FeedCompatibilityPrompt `json:"prompt,omitempty"`
+}
+
+// FeedPage はホームフィードの1ページ分の結果です
+// NextCursor が空の場合は続きがありません
+type FeedPage struct {
+	Items      []*FeedItem `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// GetFeed はフォロー中の推しの新着コンテンツ、お知らせ、相性診断のおすすめを新しい順に取得します
+// cursor には前のページの NextCursor を指定します
+func (uc *FeedUseCase) GetFeed(ctx context.Context, userID, cursor string, limit int) (*FeedPage, error) {
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	}
+	if limit > maxFeedLimit {
+		limit = maxFeedLimit
+	}
+
+	var position *repository.FeedCursor
+	if cursor != "" {
+		decoded, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		position = decoded
+	}
+
+	// 続きの有無を判定するため1件多く取得する
+	entries, err := uc.feedRepo.ListEntries(ctx, userID, position, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &FeedPage{Items: []*FeedItem{}}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		last := entries[len(entries)-1]
+		page.NextCursor = encodeFeedCursor(repository.FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	items, err := uc.hydrate(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+	page.Items = items
+
+	return page, nil
+}
+
+// hydrate はフィード項目の参照から表示用のデータをまとめて取得します
+// 取得までの間に削除された項目は除外します
+func (uc *FeedUseCase) hydrate(ctx context.Context, entries []*entity.FeedEntry) ([]*FeedItem, error) {
+	var oshiIDs, contentIDs, newsIDs []uuid.UUID
+	for _, entry := range entries {
+		oshiIDs = append(oshiIDs, entry.OshiID)
+		switch entry.Type {
+		case entity.FeedItemTypeContent:
+			contentIDs = append(contentIDs, entry.ID)
+		case entity.FeedItemTypeNews:
+			newsIDs = append(newsIDs, entry.ID)
+		}
+	}
+
+	oshis, err := uc.oshiRepo.FindByIDs(ctx, oshiIDs)
+	if err != nil {
+		return nil, err
+	}
+	oshiByID := make(map[uuid.UUID]*entity.Oshi, len(oshis))
+	for _, oshi := range oshis {
+		oshiByID[oshi.ID] = oshi
+	}
+
+	contents, err := uc.contentRepo.FindByIDs(ctx, contentIDs)
+	if err != nil {
+		return nil, err
+	}
+	contentByID := make(map[uuid.UUID]*entity.Content, len(contents))
+	for _, content := range contents {
+		contentByID[content.ID] = content
+	}
+
+	newsList, err := uc.newsRepo.FindByIDs(ctx, newsIDs)
+	if err != nil {
+		return nil, err
+	}
+	newsByID := make(map[uuid.UUID]*entity.OshiNews, len(newsList))
+	for _, news := range newsList {
+		newsByID[news.ID] = news
+	}
+
+	items := make([]*FeedItem, 0, len(entries))
+	for _, entry := range entries {
+		oshi, ok := oshiByID[entry.OshiID]
+		if !ok {
+			continue
+		}
+
+		item := &FeedItem{
+			Type:      entry.Type,
+			ID:        entry.ID,
+			Oshi:      newFeedOshi(oshi),
+			CreatedAt: entry.CreatedAt,
+		}
+		switch entry.Type {
+		case entity.FeedItemTypeContent:
+			content, ok := contentByID[entry.ID]
+			if !ok {
+				continue
+			}
+			item.Content = &FeedContent{
+				ID:          content.ID,
+				UserID:      content.UserID,
+				Title:       content.Title,
+				Description: content.Description,
+				ContentType: content.ContentType,
+				Price:       content.Price,
+			}
+		case entity.FeedItemTypeNews:
+			news, ok := newsByID[entry.ID]
+			if !ok {
+				continue
+			}
+			item.News = news
+		case entity.FeedItemTypeCompatibilityPrompt:
+			item.Prompt = &FeedCompatibilityPrompt{
+				Message: oshi.Name + "との相性を診断してみましょう",
+			}
+		default:
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// newFeedOshi は推しからフィード表示用の概要を作成します
+func newFeedOshi(oshi *entity.Oshi) *FeedOshi {
+	summary := &FeedOshi{
+		ID:        oshi.ID,
+		Name:      oshi.Name,
+		GroupName: oshi.GroupName,
+	}
+	if len(oshi.ProfileImages) > 0 {
+		summary.ProfileImage = oshi.ProfileImages[0]
+	}
+	return summary
+}
+
+// encodeFeedCursor はフィードのページ位置を不透明な文字列に変換します
+func encodeFeedCursor(cursor repository.FeedCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeFeedCursor は不透明な文字列からフィードのページ位置を復元します
+func decodeFeedCursor(cursor string) (*repository.FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &repository.FeedCursor{CreatedAt: t, ID: parsedID}, nil
+}
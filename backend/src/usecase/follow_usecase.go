@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// FollowUseCase は推しのフォローのユースケースを実装します
+type FollowUseCase struct {
+	followRepo repository.FollowRepository
+	oshiRepo   repository.OshiRepository
+}
+
+// NewFollowUseCase は新しいFollowUseCaseを作成します
+func NewFollowUseCase(
+	followRepo repository.FollowRepository,
+	oshiRepo repository.OshiRepository,
+) *FollowUseCase {
+	return &FollowUseCase{
+		followRepo: followRepo,
+		oshiRepo:   oshiRepo,
+	}
+}
+
+// FollowedOshi はフォロー中の推しです
+type FollowedOshi struct {
+	Oshi       *entity.Oshi `json:"oshi"`
+	FollowedAt time.Time    `json:"followed_at"`
+}
+
+// Follow は推しをフォローします
+// 既にフォローしている場合も成功として扱います
+func (uc *FollowUseCase) Follow(ctx context.Context, userID string, oshiID uuid.UUID) (*entity.Oshi, error) {
+	oshi, err := uc.findOshi(ctx, oshiID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.followRepo.Follow(ctx, entity.NewOshiFollow(userID, oshi.ID))
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		oshi.FollowerCount++
+	}
+
+	return oshi, nil
+}
+
+// Unfollow は推しのフォローを解除します
+// フォローしていない場合も成功として扱います
+func (uc *FollowUseCase) Unfollow(ctx context.Context, userID string, oshiID uuid.UUID) error {
+	_, err := uc.followRepo.Unfollow(ctx, userID, oshiID)
+	return err
+}
+
+// ListFollowing はユーザーがフォロー中の推しを新しい順に取得します
+func (uc *FollowUseCase) ListFollowing(ctx context.Context, userID string) ([]*FollowedOshi, error) {
+	follows, err := uc.followRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(follows))
+	for i, follow := range follows {
+		ids[i] = follow.OshiID
+	}
+	oshis, err := uc.oshiRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	oshiByID := make(map[uuid.UUID]*entity.Oshi, len(oshis))
+	for _, oshi := range oshis {
+		oshiByID[oshi.ID] = oshi
+	}
+
+	following := make([]*FollowedOshi, 0, len(follows))
+	for _, follow := range follows {
+		oshi, ok := oshiByID[follow.OshiID]
+		if !ok {
+			continue
+		}
+		following = append(following, &FollowedOshi{
+			Oshi:       oshi,
+			FollowedAt: follow.CreatedAt,
+		})
+	}
+
+	return following, nil
+}
+
+// Name はエクスポートファイル内のファイル名です
+func (uc *FollowUseCase) Name() string {
+	return "oshi_follows"
+}
+
+// ExportPersonalData はユーザーのフォロー一覧を返します
+func (uc *FollowUseCase) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	return uc.followRepo.ListByUserID(ctx, userID)
+}
+
+// ErasePersonalData はユーザーの全てのフォローを解除します
+func (uc *FollowUseCase) ErasePersonalData(ctx context.Context, userID string) error {
+	return uc.followRepo.DeleteAllByUserID(ctx, userID)
+}
+
+// findOshi は推しを取得します
+func (uc *FollowUseCase) findOshi(ctx context.Context, id uuid.UUID) (*entity.Oshi, error) {
+	oshi, err := uc.oshiRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if oshi == nil {
+		return nil, ErrOshiNotFound
+	}
+	return oshi, nil
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ジョブの種類
+const (
+	JobTypeAccountDelete        = "account.delete"
+	JobTypeAccountExport        = "account.export"
+	JobTypeStorageDelete        = "storage.delete"
+	JobTypePushDeliver          = "push.deliver"
+	JobTypeNotificationFanout   = "notification.fanout"
+	JobTypeEmailSend            = "email.send"
+	JobTypeContentRelease       = "content.release"
+	JobTypeContentSearchReindex = "content.search_reindex"
+)
+
+// JobQueue はバックグラウンドジョブを登録するインターフェースです
+type JobQueue interface {
+	// Enqueue はジョブを登録します。runAt がゼロ値の場合は即時実行の対象になります
+	Enqueue(ctx context.Context, jobType string, payload interface{}, runAt time.Time) error
+}
+
+// StorageDeletePayload はストレージ上のファイル削除ジョブのペイロードです
+type StorageDeletePayload struct {
+	Path string `json:"path"`
+}
+
+// NewStorageDeleteJobHandler はストレージ上のファイルを削除するジョブハンドラーを作成します
+func NewStorageDeleteJobHandler(fileStorage FileStorage) func(ctx context.Context, payload []byte) error {
+	return func(ctx context.Context, payload []byte) error {
+		var p StorageDeletePayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return fmt.Errorf("failed to decode storage delete payload: %w", err)
+		}
+		if p.Path == "" {
+			return nil
+		}
+		return fileStorage.Delete(ctx, p.Path)
+	}
+}
+
+// enqueueStorageDelete はファイル削除ジョブを登録します
+func enqueueStorageDelete(ctx context.Context, jobQueue JobQueue, path string, runAt time.Time) error {
+	if path == "" {
+		return nil
+	}
+	return jobQueue.Enqueue(ctx, JobTypeStorageDelete, StorageDeletePayload{Path: path}, runAt)
+}
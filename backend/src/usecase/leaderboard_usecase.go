@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidLeaderboardPeriod = errors.New("invalid leaderboard period")
+)
+
+const (
+	defaultLeaderboardLimit = 50
+	maxLeaderboardLimit     = 100
+	leaderboardCacheTTL     = time.Minute
+)
+
+// Cache は計算結果を一定時間保持するキャッシュのインターフェースです
+type Cache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, ttl time.Duration)
+}
+
+// LeaderboardUseCase は推しごとのファンランキングのユースケースを実装します
+type LeaderboardUseCase struct {
+	leaderboardRepo repository.LeaderboardRepository
+	flagRepo        repository.AccountFlagRepository
+	oshiRepo        repository.OshiRepository
+	profileRepo     repository.ProfileRepository
+	userRepo        repository.UserRepository
+	relationRepo    repository.UserRelationRepository
+	cache           Cache
+	location        *time.Location
+	publisher       RealtimePublisher
+}
+
+// NewLeaderboardUseCase は新しいLeaderboardUseCaseを作成します
+// location は日間・週間・月間の区切りに使うタイムゾーンです
+func NewLeaderboardUseCase(
+	leaderboardRepo repository.LeaderboardRepository,
+	flagRepo repository.AccountFlagRepository,
+	oshiRepo repository.OshiRepository,
+	profileRepo repository.ProfileRepository,
+	userRepo repository.UserRepository,
+	relationRepo repository.UserRelationRepository,
+	cache Cache,
+	location *time.Location,
+	publisher RealtimePublisher,
+) *LeaderboardUseCase {
+	return &LeaderboardUseCase{
+		leaderboardRepo: leaderboardRepo,
+		flagRepo:        flagRepo,
+		oshiRepo:        oshiRepo,
+		profileRepo:     profileRepo,
+		userRepo:        userRepo,
+		relationRepo:    relationRepo,
+		cache:           cache,
+		location:        location,
+		publisher:       publisher,
+	}
+}
+
+// LeaderboardUpdatedEvent は推しのランキングが更新されたことを知らせるイベントの内容です
+// 閲覧者ごとのブロック・ミュートを反映するため、クライアントは順位をAPIから取得し直します
+type LeaderboardUpdatedEvent struct {
+	OshiID uuid.UUID `json:"oshi_id"`
+}
+
+// OnActivity は記録された行動のポイントを各期間のランキングに加算し、ランキングの購読者に知らせます
+func (uc *LeaderboardUseCase) OnActivity(ctx context.Context, event *entity.ActivityEvent, points float64) error {
+	if points <= 0 {
+		return nil
+	}
+
+	buckets := make([]entity.LeaderboardBucket, 0, len(entity.LeaderboardPeriods))
+	for _, period := range entity.LeaderboardPeriods {
+		buckets = append(buckets, entity.LeaderboardBucket{
+			Period:      period,
+			PeriodStart: period.Start(event.OccurredAt, uc.location),
+		})
+	}
+
+	if err := uc.leaderboardRepo.AddPoints(ctx, event.UserID, event.OshiID, points, buckets); err != nil {
+		return err
+	}
+
+	publishRealtime(ctx, uc.publisher, leaderboardTopic(event.OshiID), entity.RealtimeEventLeaderboard, &LeaderboardUpdatedEvent{OshiID: event.OshiID})
+	return nil
+}
+
+// GetLeaderboard は推しの現在の期間のランキングを上位から取得します
+// 結果は短時間キャッシュするため、直近の行動はすぐには反映されない場合があります
+// 閲覧者がブロック・ミュートしたファンと閲覧者をブロックしたファンは表示しません
+func (uc *LeaderboardUseCase) GetLeaderboard(ctx context.Context, viewerID string, oshiID uuid.UUID, period entity.LeaderboardPeriod, limit, offset int) ([]*entity.LeaderboardEntry, error) {
+	bucket, err := uc.currentBucket(period)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.ensureOshi(ctx, oshiID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+	if limit > maxLeaderboardLimit {
+		limit = maxLeaderboardLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	// ブロック・ミュートの無い閲覧者には全員で共有するキャッシュを返す
+	if viewerID != "" {
+		hasRelations, err := uc.relationRepo.HasAny(ctx, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		if !hasRelations {
+			viewerID = ""
+		}
+	}
+
+	key := fmt.Sprintf("leaderboard:%s:%s:%d:%d:%d", oshiID, bucket.Period, bucket.PeriodStart.Unix(), limit, offset)
+	if viewerID == "" {
+		if cached, ok := uc.cache.Get(key); ok {
+			return cached.([]*entity.LeaderboardEntry), nil
+		}
+	}
+
+	entries, err := uc.leaderboardRepo.ListTop(ctx, oshiID, bucket, viewerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []*entity.LeaderboardEntry{}
+	}
+	if viewerID == "" {
+		uc.cache.Set(key, entries, leaderboardCacheTTL)
+	}
+
+	return entries, nil
+}
+
+// GetPosition はユーザー自身の現在の期間の順位を取得します
+// ランキング非表示のユーザーも、自分の順位は表示された場合の順位として確認できます
+func (uc *LeaderboardUseCase) GetPosition(ctx context.Context, userID string, oshiID uuid.UUID, period entity.LeaderboardPeriod) (*entity.LeaderboardPosition, error) {
+	bucket, err := uc.currentBucket(period)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.ensureOshi(ctx, oshiID); err != nil {
+		return nil, err
+	}
+
+	visible, err := uc.isVisible(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	points, ahead, total, err := uc.leaderboardRepo.FindPosition(ctx, userID, oshiID, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	position := &entity.LeaderboardPosition{
+		OshiID:  oshiID,
+		Period:  bucket.Period,
+		Points:  points,
+		Total:   total,
+		Visible: visible,
+	}
+	if points > 0 {
+		if !visible {
+			position.Total++
+		}
+		position.Rank = ahead + 1
+		position.Percentile = math.Round(float64(position.Total-position.Rank+1)/float64(position.Total)*1000) / 10
+	}
+
+	return position, nil
+}
+
+// FlagAccount はアカウントを不正の疑いで指定し、ランキングから除外します
+func (uc *LeaderboardUseCase) FlagAccount(ctx context.Context, moderatorID, userID, reason string) (*entity.AccountFlag, error) {
+	if _, err := uc.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	flag := entity.NewAccountFlag(userID, reason, moderatorID)
+	if err := uc.flagRepo.Save(ctx, flag); err != nil {
+		return nil, err
+	}
+
+	return flag, nil
+}
+
+// UnflagAccount はアカウントの指定を解除します
+func (uc *LeaderboardUseCase) UnflagAccount(ctx context.Context, userID string) error {
+	return uc.flagRepo.Delete(ctx, userID)
+}
+
+// ListFlaggedAccounts は指定されたアカウントの一覧を取得します
+func (uc *LeaderboardUseCase) ListFlaggedAccounts(ctx context.Context, limit, offset int) ([]*entity.AccountFlag, error) {
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+	if limit > maxLeaderboardLimit {
+		limit = maxLeaderboardLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	flags, err := uc.flagRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if flags == nil {
+		flags = []*entity.AccountFlag{}
+	}
+
+	return flags, nil
+}
+
+// currentBucket は現在の集計単位を返します
+func (uc *LeaderboardUseCase) currentBucket(period entity.LeaderboardPeriod) (entity.LeaderboardBucket, error) {
+	if period == "" {
+		period = entity.LeaderboardPeriodWeekly
+	}
+	if !entity.IsValidLeaderboardPeriod(period) {
+		return entity.LeaderboardBucket{}, ErrInvalidLeaderboardPeriod
+	}
+
+	return entity.LeaderboardBucket{
+		Period:      period,
+		PeriodStart: period.Start(time.Now(), uc.location),
+	}, nil
+}
+
+// ensureOshi は推しが存在することを確認します
+func (uc *LeaderboardUseCase) ensureOshi(ctx context.Context, oshiID uuid.UUID) error {
+	oshi, err := uc.oshiRepo.FindByID(ctx, oshiID)
+	if err != nil {
+		return err
+	}
+	if oshi == nil {
+		return ErrOshiNotFound
+	}
+	return nil
+}
+
+// isVisible はユーザーが他のユーザーのランキングに表示されるかどうかを確認します
+func (uc *LeaderboardUseCase) isVisible(ctx context.Context, userID string) (bool, error) {
+	flagged, err := uc.flagRepo.IsFlagged(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if flagged {
+		return false, nil
+	}
+
+	profile, err := uc.profileRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return profile == nil || !profile.Privacy.LeaderboardOptOut, nil
+}
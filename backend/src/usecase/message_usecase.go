@@ -0,0 +1,677 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrConversationNotFound     = errors.New("conversation not found")
+	ErrInvalidMessageRecipient  = errors.New("conversations can only be started with creators")
+	ErrMessagingBlocked         = errors.New("cannot exchange messages with this user")
+	ErrMessageQuotaExceeded     = errors.New("monthly message quota exceeded and no message tickets left")
+	ErrInvalidInboxFilter       = errors.New("invalid inbox filter")
+	ErrInvalidTicketQuantity    = errors.New("ticket quantity must be between 1 and 1000")
+	ErrInvalidMessageAttachment = errors.New("message attachments must be jpg, png, gif or webp images up to 5MB")
+)
+
+const (
+	defaultMessageLimit = 30
+	maxMessageLimit     = 100
+	maxPollMessages     = 100
+	maxTicketGrant      = 1000
+	defaultPollTimeout  = 25 * time.Second
+	maxPollTimeout      = 30 * time.Second
+
+	maxMessageAttachmentUploads = 4
+
+	// messagePreviewLength はプッシュ通知に含めるメッセージの本文の最大文字数です
+	messagePreviewLength = 80
+)
+
+// MessageUseCase はファンとキャストのメッセージのユースケースを実装します
+// ファンからのメッセージはプランの月間の送信枠、枠を使い切った後はメッセージチケットを消費します
+// キャストからの返信は枠を消費しません
+type MessageUseCase struct {
+	conversationRepo repository.ConversationRepository
+	messageRepo      repository.DirectMessageRepository
+	ticketRepo       repository.MessageTicketRepository
+	userRepo         repository.UserRepository
+	relationRepo     repository.UserRelationRepository
+	subscriptionRepo repository.SubscriptionRepository
+	fileStorage      FileStorage
+	jobQueue         JobQueue
+	broker           RealtimeBroker
+	notifier         Notifier
+	monthlyQuotas    map[entity.PlanType]int
+	location         *time.Location
+}
+
+// NewMessageUseCase は新しいMessageUseCaseを作成します
+// monthlyQuotas にはプランごとの月間の送信枠を指定し、含まれないプランとサブスクリプションが無いファンの枠は0件です
+// 月の区切りは location のタイムゾーンで判定します
+func NewMessageUseCase(
+	conversationRepo repository.ConversationRepository,
+	messageRepo repository.DirectMessageRepository,
+	ticketRepo repository.MessageTicketRepository,
+	userRepo repository.UserRepository,
+	relationRepo repository.UserRelationRepository,
+	subscriptionRepo repository.SubscriptionRepository,
+	fileStorage FileStorage,
+	jobQueue JobQueue,
+	broker RealtimeBroker,
+	notifier Notifier,
+	monthlyQuotas map[entity.PlanType]int,
+	location *time.Location,
+) *MessageUseCase {
+	return &MessageUseCase{
+		conversationRepo: conversationRepo,
+		messageRepo:      messageRepo,
+		ticketRepo:       ticketRepo,
+		userRepo:         userRepo,
+		relationRepo:     relationRepo,
+		subscriptionRepo: subscriptionRepo,
+		fileStorage:      fileStorage,
+		jobQueue:         jobQueue,
+		broker:           broker,
+		notifier:         notifier,
+		monthlyQuotas:    monthlyQuotas,
+		location:         location,
+	}
+}
+
+// ConversationView は閲覧者の未読件数を含むやり取りです
+type ConversationView struct {
+	*entity.Conversation
+	UnreadCount int `json:"unread_count"`
+}
+
+// ConversationPage は受信箱の1ページ分の結果です
+// NextCursor が空の場合は続きがありません
+type ConversationPage struct {
+	Items      []*ConversationView `json:"items"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// MessageView は既読の状態を含むメッセージです
+// Read は受信者がメッセージを既読にしたかどうかです
+type MessageView struct {
+	*entity.DirectMessage
+	Read bool `json:"read"`
+}
+
+// MessagePage はメッセージの一覧の1ページ分の結果です
+// NextCursor が空の場合はそれより古いメッセージはありません
+type MessagePage struct {
+	Items      []*MessageView `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// MessagePollResult はロングポーリングの結果です
+// Messages が空の場合も既読などやり取りの状態が変わった可能性があるため、クライアントは必要に応じて再取得します
+type MessagePollResult struct {
+	Messages []*entity.DirectMessage `json:"messages"`
+	Cursor   string                  `json:"cursor"`
+}
+
+// ConversationReadEvent はやり取りの相手が既読にしたことを知らせるイベントの内容です
+type ConversationReadEvent struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         string    `json:"user_id"`
+	ReadAt         time.Time `json:"read_at"`
+}
+
+// InboxQuery は受信箱の取得条件です
+type InboxQuery struct {
+	ViewerID string
+	Role     repository.InboxRole // 空の場合はファンとして始めたやり取り
+	Filter   string               // "unread" または "awaiting_reply"
+	Plan     entity.PlanType      // キャストの受信箱でファンのプランを絞り込む場合に指定します
+	Cursor   string
+	Limit    int
+}
+
+// SendMessageInput はメッセージの送信の入力データです
+type SendMessageInput struct {
+	ConversationID uuid.UUID
+	SenderID       string
+	Body           string
+	Attachments    []*multipart.FileHeader
+}
+
+// StartConversation はファンとキャストのやり取りを始めます
+// 既にやり取りがある場合はそれを返します
+func (uc *MessageUseCase) StartConversation(ctx context.Context, fanID, creatorID string) (*ConversationView, error) {
+	conversation, err := entity.NewConversation(fanID, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	creator, err := uc.userRepo.FindByID(ctx, creatorID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if creator.Role != entity.RoleCreator {
+		return nil, ErrInvalidMessageRecipient
+	}
+	if err := uc.ensureNotBlocked(ctx, fanID, creatorID); err != nil {
+		return nil, err
+	}
+
+	conversation, err = uc.conversationRepo.FindOrCreate(ctx, conversation)
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := uc.conversationViews(ctx, fanID, []*entity.Conversation{conversation})
+	if err != nil {
+		return nil, err
+	}
+	return views[0], nil
+}
+
+// ListInbox は受信箱のやり取りを最後のメッセージが新しい順に取得します
+func (uc *MessageUseCase) ListInbox(ctx context.Context, query InboxQuery) (*ConversationPage, error) {
+	filter := repository.InboxFilter{ViewerID: query.ViewerID, Role: query.Role}
+	if filter.Role == "" {
+		filter.Role = repository.InboxRoleFan
+	}
+	if filter.Role != repository.InboxRoleFan && filter.Role != repository.InboxRoleCreator {
+		return nil, ErrInvalidInboxFilter
+	}
+
+	switch query.Filter {
+	case "":
+	case "unread":
+		filter.UnreadOnly = true
+	case "awaiting_reply":
+		filter.AwaitingReply = true
+	default:
+		return nil, ErrInvalidInboxFilter
+	}
+
+	if query.Plan != "" {
+		// ファンのプランでの絞り込みはキャストの受信箱でのみ使えます
+		if filter.Role != repository.InboxRoleCreator || (query.Plan != entity.PlanTypeBasic && query.Plan != entity.PlanTypePremium) {
+			return nil, ErrInvalidInboxFilter
+		}
+		filter.FanPlan = query.Plan
+	}
+
+	limit := messageLimit(query.Limit)
+	position, err := parseCommunityCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// 続きの有無を判定するため1件多く取得する
+	conversations, err := uc.conversationRepo.ListInbox(ctx, filter, position, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ConversationPage{Items: []*ConversationView{}}
+	if len(conversations) > limit {
+		conversations = conversations[:limit]
+		last := conversations[len(conversations)-1]
+		page.NextCursor = encodeFeedCursor(repository.FeedCursor{CreatedAt: last.UpdatedAt, ID: last.ID})
+	}
+
+	items, err := uc.conversationViews(ctx, query.ViewerID, conversations)
+	if err != nil {
+		return nil, err
+	}
+	page.Items = items
+
+	return page, nil
+}
+
+// GetConversation はやり取りを取得します
+func (uc *MessageUseCase) GetConversation(ctx context.Context, viewerID string, conversationID uuid.UUID) (*ConversationView, error) {
+	conversation, err := uc.findParticipating(ctx, viewerID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := uc.conversationViews(ctx, viewerID, []*entity.Conversation{conversation})
+	if err != nil {
+		return nil, err
+	}
+	return views[0], nil
+}
+
+// ListMessages はやり取りのメッセージを新しい順に取得します
+// cursor には前のページの NextCursor を指定します
+func (uc *MessageUseCase) ListMessages(ctx context.Context, viewerID string, conversationID uuid.UUID, cursor string, limit int) (*MessagePage, error) {
+	limit = messageLimit(limit)
+	position, err := parseCommunityCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	conversation, err := uc.findParticipating(ctx, viewerID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := uc.messageRepo.ListByConversationID(ctx, conversationID, position, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &MessagePage{Items: []*MessageView{}}
+	if len(messages) > limit {
+		messages = messages[:limit]
+		last := messages[len(messages)-1]
+		page.NextCursor = encodeFeedCursor(repository.FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	for _, message := range messages {
+		page.Items = append(page.Items, messageView(conversation, message))
+	}
+
+	return page, nil
+}
+
+// SendMessage はやり取りにメッセージを送信します
+// 添付の画像はストレージにアップロードし、保存に失敗した場合や送信枠が無い場合は削除します
+func (uc *MessageUseCase) SendMessage(ctx context.Context, input SendMessageInput) (*MessageView, error) {
+	if len(input.Attachments) > maxMessageAttachmentUploads {
+		return nil, entity.ErrTooManyMessageAttachments
+	}
+	for _, attachment := range input.Attachments {
+		if !isValidImageUpload(attachment) {
+			return nil, ErrInvalidMessageAttachment
+		}
+	}
+
+	conversation, err := uc.findParticipating(ctx, input.SenderID, input.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.ensureNotBlocked(ctx, conversation.FanID, conversation.CreatorID); err != nil {
+		return nil, err
+	}
+
+	// 本文の検証を画像のアップロードより先に行う
+	if _, err := entity.NewDirectMessage(conversation.ID, input.SenderID, input.Body, make([]string, len(input.Attachments))); err != nil {
+		return nil, err
+	}
+
+	fromFan := input.SenderID == conversation.FanID
+	var quota *entity.MessageQuota
+	if fromFan {
+		// アップロードの前に枠の有無を確認し、確定は保存時にまとめて行う
+		quota, err = uc.GetQuota(ctx, input.SenderID)
+		if err != nil {
+			return nil, err
+		}
+		if !quota.CanSend() {
+			return nil, ErrMessageQuotaExceeded
+		}
+	}
+
+	attachmentURLs := make([]string, 0, len(input.Attachments))
+	for _, attachment := range input.Attachments {
+		attachmentURL, err := uc.fileStorage.Upload(ctx, attachment)
+		if err != nil {
+			uc.deleteUploaded(ctx, attachmentURLs)
+			return nil, fmt.Errorf("failed to upload message attachment: %w", err)
+		}
+		attachmentURLs = append(attachmentURLs, attachmentURL)
+	}
+
+	message, err := entity.NewDirectMessage(conversation.ID, input.SenderID, input.Body, attachmentURLs)
+	if err != nil {
+		uc.deleteUploaded(ctx, attachmentURLs)
+		return nil, err
+	}
+
+	if fromFan {
+		conversation.FanPlan = quota.Plan
+		charged, err := uc.messageRepo.CreateChargedByFan(ctx, conversation, message, quota.MonthlyLimit, uc.periodStart(message.CreatedAt))
+		if err != nil {
+			uc.deleteUploaded(ctx, attachmentURLs)
+			return nil, err
+		}
+		if !charged {
+			uc.deleteUploaded(ctx, attachmentURLs)
+			return nil, ErrMessageQuotaExceeded
+		}
+	} else if err := uc.messageRepo.Create(ctx, conversation, message); err != nil {
+		uc.deleteUploaded(ctx, attachmentURLs)
+		return nil, err
+	}
+
+	conversation.LastMessageAt = &message.CreatedAt
+	conversation.LastSenderID = message.SenderID
+	conversation.UpdatedAt = message.CreatedAt
+
+	view := messageView(conversation, message)
+	uc.publishMessage(ctx, conversation, view)
+
+	return view, nil
+}
+
+// MarkRead はやり取りの現在までのメッセージを既読にします
+// 相手に既読になったことを知らせます
+func (uc *MessageUseCase) MarkRead(ctx context.Context, viewerID string, conversationID uuid.UUID) error {
+	conversation, err := uc.findParticipating(ctx, viewerID, conversationID)
+	if err != nil {
+		return err
+	}
+
+	readAt := time.Now()
+	if err := uc.conversationRepo.MarkRead(ctx, conversation.ID, viewerID, readAt); err != nil {
+		return err
+	}
+
+	publishRealtime(ctx, uc.broker, userTopic(conversation.CounterpartID(viewerID)), entity.RealtimeEventConversationRead, &ConversationReadEvent{
+		ConversationID: conversation.ID,
+		UserID:         viewerID,
+		ReadAt:         readAt,
+	})
+	return nil
+}
+
+// Poll はユーザーの新しいメッセージを待って返します
+// cursor より後のメッセージがあればすぐに返し、無ければ更新の通知か timeout まで待ちます
+// cursor が空の場合は現在以降のメッセージを待ちます
+func (uc *MessageUseCase) Poll(ctx context.Context, userID, cursor string, timeout time.Duration) (*MessagePollResult, error) {
+	if timeout <= 0 {
+		timeout = defaultPollTimeout
+	}
+	if timeout > maxPollTimeout {
+		timeout = maxPollTimeout
+	}
+
+	position := &repository.FeedCursor{CreatedAt: time.Now()}
+	if cursor != "" {
+		var err error
+		if position, err = decodeFeedCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	// 取得と待機の間に届いたイベントを取りこぼさないよう、先に購読する
+	events, unsubscribe := uc.broker.Subscribe(userTopic(userID))
+	defer unsubscribe()
+
+	messages, err := uc.messageRepo.ListSince(ctx, userID, *position, maxPollMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messages) == 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+	wait:
+		for {
+			select {
+			case event, ok := <-events:
+				// サーバーの停止で購読が閉じられた場合はそのまま返す
+				if !ok {
+					break wait
+				}
+				if event.Type != entity.RealtimeEventMessage && event.Type != entity.RealtimeEventConversationRead {
+					continue
+				}
+				messages, err = uc.messageRepo.ListSince(ctx, userID, *position, maxPollMessages)
+				if err != nil {
+					return nil, err
+				}
+				break wait
+			case <-timer.C:
+				break wait
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	if len(messages) > 0 {
+		last := messages[len(messages)-1]
+		position = &repository.FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	} else {
+		messages = []*entity.DirectMessage{}
+	}
+
+	return &MessagePollResult{Messages: messages, Cursor: encodeFeedCursor(*position)}, nil
+}
+
+// GetQuota はファンの今月のメッセージの送信枠とチケットの残り枚数を取得します
+func (uc *MessageUseCase) GetQuota(ctx context.Context, userID string) (*entity.MessageQuota, error) {
+	plan := uc.activePlan(ctx, userID)
+	now := time.Now()
+	periodStart := uc.periodStart(now)
+
+	used, err := uc.messageRepo.CountPlanChargedSince(ctx, userID, periodStart)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := uc.ticketRepo.Balance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &entity.MessageQuota{
+		Plan:         plan,
+		MonthlyLimit: uc.monthlyQuotas[plan],
+		Used:         used,
+		Tickets:      tickets,
+		ResetsAt:     periodStart.AddDate(0, 1, 0),
+	}
+	if quota.Used < quota.MonthlyLimit {
+		quota.Remaining = quota.MonthlyLimit - quota.Used
+	}
+
+	return quota, nil
+}
+
+// GrantTickets はユーザーにメッセージチケットを付与し、付与後の残り枚数を返します
+func (uc *MessageUseCase) GrantTickets(ctx context.Context, userID string, quantity int) (int, error) {
+	if quantity <= 0 || quantity > maxTicketGrant {
+		return 0, ErrInvalidTicketQuantity
+	}
+	if _, err := uc.userRepo.FindByID(ctx, userID); err != nil {
+		return 0, ErrUserNotFound
+	}
+
+	return uc.ticketRepo.Add(ctx, userID, quantity)
+}
+
+// Name はデータの種類の名前を返します
+func (uc *MessageUseCase) Name() string {
+	return "direct_messages"
+}
+
+// ExportPersonalData はユーザーのやり取りと送ったメッセージ、チケットの残り枚数を返します
+func (uc *MessageUseCase) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	conversations, err := uc.conversationRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := uc.messageRepo.ListBySenderID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := uc.ticketRepo.Balance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"conversations": conversations,
+		"messages":      messages,
+		"tickets":       tickets,
+	}, nil
+}
+
+// ErasePersonalData はユーザーが送ったメッセージの本文と添付を消し、チケットを削除します
+// 相手のやり取りの履歴を保つため、メッセージは削除済みとして残します
+func (uc *MessageUseCase) ErasePersonalData(ctx context.Context, userID string) error {
+	messages, err := uc.messageRepo.ListBySenderID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, message := range messages {
+		for _, attachmentURL := range message.AttachmentURLs {
+			if err := enqueueStorageDelete(ctx, uc.jobQueue, attachmentURL, time.Time{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := uc.messageRepo.SoftDeleteAllBySenderID(ctx, userID); err != nil {
+		return err
+	}
+	return uc.ticketRepo.DeleteByUserID(ctx, userID)
+}
+
+// publishMessage は送信したメッセージを送信者の他の端末と受信者に配信し、受信者にプッシュ通知を送ります
+// 受信者が送信者をミュートしている場合は受信者には配信しません
+func (uc *MessageUseCase) publishMessage(ctx context.Context, conversation *entity.Conversation, view *MessageView) {
+	publishRealtime(ctx, uc.broker, userTopic(view.SenderID), entity.RealtimeEventMessage, view)
+
+	recipientID := conversation.CounterpartID(view.SenderID)
+	hidden, err := uc.relationRepo.IsHidden(ctx, recipientID, view.SenderID)
+	if err != nil {
+		fmt.Printf("failed to check message recipient relation: %v\n", err)
+		return
+	}
+	if hidden {
+		return
+	}
+	publishRealtime(ctx, uc.broker, userTopic(recipientID), entity.RealtimeEventMessage, view)
+
+	sender, err := uc.userRepo.FindByID(ctx, view.SenderID)
+	if err != nil {
+		fmt.Printf("failed to find message sender: %v\n", err)
+		return
+	}
+	notify(ctx, uc.notifier, NotifyInput{
+		UserID:      recipientID,
+		Category:    entity.NotificationCategoryMessage,
+		Template:    "message.received",
+		CollapseKey: conversation.ID.String(),
+		Params: map[string]string{
+			"sender_name": sender.Name,
+			"preview":     messagePreview(view.Body),
+		},
+		Data: map[string]string{
+			"conversation_id": conversation.ID.String(),
+			"message_id":      view.ID.String(),
+		},
+		SkipInbox: true,
+	})
+}
+
+// messagePreview はプッシュ通知に含めるメッセージの本文を返します
+func messagePreview(body string) string {
+	runes := []rune(strings.TrimSpace(body))
+	if len(runes) <= messagePreviewLength {
+		return string(runes)
+	}
+	return string(runes[:messagePreviewLength]) + "…"
+}
+
+// findParticipating はユーザーが参加しているやり取りを取得します
+// 参加していないやり取りは存在しないものとして扱います
+func (uc *MessageUseCase) findParticipating(ctx context.Context, userID string, conversationID uuid.UUID) (*entity.Conversation, error) {
+	conversation, err := uc.conversationRepo.FindByID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conversation == nil || !conversation.IsParticipant(userID) {
+		return nil, ErrConversationNotFound
+	}
+	return conversation, nil
+}
+
+// ensureNotBlocked はファンとキャストのどちらかが相手をブロックしていないかを確認します
+func (uc *MessageUseCase) ensureNotBlocked(ctx context.Context, fanID, creatorID string) error {
+	for _, pair := range [][2]string{{fanID, creatorID}, {creatorID, fanID}} {
+		blocked, err := uc.relationRepo.IsBlocked(ctx, pair[0], pair[1])
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return ErrMessagingBlocked
+		}
+	}
+	return nil
+}
+
+// activePlan はユーザーの有効なサブスクリプションのプランを返します
+// サブスクリプションが無い場合もエラーが返るため、取得できなければプラン無しとして扱います
+func (uc *MessageUseCase) activePlan(ctx context.Context, userID string) entity.PlanType {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return ""
+	}
+	subscription, err := uc.subscriptionRepo.FindActiveByUserID(ctx, id)
+	if err != nil || subscription == nil || !subscription.IsActive() {
+		return ""
+	}
+	return subscription.PlanType
+}
+
+// periodStart は送信枠を数える月の初めの日時を返します
+func (uc *MessageUseCase) periodStart(now time.Time) time.Time {
+	local := now.In(uc.location)
+	return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, uc.location)
+}
+
+// conversationViews はやり取りに閲覧者の未読件数を付けて返します
+func (uc *MessageUseCase) conversationViews(ctx context.Context, viewerID string, conversations []*entity.Conversation) ([]*ConversationView, error) {
+	ids := make([]uuid.UUID, 0, len(conversations))
+	for _, conversation := range conversations {
+		ids = append(ids, conversation.ID)
+	}
+	counts, err := uc.conversationRepo.CountUnread(ctx, viewerID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*ConversationView, 0, len(conversations))
+	for _, conversation := range conversations {
+		views = append(views, &ConversationView{Conversation: conversation, UnreadCount: counts[conversation.ID]})
+	}
+	return views, nil
+}
+
+// deleteUploaded はアップロード済みのファイルを削除します
+func (uc *MessageUseCase) deleteUploaded(ctx context.Context, fileURLs []string) {
+	for _, fileURL := range fileURLs {
+		_ = uc.fileStorage.Delete(ctx, fileURL)
+	}
+}
+
+// messageView はメッセージに受信者の既読の状態を付けて返します
+func messageView(conversation *entity.Conversation, message *entity.DirectMessage) *MessageView {
+	readAt := conversation.LastReadAtOf(conversation.CounterpartID(message.SenderID))
+	return &MessageView{
+		DirectMessage: message,
+		Read:          readAt != nil && !message.CreatedAt.After(*readAt),
+	}
+}
+
+// messageLimit は一覧の取得件数を既定値と上限の範囲に収めます
+func messageLimit(limit int) int {
+	if limit <= 0 {
+		return defaultMessageLimit
+	}
+	if limit > maxMessageLimit {
+		return maxMessageLimit
+	}
+	return limit
+}
@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+var (
+	ErrMFANotEnrolled      = errors.New("mfa is not enrolled")
+	ErrMFAAlreadyEnabled   = errors.New("mfa is already enabled")
+	ErrInvalidMFACode      = errors.New("invalid mfa code")
+	ErrMFARequiredForRole  = errors.New("mfa cannot be disabled for this role")
+	ErrMFAEnrollmentNeeded = errors.New("mfa enrollment has not been started")
+	ErrMFALocked           = errors.New("too many failed mfa attempts, try again later")
+)
+
+const (
+	recoveryCodeCount    = 10
+	recoveryCodeLength   = 10
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	// 総当たりでコードを推測されないよう、連続して失敗した場合は一定時間検証をロックする
+	mfaMaxFailedAttempts = 5
+	mfaLockDuration      = 15 * time.Minute
+)
+
+// MFAUseCase は二要素認証（TOTP）関連のユースケースを実装します
+type MFAUseCase struct {
+	mfaRepo    repository.MFARepository
+	userRepo   repository.UserRepository
+	otpService auth.OTPService
+}
+
+// NewMFAUseCase は新しいMFAUseCaseを作成します
+func NewMFAUseCase(
+	mfaRepo repository.MFARepository,
+	userRepo repository.UserRepository,
+	otpService auth.OTPService,
+) *MFAUseCase {
+	return &MFAUseCase{
+		mfaRepo:    mfaRepo,
+		userRepo:   userRepo,
+		otpService: otpService,
+	}
+}
+
+// MFAStatus は二要素認証の状態を表します
+type MFAStatus struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// MFAEnrollment は二要素認証の登録開始時に返す情報です
+type MFAEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// Status はユーザーの二要素認証の状態を取得します
+func (uc *MFAUseCase) Status(ctx context.Context, userID string) (*MFAStatus, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	mfa, err := uc.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MFAStatus{
+		Required: user.RequiresMFA(),
+	}
+	if mfa != nil && mfa.Enabled {
+		status.Enabled = true
+		status.RecoveryCodesRemaining, err = uc.mfaRepo.CountUnusedRecoveryCodes(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// IsEnabled はユーザーの二要素認証が有効かどうかを確認します
+func (uc *MFAUseCase) IsEnabled(ctx context.Context, userID string) (bool, error) {
+	mfa, err := uc.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return mfa != nil && mfa.Enabled, nil
+}
+
+// BeginEnrollment は二要素認証の登録を開始し、シークレットを発行します
+func (uc *MFAUseCase) BeginEnrollment(ctx context.Context, userID string) (*MFAEnrollment, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	existing, err := uc.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := uc.otpService.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	// 確認前の設定は上書きして再登録できるようにする
+	if err := uc.mfaRepo.Save(ctx, entity.NewUserMFA(userID, secret)); err != nil {
+		return nil, err
+	}
+
+	// 認証アプリに表示するアカウント名（メールアドレスがなければ名前）
+	account := user.Email
+	if !user.HasEmail() {
+		account = user.Name
+	}
+
+	return &MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: uc.otpService.ProvisioningURI(secret, account),
+	}, nil
+}
+
+// ConfirmEnrollment は認証アプリのコードで登録を確定し、リカバリーコードを発行します
+func (uc *MFAUseCase) ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	mfa, err := uc.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, ErrMFAEnrollmentNeeded
+	}
+	if mfa.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	step, ok := uc.otpService.Validate(mfa.Secret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	mfa.Confirm(step)
+	if err := uc.mfaRepo.Save(ctx, mfa); err != nil {
+		return nil, err
+	}
+
+	return uc.issueRecoveryCodes(ctx, userID)
+}
+
+// Verify はTOTPコードまたはリカバリーコードで二要素認証を検証します
+// 失敗回数はチャレンジではなくユーザーごとに数えるため、ログインし直しても上限はリセットされません
+func (uc *MFAUseCase) Verify(ctx context.Context, userID, code string) error {
+	mfa, err := uc.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil || !mfa.Enabled {
+		return ErrMFANotEnrolled
+	}
+	now := time.Now()
+	if mfa.IsLockedAt(now) {
+		return ErrMFALocked
+	}
+
+	if step, ok := uc.otpService.Validate(mfa.Secret, code, now); ok {
+		used, err := uc.mfaRepo.UseStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return uc.recordFailure(ctx, userID, now)
+		}
+		return nil
+	}
+
+	used, err := uc.mfaRepo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return uc.recordFailure(ctx, userID, now)
+	}
+
+	return uc.mfaRepo.ResetFailedAttempts(ctx, userID)
+}
+
+// recordFailure は検証の失敗を記録し、ErrInvalidMFACode を返します
+func (uc *MFAUseCase) recordFailure(ctx context.Context, userID string, now time.Time) error {
+	if err := uc.mfaRepo.RecordFailedAttempt(ctx, userID, mfaMaxFailedAttempts, now.Add(mfaLockDuration)); err != nil {
+		return err
+	}
+	return ErrInvalidMFACode
+}
+
+// Disable は二要素認証を無効化します
+// ロールで二要素認証が必須のユーザーは無効化できません
+func (uc *MFAUseCase) Disable(ctx context.Context, userID, code string) error {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user.RequiresMFA() {
+		return ErrMFARequiredForRole
+	}
+
+	if err := uc.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+
+	return uc.mfaRepo.Delete(ctx, userID)
+}
+
+// RegenerateRecoveryCodes はリカバリーコードを再発行します
+func (uc *MFAUseCase) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	if err := uc.Verify(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	return uc.issueRecoveryCodes(ctx, userID)
+}
+
+// issueRecoveryCodes は新しいリカバリーコードを生成して保存します
+func (uc *MFAUseCase) issueRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	plain := make([]string, 0, recoveryCodeCount)
+	codes := make([]*entity.MFARecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		plain = append(plain, code)
+		codes = append(codes, entity.NewMFARecoveryCode(userID, hashRecoveryCode(code)))
+	}
+
+	if err := uc.mfaRepo.ReplaceRecoveryCodes(ctx, userID, codes); err != nil {
+		return nil, err
+	}
+
+	return plain, nil
+}
+
+// generateRecoveryCode は "xxxxx-xxxxx" 形式のリカバリーコードを生成します
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+
+	var sb strings.Builder
+	for i, b := range buf {
+		if i == recoveryCodeLength/2 {
+			sb.WriteByte('-')
+		}
+		sb.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+	}
+	return sb.String(), nil
+}
+
+// hashRecoveryCode は正規化したリカバリーコードのハッシュを返します
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
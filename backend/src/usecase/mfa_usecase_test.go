@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// memoryMFARepository はテスト用のメモリ上のMFARepositoryです
+// ステップの再利用と失敗回数の扱いはPostgreSQLの実装と同じです
+type memoryMFARepository struct {
+	mu      sync.Mutex
+	configs map[string]*entity.UserMFA
+	codes   map[string][]*entity.MFARecoveryCode
+}
+
+func newMemoryMFARepository(configs ...*entity.UserMFA) *memoryMFARepository {
+	r := &memoryMFARepository{
+		configs: map[string]*entity.UserMFA{},
+		codes:   map[string][]*entity.MFARecoveryCode{},
+	}
+	for _, mfa := range configs {
+		r.configs[mfa.UserID] = mfa
+	}
+	return r
+}
+
+func (r *memoryMFARepository) FindByUserID(ctx context.Context, userID string) (*entity.UserMFA, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mfa, ok := r.configs[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *mfa
+	return &copied, nil
+}
+
+func (r *memoryMFARepository) Save(ctx context.Context, mfa *entity.UserMFA) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *mfa
+	r.configs[mfa.UserID] = &copied
+	return nil
+}
+
+func (r *memoryMFARepository) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mfa, ok := r.configs[userID]
+	if !ok || mfa.LastUsedStep >= step {
+		return false, nil
+	}
+	mfa.LastUsedStep = step
+	mfa.FailedAttempts = 0
+	mfa.LockedUntil = nil
+	return true, nil
+}
+
+func (r *memoryMFARepository) RecordFailedAttempt(ctx context.Context, userID string, maxAttempts int, lockUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mfa, ok := r.configs[userID]
+	if !ok {
+		return nil
+	}
+	mfa.FailedAttempts++
+	if mfa.FailedAttempts >= maxAttempts {
+		mfa.FailedAttempts = 0
+		mfa.LockedUntil = &lockUntil
+	}
+	return nil
+}
+
+func (r *memoryMFARepository) ResetFailedAttempts(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if mfa, ok := r.configs[userID]; ok {
+		mfa.FailedAttempts = 0
+		mfa.LockedUntil = nil
+	}
+	return nil
+}
+
+func (r *memoryMFARepository) Delete(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.configs, userID)
+	delete(r.codes, userID)
+	return nil
+}
+
+func (r *memoryMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*entity.MFARecoveryCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codes[userID] = codes
+	return nil
+}
+
+func (r *memoryMFARepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, code := range r.codes[userID] {
+		if code.CodeHash == codeHash && code.UsedAt == nil {
+			now := time.Now()
+			code.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryMFARepository) CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for _, code := range r.codes[userID] {
+		if code.UsedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// stubOTPService はコードとタイムステップの対応表で検証するテスト用のOTPServiceです
+type stubOTPService struct {
+	auth.OTPService
+	steps map[string]int64
+}
+
+func (s stubOTPService) Validate(secret, code string, at time.Time) (int64, bool) {
+	step, ok := s.steps[code]
+	return step, ok
+}
+
+// newEnabledMFAFixture は二要素認証を有効にしたユーザーとMFAUseCaseを作成します
+func newEnabledMFAFixture(steps map[string]int64) (*MFAUseCase, *memoryMFARepository, string) {
+	userID := uuid.New().String()
+	mfa := entity.NewUserMFA(userID, "secret")
+	mfa.Confirm(1)
+	repo := newMemoryMFARepository(mfa)
+	uc := NewMFAUseCase(repo, newMemoryUserRepository(), stubOTPService{steps: steps})
+	return uc, repo, userID
+}
+
+func TestMFAVerifyRejectsReplayedCode(t *testing.T) {
+	uc, _, userID := newEnabledMFAFixture(map[string]int64{"111111": 1, "222222": 2})
+	ctx := context.Background()
+
+	// 登録確認に使ったステップのコードは再利用できない
+	if err := uc.Verify(ctx, userID, "111111"); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("verify with the enrollment step error = %v, want ErrInvalidMFACode", err)
+	}
+	if err := uc.Verify(ctx, userID, "222222"); err != nil {
+		t.Fatalf("verify with a new step failed: %v", err)
+	}
+	if err := uc.Verify(ctx, userID, "222222"); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("replayed code error = %v, want ErrInvalidMFACode", err)
+	}
+}
+
+func TestMFAVerifyLocksAfterRepeatedFailures(t *testing.T) {
+	uc, _, userID := newEnabledMFAFixture(map[string]int64{"222222": 2})
+	ctx := context.Background()
+
+	for i := 0; i < mfaMaxFailedAttempts; i++ {
+		if err := uc.Verify(ctx, userID, "000000"); !errors.Is(err, ErrInvalidMFACode) {
+			t.Fatalf("attempt %d error = %v, want ErrInvalidMFACode", i+1, err)
+		}
+	}
+
+	// ロック中は正しいコードでも検証できない
+	if err := uc.Verify(ctx, userID, "222222"); !errors.Is(err, ErrMFALocked) {
+		t.Fatalf("verify while locked error = %v, want ErrMFALocked", err)
+	}
+}
+
+func TestMFAVerifyRecoveryCodeIsSingleUseAndResetsFailures(t *testing.T) {
+	uc, repo, userID := newEnabledMFAFixture(nil)
+	ctx := context.Background()
+
+	codes, err := uc.issueRecoveryCodes(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to issue recovery codes: %v", err)
+	}
+	for i := 0; i < mfaMaxFailedAttempts-1; i++ {
+		if err := uc.Verify(ctx, userID, "wrong-code"); !errors.Is(err, ErrInvalidMFACode) {
+			t.Fatalf("attempt %d error = %v, want ErrInvalidMFACode", i+1, err)
+		}
+	}
+
+	if err := uc.Verify(ctx, userID, codes[0]); err != nil {
+		t.Fatalf("verify with a recovery code failed: %v", err)
+	}
+	if got := repo.configs[userID].FailedAttempts; got != 0 {
+		t.Errorf("failed attempts = %d, want reset to 0", got)
+	}
+	if err := uc.Verify(ctx, userID, codes[0]); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("reused recovery code error = %v, want ErrInvalidMFACode", err)
+	}
+}
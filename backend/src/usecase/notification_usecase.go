@@ -0,0 +1,721 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/notification"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDeviceNotFound               = errors.New("device not found")
+	ErrNotificationTemplateNotFound = errors.New("notification template not found")
+	ErrNotificationNotFound         = errors.New("notification not found")
+)
+
+const (
+	defaultDeliveryLimit     = 20
+	maxDeliveryLimit         = 100
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+
+	// notificationFanoutPageSize は推しのフォロワーへの通知のジョブ1回で通知するフォロワーの人数です
+	notificationFanoutPageSize = 500
+
+	// pushClaimTimeout を過ぎても送信処理中のままの通知は、処理が中断されたものとして送り直します
+	pushClaimTimeout = 10 * time.Minute
+
+	exportDeliveryPageSize = 500
+)
+
+// PushSendResult は端末ごとのプッシュ通知の送信結果です
+// InvalidToken はトークンが無効になっており、今後も送信できないことを表します
+type PushSendResult struct {
+	MessageID    string
+	Err          error
+	InvalidToken bool
+}
+
+// PushSender はプッシュ通知を端末に送信するインターフェースです
+type PushSender interface {
+	// Send は端末のトークンにプッシュ通知を送信し、tokens と同じ順序で結果を返します
+	// 送信自体が行えなかった場合はエラーを返します
+	Send(ctx context.Context, tokens []string, message *entity.PushMessage) ([]PushSendResult, error)
+}
+
+// NotifyInput はお知らせの送信依頼の入力です
+// Params はテンプレートのパラメータ、Data はアプリに渡す値です
+// メッセージのように専用の受信箱があるものは SkipInbox を指定し、プッシュ通知だけを送ります
+type NotifyInput struct {
+	UserID      string                      `json:"user_id"`
+	Category    entity.NotificationCategory `json:"category"`
+	Template    string                      `json:"template"`
+	CollapseKey string                      `json:"collapse_key,omitempty"`
+	Params      map[string]string           `json:"params"`
+	Data        map[string]string           `json:"data"`
+	SkipInbox   bool                        `json:"skip_inbox,omitempty"`
+}
+
+// Notifier はユーザーへのお知らせの送信を依頼するインターフェースです
+type Notifier interface {
+	// Notify はユーザーにお知らせを送ります
+	Notify(ctx context.Context, input NotifyInput) error
+
+	// NotifyOshiFollowers は推しのフォロワーにバックグラウンドでお知らせを送ります
+	// input.UserID に指定したユーザーを隠しているフォロワーには送りません
+	NotifyOshiFollowers(ctx context.Context, oshiID uuid.UUID, input NotifyInput) error
+}
+
+// notify はお知らせの送信を依頼します
+// お知らせは本来の処理の付随的なものなので、失敗してもログに記録するだけにします
+func notify(ctx context.Context, notifier Notifier, input NotifyInput) {
+	if notifier == nil {
+		return
+	}
+	if err := notifier.Notify(ctx, input); err != nil {
+		fmt.Printf("failed to notify %s: %v\n", input.Template, err)
+	}
+}
+
+// notifyOshiFollowers は推しのフォロワーへのお知らせの送信を依頼します
+func notifyOshiFollowers(ctx context.Context, notifier Notifier, oshiID uuid.UUID, input NotifyInput) {
+	if notifier == nil {
+		return
+	}
+	if err := notifier.NotifyOshiFollowers(ctx, oshiID, input); err != nil {
+		fmt.Printf("failed to notify oshi followers %s: %v\n", input.Template, err)
+	}
+}
+
+// PushDeliverPayload はプッシュ通知の送信ジョブのペイロードです
+type PushDeliverPayload struct {
+	UserID string `json:"user_id"`
+}
+
+// NotificationFanoutPayload は推しのフォロワーへのお知らせのジョブのペイロードです
+// After より後のIDのフォロワーから順に送ります
+type NotificationFanoutPayload struct {
+	OshiID uuid.UUID   `json:"oshi_id"`
+	Input  NotifyInput `json:"input"`
+	After  string      `json:"after,omitempty"`
+}
+
+// NotificationView は閲覧者の言語の文面を含むお知らせです
+type NotificationView struct {
+	*entity.Notification
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// NotificationPage は通知の受信箱の1ページ分の結果です
+// NextCursor が空の場合はそれより古いお知らせはありません
+type NotificationPage struct {
+	Items       []*NotificationView `json:"items"`
+	UnreadCount int                 `json:"unread_count"`
+	NextCursor  string              `json:"next_cursor,omitempty"`
+}
+
+// NotificationEvent は新しいお知らせを知らせるイベントの内容です
+type NotificationEvent struct {
+	Notification *NotificationView `json:"notification"`
+	UnreadCount  int               `json:"unread_count"`
+}
+
+// NotificationsReadEvent はお知らせを既読にしたことを他の端末に知らせるイベントの内容です
+type NotificationsReadEvent struct {
+	UnreadCount int `json:"unread_count"`
+}
+
+// NotificationUseCase はお知らせのユースケースを実装します
+// お知らせは通知の受信箱に保存してリアルタイムに配信し、プッシュ通知でも送ります
+// プッシュ通知は batchWindow の間に届いた分をまとめて送り、ユーザーが設定した通知を送らない時間帯の間は送信を遅らせます
+type NotificationUseCase struct {
+	notificationRepo repository.NotificationRepository
+	deviceRepo       repository.DeviceTokenRepository
+	settingsRepo     repository.NotificationSettingsRepository
+	pushRepo         repository.PushNotificationRepository
+	profileRepo      repository.ProfileRepository
+	followRepo       repository.FollowRepository
+	templates        *notification.Templates
+	sender           PushSender
+	jobQueue         JobQueue
+	publisher        RealtimePublisher
+	batchWindow      time.Duration
+}
+
+// NewNotificationUseCase は新しいNotificationUseCaseを作成します
+func NewNotificationUseCase(
+	notificationRepo repository.NotificationRepository,
+	deviceRepo repository.DeviceTokenRepository,
+	settingsRepo repository.NotificationSettingsRepository,
+	pushRepo repository.PushNotificationRepository,
+	profileRepo repository.ProfileRepository,
+	followRepo repository.FollowRepository,
+	templates *notification.Templates,
+	sender PushSender,
+	jobQueue JobQueue,
+	publisher RealtimePublisher,
+	batchWindow time.Duration,
+) *NotificationUseCase {
+	return &NotificationUseCase{
+		notificationRepo: notificationRepo,
+		deviceRepo:       deviceRepo,
+		settingsRepo:     settingsRepo,
+		pushRepo:         pushRepo,
+		profileRepo:      profileRepo,
+		followRepo:       followRepo,
+		templates:        templates,
+		sender:           sender,
+		jobQueue:         jobQueue,
+		publisher:        publisher,
+		batchWindow:      batchWindow,
+	}
+}
+
+// ListNotifications は通知の受信箱のお知らせを新しい順に返します
+// 古いお知らせは前のレスポンスの next_cursor を cursor に指定して取得します
+func (uc *NotificationUseCase) ListNotifications(ctx context.Context, userID string, unreadOnly bool, cursor string, limit int) (*NotificationPage, error) {
+	if limit <= 0 {
+		limit = defaultNotificationLimit
+	}
+	if limit > maxNotificationLimit {
+		limit = maxNotificationLimit
+	}
+	position, err := parseCommunityCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := uc.notificationRepo.ListByUserID(ctx, userID, unreadOnly, position, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	locale, _, err := uc.localization(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &NotificationPage{Items: []*NotificationView{}, UnreadCount: unread}
+	if len(notifications) > limit {
+		last := notifications[limit-1]
+		page.NextCursor = encodeFeedCursor(repository.FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		notifications = notifications[:limit]
+	}
+	for _, n := range notifications {
+		page.Items = append(page.Items, uc.view(n, locale))
+	}
+
+	return page, nil
+}
+
+// MarkNotificationRead はお知らせを既読にし、未読の件数を返します
+func (uc *NotificationUseCase) MarkNotificationRead(ctx context.Context, userID string, id uuid.UUID) (int, error) {
+	found, err := uc.notificationRepo.MarkRead(ctx, userID, id, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, ErrNotificationNotFound
+	}
+
+	return uc.publishUnreadCount(ctx, userID)
+}
+
+// MarkAllNotificationsRead は全てのお知らせを既読にし、未読の件数を返します
+func (uc *NotificationUseCase) MarkAllNotificationsRead(ctx context.Context, userID string) (int, error) {
+	if err := uc.notificationRepo.MarkAllRead(ctx, userID, time.Now()); err != nil {
+		return 0, err
+	}
+
+	return uc.publishUnreadCount(ctx, userID)
+}
+
+// publishUnreadCount は未読の件数をユーザーの他の端末に知らせ、その件数を返します
+func (uc *NotificationUseCase) publishUnreadCount(ctx context.Context, userID string) (int, error) {
+	unread, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	publishRealtime(ctx, uc.publisher, userTopic(userID), entity.RealtimeEventNotificationsRead, &NotificationsReadEvent{UnreadCount: unread})
+	return unread, nil
+}
+
+// RegisterDevice はプッシュ通知を受け取る端末を登録します
+func (uc *NotificationUseCase) RegisterDevice(ctx context.Context, userID, token string, platform entity.DevicePlatform) (*entity.DeviceToken, error) {
+	device, err := entity.NewDeviceToken(userID, token, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.deviceRepo.Save(ctx, device)
+}
+
+// ListDevices はユーザーが登録した端末を返します
+func (uc *NotificationUseCase) ListDevices(ctx context.Context, userID string) ([]*entity.DeviceToken, error) {
+	devices, err := uc.deviceRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if devices == nil {
+		devices = []*entity.DeviceToken{}
+	}
+
+	return devices, nil
+}
+
+// UnregisterDevice は端末の登録を解除します
+func (uc *NotificationUseCase) UnregisterDevice(ctx context.Context, userID string, id uuid.UUID) error {
+	deleted, err := uc.deviceRepo.Delete(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// GetSettings はユーザーの通知設定を返します
+func (uc *NotificationUseCase) GetSettings(ctx context.Context, userID string) (*entity.NotificationSettings, error) {
+	return uc.settings(ctx, userID)
+}
+
+// UpdateSettings はユーザーの通知設定を置き換えます
+func (uc *NotificationUseCase) UpdateSettings(ctx context.Context, userID string, quietHours *entity.QuietHours, disabledCategories []entity.NotificationCategory) (*entity.NotificationSettings, error) {
+	if disabledCategories == nil {
+		disabledCategories = []entity.NotificationCategory{}
+	}
+	settings := &entity.NotificationSettings{
+		UserID:             userID,
+		QuietHours:         quietHours,
+		DisabledCategories: disabledCategories,
+		UpdatedAt:          time.Now(),
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.settingsRepo.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// ListDeliveries はユーザーへのプッシュ通知の送信記録を新しい順に返します
+func (uc *NotificationUseCase) ListDeliveries(ctx context.Context, userID string, limit, offset int) ([]*entity.PushDelivery, error) {
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	deliveries, err := uc.pushRepo.ListDeliveriesByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []*entity.PushDelivery{}
+	}
+
+	return deliveries, nil
+}
+
+// Notify はお知らせを通知の受信箱に保存してリアルタイムに配信し、プッシュ通知の送信ジョブを登録します
+// ユーザーがその種類の通知を無効にしている場合はプッシュ通知を送りません
+func (uc *NotificationUseCase) Notify(ctx context.Context, input NotifyInput) error {
+	if !entity.IsValidNotificationCategory(input.Category) {
+		return fmt.Errorf("invalid notification category: %s", input.Category)
+	}
+	if !uc.templates.Has(input.Template) {
+		return fmt.Errorf("%w: %s", ErrNotificationTemplateNotFound, input.Template)
+	}
+
+	locale, loc, err := uc.localization(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !input.SkipInbox {
+		n := entity.NewNotification(input.UserID, input.Category, input.Template, input.Params, input.Data)
+		if err := uc.notificationRepo.Create(ctx, n); err != nil {
+			return err
+		}
+
+		unread, err := uc.notificationRepo.CountUnread(ctx, input.UserID)
+		if err != nil {
+			return err
+		}
+		publishRealtime(ctx, uc.publisher, userTopic(input.UserID), entity.RealtimeEventNotification, &NotificationEvent{
+			Notification: uc.view(n, locale),
+			UnreadCount:  unread,
+		})
+	}
+
+	settings, err := uc.settings(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+	if !settings.Allows(input.Category) {
+		return nil
+	}
+
+	deliverAfter := settings.NextDeliveryTime(time.Now().Add(uc.batchWindow), loc)
+	push := entity.NewPushNotification(input.UserID, input.Category, input.Template, input.CollapseKey, input.Params, input.Data, deliverAfter)
+	if err := uc.pushRepo.Create(ctx, push); err != nil {
+		return err
+	}
+
+	if err := uc.jobQueue.Enqueue(ctx, JobTypePushDeliver, PushDeliverPayload{UserID: input.UserID}, deliverAfter); err != nil {
+		return fmt.Errorf("failed to enqueue push delivery: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyOshiFollowers は推しのフォロワーにお知らせを送るジョブを登録します
+// フォロワーが多い推しでも呼び出し元を待たせないよう、送信はジョブで行います
+func (uc *NotificationUseCase) NotifyOshiFollowers(ctx context.Context, oshiID uuid.UUID, input NotifyInput) error {
+	if !uc.templates.Has(input.Template) {
+		return fmt.Errorf("%w: %s", ErrNotificationTemplateNotFound, input.Template)
+	}
+
+	if err := uc.jobQueue.Enqueue(ctx, JobTypeNotificationFanout, NotificationFanoutPayload{OshiID: oshiID, Input: input}, time.Time{}); err != nil {
+		return fmt.Errorf("failed to enqueue notification fanout: %w", err)
+	}
+
+	return nil
+}
+
+// HandleFanoutJob は推しのフォロワーへのお知らせのジョブを処理します
+// 1回のジョブでは一定の人数にだけ送り、続きは次のジョブとして登録します
+// 1人への送信に失敗しても、送信済みのフォロワーに重複して送らないようログに記録して続けます
+func (uc *NotificationUseCase) HandleFanoutJob(ctx context.Context, payload []byte) error {
+	var p NotificationFanoutPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("failed to decode notification fanout payload: %w", err)
+	}
+
+	followerIDs, err := uc.followRepo.ListFollowerIDs(ctx, p.OshiID, p.Input.UserID, p.After, notificationFanoutPageSize)
+	if err != nil {
+		return err
+	}
+
+	for _, followerID := range followerIDs {
+		if followerID == p.Input.UserID {
+			continue
+		}
+		input := p.Input
+		input.UserID = followerID
+		if err := uc.Notify(ctx, input); err != nil {
+			fmt.Printf("failed to notify oshi follower: %v\n", err)
+		}
+	}
+
+	if len(followerIDs) < notificationFanoutPageSize {
+		return nil
+	}
+	p.After = followerIDs[len(followerIDs)-1]
+	return uc.jobQueue.Enqueue(ctx, JobTypeNotificationFanout, p, time.Time{})
+}
+
+// HandleDeliveryJob はユーザーの送信時刻を過ぎたプッシュ通知をまとめて送信するジョブを処理します
+// 送信自体に失敗した場合は通知を送信待ちに戻し、エラーを返してジョブを再試行させます
+func (uc *NotificationUseCase) HandleDeliveryJob(ctx context.Context, payload []byte) error {
+	var p PushDeliverPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("failed to decode push delivery payload: %w", err)
+	}
+
+	settings, err := uc.settings(ctx, p.UserID)
+	if err != nil {
+		return err
+	}
+	locale, loc, err := uc.localization(ctx, p.UserID)
+	if err != nil {
+		return err
+	}
+
+	// 登録後に通知を送らない時間帯が設定された場合は、時間帯の終わりに送り直します
+	now := time.Now()
+	if next := settings.NextDeliveryTime(now, loc); next.After(now) {
+		return uc.jobQueue.Enqueue(ctx, JobTypePushDeliver, p, next)
+	}
+
+	// まとめて送るため、まもなく送信時刻になる通知も一緒に送ります
+	claimed, err := uc.pushRepo.ClaimDue(ctx, p.UserID, now.Add(uc.batchWindow), now.Add(-pushClaimTimeout))
+	if err != nil {
+		return err
+	}
+	if len(claimed) == 0 {
+		return nil
+	}
+
+	var allowed []*entity.PushNotification
+	var disabledIDs []uuid.UUID
+	for _, push := range claimed {
+		if settings.Allows(push.Category) {
+			allowed = append(allowed, push)
+		} else {
+			disabledIDs = append(disabledIDs, push.ID)
+		}
+	}
+	if err := uc.pushRepo.Complete(ctx, disabledIDs, entity.PushStatusSkipped, now); err != nil {
+		return err
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	ids := pushNotificationIDs(allowed)
+
+	devices, err := uc.deviceRepo.ListByUserID(ctx, p.UserID)
+	if err != nil {
+		_ = uc.pushRepo.Release(ctx, ids, now)
+		return err
+	}
+	if len(devices) == 0 {
+		return uc.pushRepo.Complete(ctx, ids, entity.PushStatusSkipped, now)
+	}
+
+	message, err := uc.compose(allowed, locale)
+	if err != nil {
+		// テンプレートの誤りは再試行しても直らないため、失敗として記録します
+		fmt.Printf("failed to compose push notification: %v\n", err)
+		return uc.pushRepo.Complete(ctx, ids, entity.PushStatusFailed, now)
+	}
+
+	tokens := make([]string, len(devices))
+	for i, device := range devices {
+		tokens[i] = device.Token
+	}
+	results, err := uc.sender.Send(ctx, tokens, message)
+	if err != nil {
+		if releaseErr := uc.pushRepo.Release(ctx, ids, now); releaseErr != nil {
+			fmt.Printf("failed to release push notifications: %v\n", releaseErr)
+		}
+		return fmt.Errorf("failed to send push notification: %w", err)
+	}
+
+	sentAt := time.Now()
+	status := entity.PushStatusFailed
+	deliveries := make([]*entity.PushDelivery, 0, len(devices))
+	var invalidIDs []uuid.UUID
+	for i, device := range devices {
+		delivery := &entity.PushDelivery{
+			ID:              uuid.New(),
+			UserID:          p.UserID,
+			NotificationIDs: ids,
+			DeviceTokenID:   device.ID,
+			Platform:        device.Platform,
+			Title:           message.Title,
+			Body:            message.Body,
+			Status:          entity.PushDeliveryStatusFailed,
+			CreatedAt:       sentAt,
+		}
+		if i < len(results) {
+			result := results[i]
+			switch {
+			case result.InvalidToken:
+				delivery.Status = entity.PushDeliveryStatusInvalidToken
+				invalidIDs = append(invalidIDs, device.ID)
+			case result.Err == nil:
+				delivery.Status = entity.PushDeliveryStatusSent
+				delivery.ProviderMessageID = result.MessageID
+				status = entity.PushStatusSent
+			}
+			if result.Err != nil {
+				delivery.Error = result.Err.Error()
+			}
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := uc.deviceRepo.DeleteByIDs(ctx, invalidIDs); err != nil {
+		fmt.Printf("failed to delete invalid device tokens: %v\n", err)
+	}
+	if err := uc.pushRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		fmt.Printf("failed to record push deliveries: %v\n", err)
+	}
+
+	return uc.pushRepo.Complete(ctx, ids, status, sentAt)
+}
+
+// compose はまとめて送る通知から端末に送る文面を作成します
+// 1件の場合はそのテンプレート、同じテンプレートと CollapseKey の通知だけの場合はそのテンプレートの要約版、
+// それ以外の場合は件数だけを伝える文面を使います
+func (uc *NotificationUseCase) compose(pushes []*entity.PushNotification, locale string) (*entity.PushMessage, error) {
+	latest := pushes[len(pushes)-1]
+	count := strconv.Itoa(len(pushes))
+
+	sameGroup := true
+	for _, push := range pushes {
+		if push.Template != latest.Template || push.CollapseKey != latest.CollapseKey {
+			sameGroup = false
+			break
+		}
+	}
+
+	key := latest.Template
+	params := latest.Params
+	data := latest.Data
+	if len(pushes) > 1 {
+		params = make(map[string]string, len(latest.Params)+1)
+		for k, v := range latest.Params {
+			params[k] = v
+		}
+		params["count"] = count
+
+		key += notification.BatchTemplateKey
+		if !sameGroup || !uc.templates.Has(key) {
+			key = notification.GenericBatchTemplate
+			data = map[string]string{}
+		}
+	}
+
+	title, body, err := uc.templates.Render(key, locale, params)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &entity.PushMessage{
+		Title:    title,
+		Body:     body,
+		Category: latest.Category,
+		Data:     make(map[string]string, len(data)+1),
+	}
+	for k, v := range data {
+		message.Data[k] = v
+	}
+	message.Data["count"] = count
+
+	return message, nil
+}
+
+// view はお知らせの文面を閲覧者の言語で作成します
+// テンプレートの誤りで作成できない場合も一覧の表示は続けるため、文面を空にします
+func (uc *NotificationUseCase) view(n *entity.Notification, locale string) *NotificationView {
+	title, body, err := uc.templates.Render(n.Template, locale, n.Params)
+	if err != nil {
+		fmt.Printf("failed to render notification: %v\n", err)
+	}
+	return &NotificationView{Notification: n, Title: title, Body: body}
+}
+
+// settings はユーザーの通知設定を返します。保存されていない場合は既定の設定を返します
+func (uc *NotificationUseCase) settings(ctx context.Context, userID string) (*entity.NotificationSettings, error) {
+	settings, err := uc.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = entity.DefaultNotificationSettings(userID)
+	}
+	return settings, nil
+}
+
+// localization はプロフィールに設定された通知の言語とタイムゾーンを返します
+func (uc *NotificationUseCase) localization(ctx context.Context, userID string) (string, *time.Location, error) {
+	profile, err := uc.profileRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	locale, timezone := entity.LocaleJa, entity.DefaultTimezone
+	if profile != nil {
+		locale, timezone = profile.Locale, profile.Timezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		if loc, err = time.LoadLocation(entity.DefaultTimezone); err != nil {
+			loc = time.UTC
+		}
+	}
+
+	return locale, loc, nil
+}
+
+// pushNotificationIDs は通知のIDの一覧を返します
+func pushNotificationIDs(pushes []*entity.PushNotification) []uuid.UUID {
+	ids := make([]uuid.UUID, len(pushes))
+	for i, push := range pushes {
+		ids[i] = push.ID
+	}
+	return ids
+}
+
+// Name はデータの種類の名前を返します
+func (uc *NotificationUseCase) Name() string {
+	return "notifications"
+}
+
+// ExportPersonalData はユーザーのお知らせ、端末、通知設定、プッシュ通知とその送信記録を返します
+func (uc *NotificationUseCase) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	notifications, err := uc.notificationRepo.ListAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := uc.ListDevices(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := uc.settings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	pushes, err := uc.pushRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []*entity.PushDelivery
+	for offset := 0; ; offset += exportDeliveryPageSize {
+		page, err := uc.pushRepo.ListDeliveriesByUserID(ctx, userID, exportDeliveryPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, page...)
+		if len(page) < exportDeliveryPageSize {
+			break
+		}
+	}
+
+	return map[string]interface{}{
+		"notifications":      notifications,
+		"devices":            devices,
+		"settings":           settings,
+		"push_notifications": pushes,
+		"push_deliveries":    deliveries,
+	}, nil
+}
+
+// ErasePersonalData はユーザーのお知らせ、端末、通知設定、プッシュ通知とその送信記録を削除します
+func (uc *NotificationUseCase) ErasePersonalData(ctx context.Context, userID string) error {
+	if err := uc.notificationRepo.DeleteAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := uc.deviceRepo.DeleteAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := uc.settingsRepo.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+	return uc.pushRepo.DeleteAllByUserID(ctx, userID)
+}
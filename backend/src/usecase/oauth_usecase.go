@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+var (
+	ErrUnknownOAuthProvider  = errors.New("unknown oauth provider")
+	ErrInvalidOAuthState     = errors.New("invalid or expired oauth state")
+	ErrIdentityAlreadyLinked = errors.New("this account is already linked to another user")
+	ErrLastLoginMethod       = errors.New("cannot unlink the only remaining login method")
+	ErrProviderAlreadyLinked = errors.New("this provider is already linked to the user")
+)
+
+const (
+	oauthStateTTL       = 10 * time.Minute
+	oauthRandomByteSize = 32
+	// oauthDefaultUserName は外部アカウントから名前を取得できない場合の名前です
+	oauthDefaultUserName = "ユーザー"
+)
+
+// OAuthUseCase は外部IdP（OAuth2/OIDC）によるログインのユースケースを実装します
+type OAuthUseCase struct {
+	providers    map[string]auth.OAuthProvider
+	identityRepo repository.IdentityRepository
+	stateRepo    repository.OAuthStateRepository
+	userRepo     repository.UserRepository
+}
+
+// NewOAuthUseCase は新しいOAuthUseCaseを作成します
+func NewOAuthUseCase(
+	providers []auth.OAuthProvider,
+	identityRepo repository.IdentityRepository,
+	stateRepo repository.OAuthStateRepository,
+	userRepo repository.UserRepository,
+) *OAuthUseCase {
+	m := make(map[string]auth.OAuthProvider, len(providers))
+	for _, p := range providers {
+		m[p.Name()] = p
+	}
+	return &OAuthUseCase{
+		providers:    m,
+		identityRepo: identityRepo,
+		stateRepo:    stateRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// Providers は利用可能なプロバイダー名の一覧を返します
+func (uc *OAuthUseCase) Providers() []string {
+	names := make([]string, 0, len(uc.providers))
+	for name := range uc.providers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// BeginInput は認可フロー開始の入力データです
+type BeginInput struct {
+	Provider string
+	// LinkUserID を指定した場合はログイン中のユーザーへの紐付けフローになります
+	LinkUserID string
+}
+
+// BeginResult は認可フロー開始の結果です
+type BeginResult struct {
+	// AuthorizationURL はユーザーを送る認可エンドポイントのURLです
+	AuthorizationURL string
+	// Binding はフローを開始したクライアントにだけ渡す値で、コールバックで同じ値の提示を求めます
+	Binding string
+}
+
+// Begin は認可フローを開始し、認可エンドポイントのURLを返します
+func (uc *OAuthUseCase) Begin(ctx context.Context, input BeginInput) (*BeginResult, error) {
+	provider, ok := uc.providers[input.Provider]
+	if !ok {
+		return nil, ErrUnknownOAuthProvider
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	// PKCEのコード検証子（43文字のURLセーフ文字列）
+	verifier, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	binding, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// 期限切れの状態を掃除する（失敗してもフローは継続する）
+	_ = uc.stateRepo.DeleteExpired(ctx)
+
+	s := entity.NewOAuthState(state, input.Provider, nonce, verifier, input.LinkUserID, hashOAuthBinding(binding), oauthStateTTL)
+	if err := uc.stateRepo.Create(ctx, s); err != nil {
+		return nil, err
+	}
+
+	return &BeginResult{
+		AuthorizationURL: provider.AuthCodeURL(state, nonce, verifier),
+		Binding:          binding,
+	}, nil
+}
+
+// CompleteInput は認可フロー完了の入力データです
+type CompleteInput struct {
+	Provider string
+	State    string
+	Code     string
+	// Binding は Begin がフローを開始したクライアントに渡した値です
+	Binding string
+}
+
+// Complete は認可コードを検証し、ログインするユーザーを返します
+// 既存の紐付け、検証済みメールアドレスによる既存ユーザーへの紐付け、新規登録の順に解決します
+// 紐付けフローの状態は CompleteLink でのみ完了できます
+func (uc *OAuthUseCase) Complete(ctx context.Context, input CompleteInput) (*entity.User, error) {
+	state, info, err := uc.exchange(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if state.LinkUserID != "" {
+		return nil, ErrInvalidOAuthState
+	}
+
+	identity, err := uc.identityRepo.FindByProviderSubject(ctx, input.Provider, info.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// 既に紐付け済みのアカウント
+	if identity != nil {
+		return uc.userRepo.FindByID(ctx, identity.UserID)
+	}
+
+	// 検証済みメールアドレスで既存ユーザーに紐付け
+	verified := info.EmailVerified && info.Email != ""
+	if verified {
+		user, err := uc.userRepo.FindByEmail(ctx, info.Email)
+		if err != nil && !errors.Is(err, entity.ErrUserNotFound) {
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+		if user != nil {
+			if _, err := uc.link(ctx, user.ID, input.Provider, info); err != nil {
+				return nil, err
+			}
+			return user, nil
+		}
+	}
+
+	// 新規登録（パスワードログインは無効）
+	// 検証されていないメールアドレスは登録せず、メールアドレスなしで作成する
+	name := info.Name
+	if name == "" && verified {
+		name = info.Email
+	}
+	if name == "" {
+		name = oauthDefaultUserName
+	}
+	var user *entity.User
+	if verified {
+		user = entity.NewUser(info.Email, "", name)
+	} else {
+		user = entity.NewUserWithoutEmail(name)
+	}
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	if _, err := uc.link(ctx, user.ID, input.Provider, info); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// CompleteLinkInput は紐付けフロー完了の入力データです
+type CompleteLinkInput struct {
+	CompleteInput
+	// UserID はコールバックを呼び出したログイン中のユーザーです
+	UserID string
+}
+
+// CompleteLink は認可コードを検証し、ログイン中のユーザーに外部アカウントを紐付けます
+// 紐付けフローを開始したユーザー本人からのコールバックのみ受け付けます
+func (uc *OAuthUseCase) CompleteLink(ctx context.Context, input CompleteLinkInput) (*entity.UserIdentity, error) {
+	state, info, err := uc.exchange(ctx, input.CompleteInput)
+	if err != nil {
+		return nil, err
+	}
+	if state.LinkUserID == "" || state.LinkUserID != input.UserID {
+		return nil, ErrInvalidOAuthState
+	}
+
+	identity, err := uc.identityRepo.FindByProviderSubject(ctx, input.Provider, info.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		if identity.UserID != input.UserID {
+			return nil, ErrIdentityAlreadyLinked
+		}
+		return identity, nil
+	}
+
+	return uc.link(ctx, input.UserID, input.Provider, info)
+}
+
+// exchange は状態を消費して検証し、認可コードを外部アカウントの情報に交換します
+// 状態はフローを開始したクライアントと同じ Binding を提示した場合のみ有効です
+func (uc *OAuthUseCase) exchange(ctx context.Context, input CompleteInput) (*entity.OAuthState, *auth.OAuthUserInfo, error) {
+	provider, ok := uc.providers[input.Provider]
+	if !ok {
+		return nil, nil, ErrUnknownOAuthProvider
+	}
+
+	state, err := uc.stateRepo.Consume(ctx, input.State)
+	if err != nil {
+		return nil, nil, err
+	}
+	if state == nil || state.IsExpired() || state.Provider != input.Provider {
+		return nil, nil, ErrInvalidOAuthState
+	}
+	if input.Binding == "" || subtle.ConstantTimeCompare([]byte(state.BindingHash), []byte(hashOAuthBinding(input.Binding))) != 1 {
+		return nil, nil, ErrInvalidOAuthState
+	}
+
+	info, err := provider.Exchange(ctx, input.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	return state, info, nil
+}
+
+// ListIdentities はユーザーに紐付けられた外部アカウントの一覧を返します
+func (uc *OAuthUseCase) ListIdentities(ctx context.Context, userID string) ([]*entity.UserIdentity, error) {
+	return uc.identityRepo.FindByUserID(ctx, userID)
+}
+
+// Unlink は外部アカウントの紐付けを解除します
+// パスワードが未設定で他の紐付けも無い場合はログイン手段が無くなるため解除できません
+func (uc *OAuthUseCase) Unlink(ctx context.Context, userID, provider string) error {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	identities, err := uc.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Password == "" && len(identities) <= 1 {
+		return ErrLastLoginMethod
+	}
+
+	return uc.identityRepo.Delete(ctx, userID, provider)
+}
+
+// link はユーザーに外部アカウントを紐付けます
+func (uc *OAuthUseCase) link(ctx context.Context, userID, provider string, info *auth.OAuthUserInfo) (*entity.UserIdentity, error) {
+	identities, err := uc.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			return nil, ErrProviderAlreadyLinked
+		}
+	}
+
+	identity := entity.NewUserIdentity(userID, provider, info.Subject, info.Email)
+	if err := uc.identityRepo.Create(ctx, identity); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// hashOAuthBinding はクライアントに渡した値のハッシュを返します
+func hashOAuthBinding(binding string) string {
+	sum := sha256.Sum256([]byte(binding))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken はURLセーフなランダム文字列を生成します
+func randomToken() (string, error) {
+	buf := make([]byte, oauthRandomByteSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,410 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/infrastructure/oauth"
+	"kimiyomi/backend/src/infrastructure/oauth/oauthtest"
+
+	"github.com/google/uuid"
+)
+
+// memoryUserRepository はテスト用のメモリ上のUserRepositoryです
+type memoryUserRepository struct {
+	mu         sync.Mutex
+	users      map[string]*entity.User
+	findErr    error
+	createdIDs []string
+}
+
+func newMemoryUserRepository(users ...*entity.User) *memoryUserRepository {
+	r := &memoryUserRepository{users: map[string]*entity.User{}}
+	for _, u := range users {
+		r.users[u.ID] = u
+	}
+	return r
+}
+
+func (r *memoryUserRepository) Create(ctx context.Context, user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if user.ID == "" {
+		user.ID = uuid.New().String()
+	}
+	r.users[user.ID] = user
+	r.createdIDs = append(r.createdIDs, user.ID)
+	return nil
+}
+
+func (r *memoryUserRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[id]
+	if !ok {
+		return nil, entity.ErrUserNotFound
+	}
+	return u, nil
+}
+
+func (r *memoryUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.findErr != nil {
+		return nil, r.findErr
+	}
+	for _, u := range r.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, entity.ErrUserNotFound
+}
+
+func (r *memoryUserRepository) Update(ctx context.Context, user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *memoryUserRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.users, id)
+	return nil
+}
+
+func (r *memoryUserRepository) List(ctx context.Context) ([]*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	users := make([]*entity.User, 0, len(r.users))
+	for _, u := range r.users {
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// memoryIdentityRepository はテスト用のメモリ上のIdentityRepositoryです
+type memoryIdentityRepository struct {
+	mu         sync.Mutex
+	identities []*entity.UserIdentity
+}
+
+func (r *memoryIdentityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.identities = append(r.identities, identity)
+	return nil
+}
+
+func (r *memoryIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryIdentityRepository) FindByUserID(ctx context.Context, userID string) ([]*entity.UserIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var identities []*entity.UserIdentity
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+func (r *memoryIdentityRepository) Delete(ctx context.Context, userID, provider string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.identities[:0]
+	for _, identity := range r.identities {
+		if identity.UserID != userID || identity.Provider != provider {
+			kept = append(kept, identity)
+		}
+	}
+	r.identities = kept
+	return nil
+}
+
+// memoryOAuthStateRepository はテスト用のメモリ上のOAuthStateRepositoryです
+type memoryOAuthStateRepository struct {
+	mu     sync.Mutex
+	states map[string]*entity.OAuthState
+}
+
+func (r *memoryOAuthStateRepository) Create(ctx context.Context, state *entity.OAuthState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[state.State] = state
+	return nil
+}
+
+func (r *memoryOAuthStateRepository) Consume(ctx context.Context, state string) (*entity.OAuthState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.states[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.states, state)
+	return s, nil
+}
+
+func (r *memoryOAuthStateRepository) DeleteExpired(ctx context.Context) error {
+	return nil
+}
+
+const stubProviderName = "stub"
+
+type oauthFixture struct {
+	idp        *oauthtest.StubIdP
+	uc         *OAuthUseCase
+	users      *memoryUserRepository
+	identities *memoryIdentityRepository
+}
+
+func newOAuthFixture(t *testing.T, users ...*entity.User) *oauthFixture {
+	t.Helper()
+
+	idp := oauthtest.NewStubIdP(t, "client-id", "client-secret")
+	provider := oauth.NewProvider(oauth.ProviderConfig{
+		Name:         stubProviderName,
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  "http://localhost/callback",
+		AuthURL:      idp.AuthURL(),
+		TokenURL:     idp.TokenURL(),
+		Scopes:       []string{"openid", "email", "profile"},
+		OIDC:         true,
+		Issuer:       idp.Issuer(),
+		JWKSURL:      idp.JWKSURL(),
+	})
+
+	f := &oauthFixture{
+		idp:        idp,
+		users:      newMemoryUserRepository(users...),
+		identities: &memoryIdentityRepository{},
+	}
+	stateRepo := &memoryOAuthStateRepository{states: map[string]*entity.OAuthState{}}
+	f.uc = NewOAuthUseCase([]auth.OAuthProvider{provider}, f.identities, stateRepo, f.users)
+	return f
+}
+
+// login は認可フローを開始し、スタブIdPでログインしてコールバックに渡す入力を返します
+func (f *oauthFixture) login(t *testing.T, user oauthtest.User) CompleteInput {
+	t.Helper()
+	return f.begin(t, BeginInput{Provider: stubProviderName}, user)
+}
+
+// begin は指定した入力で認可フローを開始し、スタブIdPでログインしてコールバックに渡す入力を返します
+func (f *oauthFixture) begin(t *testing.T, begin BeginInput, user oauthtest.User) CompleteInput {
+	t.Helper()
+
+	result, err := f.uc.Begin(context.Background(), begin)
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	code, state, err := f.idp.Authorize(result.AuthorizationURL, user)
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+	return CompleteInput{Provider: stubProviderName, State: state, Code: code, Binding: result.Binding}
+}
+
+func TestOAuthCompleteStateIsSingleUse(t *testing.T) {
+	f := newOAuthFixture(t)
+	input := f.login(t, oauthtest.User{Subject: "sub-1", Email: "fan@example.com", EmailVerified: true})
+
+	if _, err := f.uc.Complete(context.Background(), input); err != nil {
+		t.Fatalf("first complete failed: %v", err)
+	}
+	if _, err := f.uc.Complete(context.Background(), input); !errors.Is(err, ErrInvalidOAuthState) {
+		t.Fatalf("second complete error = %v, want ErrInvalidOAuthState", err)
+	}
+}
+
+func TestOAuthCompleteRejectsNonceMismatch(t *testing.T) {
+	f := newOAuthFixture(t)
+	input := f.login(t, oauthtest.User{
+		Subject:       "sub-1",
+		Email:         "fan@example.com",
+		EmailVerified: true,
+		Nonce:         "another-nonce",
+	})
+
+	if _, err := f.uc.Complete(context.Background(), input); err == nil {
+		t.Fatal("complete with a mismatched nonce must fail")
+	}
+	if len(f.users.createdIDs) != 0 || len(f.identities.identities) != 0 {
+		t.Fatal("no user or identity must be created when the nonce mismatches")
+	}
+}
+
+func TestOAuthCompleteLinksExistingUserByVerifiedEmail(t *testing.T) {
+	existing := entity.NewUser("fan@example.com", "hashed-password", "Fan")
+	existing.ID = uuid.New().String()
+	f := newOAuthFixture(t, existing)
+
+	input := f.login(t, oauthtest.User{Subject: "sub-1", Email: "fan@example.com", EmailVerified: true})
+	user, err := f.uc.Complete(context.Background(), input)
+	if err != nil {
+		t.Fatalf("complete failed: %v", err)
+	}
+
+	if user.ID != existing.ID {
+		t.Errorf("user = %s, want existing user %s", user.ID, existing.ID)
+	}
+	if len(f.users.createdIDs) != 0 {
+		t.Error("no user must be created when linking by verified email")
+	}
+	identity, _ := f.identities.FindByProviderSubject(context.Background(), stubProviderName, "sub-1")
+	if identity == nil || identity.UserID != existing.ID {
+		t.Errorf("identity = %+v, want linked to %s", identity, existing.ID)
+	}
+}
+
+func TestOAuthCompleteDoesNotLinkByUnverifiedEmail(t *testing.T) {
+	existing := entity.NewUser("fan@example.com", "hashed-password", "Fan")
+	existing.ID = uuid.New().String()
+
+	for _, emailVerified := range []interface{}{false, nil} {
+		f := newOAuthFixture(t, existing)
+		input := f.login(t, oauthtest.User{Subject: "sub-1", Email: "fan@example.com", EmailVerified: emailVerified})
+
+		user, err := f.uc.Complete(context.Background(), input)
+		if err != nil {
+			t.Fatalf("email_verified=%v: complete failed: %v", emailVerified, err)
+		}
+		if user.ID == existing.ID {
+			t.Fatalf("email_verified=%v: an unverified email must not log in to the existing user", emailVerified)
+		}
+		if user.Email == existing.Email || user.HasEmail() {
+			t.Errorf("email_verified=%v: email = %q, want a placeholder address", emailVerified, user.Email)
+		}
+	}
+}
+
+func TestOAuthCompleteSignsUpWithoutEmail(t *testing.T) {
+	f := newOAuthFixture(t)
+	input := f.login(t, oauthtest.User{Subject: "sub-1"})
+
+	user, err := f.uc.Complete(context.Background(), input)
+	if err != nil {
+		t.Fatalf("complete failed: %v", err)
+	}
+	if len(f.users.createdIDs) != 1 || user.HasEmail() || user.Name == "" {
+		t.Fatalf("user = %+v, want a new user without an email", user)
+	}
+
+	// 同じ外部アカウントで再度ログインすると同じユーザーになる
+	again, err := f.uc.Complete(context.Background(), f.login(t, oauthtest.User{Subject: "sub-1"}))
+	if err != nil {
+		t.Fatalf("second complete failed: %v", err)
+	}
+	if again.ID != user.ID {
+		t.Errorf("user = %s, want %s", again.ID, user.ID)
+	}
+}
+
+func TestOAuthCompleteReturnsUserLookupError(t *testing.T) {
+	f := newOAuthFixture(t)
+	f.users.findErr = errors.New("connection refused")
+	input := f.login(t, oauthtest.User{Subject: "sub-1", Email: "fan@example.com", EmailVerified: true})
+
+	if _, err := f.uc.Complete(context.Background(), input); err == nil {
+		t.Fatal("complete must fail when the user lookup fails")
+	}
+	if len(f.users.createdIDs) != 0 {
+		t.Fatal("no user must be created when the user lookup fails")
+	}
+}
+
+func TestOAuthCompleteRejectsMissingOrForeignBinding(t *testing.T) {
+	for _, binding := range []string{"", "binding-of-another-client"} {
+		f := newOAuthFixture(t)
+		input := f.login(t, oauthtest.User{Subject: "sub-1", Email: "fan@example.com", EmailVerified: true})
+		input.Binding = binding
+
+		if _, err := f.uc.Complete(context.Background(), input); !errors.Is(err, ErrInvalidOAuthState) {
+			t.Fatalf("binding=%q: complete error = %v, want ErrInvalidOAuthState", binding, err)
+		}
+		if len(f.users.createdIDs) != 0 || len(f.identities.identities) != 0 {
+			t.Fatalf("binding=%q: no user or identity must be created", binding)
+		}
+	}
+}
+
+func TestOAuthCompleteRefusesLinkState(t *testing.T) {
+	victim := entity.NewUser("victim@example.com", "hashed-password", "Victim")
+	victim.ID = uuid.New().String()
+	f := newOAuthFixture(t, victim)
+
+	input := f.begin(t, BeginInput{Provider: stubProviderName, LinkUserID: victim.ID},
+		oauthtest.User{Subject: "attacker-sub", Email: "attacker@example.com", EmailVerified: true})
+
+	if _, err := f.uc.Complete(context.Background(), input); !errors.Is(err, ErrInvalidOAuthState) {
+		t.Fatalf("complete error = %v, want ErrInvalidOAuthState", err)
+	}
+	if len(f.identities.identities) != 0 {
+		t.Fatal("a link state must not attach an identity through the login callback")
+	}
+}
+
+func TestOAuthCompleteLinkAttachesIdentityToCaller(t *testing.T) {
+	user := entity.NewUser("fan@example.com", "hashed-password", "Fan")
+	user.ID = uuid.New().String()
+	f := newOAuthFixture(t, user)
+
+	input := f.begin(t, BeginInput{Provider: stubProviderName, LinkUserID: user.ID},
+		oauthtest.User{Subject: "sub-1", Email: "other@example.com", EmailVerified: true})
+
+	identity, err := f.uc.CompleteLink(context.Background(), CompleteLinkInput{CompleteInput: input, UserID: user.ID})
+	if err != nil {
+		t.Fatalf("complete link failed: %v", err)
+	}
+	if identity.UserID != user.ID || identity.Subject != "sub-1" {
+		t.Errorf("identity = %+v, want sub-1 linked to %s", identity, user.ID)
+	}
+}
+
+func TestOAuthCompleteLinkRejectsAnotherCaller(t *testing.T) {
+	victim := entity.NewUser("victim@example.com", "hashed-password", "Victim")
+	victim.ID = uuid.New().String()
+	attacker := entity.NewUser("attacker@example.com", "hashed-password", "Attacker")
+	attacker.ID = uuid.New().String()
+	f := newOAuthFixture(t, victim, attacker)
+
+	input := f.begin(t, BeginInput{Provider: stubProviderName, LinkUserID: attacker.ID},
+		oauthtest.User{Subject: "attacker-sub", Email: "attacker@example.com", EmailVerified: true})
+
+	if _, err := f.uc.CompleteLink(context.Background(), CompleteLinkInput{CompleteInput: input, UserID: victim.ID}); !errors.Is(err, ErrInvalidOAuthState) {
+		t.Fatalf("complete link error = %v, want ErrInvalidOAuthState", err)
+	}
+	if len(f.identities.identities) != 0 {
+		t.Fatal("no identity must be linked when the caller did not start the flow")
+	}
+}
+
+func TestOAuthCompleteLinkRefusesLoginState(t *testing.T) {
+	user := entity.NewUser("fan@example.com", "hashed-password", "Fan")
+	user.ID = uuid.New().String()
+	f := newOAuthFixture(t, user)
+
+	input := f.login(t, oauthtest.User{Subject: "sub-1", Email: "other@example.com", EmailVerified: true})
+
+	if _, err := f.uc.CompleteLink(context.Background(), CompleteLinkInput{CompleteInput: input, UserID: user.ID}); !errors.Is(err, ErrInvalidOAuthState) {
+		t.Fatalf("complete link error = %v, want ErrInvalidOAuthState", err)
+	}
+}
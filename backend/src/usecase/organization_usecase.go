@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOrganizationNotFound  = errors.New("organization not found")
+	ErrOrganizationForbidden = errors.New("not allowed to manage this organization")
+	ErrAlreadyMember         = errors.New("user is already a member of the organization")
+	ErrAlreadyInvited        = errors.New("user has already been invited to the organization")
+	ErrInvitationNotFound    = errors.New("invitation not found")
+	ErrMemberNotFound        = errors.New("organization member not found")
+	ErrCannotRemoveOwner     = errors.New("the owner cannot be removed from the organization")
+)
+
+// OrganizationUseCase はグループ・事務所とメンバーのユースケースを実装します
+type OrganizationUseCase struct {
+	orgRepo        repository.OrganizationRepository
+	invitationRepo repository.OrganizationInvitationRepository
+	userRepo       repository.UserRepository
+}
+
+// NewOrganizationUseCase は新しいOrganizationUseCaseを作成します
+func NewOrganizationUseCase(
+	orgRepo repository.OrganizationRepository,
+	invitationRepo repository.OrganizationInvitationRepository,
+	userRepo repository.UserRepository,
+) *OrganizationUseCase {
+	return &OrganizationUseCase{
+		orgRepo:        orgRepo,
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// CreateOrganizationInput は組織作成の入力データです
+type CreateOrganizationInput struct {
+	ActorID     string
+	Name        string
+	Type        entity.OrganizationType
+	Description string
+}
+
+// CreateOrganization は組織を作成し、作成者を所有者にします
+func (uc *OrganizationUseCase) CreateOrganization(ctx context.Context, input CreateOrganizationInput) (*entity.Organization, error) {
+	org, err := entity.NewOrganization(input.Name, input.Type, input.Description, input.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := entity.NewOrganizationMember(org.ID, input.ActorID, entity.OrganizationRoleOwner)
+	if err := uc.orgRepo.Create(ctx, org, owner); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// ListMyOrganizations はユーザーが所属する組織の一覧を取得します
+func (uc *OrganizationUseCase) ListMyOrganizations(ctx context.Context, userID string) ([]*entity.OrganizationMembership, error) {
+	memberships, err := uc.orgRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if memberships == nil {
+		memberships = []*entity.OrganizationMembership{}
+	}
+
+	return memberships, nil
+}
+
+// GetOrganization は組織とメンバー一覧を取得します
+// 組織のメンバーのみ閲覧できます
+func (uc *OrganizationUseCase) GetOrganization(ctx context.Context, actorID string, orgID uuid.UUID) (*entity.Organization, []*entity.OrganizationMember, error) {
+	org, _, err := uc.authorize(ctx, actorID, orgID, entity.OrganizationRoleMember)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members, err := uc.orgRepo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return org, members, nil
+}
+
+// UpdateOrganizationInput は組織更新の入力データです
+// nil の項目は変更しません
+type UpdateOrganizationInput struct {
+	ActorID        string
+	OrganizationID uuid.UUID
+	Name           *string
+	Type           *entity.OrganizationType
+	Description    *string
+}
+
+// UpdateOrganization は組織の情報を更新します。所有者のみ実行できます
+func (uc *OrganizationUseCase) UpdateOrganization(ctx context.Context, input UpdateOrganizationInput) (*entity.Organization, error) {
+	org, _, err := uc.authorize(ctx, input.ActorID, input.OrganizationID, entity.OrganizationRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		org.Name = *input.Name
+	}
+	if input.Type != nil {
+		org.Type = *input.Type
+	}
+	if input.Description != nil {
+		org.Description = *input.Description
+	}
+	if err := org.Validate(); err != nil {
+		return nil, err
+	}
+	org.Touch()
+
+	if err := uc.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// DeleteOrganization は組織を削除します。所有者のみ実行できます
+func (uc *OrganizationUseCase) DeleteOrganization(ctx context.Context, actorID string, orgID uuid.UUID) error {
+	if _, _, err := uc.authorize(ctx, actorID, orgID, entity.OrganizationRoleOwner); err != nil {
+		return err
+	}
+
+	return uc.orgRepo.Delete(ctx, orgID)
+}
+
+// InviteMemberInput はメンバー招待の入力データです
+type InviteMemberInput struct {
+	ActorID        string
+	OrganizationID uuid.UUID
+	UserID         string
+	Role           entity.OrganizationRole
+}
+
+// InviteMember はユーザーを組織に招待します
+// マネージャーはメンバーのみ、所有者はマネージャーも招待できます
+func (uc *OrganizationUseCase) InviteMember(ctx context.Context, input InviteMemberInput) (*entity.OrganizationInvitation, error) {
+	if !entity.IsValidOrganizationRole(input.Role) {
+		return nil, entity.ErrInvalidOrganizationRole
+	}
+
+	required := entity.OrganizationRoleManager
+	if input.Role == entity.OrganizationRoleManager {
+		required = entity.OrganizationRoleOwner
+	}
+	if _, _, err := uc.authorize(ctx, input.ActorID, input.OrganizationID, required); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil || user.IsDeleted() {
+		return nil, ErrUserNotFound
+	}
+
+	member, err := uc.orgRepo.FindMember(ctx, input.OrganizationID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if member != nil {
+		return nil, ErrAlreadyMember
+	}
+
+	pending, err := uc.invitationRepo.FindPending(ctx, input.OrganizationID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, ErrAlreadyInvited
+	}
+
+	invitation := entity.NewOrganizationInvitation(input.OrganizationID, user.ID, input.Role, input.ActorID)
+	if err := uc.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// ListMyInvitations はユーザー宛ての回答待ちの招待一覧を取得します
+func (uc *OrganizationUseCase) ListMyInvitations(ctx context.Context, userID string) ([]*entity.OrganizationInvitation, error) {
+	invitations, err := uc.invitationRepo.ListPendingByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if invitations == nil {
+		invitations = []*entity.OrganizationInvitation{}
+	}
+
+	return invitations, nil
+}
+
+// RespondToInvitation は招待を承諾または辞退します
+func (uc *OrganizationUseCase) RespondToInvitation(ctx context.Context, userID string, invitationID uuid.UUID, accept bool) (*entity.OrganizationInvitation, error) {
+	invitation, err := uc.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil || invitation.UserID != userID || !invitation.IsPending(time.Now()) {
+		return nil, ErrInvitationNotFound
+	}
+
+	if accept {
+		member := entity.NewOrganizationMember(invitation.OrganizationID, userID, invitation.Role)
+		if err := uc.orgRepo.AddMember(ctx, member); err != nil {
+			return nil, err
+		}
+	}
+
+	invitation.Respond(accept)
+	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// UpdateMemberRole はメンバーの役割を変更します。所有者のみ実行できます
+func (uc *OrganizationUseCase) UpdateMemberRole(ctx context.Context, actorID string, orgID uuid.UUID, userID string, role entity.OrganizationRole) error {
+	if !entity.IsValidOrganizationRole(role) {
+		return entity.ErrInvalidOrganizationRole
+	}
+	if _, _, err := uc.authorize(ctx, actorID, orgID, entity.OrganizationRoleOwner); err != nil {
+		return err
+	}
+
+	member, err := uc.orgRepo.FindMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrMemberNotFound
+	}
+	if member.Role == entity.OrganizationRoleOwner {
+		return ErrCannotRemoveOwner
+	}
+
+	return uc.orgRepo.UpdateMemberRole(ctx, orgID, userID, role)
+}
+
+// RemoveMember はメンバーを組織から外します
+// 所有者は全員を、マネージャーはメンバーを外せます。自分自身は役割に関わらず脱退できますが、所有者は脱退できません
+func (uc *OrganizationUseCase) RemoveMember(ctx context.Context, actorID string, orgID uuid.UUID, userID string) error {
+	_, actor, err := uc.authorize(ctx, actorID, orgID, entity.OrganizationRoleMember)
+	if err != nil {
+		return err
+	}
+
+	member, err := uc.orgRepo.FindMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrMemberNotFound
+	}
+	if member.Role == entity.OrganizationRoleOwner {
+		return ErrCannotRemoveOwner
+	}
+
+	allowed := actorID == userID ||
+		actor.Role == entity.OrganizationRoleOwner ||
+		(actor.Role == entity.OrganizationRoleManager && member.Role == entity.OrganizationRoleMember)
+	if !allowed {
+		return ErrOrganizationForbidden
+	}
+
+	return uc.orgRepo.RemoveMember(ctx, orgID, userID)
+}
+
+// authorize は組織を取得し、操作するユーザーが必要な役割を持つか確認します
+// required に member を指定した場合は、組織に所属していれば許可します
+func (uc *OrganizationUseCase) authorize(ctx context.Context, actorID string, orgID uuid.UUID, required entity.OrganizationRole) (*entity.Organization, *entity.OrganizationMember, error) {
+	org, err := uc.orgRepo.FindByID(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if org == nil {
+		return nil, nil, ErrOrganizationNotFound
+	}
+
+	member, err := uc.orgRepo.FindMember(ctx, orgID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 所属していないユーザーには組織の存在を明かさない
+	if member == nil {
+		return nil, nil, ErrOrganizationNotFound
+	}
+
+	switch required {
+	case entity.OrganizationRoleOwner:
+		if member.Role != entity.OrganizationRoleOwner {
+			return nil, nil, ErrOrganizationForbidden
+		}
+	case entity.OrganizationRoleManager:
+		if !member.Role.CanManageMembers() {
+			return nil, nil, ErrOrganizationForbidden
+		}
+	}
+
+	return org, member, nil
+}
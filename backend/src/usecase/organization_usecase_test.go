@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// memoryOrganizationRepository はテスト用のメモリ上のOrganizationRepositoryです
+// テストで使わないメソッドは埋め込んだインターフェースに委ねます（呼び出すとpanicします）
+type memoryOrganizationRepository struct {
+	repository.OrganizationRepository
+
+	mu      sync.Mutex
+	orgs    []*entity.Organization
+	members []*entity.OrganizationMember
+}
+
+func (r *memoryOrganizationRepository) Create(ctx context.Context, org *entity.Organization, owner *entity.OrganizationMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.orgs = append(r.orgs, org)
+	r.members = append(r.members, owner)
+	return nil
+}
+
+func (r *memoryOrganizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, org := range r.orgs {
+		if org.ID == id {
+			return org, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryOrganizationRepository) AddMember(ctx context.Context, member *entity.OrganizationMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.members = append(r.members, member)
+	return nil
+}
+
+func (r *memoryOrganizationRepository) FindMember(ctx context.Context, orgID uuid.UUID, userID string) (*entity.OrganizationMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, member := range r.members {
+		if member.OrganizationID == orgID && member.UserID == userID {
+			return member, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryOrganizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID) ([]*entity.OrganizationMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var members []*entity.OrganizationMember
+	for _, member := range r.members {
+		if member.OrganizationID == orgID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (r *memoryOrganizationRepository) UpdateMemberRole(ctx context.Context, orgID uuid.UUID, userID string, role entity.OrganizationRole) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, member := range r.members {
+		if member.OrganizationID == orgID && member.UserID == userID {
+			member.Role = role
+		}
+	}
+	return nil
+}
+
+func (r *memoryOrganizationRepository) RemoveMember(ctx context.Context, orgID uuid.UUID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, member := range r.members {
+		if member.OrganizationID == orgID && member.UserID == userID {
+			r.members = append(r.members[:i], r.members[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *memoryOrganizationRepository) IsManagerOf(ctx context.Context, managerID, memberID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, manager := range r.members {
+		if manager.UserID != managerID || !manager.Role.CanManageMembers() {
+			continue
+		}
+		for _, member := range r.members {
+			if member.OrganizationID == manager.OrganizationID && member.UserID == memberID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// memoryInvitationRepository はテスト用のメモリ上のOrganizationInvitationRepositoryです
+type memoryInvitationRepository struct {
+	mu          sync.Mutex
+	invitations []*entity.OrganizationInvitation
+}
+
+func (r *memoryInvitationRepository) Create(ctx context.Context, invitation *entity.OrganizationInvitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.invitations = append(r.invitations, invitation)
+	return nil
+}
+
+func (r *memoryInvitationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.OrganizationInvitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, invitation := range r.invitations {
+		if invitation.ID == id {
+			return invitation, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryInvitationRepository) FindPending(ctx context.Context, orgID uuid.UUID, userID string) (*entity.OrganizationInvitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, invitation := range r.invitations {
+		if invitation.OrganizationID == orgID && invitation.UserID == userID && invitation.IsPending(time.Now()) {
+			return invitation, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryInvitationRepository) ListPendingByUserID(ctx context.Context, userID string) ([]*entity.OrganizationInvitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var invitations []*entity.OrganizationInvitation
+	for _, invitation := range r.invitations {
+		if invitation.UserID == userID && invitation.IsPending(time.Now()) {
+			invitations = append(invitations, invitation)
+		}
+	}
+	return invitations, nil
+}
+
+func (r *memoryInvitationRepository) Update(ctx context.Context, invitation *entity.OrganizationInvitation) error {
+	return nil
+}
+
+// organizationFixture は所有者・マネージャー・メンバーが所属する組織と、所属していないユーザーです
+type organizationFixture struct {
+	owner    *entity.User
+	manager  *entity.User
+	member   *entity.User
+	outsider *entity.User
+	org      *entity.Organization
+	orgRepo  *memoryOrganizationRepository
+	uc       *OrganizationUseCase
+}
+
+func newOrganizationFixture(t *testing.T) *organizationFixture {
+	t.Helper()
+	f := &organizationFixture{
+		owner:    newTestUser(entity.RoleCreator),
+		manager:  newTestUser(entity.RoleCreator),
+		member:   newTestUser(entity.RoleCreator),
+		outsider: newTestUser(entity.RoleCreator),
+		orgRepo:  &memoryOrganizationRepository{},
+	}
+	f.uc = NewOrganizationUseCase(f.orgRepo, &memoryInvitationRepository{}, newMemoryUserRepository(f.owner, f.manager, f.member, f.outsider))
+
+	org, err := f.uc.CreateOrganization(context.Background(), CreateOrganizationInput{
+		ActorID: f.owner.ID,
+		Name:    "group",
+		Type:    entity.OrganizationTypeGroup,
+	})
+	if err != nil {
+		t.Fatalf("failed to create organization: %v", err)
+	}
+	f.org = org
+	f.orgRepo.members = append(f.orgRepo.members,
+		entity.NewOrganizationMember(org.ID, f.manager.ID, entity.OrganizationRoleManager),
+		entity.NewOrganizationMember(org.ID, f.member.ID, entity.OrganizationRoleMember),
+	)
+	return f
+}
+
+func TestOrganizationIsHiddenFromNonMembers(t *testing.T) {
+	f := newOrganizationFixture(t)
+	ctx := context.Background()
+
+	if _, _, err := f.uc.GetOrganization(ctx, f.outsider.ID, f.org.ID); !errors.Is(err, ErrOrganizationNotFound) {
+		t.Fatalf("get by an outsider error = %v, want ErrOrganizationNotFound", err)
+	}
+	if err := f.uc.DeleteOrganization(ctx, f.outsider.ID, f.org.ID); !errors.Is(err, ErrOrganizationNotFound) {
+		t.Fatalf("delete by an outsider error = %v, want ErrOrganizationNotFound", err)
+	}
+	if _, members, err := f.uc.GetOrganization(ctx, f.member.ID, f.org.ID); err != nil || len(members) != 3 {
+		t.Fatalf("member must see the organization (members %d, err %v)", len(members), err)
+	}
+}
+
+func TestOrganizationInviteRequiresRole(t *testing.T) {
+	f := newOrganizationFixture(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		name  string
+		actor string
+		role  entity.OrganizationRole
+		want  error
+	}{
+		{"member invites a member", f.member.ID, entity.OrganizationRoleMember, ErrOrganizationForbidden},
+		{"manager invites a manager", f.manager.ID, entity.OrganizationRoleManager, ErrOrganizationForbidden},
+		{"outsider invites a member", f.outsider.ID, entity.OrganizationRoleMember, ErrOrganizationNotFound},
+		{"invite as owner", f.owner.ID, entity.OrganizationRoleOwner, entity.ErrInvalidOrganizationRole},
+	}
+	for _, tc := range cases {
+		_, err := f.uc.InviteMember(ctx, InviteMemberInput{
+			ActorID:        tc.actor,
+			OrganizationID: f.org.ID,
+			UserID:         f.outsider.ID,
+			Role:           tc.role,
+		})
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: error = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestOrganizationInvitationCanOnlyBeAcceptedByInvitee(t *testing.T) {
+	f := newOrganizationFixture(t)
+	ctx := context.Background()
+
+	invitation, err := f.uc.InviteMember(ctx, InviteMemberInput{
+		ActorID:        f.manager.ID,
+		OrganizationID: f.org.ID,
+		UserID:         f.outsider.ID,
+		Role:           entity.OrganizationRoleMember,
+	})
+	if err != nil {
+		t.Fatalf("failed to invite: %v", err)
+	}
+
+	if _, err := f.uc.RespondToInvitation(ctx, f.member.ID, invitation.ID, true); !errors.Is(err, ErrInvitationNotFound) {
+		t.Fatalf("respond by another user error = %v, want ErrInvitationNotFound", err)
+	}
+	if _, err := f.uc.RespondToInvitation(ctx, f.outsider.ID, invitation.ID, true); err != nil {
+		t.Fatalf("failed to accept invitation: %v", err)
+	}
+	member, _ := f.orgRepo.FindMember(ctx, f.org.ID, f.outsider.ID)
+	if member == nil || member.Role != entity.OrganizationRoleMember {
+		t.Fatalf("accepted invitee must join as a member, got %+v", member)
+	}
+}
+
+func TestOrganizationRemoveMemberRespectsRoles(t *testing.T) {
+	f := newOrganizationFixture(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		name   string
+		actor  string
+		target string
+		want   error
+	}{
+		{"manager removes the owner", f.manager.ID, f.owner.ID, ErrCannotRemoveOwner},
+		{"owner leaves", f.owner.ID, f.owner.ID, ErrCannotRemoveOwner},
+		{"member removes a manager", f.member.ID, f.manager.ID, ErrOrganizationForbidden},
+		{"outsider removes a member", f.outsider.ID, f.member.ID, ErrOrganizationNotFound},
+	}
+	for _, tc := range cases {
+		if err := f.uc.RemoveMember(ctx, tc.actor, f.org.ID, tc.target); !errors.Is(err, tc.want) {
+			t.Errorf("%s: error = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+
+	if err := f.uc.UpdateMemberRole(ctx, f.manager.ID, f.org.ID, f.member.ID, entity.OrganizationRoleManager); !errors.Is(err, ErrOrganizationForbidden) {
+		t.Errorf("role change by a manager error = %v, want ErrOrganizationForbidden", err)
+	}
+	if err := f.uc.RemoveMember(ctx, f.manager.ID, f.org.ID, f.member.ID); err != nil {
+		t.Errorf("manager failed to remove a member: %v", err)
+	}
+	if err := f.uc.RemoveMember(ctx, f.manager.ID, f.org.ID, f.manager.ID); err != nil {
+		t.Errorf("manager failed to leave: %v", err)
+	}
+}
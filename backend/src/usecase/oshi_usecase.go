@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"strings"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOshiNotFound       = errors.New("oshi not found")
+	ErrOshiForbidden      = errors.New("not allowed to manage this oshi")
+	ErrInvalidOshiCreator = errors.New("linked account must be a creator")
+	ErrInvalidOshiImage   = errors.New("oshi image must be a jpg, png, gif or webp image up to 5MB")
+	ErrOshiNewsNotFound   = errors.New("oshi news not found")
+)
+
+const (
+	defaultOshiListLimit = 20
+	maxOshiListLimit     = 100
+)
+
+// OshiUseCase は推しカタログのユースケースを実装します
+type OshiUseCase struct {
+	oshiRepo    repository.OshiRepository
+	newsRepo    repository.OshiNewsRepository
+	userRepo    repository.UserRepository
+	fileStorage FileStorage
+}
+
+// NewOshiUseCase は新しいOshiUseCaseを作成します
+func NewOshiUseCase(
+	oshiRepo repository.OshiRepository,
+	newsRepo repository.OshiNewsRepository,
+	userRepo repository.UserRepository,
+	fileStorage FileStorage,
+) *OshiUseCase {
+	return &OshiUseCase{
+		oshiRepo:    oshiRepo,
+		newsRepo:    newsRepo,
+		userRepo:    userRepo,
+		fileStorage: fileStorage,
+	}
+}
+
+// OshiActor は推しを操作するユーザーです
+type OshiActor struct {
+	UserID    string
+	ManageAny bool // 全ての推しを編集できる権限を持つかどうか
+}
+
+// OshiInput は推しの登録・更新の入力データです
+// 更新時は nil の項目を変更しません
+type OshiInput struct {
+	Name                    *string
+	GroupName               *string
+	Agency                  *string
+	Description             *string
+	ProfileImages           *[]string // アップロード済みの画像の並べ替えと削除のみ
+	Tags                    *[]string
+	CreatorUserID           *string // 空文字で紐付けを解除
+	OfficialDiagnosisResult *string
+}
+
+// CreateOshi は推しを登録します
+func (uc *OshiUseCase) CreateOshi(ctx context.Context, actor OshiActor, input OshiInput) (*entity.Oshi, error) {
+	if input.Name == nil {
+		return nil, entity.ErrInvalidOshiName
+	}
+
+	oshi := entity.NewOshi(*input.Name, actor.UserID)
+	if err := uc.apply(ctx, actor, oshi, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.oshiRepo.Create(ctx, oshi); err != nil {
+		return nil, err
+	}
+
+	return oshi, nil
+}
+
+// UpdateOshi は推しを部分的に更新します
+// プロフィール画像の一覧から外された画像はストレージから削除します
+func (uc *OshiUseCase) UpdateOshi(ctx context.Context, actor OshiActor, id uuid.UUID, input OshiInput) (*entity.Oshi, error) {
+	oshi, err := uc.findManageable(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previousImages := oshi.ProfileImages
+	if err := uc.apply(ctx, actor, oshi, input); err != nil {
+		return nil, err
+	}
+	oshi.Touch()
+
+	if err := uc.oshiRepo.Update(ctx, oshi); err != nil {
+		return nil, err
+	}
+
+	for _, image := range previousImages {
+		if !contains(oshi.ProfileImages, image) {
+			_ = uc.fileStorage.Delete(ctx, image)
+		}
+	}
+
+	return oshi, nil
+}
+
+// DeleteOshi は推しを削除します
+// 紐付いていたコンテンツは推しの紐付けのみ解除されます
+func (uc *OshiUseCase) DeleteOshi(ctx context.Context, actor OshiActor, id uuid.UUID) error {
+	oshi, err := uc.findManageable(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.oshiRepo.Delete(ctx, oshi.ID); err != nil {
+		return err
+	}
+
+	for _, image := range oshi.ProfileImages {
+		_ = uc.fileStorage.Delete(ctx, image)
+	}
+
+	return nil
+}
+
+// UploadImage はプロフィール画像をアップロードして推しに追加します
+func (uc *OshiUseCase) UploadImage(ctx context.Context, actor OshiActor, id uuid.UUID, file *multipart.FileHeader) (*entity.Oshi, error) {
+	if !isValidImageUpload(file) {
+		return nil, ErrInvalidOshiImage
+	}
+
+	oshi, err := uc.findManageable(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	imageURL, err := uc.fileStorage.Upload(ctx, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload oshi image: %w", err)
+	}
+
+	oshi.ProfileImages = append(oshi.ProfileImages, imageURL)
+	if err := oshi.Validate(); err != nil {
+		_ = uc.fileStorage.Delete(ctx, imageURL)
+		return nil, err
+	}
+	oshi.Touch()
+
+	if err := uc.oshiRepo.Update(ctx, oshi); err != nil {
+		// アップロードしたファイルを削除
+		_ = uc.fileStorage.Delete(ctx, imageURL)
+		return nil, err
+	}
+
+	return oshi, nil
+}
+
+// GetOshi は推しを取得します
+func (uc *OshiUseCase) GetOshi(ctx context.Context, id uuid.UUID) (*entity.Oshi, error) {
+	oshi, err := uc.oshiRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if oshi == nil {
+		return nil, ErrOshiNotFound
+	}
+
+	return oshi, nil
+}
+
+// ListOshisInput は推しの一覧取得の入力データです
+type ListOshisInput struct {
+	Query     string
+	GroupName string
+	Tag       string
+	Limit     int
+	Offset    int
+}
+
+// ListOshis は条件に一致する推しの一覧と総件数を取得します
+func (uc *OshiUseCase) ListOshis(ctx context.Context, input ListOshisInput) ([]*entity.Oshi, int, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultOshiListLimit
+	}
+	if limit > maxOshiListLimit {
+		limit = maxOshiListLimit
+	}
+	offset := input.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	oshis, total, err := uc.oshiRepo.List(ctx, repository.OshiFilter{
+		Query:     strings.TrimSpace(input.Query),
+		GroupName: strings.TrimSpace(input.GroupName),
+		Tag:       entity.NormalizeOshiTag(input.Tag),
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if oshis == nil {
+		oshis = []*entity.Oshi{}
+	}
+
+	return oshis, total, nil
+}
+
+// PostNews は推しのお知らせを投稿します
+// お知らせはフォロワーのホームフィードに表示されます
+func (uc *OshiUseCase) PostNews(ctx context.Context, actor OshiActor, oshiID uuid.UUID, title, body string) (*entity.OshiNews, error) {
+	oshi, err := uc.findManageable(ctx, actor, oshiID)
+	if err != nil {
+		return nil, err
+	}
+
+	news, err := entity.NewOshiNews(oshi.ID, actor.UserID, title, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.newsRepo.Create(ctx, news); err != nil {
+		return nil, err
+	}
+
+	return news, nil
+}
+
+// DeleteNews は推しのお知らせを削除します
+func (uc *OshiUseCase) DeleteNews(ctx context.Context, actor OshiActor, oshiID, newsID uuid.UUID) error {
+	oshi, err := uc.findManageable(ctx, actor, oshiID)
+	if err != nil {
+		return err
+	}
+
+	news, err := uc.newsRepo.FindByID(ctx, newsID)
+	if err != nil {
+		return err
+	}
+	if news == nil || news.OshiID != oshi.ID {
+		return ErrOshiNewsNotFound
+	}
+
+	return uc.newsRepo.Delete(ctx, news.ID)
+}
+
+// ListNews は推しのお知らせを新しい順に取得します
+func (uc *OshiUseCase) ListNews(ctx context.Context, oshiID uuid.UUID, limit, offset int) ([]*entity.OshiNews, error) {
+	if _, err := uc.GetOshi(ctx, oshiID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultOshiListLimit
+	}
+	if limit > maxOshiListLimit {
+		limit = maxOshiListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	newsList, err := uc.newsRepo.ListByOshiID(ctx, oshiID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if newsList == nil {
+		newsList = []*entity.OshiNews{}
+	}
+
+	return newsList, nil
+}
+
+// findManageable は操作するユーザーが編集できる推しを取得します
+func (uc *OshiUseCase) findManageable(ctx context.Context, actor OshiActor, id uuid.UUID) (*entity.Oshi, error) {
+	oshi, err := uc.GetOshi(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.ManageAny && !oshi.IsManagedBy(actor.UserID) {
+		return nil, ErrOshiForbidden
+	}
+
+	return oshi, nil
+}
+
+// apply は入力データを推しに反映して検証します
+func (uc *OshiUseCase) apply(ctx context.Context, actor OshiActor, oshi *entity.Oshi, input OshiInput) error {
+	if input.Name != nil {
+		oshi.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.GroupName != nil {
+		oshi.GroupName = strings.TrimSpace(*input.GroupName)
+	}
+	if input.Agency != nil {
+		oshi.Agency = strings.TrimSpace(*input.Agency)
+	}
+	if input.Description != nil {
+		oshi.Description = strings.TrimSpace(*input.Description)
+	}
+	if input.ProfileImages != nil {
+		// 画像の追加はアップロードのみとし、ここでは並べ替えと削除だけを受け付ける
+		for _, image := range *input.ProfileImages {
+			if !contains(oshi.ProfileImages, image) {
+				return ErrInvalidOshiImage
+			}
+		}
+		oshi.ProfileImages = append([]string{}, (*input.ProfileImages)...)
+	}
+	if input.Tags != nil {
+		oshi.SetTags(*input.Tags)
+	}
+	if input.OfficialDiagnosisResult != nil {
+		oshi.OfficialDiagnosisResult = strings.TrimSpace(*input.OfficialDiagnosisResult)
+	}
+	if input.CreatorUserID != nil {
+		if err := uc.linkCreator(ctx, actor, oshi, *input.CreatorUserID); err != nil {
+			return err
+		}
+	}
+
+	return oshi.Validate()
+}
+
+// linkCreator は推し本人のクリエイターアカウントを紐付けます
+// 全ての推しを編集できる権限が無い場合は、自分自身のみ紐付けられます
+func (uc *OshiUseCase) linkCreator(ctx context.Context, actor OshiActor, oshi *entity.Oshi, creatorUserID string) error {
+	if creatorUserID == "" {
+		oshi.CreatorUserID = nil
+		return nil
+	}
+	if !actor.ManageAny && creatorUserID != actor.UserID {
+		return ErrOshiForbidden
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, creatorUserID)
+	if err != nil || user.IsDeleted() {
+		return ErrInvalidOshiCreator
+	}
+	if user.Role != entity.RoleCreator && user.Role != entity.RoleAdmin {
+		return ErrInvalidOshiCreator
+	}
+
+	oshi.CreatorUserID = &user.ID
+	return nil
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+var (
+	ErrInvalidAvatar = errors.New("avatar must be a jpg, png, gif or webp image up to 5MB")
+)
+
+const maxAvatarSize = 5 << 20
+
+// ProfileUseCase はユーザープロフィール関連のユースケースを実装します
+type ProfileUseCase struct {
+	profileRepo  repository.ProfileRepository
+	userRepo     repository.UserRepository
+	relationRepo repository.UserRelationRepository
+	fileStorage  FileStorage
+}
+
+// NewProfileUseCase は新しいProfileUseCaseを作成します
+func NewProfileUseCase(
+	profileRepo repository.ProfileRepository,
+	userRepo repository.UserRepository,
+	relationRepo repository.UserRelationRepository,
+	fileStorage FileStorage,
+) *ProfileUseCase {
+	return &ProfileUseCase{
+		profileRepo:  profileRepo,
+		userRepo:     userRepo,
+		relationRepo: relationRepo,
+		fileStorage:  fileStorage,
+	}
+}
+
+// GetMe はログイン中のユーザーとそのプロフィールを取得します
+func (uc *ProfileUseCase) GetMe(ctx context.Context, userID string) (*entity.User, *entity.UserProfile, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, nil, ErrUserNotFound
+	}
+
+	profile, err := uc.findOrDefault(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, profile, nil
+}
+
+// UpdateProfileInput はプロフィール更新の入力データです
+// nil の項目は変更しません
+type UpdateProfileInput struct {
+	UserID       string
+	DisplayName  *string
+	Bio          *string
+	Birthday     *string // YYYY-MM-DD 形式、空文字で削除
+	FavoriteOshi *[]string
+	Locale       *string
+	Timezone     *string
+	Privacy      *entity.ProfilePrivacy
+}
+
+// UpdateProfile はプロフィールを部分的に更新します
+func (uc *ProfileUseCase) UpdateProfile(ctx context.Context, input UpdateProfileInput) (*entity.UserProfile, error) {
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	profile, err := uc.findOrDefault(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.DisplayName != nil {
+		name := strings.TrimSpace(*input.DisplayName)
+		if name == "" {
+			return nil, ErrInvalidUserInput
+		}
+		profile.DisplayName = name
+	}
+	if input.Bio != nil {
+		profile.Bio = strings.TrimSpace(*input.Bio)
+	}
+	if input.Birthday != nil {
+		if *input.Birthday == "" {
+			profile.Birthday = nil
+		} else {
+			birthday, err := time.Parse("2006-01-02", *input.Birthday)
+			if err != nil {
+				return nil, entity.ErrInvalidBirthday
+			}
+			profile.Birthday = &birthday
+		}
+	}
+	if input.FavoriteOshi != nil {
+		oshi := make([]string, 0, len(*input.FavoriteOshi))
+		for _, name := range *input.FavoriteOshi {
+			oshi = append(oshi, strings.TrimSpace(name))
+		}
+		profile.FavoriteOshi = oshi
+	}
+	if input.Locale != nil {
+		profile.Locale = *input.Locale
+	}
+	if input.Timezone != nil {
+		profile.Timezone = *input.Timezone
+	}
+	if input.Privacy != nil {
+		profile.Privacy = *input.Privacy
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	profile.Touch()
+
+	if err := uc.profileRepo.Save(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// UploadAvatar はアバター画像をストレージにアップロードし、プロフィールに設定します
+// 以前のアバター画像は削除します
+func (uc *ProfileUseCase) UploadAvatar(ctx context.Context, userID string, file *multipart.FileHeader) (*entity.UserProfile, error) {
+	if !isValidImageUpload(file) {
+		return nil, ErrInvalidAvatar
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	profile, err := uc.findOrDefault(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	avatarURL, err := uc.fileStorage.Upload(ctx, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload avatar: %w", err)
+	}
+
+	previous := profile.AvatarURL
+	profile.AvatarURL = avatarURL
+	profile.Touch()
+
+	if err := uc.profileRepo.Save(ctx, profile); err != nil {
+		// アップロードしたファイルを削除
+		_ = uc.fileStorage.Delete(ctx, avatarURL)
+		return nil, err
+	}
+
+	if previous != "" {
+		_ = uc.fileStorage.Delete(ctx, previous)
+	}
+
+	return profile, nil
+}
+
+// GetPublicProfile は他のユーザーに公開するプロフィールを取得します
+// includePrivate が true の場合は公開設定に関わらずすべての項目を返します
+// 閲覧者をブロックしているユーザーのプロフィールは見つからないものとして扱います（includePrivate が true の場合を除く）
+func (uc *ProfileUseCase) GetPublicProfile(ctx context.Context, viewerID, userID string, includePrivate bool) (*entity.PublicProfile, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if viewerID != "" && !includePrivate {
+		blocked, err := uc.relationRepo.IsBlocked(ctx, userID, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, ErrUserNotFound
+		}
+	}
+
+	profile, err := uc.findOrDefault(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile.Public(includePrivate), nil
+}
+
+// findOrDefault はプロフィールを取得し、未作成の場合は既定値のプロフィールを返します
+func (uc *ProfileUseCase) findOrDefault(ctx context.Context, user *entity.User) (*entity.UserProfile, error) {
+	profile, err := uc.profileRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		profile = entity.NewUserProfile(user.ID, user.Name)
+	}
+	return profile, nil
+}
+
+// isValidImageUpload はアップロードされた画像の拡張子とサイズを確認します
+func isValidImageUpload(file *multipart.FileHeader) bool {
+	if file == nil || file.Size <= 0 || file.Size > maxAvatarSize {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp":
+		return true
+	default:
+		return false
+	}
+}
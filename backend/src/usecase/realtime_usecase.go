@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+var ErrTooManyRealtimeTopics = errors.New("too many leaderboards to subscribe")
+
+// maxLeaderboardSubscriptions は1つの接続で購読できる推しのランキングの数の上限です
+const maxLeaderboardSubscriptions = 5
+
+// RealtimePublisher はリアルタイムのイベントを配信するインターフェースです
+type RealtimePublisher interface {
+	// Publish はトピックの購読者にイベントを配信します
+	// 購読者がいない場合や受け取りが遅れている購読者へのイベントは破棄されます
+	Publish(ctx context.Context, topic string, event *entity.RealtimeEvent) error
+}
+
+// RealtimeBroker はリアルタイムのイベントの配信と購読を行うインターフェースです
+type RealtimeBroker interface {
+	RealtimePublisher
+	// Subscribe はトピックを購読し、イベントを受け取るチャネルと購読を解除する関数を返します
+	// チャネルは購読の解除またはブローカーの停止で閉じられます
+	Subscribe(topic string) (<-chan *entity.RealtimeEvent, func())
+}
+
+// userTopic はユーザー宛てのイベントのトピックを返します
+func userTopic(userID string) string {
+	return "user:" + userID
+}
+
+// leaderboardTopic は推しのランキングの更新のトピックを返します
+func leaderboardTopic(oshiID uuid.UUID) string {
+	return "leaderboard:" + oshiID.String()
+}
+
+// publishRealtime はイベントを配信します
+// リアルタイムの配信は補助的なものなので、失敗しても呼び出し元の処理は続けます
+func publishRealtime(ctx context.Context, publisher RealtimePublisher, topic string, eventType entity.RealtimeEventType, data interface{}) {
+	if publisher == nil {
+		return
+	}
+	event, err := entity.NewRealtimeEvent(eventType, data)
+	if err == nil {
+		err = publisher.Publish(ctx, topic, event)
+	}
+	if err != nil {
+		fmt.Printf("failed to publish realtime event %s: %v\n", eventType, err)
+	}
+}
+
+// RealtimeUseCase はリアルタイムの配信の購読のユースケースを実装します
+type RealtimeUseCase struct {
+	broker RealtimeBroker
+}
+
+// NewRealtimeUseCase は新しいRealtimeUseCaseを作成します
+func NewRealtimeUseCase(broker RealtimeBroker) *RealtimeUseCase {
+	return &RealtimeUseCase{broker: broker}
+}
+
+// Subscribe はユーザー宛てのイベントと、指定された推しのランキングの更新を購読します
+// 返したチャネルは購読の解除またはサーバーの停止で閉じられます
+func (uc *RealtimeUseCase) Subscribe(userID string, leaderboardOshiIDs []uuid.UUID) (<-chan *entity.RealtimeEvent, func(), error) {
+	if len(leaderboardOshiIDs) > maxLeaderboardSubscriptions {
+		return nil, nil, ErrTooManyRealtimeTopics
+	}
+
+	topics := []string{userTopic(userID)}
+	for _, oshiID := range leaderboardOshiIDs {
+		topics = append(topics, leaderboardTopic(oshiID))
+	}
+
+	out := make(chan *entity.RealtimeEvent)
+	done := make(chan struct{})
+	unsubscribes := make([]func(), 0, len(topics))
+	var wg sync.WaitGroup
+	for _, topic := range topics {
+		events, unsubscribe := uc.broker.Subscribe(topic)
+		unsubscribes = append(unsubscribes, unsubscribe)
+
+		wg.Add(1)
+		go func(events <-chan *entity.RealtimeEvent) {
+			defer wg.Done()
+			for event := range events {
+				select {
+				case out <- event:
+				case <-done:
+					return
+				}
+			}
+		}(events)
+	}
+
+	// 購読の解除とサーバーの停止のどちらでも接続を終えられるよう、全ての転送が終わったら閉じる
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	var once sync.Once
+	return out, func() {
+		once.Do(func() {
+			close(done)
+			for _, unsubscribe := range unsubscribes {
+				unsubscribe()
+			}
+		})
+	}, nil
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+var (
+	ErrInvalidRole         = errors.New("invalid role")
+	ErrCannotChangeOwnRole = errors.New("cannot change your own role")
+)
+
+// roleVersionCacheTTL はロールバージョンをキャッシュする期間です
+// 複数レプリカ構成では、ロール変更が他のレプリカに反映されるまで最大この期間かかります
+const roleVersionCacheTTL = 30 * time.Second
+
+// cachedRoleVersion はキャッシュされたロールバージョンです
+type cachedRoleVersion struct {
+	version   int
+	expiresAt time.Time
+}
+
+// RoleUseCase はロールと権限の管理に関するユースケースを実装します
+type RoleUseCase struct {
+	userRepo repository.UserRepository
+
+	mu       sync.Mutex
+	versions map[string]cachedRoleVersion
+	sweptAt  time.Time
+}
+
+// NewRoleUseCase は新しいRoleUseCaseを作成します
+func NewRoleUseCase(userRepo repository.UserRepository) *RoleUseCase {
+	return &RoleUseCase{
+		userRepo: userRepo,
+		versions: map[string]cachedRoleVersion{},
+	}
+}
+
+// ListRoles は全ロールと権限の対応表を返します
+func (uc *RoleUseCase) ListRoles() map[string][]auth.Permission {
+	return auth.RolePermissions()
+}
+
+// AssignRoleInput はロール割り当ての入力データです
+type AssignRoleInput struct {
+	ActorID string
+	UserID  string
+	Role    string
+}
+
+// AssignRole はユーザーにロールを割り当てます
+// ロールバージョンが進むため、対象ユーザーの既存トークンは無効になります
+func (uc *RoleUseCase) AssignRole(ctx context.Context, input AssignRoleInput) (*entity.User, error) {
+	if !entity.IsValidRole(input.Role) {
+		return nil, ErrInvalidRole
+	}
+	if input.ActorID == input.UserID {
+		return nil, ErrCannotChangeOwnRole
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Role == input.Role {
+		return user, nil
+	}
+
+	user.UpdateRole(input.Role)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	uc.mu.Lock()
+	delete(uc.versions, user.ID)
+	uc.mu.Unlock()
+
+	return user, nil
+}
+
+// CurrentRoleVersion はユーザーの現在のロールバージョンを返します
+func (uc *RoleUseCase) CurrentRoleVersion(ctx context.Context, userID string) (int, error) {
+	uc.mu.Lock()
+	cached, ok := uc.versions[userID]
+	if ok && !time.Now().Before(cached.expiresAt) {
+		delete(uc.versions, userID)
+		ok = false
+	}
+	uc.mu.Unlock()
+	if ok {
+		return cached.version, nil
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return 0, ErrUserNotFound
+	}
+
+	now := time.Now()
+	uc.mu.Lock()
+	uc.sweepExpiredVersions(now)
+	uc.versions[userID] = cachedRoleVersion{
+		version:   user.RoleVersion,
+		expiresAt: now.Add(roleVersionCacheTTL),
+	}
+	uc.mu.Unlock()
+
+	return user.RoleVersion, nil
+}
+
+// sweepExpiredVersions は期限切れのロールバージョンのキャッシュを削除します
+// 参照されなくなったユーザーが残り続けないように、キャッシュの有効期間ごとに1回全体を走査します
+// 呼び出し側で uc.mu をロックしている必要があります
+func (uc *RoleUseCase) sweepExpiredVersions(now time.Time) {
+	if now.Sub(uc.sweptAt) < roleVersionCacheTTL {
+		return
+	}
+	for userID, cached := range uc.versions {
+		if !now.Before(cached.expiresAt) {
+			delete(uc.versions, userID)
+		}
+	}
+	uc.sweptAt = now
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+func TestRoleVersionCacheDropsExpiredEntries(t *testing.T) {
+	user := entity.NewUser("fan@example.com", "hashed-password", "Fan")
+	user.ID = uuid.New().String()
+	uc := NewRoleUseCase(newMemoryUserRepository(user))
+
+	past := time.Now().Add(-time.Second)
+	for i := 0; i < 3; i++ {
+		uc.versions[uuid.New().String()] = cachedRoleVersion{version: 1, expiresAt: past}
+	}
+	uc.versions[user.ID] = cachedRoleVersion{version: 0, expiresAt: past}
+
+	version, err := uc.CurrentRoleVersion(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("failed to get role version: %v", err)
+	}
+	if version != user.RoleVersion {
+		t.Errorf("role version = %d, want %d from the repository", version, user.RoleVersion)
+	}
+	if len(uc.versions) != 1 {
+		t.Errorf("cached versions = %d, want only the user just looked up", len(uc.versions))
+	}
+}
+
+func TestAssignRoleInvalidatesCachedRoleVersion(t *testing.T) {
+	admin := entity.NewUser("admin@example.com", "hashed-password", "Admin")
+	admin.ID = uuid.New().String()
+	user := entity.NewUser("fan@example.com", "hashed-password", "Fan")
+	user.ID = uuid.New().String()
+	uc := NewRoleUseCase(newMemoryUserRepository(admin, user))
+	ctx := context.Background()
+
+	before, err := uc.CurrentRoleVersion(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("failed to get role version: %v", err)
+	}
+
+	if _, err := uc.AssignRole(ctx, AssignRoleInput{ActorID: admin.ID, UserID: user.ID, Role: entity.RoleCreator}); err != nil {
+		t.Fatalf("assign role failed: %v", err)
+	}
+
+	// キャッシュの有効期間内でも、ロール変更前のトークンはすぐに無効になる
+	after, err := uc.CurrentRoleVersion(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("failed to get role version: %v", err)
+	}
+	if after <= before {
+		t.Errorf("role version = %d after the change, want greater than %d", after, before)
+	}
+}
+
+func TestAssignRoleRejectsOwnRoleAndUnknownRoles(t *testing.T) {
+	admin := entity.NewUser("admin@example.com", "hashed-password", "Admin")
+	admin.ID = uuid.New().String()
+	uc := NewRoleUseCase(newMemoryUserRepository(admin))
+	ctx := context.Background()
+
+	if _, err := uc.AssignRole(ctx, AssignRoleInput{ActorID: admin.ID, UserID: admin.ID, Role: entity.RoleFan}); !errors.Is(err, ErrCannotChangeOwnRole) {
+		t.Errorf("own role change error = %v, want ErrCannotChangeOwnRole", err)
+	}
+	if _, err := uc.AssignRole(ctx, AssignRoleInput{ActorID: uuid.New().String(), UserID: admin.ID, Role: "owner"}); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("unknown role error = %v, want ErrInvalidRole", err)
+	}
+}
@@ -0,0 +1,372 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrSessionNotFound     = errors.New("session not found")
+	// ErrMFAEnrollmentRequired はロールで必須の二要素認証を登録していないユーザーがトークンをリフレッシュした場合のエラーです
+	ErrMFAEnrollmentRequired = errors.New("mfa enrollment is required for this role")
+)
+
+const (
+	// sessionCacheTTL はセッションの有効状態をキャッシュする期間です
+	sessionCacheTTL = 30 * time.Second
+
+	securityEventListLimit = 50
+)
+
+// SecurityEventNotifier はセキュリティイベントをユーザーに通知するインターフェースです
+type SecurityEventNotifier interface {
+	NotifySecurityEvent(ctx context.Context, event *entity.SecurityEvent) error
+}
+
+// DeviceInfo はログイン元の端末情報です
+type DeviceInfo struct {
+	DeviceID   string
+	UserAgent  string
+	IPAddress  string
+	AppVersion string
+	FCMToken   string
+}
+
+// SessionTokens はセッションに紐付くアクセストークンとリフレッシュトークンです
+type SessionTokens struct {
+	SessionID    string
+	AccessToken  string
+	RefreshToken string
+}
+
+// cachedSessionState はキャッシュされたセッションの有効状態です
+type cachedSessionState struct {
+	active    bool
+	expiresAt time.Time
+}
+
+// SessionUseCase はデバイスセッションとリフレッシュトークンのユースケースを実装します
+type SessionUseCase struct {
+	sessionRepo  repository.SessionRepository
+	eventRepo    repository.SecurityEventRepository
+	userRepo     repository.UserRepository
+	mfaRepo      repository.MFARepository
+	tokenService auth.TokenService
+	notifier     SecurityEventNotifier
+	refreshTTL   time.Duration
+
+	mu      sync.Mutex
+	states  map[string]cachedSessionState
+	sweptAt time.Time
+}
+
+// NewSessionUseCase は新しいSessionUseCaseを作成します
+// notifier が nil の場合、セキュリティイベントは記録のみ行います
+func NewSessionUseCase(
+	sessionRepo repository.SessionRepository,
+	eventRepo repository.SecurityEventRepository,
+	userRepo repository.UserRepository,
+	mfaRepo repository.MFARepository,
+	tokenService auth.TokenService,
+	notifier SecurityEventNotifier,
+	refreshTTL time.Duration,
+) *SessionUseCase {
+	return &SessionUseCase{
+		sessionRepo:  sessionRepo,
+		eventRepo:    eventRepo,
+		userRepo:     userRepo,
+		mfaRepo:      mfaRepo,
+		tokenService: tokenService,
+		notifier:     notifier,
+		refreshTTL:   refreshTTL,
+		states:       map[string]cachedSessionState{},
+	}
+}
+
+// StartSessionInput はセッション開始の入力データです
+type StartSessionInput struct {
+	UserID      string
+	Role        string
+	RoleVersion int
+	Device      DeviceInfo
+}
+
+// StartSession はログイン成功時に新しいデバイスセッションを作成し、トークンを発行します
+// 初めての端末からのログインはセキュリティイベントとして記録します
+func (uc *SessionUseCase) StartSession(ctx context.Context, input StartSessionInput) (*SessionTokens, error) {
+	known, err := uc.sessionRepo.HasKnownDevice(ctx, input.UserID, input.Device.DeviceID, input.Device.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session := entity.NewDeviceSession(
+		input.UserID,
+		hashRefreshToken(refreshToken),
+		input.Device.DeviceID,
+		input.Device.UserAgent,
+		input.Device.IPAddress,
+		input.Device.AppVersion,
+		input.Device.FCMToken,
+		uc.refreshTTL,
+	)
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	if !known {
+		uc.recordEvent(ctx, entity.NewSecurityEvent(input.UserID, entity.SecurityEventNewDeviceLogin, session))
+	}
+
+	accessToken, err := uc.tokenService.GenerateToken(input.UserID, input.Role, input.RoleVersion, session.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionTokens{
+		SessionID:    session.ID.String(),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Refresh はリフレッシュトークンをローテーションし、新しいトークンを発行します
+// ロールはユーザーの現在の値でトークンに埋め込みます
+// 二要素認証が必須のロールに変更されたユーザーが未登録の場合は、セッションを失効させてログインし直させます
+// 使用済みのリフレッシュトークンが再び使われた場合は、トークンが漏洩したものとしてセッション全体を失効させます
+func (uc *SessionUseCase) Refresh(ctx context.Context, refreshToken string, device DeviceInfo) (*SessionTokens, *entity.User, error) {
+	hash := hashRefreshToken(refreshToken)
+	session, err := uc.sessionRepo.FindByRefreshTokenHash(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session == nil {
+		if err := uc.revokeReusedSession(ctx, hash); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if !session.IsActive(time.Now()) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, session.UserID)
+	if err != nil {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if user.RequiresMFA() {
+		mfa, err := uc.mfaRepo.FindByUserID(ctx, user.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if mfa == nil || !mfa.Enabled {
+			if err := uc.sessionRepo.Revoke(ctx, user.ID, session.ID); err != nil {
+				return nil, nil, err
+			}
+			uc.invalidate(session.ID.String())
+			return nil, nil, ErrMFAEnrollmentRequired
+		}
+	}
+
+	newRefreshToken, err := randomToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session.Rotate(hashRefreshToken(newRefreshToken), device.UserAgent, device.IPAddress, device.AppVersion, uc.refreshTTL)
+	if device.FCMToken != "" {
+		session.FCMToken = device.FCMToken
+	}
+	rotated, err := uc.sessionRepo.Rotate(ctx, session, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 同じトークンで同時にリクエストされ、他のリクエストが先にローテーションした場合
+	if !rotated {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	accessToken, err := uc.tokenService.GenerateToken(user.ID, user.Role, user.RoleVersion, session.ID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &SessionTokens{
+		SessionID:    session.ID.String(),
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+	}, user, nil
+}
+
+// revokeReusedSession は使用済みのリフレッシュトークンが使われた場合に、そのトークンのセッションを失効させます
+// 使用済みのトークンでない場合は何もしません
+func (uc *SessionUseCase) revokeReusedSession(ctx context.Context, hash string) error {
+	session, err := uc.sessionRepo.FindByConsumedRefreshTokenHash(ctx, hash)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.RevokedAt != nil {
+		return nil
+	}
+
+	if err := uc.sessionRepo.Revoke(ctx, session.UserID, session.ID); err != nil {
+		return err
+	}
+	uc.invalidate(session.ID.String())
+
+	uc.recordEvent(ctx, entity.NewSecurityEvent(session.UserID, entity.SecurityEventRefreshTokenReused, session))
+	return nil
+}
+
+// ListSessions はユーザーの有効なセッション一覧を取得します
+func (uc *SessionUseCase) ListSessions(ctx context.Context, userID string) ([]*entity.DeviceSession, error) {
+	return uc.sessionRepo.FindActiveByUserID(ctx, userID)
+}
+
+// RevokeSession はユーザーのセッションを失効させます
+// 失効したセッションのリフレッシュトークンとアクセストークンは以後使用できません
+func (uc *SessionUseCase) RevokeSession(ctx context.Context, userID string, sessionID uuid.UUID) error {
+	session, err := uc.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	if err := uc.sessionRepo.Revoke(ctx, userID, sessionID); err != nil {
+		return ErrSessionNotFound
+	}
+	uc.invalidate(sessionID.String())
+
+	uc.recordEvent(ctx, entity.NewSecurityEvent(userID, entity.SecurityEventSessionRevoked, session))
+	return nil
+}
+
+// RevokeAllSessions はユーザーのすべてのセッションを失効させます
+func (uc *SessionUseCase) RevokeAllSessions(ctx context.Context, userID string) error {
+	sessions, err := uc.sessionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := uc.sessionRepo.RevokeAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		uc.invalidate(s.ID.String())
+	}
+	return nil
+}
+
+// UpdateFCMToken はセッションのプッシュ通知用トークンを更新します
+func (uc *SessionUseCase) UpdateFCMToken(ctx context.Context, userID string, sessionID uuid.UUID, fcmToken string) error {
+	session, err := uc.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID || !session.IsActive(time.Now()) {
+		return ErrSessionNotFound
+	}
+
+	session.FCMToken = fcmToken
+	session.LastSeenAt = time.Now()
+	return uc.sessionRepo.Update(ctx, session)
+}
+
+// ListSecurityEvents はユーザーの最近のセキュリティイベントを取得します
+func (uc *SessionUseCase) ListSecurityEvents(ctx context.Context, userID string) ([]*entity.SecurityEvent, error) {
+	return uc.eventRepo.FindByUserID(ctx, userID, securityEventListLimit)
+}
+
+// IsSessionActive はセッションが有効かどうかを確認します
+func (uc *SessionUseCase) IsSessionActive(ctx context.Context, sessionID string) (bool, error) {
+	uc.mu.Lock()
+	cached, ok := uc.states[sessionID]
+	if ok && !time.Now().Before(cached.expiresAt) {
+		delete(uc.states, sessionID)
+		ok = false
+	}
+	uc.mu.Unlock()
+	if ok {
+		return cached.active, nil
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return false, nil
+	}
+	session, err := uc.sessionRepo.FindByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	active := session != nil && session.IsActive(time.Now())
+
+	now := time.Now()
+	uc.mu.Lock()
+	uc.sweepExpiredStates(now)
+	uc.states[sessionID] = cachedSessionState{
+		active:    active,
+		expiresAt: now.Add(sessionCacheTTL),
+	}
+	uc.mu.Unlock()
+
+	return active, nil
+}
+
+// sweepExpiredStates は期限切れのセッションの有効状態のキャッシュを削除します
+// 参照されなくなったセッションが残り続けないように、キャッシュの有効期間ごとに1回全体を走査します
+// 呼び出し側で uc.mu をロックしている必要があります
+func (uc *SessionUseCase) sweepExpiredStates(now time.Time) {
+	if now.Sub(uc.sweptAt) < sessionCacheTTL {
+		return
+	}
+	for id, state := range uc.states {
+		if !now.Before(state.expiresAt) {
+			delete(uc.states, id)
+		}
+	}
+	uc.sweptAt = now
+}
+
+// invalidate はセッションの有効状態のキャッシュを破棄します
+func (uc *SessionUseCase) invalidate(sessionID string) {
+	uc.mu.Lock()
+	delete(uc.states, sessionID)
+	uc.mu.Unlock()
+}
+
+// recordEvent はセキュリティイベントを記録し、通知します
+// 記録や通知の失敗はログインなどの本来の処理を妨げません
+func (uc *SessionUseCase) recordEvent(ctx context.Context, event *entity.SecurityEvent) {
+	if err := uc.eventRepo.Create(ctx, event); err != nil {
+		fmt.Printf("failed to record security event: %v\n", err)
+		return
+	}
+	if uc.notifier != nil {
+		if err := uc.notifier.NotifySecurityEvent(ctx, event); err != nil {
+			fmt.Printf("failed to notify security event: %v\n", err)
+		}
+	}
+}
+
+// hashRefreshToken はリフレッシュトークンのハッシュを返します
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
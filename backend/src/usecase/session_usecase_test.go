@@ -0,0 +1,336 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"kimiyomi/backend/src/domain/auth"
+	"kimiyomi/backend/src/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// memorySessionRepository はテスト用のメモリ上のSessionRepositoryです
+// 呼び出し側がエンティティを書き換えても保存内容が変わらないように、複製を保存して返します
+type memorySessionRepository struct {
+	mu       sync.Mutex
+	sessions map[uuid.UUID]entity.DeviceSession
+	consumed map[string]uuid.UUID
+}
+
+func newMemorySessionRepository() *memorySessionRepository {
+	return &memorySessionRepository{
+		sessions: map[uuid.UUID]entity.DeviceSession{},
+		consumed: map[string]uuid.UUID{},
+	}
+}
+
+func (r *memorySessionRepository) Create(ctx context.Context, session *entity.DeviceSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.ID] = *session
+	return nil
+}
+
+func (r *memorySessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.DeviceSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	return &s, nil
+}
+
+func (r *memorySessionRepository) FindByRefreshTokenHash(ctx context.Context, hash string) (*entity.DeviceSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.sessions {
+		if s.RefreshTokenHash == hash {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memorySessionRepository) FindByConsumedRefreshTokenHash(ctx context.Context, hash string) (*entity.DeviceSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, ok := r.consumed[hash]
+	if !ok {
+		return nil, nil
+	}
+	s := r.sessions[id]
+	return &s, nil
+}
+
+func (r *memorySessionRepository) FindActiveByUserID(ctx context.Context, userID string) ([]*entity.DeviceSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sessions []*entity.DeviceSession
+	for _, s := range r.sessions {
+		s := s
+		if s.UserID == userID && s.IsActive(time.Now()) {
+			sessions = append(sessions, &s)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *memorySessionRepository) HasKnownDevice(ctx context.Context, userID, deviceID, userAgent string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.sessions {
+		if s.UserID == userID && s.DeviceID == deviceID && s.UserAgent == userAgent {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memorySessionRepository) Update(ctx context.Context, session *entity.DeviceSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.ID] = *session
+	return nil
+}
+
+func (r *memorySessionRepository) Rotate(ctx context.Context, session *entity.DeviceSession, previousHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current, ok := r.sessions[session.ID]
+	if !ok || current.RefreshTokenHash != previousHash {
+		return false, nil
+	}
+	r.sessions[session.ID] = *session
+	r.consumed[previousHash] = session.ID
+	return true, nil
+}
+
+func (r *memorySessionRepository) Revoke(ctx context.Context, userID string, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[id]
+	if !ok || s.UserID != userID {
+		return ErrSessionNotFound
+	}
+	now := time.Now()
+	s.RevokedAt = &now
+	r.sessions[id] = s
+	return nil
+}
+
+func (r *memorySessionRepository) RevokeAllByUserID(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for id, s := range r.sessions {
+		if s.UserID == userID && s.RevokedAt == nil {
+			s.RevokedAt = &now
+			r.sessions[id] = s
+		}
+	}
+	return nil
+}
+
+// memorySecurityEventRepository はテスト用のメモリ上のSecurityEventRepositoryです
+type memorySecurityEventRepository struct {
+	mu     sync.Mutex
+	events []*entity.SecurityEvent
+}
+
+func (r *memorySecurityEventRepository) Create(ctx context.Context, event *entity.SecurityEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *memorySecurityEventRepository) FindByUserID(ctx context.Context, userID string, limit int) ([]*entity.SecurityEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var events []*entity.SecurityEvent
+	for _, event := range r.events {
+		if event.UserID == userID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// recordingNotifier はテスト用の通知したセキュリティイベントを記録するSecurityEventNotifierです
+type recordingNotifier struct {
+	mu     sync.Mutex
+	events []entity.SecurityEventType
+}
+
+func (n *recordingNotifier) NotifySecurityEvent(ctx context.Context, event *entity.SecurityEvent) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, event.Type)
+	return nil
+}
+
+// stubTokenService はテスト用の署名しないTokenServiceです
+type stubTokenService struct {
+	auth.TokenService
+}
+
+func (s stubTokenService) GenerateToken(userID string, role string, roleVersion int, sessionID string) (string, error) {
+	return userID + ":" + role + ":" + sessionID, nil
+}
+
+type sessionFixture struct {
+	users    *memoryUserRepository
+	sessions *memorySessionRepository
+	events   *memorySecurityEventRepository
+	mfa      *memoryMFARepository
+	notifier *recordingNotifier
+	uc       *SessionUseCase
+}
+
+func newSessionFixture(users ...*entity.User) *sessionFixture {
+	f := &sessionFixture{
+		users:    newMemoryUserRepository(users...),
+		sessions: newMemorySessionRepository(),
+		events:   &memorySecurityEventRepository{},
+		mfa:      newMemoryMFARepository(),
+		notifier: &recordingNotifier{},
+	}
+	f.uc = NewSessionUseCase(f.sessions, f.events, f.users, f.mfa, stubTokenService{}, f.notifier, time.Hour)
+	return f
+}
+
+func (f *sessionFixture) start(t *testing.T, user *entity.User) *SessionTokens {
+	t.Helper()
+	tokens, err := f.uc.StartSession(context.Background(), StartSessionInput{
+		UserID:      user.ID,
+		Role:        user.Role,
+		RoleVersion: user.RoleVersion,
+		Device:      DeviceInfo{DeviceID: "device-1", UserAgent: "test"},
+	})
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	return tokens
+}
+
+func TestSessionRefreshRequiresMFAEnrollmentAfterPromotion(t *testing.T) {
+	user := entity.NewUser("fan@example.com", "hashed-password", "Fan")
+	user.ID = uuid.New().String()
+	f := newSessionFixture(user)
+	ctx := context.Background()
+
+	tokens := f.start(t, user)
+	user.UpdateRole(entity.RoleCreator)
+
+	if _, _, err := f.uc.Refresh(ctx, tokens.RefreshToken, DeviceInfo{}); !errors.Is(err, ErrMFAEnrollmentRequired) {
+		t.Fatalf("refresh error = %v, want ErrMFAEnrollmentRequired", err)
+	}
+	active, err := f.uc.IsSessionActive(ctx, tokens.SessionID)
+	if err != nil || active {
+		t.Fatalf("session active = %v (err %v), want revoked", active, err)
+	}
+
+	// 登録後も失効したセッションはリフレッシュできない
+	mfa := entity.NewUserMFA(user.ID, "secret")
+	mfa.Enabled = true
+	f.mfa.configs[user.ID] = mfa
+	if _, _, err := f.uc.Refresh(ctx, tokens.RefreshToken, DeviceInfo{}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("refresh after revocation error = %v, want ErrInvalidRefreshToken", err)
+	}
+}
+
+func TestSessionRefreshAllowsEnrolledPrivilegedUser(t *testing.T) {
+	user := entity.NewUser("creator@example.com", "hashed-password", "Creator")
+	user.ID = uuid.New().String()
+	user.Role = entity.RoleCreator
+	f := newSessionFixture(user)
+	mfa := entity.NewUserMFA(user.ID, "secret")
+	mfa.Enabled = true
+	f.mfa.configs[user.ID] = mfa
+
+	tokens := f.start(t, user)
+	refreshed, _, err := f.uc.Refresh(context.Background(), tokens.RefreshToken, DeviceInfo{})
+	if err != nil {
+		t.Fatalf("refresh failed: %v", err)
+	}
+	if refreshed.RefreshToken == tokens.RefreshToken {
+		t.Error("refresh token must be rotated")
+	}
+}
+
+func TestSessionStateCacheDropsExpiredEntries(t *testing.T) {
+	user := entity.NewUser("fan@example.com", "hashed-password", "Fan")
+	user.ID = uuid.New().String()
+	f := newSessionFixture(user)
+	tokens := f.start(t, user)
+
+	past := time.Now().Add(-time.Second)
+	for i := 0; i < 3; i++ {
+		f.uc.states[uuid.New().String()] = cachedSessionState{active: true, expiresAt: past}
+	}
+	f.uc.states[tokens.SessionID] = cachedSessionState{active: false, expiresAt: past}
+
+	active, err := f.uc.IsSessionActive(context.Background(), tokens.SessionID)
+	if err != nil {
+		t.Fatalf("failed to check session: %v", err)
+	}
+	if !active {
+		t.Error("an expired cache entry must not be used")
+	}
+	if len(f.uc.states) != 1 {
+		t.Errorf("cached states = %d, want only the session just checked", len(f.uc.states))
+	}
+}
+
+func TestSessionRefreshTokenReuseRevokesSession(t *testing.T) {
+	user := entity.NewUser("fan@example.com", "hashed-password", "Fan")
+	user.ID = uuid.New().String()
+	f := newSessionFixture(user)
+	ctx := context.Background()
+
+	tokens := f.start(t, user)
+	refreshed, _, err := f.uc.Refresh(ctx, tokens.RefreshToken, DeviceInfo{})
+	if err != nil {
+		t.Fatalf("refresh failed: %v", err)
+	}
+
+	// ローテーション済みのトークンが再び使われたら、漏洩したものとしてセッションを失効させる
+	if _, _, err := f.uc.Refresh(ctx, tokens.RefreshToken, DeviceInfo{}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("reused refresh error = %v, want ErrInvalidRefreshToken", err)
+	}
+	if _, _, err := f.uc.Refresh(ctx, refreshed.RefreshToken, DeviceInfo{}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("refresh with the latest token error = %v, want ErrInvalidRefreshToken", err)
+	}
+	active, err := f.uc.IsSessionActive(ctx, tokens.SessionID)
+	if err != nil || active {
+		t.Fatalf("session active = %v (err %v), want revoked", active, err)
+	}
+
+	want := []entity.SecurityEventType{entity.SecurityEventNewDeviceLogin, entity.SecurityEventRefreshTokenReused}
+	events, _ := f.events.FindByUserID(ctx, user.ID, 10)
+	if len(events) != len(want) {
+		t.Fatalf("recorded events = %d, want %d", len(events), len(want))
+	}
+	for i, event := range events {
+		if event.Type != want[i] || f.notifier.events[i] != want[i] {
+			t.Errorf("event %d = %s (notified %s), want %s", i, event.Type, f.notifier.events[i], want[i])
+		}
+	}
+}
+
+func TestSessionKnownDeviceLoginIsNotNotified(t *testing.T) {
+	user := entity.NewUser("fan@example.com", "hashed-password", "Fan")
+	user.ID = uuid.New().String()
+	f := newSessionFixture(user)
+
+	f.start(t, user)
+	f.start(t, user)
+	if len(f.notifier.events) != 1 {
+		t.Errorf("notified events = %v, want only the first login", f.notifier.events)
+	}
+}
@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrShareLinkNotFound = errors.New("share link not found")
+	ErrShareLinkGone     = errors.New("share link has expired or been revoked")
+)
+
+const (
+	shareSlugByteSize      = 12
+	defaultShareExpiryDays = 30
+	maxShareExpiryDays     = 365
+	shareCardURLTTL        = time.Hour
+	shareCardKeyPrefix     = "share-cards/"
+)
+
+// CardRenderer はシェアカード画像を描画するインターフェースです
+type CardRenderer interface {
+	// Render はシェアカードを描画してPNG形式で返します
+	Render(card entity.ShareCard) ([]byte, error)
+}
+
+// ShareCardStorage はシェアカード画像を保存するストレージのインターフェースです
+type ShareCardStorage interface {
+	// Put は指定されたキーでデータを保存します
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	// GetSignedURL は閲覧用の署名付きURLを生成します
+	GetSignedURL(ctx context.Context, key string, duration time.Duration) (string, error)
+}
+
+// ShareUseCase は診断結果の共有リンクのユースケースを実装します
+type ShareUseCase struct {
+	shareRepo   repository.ShareLinkRepository
+	oshiRepo    repository.OshiRepository
+	renderer    CardRenderer
+	cardStorage ShareCardStorage
+	jobQueue    JobQueue
+	baseURL     string
+}
+
+// NewShareUseCase は新しいShareUseCaseを作成します
+// baseURL は共有ページのURLの組み立てに使う公開URLです
+func NewShareUseCase(
+	shareRepo repository.ShareLinkRepository,
+	oshiRepo repository.OshiRepository,
+	renderer CardRenderer,
+	cardStorage ShareCardStorage,
+	jobQueue JobQueue,
+	baseURL string,
+) *ShareUseCase {
+	return &ShareUseCase{
+		shareRepo:   shareRepo,
+		oshiRepo:    oshiRepo,
+		renderer:    renderer,
+		cardStorage: cardStorage,
+		jobQueue:    jobQueue,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// CreateShareLinkInput は共有リンク作成の入力データです
+type CreateShareLinkInput struct {
+	UserID        string
+	Kind          entity.ShareKind
+	ResultID      string
+	Title         string
+	Summary       string
+	Score         *int
+	OshiID        *uuid.UUID
+	ExpiresInDays int // 0 の場合は既定の30日
+}
+
+// ShareLinkView は共有リンクと公開URLです
+type ShareLinkView struct {
+	*entity.ShareLink
+	URL      string `json:"url"`
+	ImageURL string `json:"image_url"`
+}
+
+// PublicShare は共有ページに公開する診断結果です
+// 作成したユーザーを特定できる情報は含めません
+type PublicShare struct {
+	Slug      string           `json:"slug"`
+	Kind      entity.ShareKind `json:"kind"`
+	OshiID    *uuid.UUID       `json:"oshi_id,omitempty"`
+	Title     string           `json:"title"`
+	Summary   string           `json:"summary"`
+	Score     *int             `json:"score,omitempty"`
+	URL       string           `json:"url"`
+	ImageURL  string           `json:"image_url"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// CreateShareLink は診断結果の共有リンクを作成します
+func (uc *ShareUseCase) CreateShareLink(ctx context.Context, input CreateShareLinkInput) (*ShareLinkView, error) {
+	days := input.ExpiresInDays
+	if days <= 0 {
+		days = defaultShareExpiryDays
+	}
+	if days > maxShareExpiryDays {
+		days = maxShareExpiryDays
+	}
+	expiresAt := time.Now().AddDate(0, 0, days)
+
+	slug, err := newShareSlug()
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := entity.NewShareLink(input.UserID, slug, input.Kind, input.ResultID, input.Title, input.Summary, input.Score, &expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.OshiID != nil {
+		oshi, err := uc.oshiRepo.FindByID(ctx, *input.OshiID)
+		if err != nil {
+			return nil, err
+		}
+		if oshi == nil {
+			return nil, ErrOshiNotFound
+		}
+		link.OshiID = &oshi.ID
+	}
+
+	if err := uc.shareRepo.Create(ctx, link); err != nil {
+		return nil, err
+	}
+
+	return uc.view(link), nil
+}
+
+// ListMyShareLinks はユーザーの共有リンクを新しい順に取得します
+func (uc *ShareUseCase) ListMyShareLinks(ctx context.Context, userID string) ([]*ShareLinkView, error) {
+	links, err := uc.shareRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*ShareLinkView, 0, len(links))
+	for _, link := range links {
+		views = append(views, uc.view(link))
+	}
+	return views, nil
+}
+
+// RevokeShareLink は共有リンクを無効にし、カード画像を削除します
+func (uc *ShareUseCase) RevokeShareLink(ctx context.Context, userID, slug string) error {
+	link, err := uc.shareRepo.FindBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if link == nil || link.UserID != userID {
+		return ErrShareLinkNotFound
+	}
+	if link.RevokedAt != nil {
+		return nil
+	}
+
+	imageKey := link.ImageKey
+	link.Revoke()
+	link.ImageKey = ""
+	if err := uc.shareRepo.Update(ctx, link); err != nil {
+		return err
+	}
+
+	if imageKey != "" {
+		if err := enqueueStorageDelete(ctx, uc.jobQueue, imageKey, time.Time{}); err != nil {
+			fmt.Printf("failed to enqueue share card deletion: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// GetPublicShare は公開中の共有リンクの診断結果を取得します
+// 作成者とブロック・ミュートの関係にある閲覧者には見つからないものとして扱います
+func (uc *ShareUseCase) GetPublicShare(ctx context.Context, viewerID, slug string) (*PublicShare, error) {
+	link, err := uc.findActive(ctx, viewerID, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	view := uc.view(link)
+	return &PublicShare{
+		Slug:      link.Slug,
+		Kind:      link.Kind,
+		OshiID:    link.OshiID,
+		Title:     link.Title,
+		Summary:   link.Summary,
+		Score:     link.Score,
+		URL:       view.URL,
+		ImageURL:  view.ImageURL,
+		CreatedAt: link.CreatedAt,
+	}, nil
+}
+
+// GetCardImageURL はシェアカード画像の署名付きURLを返します
+// 初回のみ画像を描画してストレージに保存し、以降は保存済みの画像を返します
+func (uc *ShareUseCase) GetCardImageURL(ctx context.Context, slug string) (string, error) {
+	link, err := uc.findActive(ctx, "", slug)
+	if err != nil {
+		return "", err
+	}
+
+	if link.ImageKey == "" {
+		image, err := uc.renderer.Render(uc.card(link))
+		if err != nil {
+			return "", fmt.Errorf("failed to render share card: %w", err)
+		}
+
+		key := shareCardKeyPrefix + link.Slug + ".png"
+		if err := uc.cardStorage.Put(ctx, key, bytes.NewReader(image), "image/png"); err != nil {
+			return "", fmt.Errorf("failed to store share card: %w", err)
+		}
+
+		link.ImageKey = key
+		if err := uc.shareRepo.Update(ctx, link); err != nil {
+			return "", err
+		}
+	}
+
+	return uc.cardStorage.GetSignedURL(ctx, link.ImageKey, shareCardURLTTL)
+}
+
+// Name はエクスポートファイル内のファイル名です
+func (uc *ShareUseCase) Name() string {
+	return "share_links"
+}
+
+// ExportPersonalData はユーザーの共有リンクを返します
+func (uc *ShareUseCase) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	return uc.shareRepo.ListByUserID(ctx, userID)
+}
+
+// ErasePersonalData はユーザーの共有リンクとカード画像を削除します
+func (uc *ShareUseCase) ErasePersonalData(ctx context.Context, userID string) error {
+	links, err := uc.shareRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		if link.ImageKey == "" {
+			continue
+		}
+		if err := enqueueStorageDelete(ctx, uc.jobQueue, link.ImageKey, time.Time{}); err != nil {
+			return err
+		}
+	}
+
+	return uc.shareRepo.DeleteAllByUserID(ctx, userID)
+}
+
+// findActive は閲覧者に表示できる公開中の共有リンクを取得します
+// viewerID が空の場合（未ログインや SNS のクローラー）はブロック・ミュートを考慮しません
+func (uc *ShareUseCase) findActive(ctx context.Context, viewerID, slug string) (*entity.ShareLink, error) {
+	link, err := uc.shareRepo.FindVisibleBySlug(ctx, slug, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil {
+		return nil, ErrShareLinkNotFound
+	}
+	if !link.IsActive(time.Now()) {
+		return nil, ErrShareLinkGone
+	}
+	return link, nil
+}
+
+// view は共有リンクに公開URLを付けて返します
+func (uc *ShareUseCase) view(link *entity.ShareLink) *ShareLinkView {
+	url := uc.baseURL + "/s/" + link.Slug
+	return &ShareLinkView{
+		ShareLink: link,
+		URL:       url,
+		ImageURL:  url + "/card.png",
+	}
+}
+
+// card は共有リンクからシェアカードの描画内容を作成します
+func (uc *ShareUseCase) card(link *entity.ShareLink) entity.ShareCard {
+	label := "推し診断の結果"
+	if link.Kind == entity.ShareKindCompatibility {
+		label = "相性診断の結果"
+	}
+	return entity.ShareCard{
+		Label:     label,
+		Title:     link.Title,
+		Summary:   link.Summary,
+		Score:     link.Score,
+		ScoreUnit: "点",
+		Footer:    "キミヨミ - キミをヨミ解く！",
+	}
+}
+
+// newShareSlug は推測できない共有リンクのスラッグを生成します
+func newShareSlug() (string, error) {
+	buf := make([]byte, shareSlugByteSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate share slug: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
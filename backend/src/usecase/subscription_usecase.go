@@ -15,6 +15,7 @@ import (
 type SubscriptionUseCase struct {
 	subscriptionRepo repository.SubscriptionRepository
 	paymentService   PaymentService
+	notifier         Notifier
 }
 
 // PaymentService は決済処理を定義するインターフェースです
@@ -29,10 +30,12 @@ type PaymentService interface {
 func NewSubscriptionUseCase(
 	subscriptionRepo repository.SubscriptionRepository,
 	paymentService PaymentService,
+	notifier Notifier,
 ) *SubscriptionUseCase {
 	return &SubscriptionUseCase{
 		subscriptionRepo: subscriptionRepo,
 		paymentService:   paymentService,
+		notifier:         notifier,
 	}
 }
 
@@ -73,6 +76,8 @@ func (uc *SubscriptionUseCase) CreateSubscription(ctx context.Context, input Cre
 		return nil, fmt.Errorf("failed to create subscription: %w", err)
 	}
 
+	uc.notify(ctx, subscription, "subscription.started")
+
 	return subscription, nil
 }
 
@@ -105,6 +110,8 @@ func (uc *SubscriptionUseCase) CancelSubscription(ctx context.Context, input Can
 		return fmt.Errorf("failed to update subscription status: %w", err)
 	}
 
+	uc.notify(ctx, subscription, "subscription.canceled")
+
 	return nil
 }
 
@@ -145,7 +152,20 @@ func (uc *SubscriptionUseCase) ProcessExpiredSubscriptions(ctx context.Context)
 			fmt.Printf("failed to cancel payment: %v\n", err)
 			continue
 		}
+
+		uc.notify(ctx, subscription, "subscription.expired")
 	}
 
 	return nil
 }
+
+// notify はサブスクリプションの状態の変化をユーザーに知らせます
+func (uc *SubscriptionUseCase) notify(ctx context.Context, subscription *entity.Subscription, template string) {
+	notify(ctx, uc.notifier, NotifyInput{
+		UserID:   subscription.UserID.String(),
+		Category: entity.NotificationCategorySystem,
+		Template: template,
+		Params:   map[string]string{"plan": string(subscription.PlanType)},
+		Data:     map[string]string{"subscription_id": subscription.ID.String()},
+	})
+}
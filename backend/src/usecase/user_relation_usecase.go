@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+
+	"kimiyomi/backend/src/domain/entity"
+	"kimiyomi/backend/src/domain/repository"
+)
+
+// UserRelationUseCase はユーザー間のブロック・ミュートのユースケースを実装します
+// 一覧の除外は各リポジトリのクエリで行うため、ここでは関係の登録と解除のみを扱います
+type UserRelationUseCase struct {
+	relationRepo repository.UserRelationRepository
+	userRepo     repository.UserRepository
+}
+
+// NewUserRelationUseCase は新しいUserRelationUseCaseを作成します
+func NewUserRelationUseCase(relationRepo repository.UserRelationRepository, userRepo repository.UserRepository) *UserRelationUseCase {
+	return &UserRelationUseCase{
+		relationRepo: relationRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// Block はユーザーをブロックします
+// ミュート中のユーザーをブロックした場合はブロックに置き換えます
+func (uc *UserRelationUseCase) Block(ctx context.Context, userID, targetID string) (*entity.UserRelation, error) {
+	return uc.save(ctx, userID, targetID, entity.UserRelationBlock)
+}
+
+// Unblock はユーザーのブロックを解除します
+// ブロックしていない場合も成功として扱います
+func (uc *UserRelationUseCase) Unblock(ctx context.Context, userID, targetID string) error {
+	_, err := uc.relationRepo.Delete(ctx, userID, targetID, entity.UserRelationBlock)
+	return err
+}
+
+// Mute はユーザーをミュートします
+// ブロック中のユーザーをミュートした場合はミュートに置き換えます
+func (uc *UserRelationUseCase) Mute(ctx context.Context, userID, targetID string) (*entity.UserRelation, error) {
+	return uc.save(ctx, userID, targetID, entity.UserRelationMute)
+}
+
+// Unmute はユーザーのミュートを解除します
+// ミュートしていない場合も成功として扱います
+func (uc *UserRelationUseCase) Unmute(ctx context.Context, userID, targetID string) error {
+	_, err := uc.relationRepo.Delete(ctx, userID, targetID, entity.UserRelationMute)
+	return err
+}
+
+// List はユーザーがブロックまたはミュートしているユーザーの一覧を新しい順に取得します
+func (uc *UserRelationUseCase) List(ctx context.Context, userID string, relationType entity.UserRelationType) ([]*entity.UserRelation, error) {
+	relations, err := uc.relationRepo.ListByUserID(ctx, userID, relationType)
+	if err != nil {
+		return nil, err
+	}
+	if relations == nil {
+		relations = []*entity.UserRelation{}
+	}
+
+	return relations, nil
+}
+
+// Name はデータの種類の名前を返します
+func (uc *UserRelationUseCase) Name() string {
+	return "user_relations"
+}
+
+// ExportPersonalData はユーザーのブロック・ミュートの一覧を返します
+func (uc *UserRelationUseCase) ExportPersonalData(ctx context.Context, userID string) (interface{}, error) {
+	blocks, err := uc.relationRepo.ListByUserID(ctx, userID, entity.UserRelationBlock)
+	if err != nil {
+		return nil, err
+	}
+	mutes, err := uc.relationRepo.ListByUserID(ctx, userID, entity.UserRelationMute)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"blocks": blocks,
+		"mutes":  mutes,
+	}, nil
+}
+
+// ErasePersonalData はユーザーが設定した関係と、ユーザーに設定された関係を削除します
+func (uc *UserRelationUseCase) ErasePersonalData(ctx context.Context, userID string) error {
+	return uc.relationRepo.DeleteAllByUserID(ctx, userID)
+}
+
+// save は対象のユーザーの存在を確認して関係を保存します
+func (uc *UserRelationUseCase) save(ctx context.Context, userID, targetID string, relationType entity.UserRelationType) (*entity.UserRelation, error) {
+	relation, err := entity.NewUserRelation(userID, targetID, relationType)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.userRepo.FindByID(ctx, targetID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := uc.relationRepo.Save(ctx, relation); err != nil {
+		return nil, err
+	}
+
+	return relation, nil
+}